@@ -461,7 +461,7 @@ func addRunInteractive(cli client.ClientInterface, cmd *cobra.Command, projectID
 		Name:        answers.Name,
 		Description: answers.Description,
 		SuiteID:     answers.SuiteID,
-		IncludeAll:  answers.IncludeAll,
+		IncludeAll:  &answers.IncludeAll,
 	}
 
 	run, err := cli.AddRun(ctx, projectID, req)
@@ -657,7 +657,8 @@ func buildAddRunReq(cmd *cobra.Command, validate bool) (*data.AddRunRequest, err
 	req.SuiteID, _ = cmd.Flags().GetInt64("suite-id")
 	req.MilestoneID, _ = cmd.Flags().GetInt64("milestone-id")
 	req.AssignedTo, _ = cmd.Flags().GetInt64("assignedto-id")
-	req.IncludeAll, _ = cmd.Flags().GetBool("include-all")
+	includeAll, _ := cmd.Flags().GetBool("include-all")
+	req.IncludeAll = &includeAll
 	caseIDsStr, _ := cmd.Flags().GetString("case-ids")
 	if caseIDsStr != "" {
 		req.CaseIDs = parseCaseIDs(caseIDsStr)
@@ -364,7 +364,8 @@ func TestAddRunInteractive_Success(t *testing.T) {
 			assert.Equal(t, "Run Ok", req.Name)
 			assert.Equal(t, "Run Desc", req.Description)
 			assert.Equal(t, int64(44), req.SuiteID)
-			assert.False(t, req.IncludeAll)
+			assert.NotNil(t, req.IncludeAll)
+			assert.False(t, *req.IncludeAll)
 			return &data.Run{ID: 902, Name: req.Name}, nil
 		},
 	}
@@ -0,0 +1,45 @@
+// Package analyze implements `gotr analyze`: statistics of a run, plan or
+// milestone computed locally from its tests and results.
+package analyze
+
+import (
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/service/analytics"
+	"github.com/spf13/cobra"
+)
+
+// GetClientFunc is the function type for obtaining an API client.
+type GetClientFunc func(cmd *cobra.Command) client.ClientInterface
+
+// Register adds the analyze command and its subcommands to the root command.
+func Register(root *cobra.Command, getClient GetClientFunc) {
+	analyzeCmd := &cobra.Command{
+		Use:   "analyze",
+		Short: "Analyze runs, plans, milestones and flaky cases locally",
+		Long: `Computes statistics of a run, plan or milestone from its tests and results,
+without TestRail report templates.
+
+The report shows:
+  • pass rate overall, by run, section, assignee and priority
+  • counts by status, including custom statuses
+  • failed and blocked tests with the defects of their results
+  • elapsed time against estimates and the estimate of untested tests
+
+Pass rate is the share of passed tests among the tested ones.
+--format selects the output: table (terminal summary), json, md or html.
+The md and html documents are self-contained and can be attached or published.
+
+Subcommands:
+  • run       — a single run
+  • plan      — all runs of a plan
+  • milestone — the runs and plans of a milestone
+  • flaky     — cases whose results flip across the latest runs of a project`,
+	}
+
+	analyzeCmd.AddCommand(newAnalyzeCmd(analytics.KindRun, getClient))
+	analyzeCmd.AddCommand(newAnalyzeCmd(analytics.KindPlan, getClient))
+	analyzeCmd.AddCommand(newAnalyzeCmd(analytics.KindMilestone, getClient))
+	analyzeCmd.AddCommand(newFlakyCmd(getClient))
+
+	root.AddCommand(analyzeCmd)
+}
@@ -0,0 +1,183 @@
+package analyze
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Korrnals/gotr/cmd/internal/testhelper"
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/Korrnals/gotr/internal/service/analytics"
+	"github.com/Korrnals/gotr/internal/ui"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runMock() *client.MockClient {
+	return &client.MockClient{
+		GetRunFunc: func(ctx context.Context, runID int64) (*data.Run, error) {
+			return &data.Run{ID: runID, Name: "Nightly", ProjectID: 1, SuiteID: 2}, nil
+		},
+		GetTestsFunc: func(ctx context.Context, runID int64, filters map[string]string) ([]data.Test, error) {
+			return []data.Test{
+				{ID: 1, CaseID: 10, Title: "Login", StatusID: 1},
+				{ID: 2, CaseID: 11, Title: "Checkout", StatusID: 5},
+			}, nil
+		},
+		GetResultsForRunFunc: func(ctx context.Context, runID int64) (data.GetResultsResponse, error) {
+			return data.GetResultsResponse{{ID: 1, TestID: 2, StatusID: 5, Defects: "BUG-7"}}, nil
+		},
+	}
+}
+
+// executeAnalyze runs `analyze <args>` under a root with the global flags.
+func executeAnalyze(t *testing.T, mock *client.MockClient, args ...string) (string, error) {
+	t.Helper()
+	root := &cobra.Command{Use: "gotr"}
+	root.PersistentFlags().StringP("format", "f", "table", "")
+	root.PersistentFlags().BoolP("quiet", "q", true, "")
+	root.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	Register(root, testhelper.GetClientForTests)
+
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetArgs(append([]string{"analyze"}, args...))
+	err := root.Execute()
+	return out.String(), err
+}
+
+func TestAnalyzeRun_JSON(t *testing.T) {
+	out, err := executeAnalyze(t, runMock(), "run", "5", "--format", "json")
+	require.NoError(t, err)
+
+	var report analytics.Report
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	assert.Equal(t, "Nightly", report.Name)
+	assert.Equal(t, 50.0, report.Summary.PassRate)
+	require.Len(t, report.Failed, 1)
+	assert.Equal(t, []string{"BUG-7"}, report.Failed[0].Defects)
+}
+
+func TestAnalyzeRun_Table(t *testing.T) {
+	out, err := executeAnalyze(t, runMock(), "run", "5")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Run 5: Nightly")
+	assert.Contains(t, out, "Pass rate: 50.0% of 2 tested")
+	assert.Contains(t, out, "T2: Checkout [BUG-7]")
+}
+
+func TestAnalyzeRun_OutputFormatFromExtension(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"report.html", "report.md"} {
+		path := filepath.Join(dir, "out", name)
+		out, err := executeAnalyze(t, runMock(), "run", "5", "-o", path)
+		require.NoError(t, err)
+		assert.Empty(t, out)
+
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(b), "Run 5: Nightly")
+	}
+	b, _ := os.ReadFile(filepath.Join(dir, "out", "report.html"))
+	assert.Contains(t, string(b), "<!DOCTYPE html>")
+
+	_, err := executeAnalyze(t, runMock(), "run", "5", "-o", filepath.Join(dir, "report.txt"))
+	assert.ErrorContains(t, err, "cannot tell the report format")
+}
+
+func TestReportFormat(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("format", "table", "")
+
+	f, err := reportFormat(cmd, "", ui.FormatJSON, ui.FormatMarkdown, ui.FormatHTML)
+	require.NoError(t, err)
+	assert.Equal(t, "table", string(f))
+
+	require.NoError(t, cmd.Flags().Set("format", "md"))
+	f, err = reportFormat(cmd, "report.html", ui.FormatJSON, ui.FormatMarkdown, ui.FormatHTML)
+	require.NoError(t, err)
+	assert.Equal(t, "md", string(f), "--format wins over the extension")
+
+	require.NoError(t, cmd.Flags().Set("format", "csv"))
+	_, err = reportFormat(cmd, "", ui.FormatJSON, ui.FormatMarkdown, ui.FormatHTML)
+	assert.ErrorContains(t, err, "unsupported format")
+
+	require.NoError(t, cmd.Flags().Set("format", "table"))
+	_, err = reportFormat(cmd, "report.md", ui.FormatJSON, ui.FormatMarkdown, ui.FormatHTML)
+	assert.ErrorContains(t, err, "terminal only")
+}
+
+func TestAnalyzePlan_NotFound(t *testing.T) {
+	_, err := executeAnalyze(t, &client.MockClient{}, "plan", "9")
+	assert.ErrorContains(t, err, "plan 9 not found")
+}
+
+// flakyMock serves two runs in which case 10 flips and case 11 passes.
+func flakyMock() *client.MockClient {
+	return &client.MockClient{
+		GetRunsFilteredFunc: func(ctx context.Context, projectID int64, filter data.RunFilter) (data.GetRunsResponse, error) {
+			return data.GetRunsResponse{{ID: 1, CreatedOn: 100}, {ID: 2, CreatedOn: 200}}, nil
+		},
+		GetStatusesFunc: func(ctx context.Context) (data.GetStatusesResponse, error) {
+			return data.GetStatusesResponse{{ID: 1, Name: "passed"}, {ID: 5, Name: "failed"}}, nil
+		},
+		GetTestsFunc: func(ctx context.Context, runID int64, filters map[string]string) ([]data.Test, error) {
+			return []data.Test{{ID: runID*10 + 1, CaseID: 10, Title: "Login"}, {ID: runID*10 + 2, CaseID: 11, Title: "Logout"}}, nil
+		},
+		GetResultsForRunFunc: func(ctx context.Context, runID int64) (data.GetResultsResponse, error) {
+			status := int64(1)
+			if runID == 2 {
+				status = 5
+			}
+			return data.GetResultsResponse{
+				{ID: runID*10 + 1, TestID: runID*10 + 1, StatusID: status, CreatedOn: runID * 100},
+				{ID: runID*10 + 2, TestID: runID*10 + 2, StatusID: 1, CreatedOn: runID * 100},
+			}, nil
+		},
+	}
+}
+
+func TestAnalyzeFlaky_CSV(t *testing.T) {
+	out, err := executeAnalyze(t, flakyMock(), "flaky", "--project-id", "1", "--min-results", "2", "--format", "csv")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Case,Title,Results,Passed,Failed,Flips,Flip rate")
+	assert.Contains(t, out, "C10,Login,2,1,1,1,1.00,1,1,1970-01-01 00:01,-")
+	assert.NotContains(t, out, "C11")
+}
+
+func TestAnalyzeFlaky_Label(t *testing.T) {
+	mock := flakyMock()
+	var gotRun int64
+	var gotTests []int64
+	mock.UpdateTestsLabelsFunc = func(ctx context.Context, runID int64, testIDs []int64, labels []string) error {
+		gotRun, gotTests = runID, testIDs
+		assert.Equal(t, []string{"flaky"}, labels)
+		return nil
+	}
+
+	out, err := executeAnalyze(t, mock, "flaky", "--project-id", "1", "--min-results", "2", "--format", "json", "--label", "flaky")
+	require.NoError(t, err)
+	var report analytics.FlakyReport
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	require.Len(t, report.Cases, 1)
+	assert.Equal(t, int64(2), gotRun)
+	assert.Equal(t, []int64{21}, gotTests)
+
+	gotRun = 0
+	_, err = executeAnalyze(t, mock, "flaky", "--project-id", "1", "--min-results", "2", "--label", "flaky", "--dry-run")
+	require.NoError(t, err)
+	assert.Zero(t, gotRun, "--dry-run does not label")
+}
+
+func TestAnalyzeFlaky_Validation(t *testing.T) {
+	_, err := executeAnalyze(t, flakyMock(), "flaky")
+	assert.ErrorContains(t, err, "--project-id is required")
+
+	_, err = executeAnalyze(t, flakyMock(), "flaky", "--project-id", "1", "-o", "flaky.txt")
+	assert.ErrorContains(t, err, "cannot tell the report format")
+}
@@ -0,0 +1,201 @@
+package analyze
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/Korrnals/gotr/internal/output"
+	"github.com/Korrnals/gotr/internal/service/analytics"
+	"github.com/Korrnals/gotr/internal/ui"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// newFlakyCmd creates the 'analyze flaky' command.
+// Endpoints: get_runs, get_tests, get_results_for_run, get_statuses,
+// update_tests_labels (--label)
+func newFlakyCmd(getClient GetClientFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "flaky",
+		Short: "Find flaky cases across recent runs",
+		Long: `Follows every case through the results of the latest runs of a project
+(optionally of one suite or milestone) and ranks the unstable ones.
+
+Only passed and failed results count. For each case the report shows:
+  • flips      — passed↔failed changes between consecutive results
+  • flip rate  — flips divided by the changes possible (results - 1)
+  • fail streak — failed results since the last pass, and the longest streak
+  • last passed — when the case last passed
+  • MTTF       — mean time from the first failure of a streak to the next pass
+
+Cases with at least one flip and --min-results results are ranked by flip
+rate, then flips and failures. Runs inside test plans are not analyzed.
+
+--label tags the test of every ranked case in its latest run through
+update_tests_labels; use --dry-run to preview.`,
+		Example: `  # Flaky cases of the last 20 runs of a suite
+  gotr analyze flaky --project-id 30 --suite-id 20069
+
+  # Last 50 runs of a milestone, top 25, as CSV
+  gotr analyze flaky --project-id 30 --milestone-id 12 --last 50 --top 25 -o flaky.csv
+
+  # Label the flaky tests in their latest run
+  gotr analyze flaky --project-id 30 --label flaky --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var opts analytics.FlakyOptions
+			opts.ProjectID, _ = cmd.Flags().GetInt64("project-id")
+			opts.SuiteID, _ = cmd.Flags().GetInt64("suite-id")
+			opts.MilestoneID, _ = cmd.Flags().GetInt64("milestone-id")
+			opts.Last, _ = cmd.Flags().GetInt("last")
+			opts.MinResults, _ = cmd.Flags().GetInt("min-results")
+			opts.Parallel, _ = cmd.Flags().GetInt("parallel")
+			top, _ := cmd.Flags().GetInt("top")
+			label, _ := cmd.Flags().GetString("label")
+			isDryRun, _ := cmd.Flags().GetBool("dry-run")
+			quiet, _ := cmd.Flags().GetBool("quiet")
+			outPath, _ := cmd.Flags().GetString("output")
+
+			if opts.ProjectID <= 0 {
+				return fmt.Errorf("--project-id is required")
+			}
+			if opts.Last <= 0 {
+				return fmt.Errorf("--last must be positive")
+			}
+			format, err := reportFormat(cmd, outPath, ui.FormatJSON, ui.FormatCSV, ui.FormatMarkdown, ui.FormatHTML)
+			if err != nil {
+				return err
+			}
+			cli := getClient(cmd)
+
+			report, err := ui.RunWithStatus(cmd.Context(), ui.StatusConfig{
+				Title:  fmt.Sprintf("Loading the last %d runs of project %d...", opts.Last, opts.ProjectID),
+				Writer: os.Stderr,
+				Quiet:  quiet,
+			}, func(ctx context.Context) (*analytics.FlakyReport, error) {
+				return analytics.CollectFlaky(ctx, cli, opts)
+			})
+			if err != nil {
+				return err
+			}
+			if top > 0 && len(report.Cases) > top {
+				report.Cases = report.Cases[:top]
+			}
+
+			if outPath == "" {
+				if err := writeFlaky(cmd.OutOrStdout(), report, format); err != nil {
+					return err
+				}
+			} else {
+				err := saveTo(outPath, func(w io.Writer) error { return writeFlaky(w, report, format) })
+				if err != nil {
+					return err
+				}
+				if !quiet {
+					ui.Successf(os.Stderr, "Report saved to %s", outPath)
+				}
+			}
+			if !quiet {
+				ui.Infof(os.Stderr, "%d of %d cases flip across %d runs", len(report.Cases), report.Analyzed, len(report.Runs))
+			}
+
+			if label == "" {
+				return nil
+			}
+			return labelFlaky(cmd.Context(), cli, report.Cases, label, isDryRun, quiet)
+		},
+	}
+
+	cmd.Flags().Int64("project-id", 0, "Project ID (required)")
+	cmd.Flags().Int64("suite-id", 0, "Only runs of this suite")
+	cmd.Flags().Int64("milestone-id", 0, "Only runs of this milestone")
+	cmd.Flags().Int("last", analytics.DefaultFlakyRuns, "Number of most recent runs to analyze")
+	cmd.Flags().Int("min-results", 3, "Minimum passed/failed results for a case to be ranked")
+	cmd.Flags().Int("top", 0, "Show only the N most unstable cases (0 = all)")
+	cmd.Flags().Int("parallel", 5, "Maximum number of runs fetched in parallel")
+	cmd.Flags().String("label", "", "Add this label to the latest test of every ranked case")
+	cmd.Flags().Bool("dry-run", false, "Show the labels --label would set without setting them")
+	cmd.Flags().StringP("output", "o", "", "Save the report to this file (.json, .csv, .md or .html)")
+
+	return cmd
+}
+
+// writeFlaky renders the flakiness report in format to w.
+func writeFlaky(w io.Writer, r *analytics.FlakyReport, format ui.OutputFormat) error {
+	if format == ui.FormatJSON {
+		return writeJSON(w, r)
+	}
+
+	t := table.NewWriter()
+	t.SetStyle(table.StyleRounded)
+	t.AppendHeader(table.Row{"Case", "Title", "Results", "Passed", "Failed", "Flips", "Flip rate", "Fail streak", "Longest", "Last passed", "MTTF"})
+	for _, c := range r.Cases {
+		lastPassed, mttf := "never", "-"
+		if c.LastPassedOn > 0 {
+			lastPassed = time.Unix(c.LastPassedOn, 0).UTC().Format("2006-01-02 15:04")
+		}
+		if c.Fixes > 0 {
+			mttf = analytics.FormatSeconds(c.MeanTimeToFix)
+		}
+		t.AppendRow(table.Row{
+			"C" + strconv.FormatInt(c.CaseID, 10), c.Title, c.Results, c.Passed, c.Failed, c.Flips,
+			strconv.FormatFloat(c.FlipRate, 'f', 2, 64), c.FailStreak, c.LongestFailStreak, lastPassed, mttf,
+		})
+	}
+
+	var out string
+	switch format {
+	case ui.FormatCSV:
+		out = t.RenderCSV()
+	case ui.FormatMarkdown:
+		out = t.RenderMarkdown()
+	case ui.FormatHTML:
+		out = t.RenderHTML()
+	default:
+		out = t.Render()
+	}
+	_, err := fmt.Fprintln(w, out)
+	return err
+}
+
+// labelFlaky adds label to the test of each case in its latest run, one
+// update_tests_labels call per run.
+func labelFlaky(ctx context.Context, cli client.ClientInterface, cases []analytics.FlakyCase, label string, isDryRun, quiet bool) error {
+	byRun := make(map[int64][]int64)
+	var runIDs []int64
+	for _, c := range cases {
+		if _, ok := byRun[c.LastRunID]; !ok {
+			runIDs = append(runIDs, c.LastRunID)
+		}
+		byRun[c.LastRunID] = append(byRun[c.LastRunID], c.LastTestID)
+	}
+	slices.Sort(runIDs)
+
+	dr := output.NewDryRunPrinter("analyze flaky")
+	for _, runID := range runIDs {
+		testIDs := byRun[runID]
+		if isDryRun {
+			dr.PrintOperation(
+				fmt.Sprintf("Label %d tests of run %d as %q", len(testIDs), runID, label),
+				"POST",
+				fmt.Sprintf("/index.php?/api/v2/update_tests_labels/%d", runID),
+				data.UpdateTestsLabelsRequest{TestIDs: testIDs, Labels: []string{label}},
+			)
+			continue
+		}
+		if err := cli.UpdateTestsLabels(ctx, runID, testIDs, []string{label}); err != nil {
+			return fmt.Errorf("failed to label tests of run %d: %w", runID, err)
+		}
+		if !quiet {
+			ui.Successf(os.Stderr, "Labeled %d tests of run %d as %q", len(testIDs), runID, label)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package analyze
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Korrnals/gotr/internal/flags"
+	"github.com/Korrnals/gotr/internal/service/analytics"
+	"github.com/Korrnals/gotr/internal/ui"
+	"github.com/Korrnals/gotr/pkg/reporter"
+	"github.com/spf13/cobra"
+)
+
+// terminalListLimit is how many failed or blocked tests the terminal
+// summary lists; the documents list all of them.
+const terminalListLimit = 20
+
+// kindHelp is the help text of the subcommand of each report kind.
+var kindHelp = map[string]struct{ short, scope, example string }{
+	analytics.KindRun: {
+		short: "Analyze a test run",
+		scope: "the tests of the run",
+		example: `  # Summary in the terminal
+  gotr analyze run 1234
+
+  # Self-contained HTML report
+  gotr analyze run 1234 -o run-1234.html`,
+	},
+	analytics.KindPlan: {
+		short: "Analyze a test plan",
+		scope: "the tests of all runs of the plan's entries",
+		example: `  # Markdown report, e.g. for a wiki page or a pull request
+  gotr analyze plan 77 --format md > plan-77.md
+
+  # JSON for further processing
+  gotr analyze plan 77 --format json | jq '.by_assignee'`,
+	},
+	analytics.KindMilestone: {
+		short: "Analyze a milestone",
+		scope: "the tests of the milestone's runs and of the runs of its plans\n(sub-milestones are not included)",
+		example: `  # Release readiness summary
+  gotr analyze milestone 12
+
+  # HTML report for the release review
+  gotr analyze milestone 12 -o release-12.html`,
+	},
+}
+
+// newAnalyzeCmd creates the 'analyze <kind>' command.
+// Endpoints: get_run/get_plan/get_milestone, get_tests, get_results_for_run,
+// get_statuses, get_priorities, get_users, get_sections, get_cases
+func newAnalyzeCmd(kind string, getClient GetClientFunc) *cobra.Command {
+	help := kindHelp[kind]
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("%s <%s_id>", kind, kind),
+		Short: help.short,
+		Long: fmt.Sprintf(`%s and reports pass rates by section, assignee
+and priority, failed and blocked tests with defects, and elapsed time
+against estimates. The statistics cover %s.
+
+--format table (default) prints a summary; json, md and html print the full
+report. With -o the report is saved to a file, its format taken from the file
+extension (.json, .md, .html) unless --format is given.`, help.short, help.scope),
+		Example: help.example,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := flags.ValidateRequiredID(args, 0, kind+"_id")
+			if err != nil {
+				return err
+			}
+			outPath, _ := cmd.Flags().GetString("output")
+			format, err := reportFormat(cmd, outPath, ui.FormatJSON, ui.FormatMarkdown, ui.FormatHTML)
+			if err != nil {
+				return err
+			}
+			quiet, _ := cmd.Flags().GetBool("quiet")
+			cli := getClient(cmd)
+
+			in, err := ui.RunWithStatus(cmd.Context(), ui.StatusConfig{
+				Title:  fmt.Sprintf("Collecting %s %d...", kind, id),
+				Writer: os.Stderr,
+				Quiet:  quiet,
+			}, func(ctx context.Context) (analytics.Input, error) {
+				return analytics.Collect(ctx, cli, kind, id)
+			})
+			if err != nil {
+				return err
+			}
+			report := analytics.Build(in)
+
+			if outPath == "" {
+				return writeReport(cmd.OutOrStdout(), report, format)
+			}
+			err = saveTo(outPath, func(w io.Writer) error { return writeReport(w, report, format) })
+			if err != nil {
+				return err
+			}
+			if !quiet {
+				ui.Successf(os.Stderr, "Report saved to %s", outPath)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "", "Save the report to this file (.json, .md or .html)")
+
+	return cmd
+}
+
+// fileFormats maps output file extensions to formats.
+var fileFormats = map[string]ui.OutputFormat{
+	".json":     ui.FormatJSON,
+	".csv":      ui.FormatCSV,
+	".md":       ui.FormatMarkdown,
+	".markdown": ui.FormatMarkdown,
+	".html":     ui.FormatHTML,
+	".htm":      ui.FormatHTML,
+}
+
+// reportFormat returns the output format from --format, or from the
+// extension of the output file when --format is not given. Besides table,
+// only the given formats are accepted.
+func reportFormat(cmd *cobra.Command, outPath string, formats ...ui.OutputFormat) (ui.OutputFormat, error) {
+	names := make([]string, 0, len(formats))
+	for _, f := range formats {
+		names = append(names, string(f))
+	}
+	list := strings.Join(names, ", ")
+
+	format := ui.FormatTable
+	changed := false
+	if f := cmd.Flags().Lookup("format"); f != nil {
+		format = ui.OutputFormat(f.Value.String())
+		changed = f.Changed
+	}
+
+	if outPath != "" && !changed {
+		if f, ok := fileFormats[strings.ToLower(filepath.Ext(outPath))]; ok && slices.Contains(formats, f) {
+			return f, nil
+		}
+		return "", fmt.Errorf("cannot tell the report format from %s: use --format %s", outPath, list)
+	}
+
+	if format == ui.FormatTable {
+		if outPath != "" {
+			return "", fmt.Errorf("--format table prints to the terminal only: use --format %s with -o", list)
+		}
+		return format, nil
+	}
+	if !slices.Contains(formats, format) {
+		return "", fmt.Errorf("unsupported format %q for this report: use table, %s", format, list)
+	}
+	return format, nil
+}
+
+// saveTo writes a report to path with write, creating missing directories.
+func saveTo(path string, write func(io.Writer) error) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	err = write(f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeReport renders the report in format to w.
+func writeReport(w io.Writer, r *analytics.Report, format ui.OutputFormat) error {
+	switch format {
+	case ui.FormatJSON:
+		return writeJSON(w, r)
+	case ui.FormatMarkdown:
+		return analytics.Markdown(w, r)
+	case ui.FormatHTML:
+		return analytics.HTML(w, r)
+	}
+	printSummary(w, r)
+	return nil
+}
+
+// writeJSON writes v as indented JSON.
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printSummary prints the terminal summary of the report.
+func printSummary(w io.Writer, r *analytics.Report) {
+	s := r.Summary
+	rep := reporter.New(analytics.Title(r)).Writer(w).
+		Section("Summary").
+		Stat("📦", "Tests", s.Total).
+		Stat("✅", "Passed", s.Passed).
+		Stat("❌", "Failed", s.Failed).
+		Stat("⚠️", "Blocked", s.Blocked).
+		StatIf(s.Retest > 0, "🔄", "Retest", s.Retest).
+		StatIf(s.Other > 0, "📋", "Other statuses", s.Other).
+		Stat("📄", "Untested", s.Untested).
+		StatFmt("📈", "Pass rate", "%.1f%% of %d tested", s.PassRate, s.Tested()).
+		Section("Time").
+		Stat("⏱️", "Elapsed", analytics.FormatSeconds(r.Time.Elapsed)).
+		StatFmt("⏱️", "Estimate", "%s (%d tests)", analytics.FormatSeconds(r.Time.Estimate), r.Time.EstimatedTests).
+		Stat("⚠️", "Over estimate", r.Time.OverEstimate).
+		Stat("📄", "Untested estimate", analytics.FormatSeconds(r.Time.UntestedEstimate))
+
+	if len(r.Runs) > 1 {
+		groupSection(rep, "By run", r.Runs)
+	}
+	groupSection(rep, "By section", r.BySection)
+	groupSection(rep, "By assignee", r.ByAssignee)
+	groupSection(rep, "By priority", r.ByPriority)
+	problemSection(rep, "❌", "Failed", r.Failed)
+	problemSection(rep, "⚠️", "Blocked", r.Blocked)
+	rep.Print()
+}
+
+func groupSection(rep *reporter.Report, name string, groups []analytics.Group) {
+	rep.Section(name)
+	for _, g := range groups {
+		rep.StatFmt("🔹", g.Name, "%.1f%% (%d/%d passed, %d failed, %d blocked, %d untested)",
+			g.PassRate, g.Passed, g.Tested(), g.Failed, g.Blocked, g.Untested)
+	}
+}
+
+func problemSection(rep *reporter.Report, icon, name string, problems []analytics.Problem) {
+	if len(problems) == 0 {
+		return
+	}
+	rep.Section(fmt.Sprintf("%s (%d)", name, len(problems)))
+	for i, p := range problems {
+		if i == terminalListLimit {
+			rep.StatFmt("📋", "More", "%d more, see --format md or html", len(problems)-i)
+			break
+		}
+		value := p.Title
+		if len(p.Defects) > 0 {
+			value += " [" + strings.Join(p.Defects, ", ") + "]"
+		}
+		rep.Stat(icon, fmt.Sprintf("T%d", p.TestID), value)
+	}
+}
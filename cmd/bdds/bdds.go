@@ -22,11 +22,15 @@ enable writing tests in a business-readable language.
 
 Available operations:
   • get — retrieve a BDD scenario for a test case
-  • add — add a BDD scenario to a test case`,
+  • add — add a BDD scenario to a test case
+  • export — write the scenarios of a suite to .feature files
+  • import — push edited .feature files back to their cases`,
 	}
 
 	bddsCmd.AddCommand(newGetCmd(getClient))
 	bddsCmd.AddCommand(newAddCmd(getClient))
+	bddsCmd.AddCommand(newExportCmd(getClient))
+	bddsCmd.AddCommand(newImportCmd(getClient))
 
 	root.AddCommand(bddsCmd)
 }
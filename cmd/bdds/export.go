@@ -0,0 +1,200 @@
+package bdds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/Korrnals/gotr/internal/output"
+	"github.com/Korrnals/gotr/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// maxBDDConcurrency limits parallel get_bdd/update_case calls.
+const maxBDDConcurrency = 5
+
+// newExportCmd creates the 'bdds export' command.
+// Endpoint: GET /get_bdd/{test_case_id} for every case of the suite
+func newExportCmd(getClient GetClientFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export BDD scenarios of a suite to .feature files",
+		Long: `Write the BDD scenario of every case in a suite to its own .feature file.
+
+Files are placed in a directory tree that mirrors the sections of the suite
+and named C<case_id>_<title>.feature. Each feature gets a @C<case_id> tag,
+which 'gotr bdds import' uses to push edited files back.
+Cases without a BDD scenario are skipped; any other API error fails the
+export and is reported per case.`,
+		Example: `  # Export suite 20069 into ./features
+  gotr bdds export --suite-id 20069 --dir features
+
+  # Edit, then push the changes back
+  gotr bdds import features`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cli := getClient(cmd)
+			ctx := cmd.Context()
+			if cli == nil {
+				return fmt.Errorf("HTTP client not initialized")
+			}
+
+			suiteID, _ := cmd.Flags().GetInt64("suite-id")
+			if suiteID <= 0 {
+				return fmt.Errorf("--suite-id is required")
+			}
+			projectID, _ := cmd.Flags().GetInt64("project-id")
+			dir, _ := cmd.Flags().GetString("dir")
+			quiet, _ := cmd.Flags().GetBool("quiet")
+
+			if isDryRun, _ := cmd.Flags().GetBool("dry-run"); isDryRun {
+				dr := output.NewDryRunPrinter("bdds export")
+				dr.PrintSimple("Export BDD", fmt.Sprintf("Suite ID: %d → %s", suiteID, dir))
+				return nil
+			}
+
+			res, err := ui.RunWithStatus(ctx, ui.StatusConfig{
+				Title:  fmt.Sprintf("Exporting BDD scenarios of suite %d", suiteID),
+				Writer: os.Stderr,
+				Quiet:  quiet,
+			}, func(ctx context.Context) (*bddExportResult, error) {
+				return exportBDDs(ctx, cli, projectID, suiteID, dir)
+			})
+			if err != nil {
+				return err
+			}
+
+			ui.Successf(os.Stdout, "Exported %d feature files to %s", len(res.Files), dir)
+			if res.Skipped > 0 && !quiet {
+				ui.Infof(os.Stdout, "Skipped %d cases without a BDD scenario", res.Skipped)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64("project-id", 0, "Project ID (resolved from the suite if omitted)")
+	cmd.Flags().Int64("suite-id", 0, "Suite ID (required)")
+	cmd.Flags().String("dir", "features", "Output directory")
+	cmd.Flags().Bool("dry-run", false, "Show what would be done without making changes")
+	_ = cmd.MarkFlagRequired("suite-id")
+
+	return cmd
+}
+
+// bddExportResult lists the written files.
+type bddExportResult struct {
+	Files   []string
+	Skipped int // cases without a BDD scenario
+}
+
+func exportBDDs(ctx context.Context, cli client.ClientInterface, projectID, suiteID int64, dir string) (*bddExportResult, error) {
+	if projectID <= 0 {
+		suite, err := cli.GetSuite(ctx, suiteID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get suite %d: %w", suiteID, err)
+		}
+		projectID = suite.ProjectID
+	}
+
+	sections, err := cli.GetSections(ctx, projectID, suiteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sections: %w", err)
+	}
+	cases, err := cli.GetCases(ctx, projectID, suiteID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cases: %w", err)
+	}
+	dirs := sectionDirs(sections)
+	templates, err := cli.GetTemplates(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get templates: %w", err)
+	}
+	bddTemplates := bddTemplateIDs(templates)
+
+	res := &bddExportResult{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var errs []error
+	sem := make(chan struct{}, maxBDDConcurrency)
+
+	for _, c := range cases {
+		if !isBDDCase(c, bddTemplates) {
+			res.Skipped++
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(c data.Case) {
+			defer func() { <-sem }()
+			defer wg.Done()
+
+			bdd, err := cli.GetBDD(ctx, c.ID)
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+				return
+			}
+			if bdd == nil || strings.TrimSpace(bdd.Content) == "" {
+				mu.Lock()
+				res.Skipped++
+				mu.Unlock()
+				return
+			}
+
+			path := filepath.Join(dir, dirs[c.SectionID], featureFileName(c))
+			err = os.MkdirAll(filepath.Dir(path), 0o755)
+			if err == nil {
+				err = os.WriteFile(path, []byte(withCaseTag(bdd.Content, c.ID)), 0o644)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to write %s: %w", path, err))
+				return
+			}
+			res.Files = append(res.Files, path)
+		}(c)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to export %d of %d cases: %w", len(errs), len(cases), errors.Join(errs...))
+	}
+	sort.Strings(res.Files)
+	return res, nil
+}
+
+// bddScenarioField is the case field of TestRail's BDD template.
+const bddScenarioField = "custom_testrail_bdd_scenario"
+
+// bddTemplateIDs returns the IDs of the BDD case templates: TestRail's
+// "Behaviour Driven Development" template and templates named after it.
+func bddTemplateIDs(templates data.GetTemplatesResponse) map[int64]bool {
+	ids := make(map[int64]bool)
+	for _, t := range templates {
+		name := strings.ToLower(t.Name)
+		if strings.Contains(name, "behaviour driven") || strings.Contains(name, "behavior driven") || strings.Contains(name, "bdd") {
+			ids[t.ID] = true
+		}
+	}
+	return ids
+}
+
+// isBDDCase reports whether c uses a BDD template or carries a BDD scenario;
+// get_bdd rejects other cases.
+func isBDDCase(c data.Case, bddTemplates map[int64]bool) bool {
+	if bddTemplates[c.TemplateID] {
+		return true
+	}
+	_, ok := c.CustomFields[bddScenarioField]
+	return ok
+}
@@ -0,0 +1,116 @@
+package bdds
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportMock() *client.MockClient {
+	return &client.MockClient{
+		GetSuiteFunc: func(ctx context.Context, suiteID int64) (*data.Suite, error) {
+			return &data.Suite{ID: suiteID, ProjectID: 3}, nil
+		},
+		GetSectionsFunc: func(ctx context.Context, projectID, suiteID int64) (data.GetSectionsResponse, error) {
+			return data.GetSectionsResponse{{ID: 1, Name: "Auth"}, {ID: 2, Name: "Login", ParentID: 1}}, nil
+		},
+		GetCasesFunc: func(ctx context.Context, projectID, suiteID, sectionID int64) (data.GetCasesResponse, error) {
+			return data.GetCasesResponse{
+				{ID: 10, Title: "Valid login", SectionID: 2, TemplateID: 4},
+				{ID: 11, Title: "Plain case", SectionID: 1, TemplateID: 1},
+				{ID: 12, Title: "Checkout", SectionID: 1, TemplateID: 1,
+					CustomFields: data.CustomFieldValues{bddScenarioField: []byte(`"Feature: Checkout"`)}},
+			}, nil
+		},
+		GetTemplatesFunc: func(ctx context.Context, projectID int64) (data.GetTemplatesResponse, error) {
+			return data.GetTemplatesResponse{{ID: 1, Name: "Test Case (Text)"}, {ID: 4, Name: "Behaviour Driven Development"}}, nil
+		},
+		GetBDDFunc: func(ctx context.Context, caseID int64) (*data.BDD, error) {
+			if caseID == 11 {
+				return nil, fmt.Errorf("get_bdd must not be called for a case that is not BDD")
+			}
+			return &data.BDD{CaseID: caseID, Content: "Feature: Login\n  Scenario: ok\n"}, nil
+		},
+	}
+}
+
+func TestExportCmd_Success(t *testing.T) {
+	dir := t.TempDir()
+	cmd := newExportCmd(getClientForTests)
+	cmd.SetContext(setupTestCmd(t, exportMock()).Context())
+	cmd.SetArgs([]string{"--suite-id", "5", "--dir", dir})
+
+	require.NoError(t, cmd.Execute())
+
+	content, err := os.ReadFile(filepath.Join(dir, "Auth", "Login", "C10_Valid login.feature"))
+	require.NoError(t, err)
+	assert.Equal(t, "@C10\nFeature: Login\n  Scenario: ok\n", string(content))
+
+	assert.FileExists(t, filepath.Join(dir, "Auth", "C12_Checkout.feature"), "a case with a BDD scenario field is exported")
+	entries, err := os.ReadDir(filepath.Join(dir, "Auth"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "case without BDD is skipped")
+}
+
+func TestExportCmd_WritesRawFeatureFromServer(t *testing.T) {
+	const feature = "Feature: Login\n  Scenario: ok\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// get_bdd returns the .feature text, not JSON
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(feature))
+	}))
+	defer server.Close()
+	httpClient, err := client.NewClient(server.URL, "u", "k", false)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	mock := exportMock()
+	mock.GetBDDFunc = httpClient.GetBDD
+	cmd := newExportCmd(getClientForTests)
+	cmd.SetContext(setupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"--suite-id", "5", "--dir", dir})
+
+	require.NoError(t, cmd.Execute())
+	content, err := os.ReadFile(filepath.Join(dir, "Auth", "Login", "C10_Valid login.feature"))
+	require.NoError(t, err)
+	assert.Equal(t, "@C10\n"+feature, string(content))
+}
+
+func TestExportCmd_GetBDDErrorIsReturned(t *testing.T) {
+	dir := t.TempDir()
+	mock := exportMock()
+	mock.GetBDDFunc = func(ctx context.Context, caseID int64) (*data.BDD, error) {
+		if caseID == 12 {
+			return nil, fmt.Errorf("error getting BDD for case 12: API returned 403 Forbidden: no access")
+		}
+		return &data.BDD{CaseID: caseID, Content: "Feature: Login\n"}, nil
+	}
+	cmd := newExportCmd(getClientForTests)
+	cmd.SetContext(setupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"--suite-id", "5", "--dir", dir})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to export 1 of 3 cases")
+	assert.Contains(t, err.Error(), "403 Forbidden")
+}
+
+func TestExportCmd_DryRun(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	cmd := newExportCmd(getClientForTests)
+	cmd.SetContext(setupTestCmd(t, &client.MockClient{}).Context())
+	cmd.SetArgs([]string{"--suite-id", "5", "--dir", dir, "--dry-run"})
+
+	require.NoError(t, cmd.Execute())
+	assert.NoDirExists(t, dir)
+}
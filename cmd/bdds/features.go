@@ -0,0 +1,122 @@
+package bdds
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// featureExt is the extension of exported Gherkin files.
+const featureExt = ".feature"
+
+// maxSlugLength limits the title part of a feature file name.
+const maxSlugLength = 60
+
+// caseTagRe matches the @C123 tag that links a feature file to its case.
+var caseTagRe = regexp.MustCompile(`(?:^|\s)@C(\d+)\b`)
+
+// unsafePathChars are replaced in section and file names.
+var unsafePathChars = regexp.MustCompile(`[<>:"/\\|?*\x00-\x1f]+`)
+
+// caseTag returns the tag that links a feature to a case.
+func caseTag(caseID int64) string {
+	return "@C" + strconv.FormatInt(caseID, 10)
+}
+
+// featureCaseIDs returns the distinct case IDs tagged on the feature (tag
+// lines before Feature:); scenario tags are ignored.
+func featureCaseIDs(content string) []int64 {
+	var ids []int64
+	seen := make(map[int64]bool)
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Feature:") {
+			break
+		}
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+		for _, m := range caseTagRe.FindAllStringSubmatch(line, -1) {
+			id, err := strconv.ParseInt(m[1], 10, 64)
+			if err == nil && !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// withCaseTag makes sure the feature carries the @C tag of its case: the tag
+// is added on its own line right before the Feature: line (or at the top).
+func withCaseTag(content string, caseID int64) string {
+	for _, id := range featureCaseIDs(content) {
+		if id == caseID {
+			return content
+		}
+	}
+
+	tag := caseTag(caseID)
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "Feature:") {
+			indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+			// Extend an existing tag line above Feature:
+			if i > 0 && strings.HasPrefix(strings.TrimSpace(lines[i-1]), "@") {
+				lines[i-1] = strings.TrimRight(lines[i-1], " \t") + " " + tag
+				return strings.Join(lines, "\n")
+			}
+			lines = append(lines[:i], append([]string{indent + tag}, lines[i:]...)...)
+			return strings.Join(lines, "\n")
+		}
+	}
+	return tag + "\n" + content
+}
+
+// sanitizePathPart makes a section or case title safe as a path component.
+func sanitizePathPart(s string) string {
+	s = strings.TrimSpace(unsafePathChars.ReplaceAllString(s, "_"))
+	s = strings.Trim(s, ". ")
+	if s == "" {
+		return "_"
+	}
+	return s
+}
+
+// featureFileName returns "C123_<title>.feature".
+func featureFileName(c data.Case) string {
+	slug := sanitizePathPart(c.Title)
+	if r := []rune(slug); len(r) > maxSlugLength {
+		slug = strings.TrimSpace(string(r[:maxSlugLength]))
+	}
+	return fmt.Sprintf("C%d_%s%s", c.ID, slug, featureExt)
+}
+
+// sectionDirs maps section IDs to their relative directory (parent sections
+// first). Unknown parents end the chain.
+func sectionDirs(sections data.GetSectionsResponse) map[int64]string {
+	byID := make(map[int64]data.Section, len(sections))
+	for _, s := range sections {
+		byID[s.ID] = s
+	}
+
+	dirs := make(map[int64]string, len(sections))
+	for _, s := range sections {
+		var parts []string
+		seen := make(map[int64]bool)
+		for cur, ok := s, true; ok && !seen[cur.ID]; cur, ok = byID[cur.ParentID] {
+			seen[cur.ID] = true
+			parts = append([]string{sanitizePathPart(cur.Name)}, parts...)
+			if cur.ParentID == 0 {
+				break
+			}
+		}
+		dirs[s.ID] = filepath.Join(parts...)
+	}
+	return dirs
+}
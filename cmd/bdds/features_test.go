@@ -0,0 +1,37 @@
+package bdds
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatureCaseIDs(t *testing.T) {
+	content := "@smoke @C12\n@C13 @C12\nFeature: Login\n\n  @C99\n  Scenario: ok\n"
+	assert.Equal(t, []int64{12, 13}, featureCaseIDs(content), "scenario tags are ignored")
+	assert.Empty(t, featureCaseIDs("Feature: untagged\n"))
+}
+
+func TestWithCaseTag(t *testing.T) {
+	assert.Equal(t, "@C5\nFeature: A\n", withCaseTag("Feature: A\n", 5))
+	assert.Equal(t, "# language: en\n@smoke @C5\nFeature: A", withCaseTag("# language: en\n@smoke\nFeature: A", 5))
+	assert.Equal(t, "@C5\nFeature: A", withCaseTag("@C5\nFeature: A", 5), "already tagged")
+	assert.Equal(t, "@C5\nGiven x", withCaseTag("Given x", 5))
+}
+
+func TestFeatureFileName(t *testing.T) {
+	assert.Equal(t, "C7_Log in_out.feature", featureFileName(data.Case{ID: 7, Title: "Log in/out"}))
+}
+
+func TestSectionDirs(t *testing.T) {
+	dirs := sectionDirs(data.GetSectionsResponse{
+		{ID: 1, Name: "Auth"},
+		{ID: 2, Name: "Login", ParentID: 1},
+		{ID: 3, Name: "Orphan", ParentID: 99},
+	})
+	assert.Equal(t, "Auth", dirs[1])
+	assert.Equal(t, filepath.Join("Auth", "Login"), dirs[2])
+	assert.Equal(t, "Orphan", dirs[3])
+}
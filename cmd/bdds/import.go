@@ -0,0 +1,178 @@
+package bdds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/Korrnals/gotr/internal/output"
+	"github.com/Korrnals/gotr/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// featureFile is a feature file linked to a case by its @C tag.
+type featureFile struct {
+	Path    string
+	CaseID  int64
+	Content string
+}
+
+// featureProblem is a feature file that cannot be imported.
+type featureProblem struct {
+	Path   string
+	Reason string
+}
+
+// newImportCmd creates the 'bdds import' command.
+// Endpoint: POST /update_case/{case_id} with custom_testrail_bdd_scenario for every feature file
+func newImportCmd(getClient GetClientFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <dir>",
+		Short: "Import .feature files back into their cases",
+		Long: `Push edited .feature files back to TestRail.
+
+Every *.feature file under the directory is matched to its case by the
+@C<case_id> tag above the Feature: line (as written by 'gotr bdds export')
+and replaces the BDD scenario of that case. Files without a tag, or with
+tags of several cases, are skipped and listed.`,
+		Example: `  # Preview which files go to which cases
+  gotr bdds import features --dry-run
+
+  # Update the scenarios
+  gotr bdds import features`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cli := getClient(cmd)
+			ctx := cmd.Context()
+
+			files, problems, err := scanFeatureFiles(args[0])
+			if err != nil {
+				return err
+			}
+			for _, p := range problems {
+				ui.Warningf(os.Stderr, "Skipping %s: %s", p.Path, p.Reason)
+			}
+			if len(files) == 0 {
+				return fmt.Errorf("no tagged .feature files found in %s", args[0])
+			}
+
+			if isDryRun, _ := cmd.Flags().GetBool("dry-run"); isDryRun {
+				dr := output.NewDryRunPrinter("bdds import")
+				for _, f := range files {
+					dr.PrintSimple("Update BDD", fmt.Sprintf("%s → case %d", f.Path, f.CaseID))
+				}
+				return nil
+			}
+			if cli == nil {
+				return fmt.Errorf("HTTP client not initialized")
+			}
+
+			quiet, _ := cmd.Flags().GetBool("quiet")
+			failed, err := ui.RunWithStatus(ctx, ui.StatusConfig{
+				Title:  fmt.Sprintf("Importing %d feature files", len(files)),
+				Writer: os.Stderr,
+				Quiet:  quiet,
+			}, func(ctx context.Context) ([]featureProblem, error) {
+				return importFeatures(ctx, cli, files), nil
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, p := range failed {
+				ui.Warningf(os.Stderr, "Failed to import %s: %s", p.Path, p.Reason)
+			}
+			ui.Successf(os.Stdout, "Imported %d of %d feature files", len(files)-len(failed), len(files))
+			if len(failed) > 0 {
+				return fmt.Errorf("%d feature files failed to import", len(failed))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Show what would be done without making changes")
+
+	return cmd
+}
+
+// scanFeatureFiles walks dir for *.feature files and links them to cases.
+func scanFeatureFiles(dir string) ([]featureFile, []featureProblem, error) {
+	var files []featureFile
+	var problems []featureProblem
+	owner := make(map[int64]string)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(path), featureExt) {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		ids := featureCaseIDs(string(content))
+		switch {
+		case len(ids) == 0:
+			problems = append(problems, featureProblem{Path: path, Reason: "no @C<case_id> tag"})
+		case len(ids) > 1:
+			problems = append(problems, featureProblem{Path: path, Reason: fmt.Sprintf("tagged with %d cases", len(ids))})
+		case owner[ids[0]] != "":
+			problems = append(problems, featureProblem{Path: path, Reason: fmt.Sprintf("case %d is already taken by %s", ids[0], owner[ids[0]])})
+		default:
+			owner[ids[0]] = path
+			files = append(files, featureFile{Path: path, CaseID: ids[0], Content: string(content)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to scan %s: %w", dir, err)
+	}
+	return files, problems, nil
+}
+
+// importFeatures writes the files to the BDD scenario field of their cases
+// and returns those that failed. add_bdd is not used: it takes a section ID
+// and creates new cases.
+func importFeatures(ctx context.Context, cli client.ClientInterface, files []featureFile) []featureProblem {
+	var failed []featureProblem
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxBDDConcurrency)
+
+	for _, f := range files {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(f featureFile) {
+			defer func() { <-sem }()
+			defer wg.Done()
+
+			if _, err := cli.UpdateCase(ctx, f.CaseID, bddScenarioRequest(f.Content)); err != nil {
+				mu.Lock()
+				failed = append(failed, featureProblem{Path: f.Path, Reason: err.Error()})
+				mu.Unlock()
+			}
+		}(f)
+	}
+	wg.Wait()
+
+	sort.Slice(failed, func(i, j int) bool { return failed[i].Path < failed[j].Path })
+	return failed
+}
+
+// bddScenarioRequest returns the update_case request that sets the BDD
+// scenario of a case to content.
+func bddScenarioRequest(content string) *data.UpdateCaseRequest {
+	scenario, _ := json.Marshal(content)
+	return &data.UpdateCaseRequest{CustomFields: data.CustomFieldValues{bddScenarioField: scenario}}
+}
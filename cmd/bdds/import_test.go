@@ -0,0 +1,145 @@
+package bdds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFeatures(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestImportCmd_Success(t *testing.T) {
+	dir := writeFeatures(t, map[string]string{
+		"Auth/C10_login.feature": "@C10\nFeature: Login\n",
+		"C11_logout.feature":     "@smoke @C11\nFeature: Logout\n",
+		"untagged.feature":       "Feature: No tag\n",
+		"notes.txt":              "@C12",
+	})
+
+	var mu sync.Mutex
+	got := make(map[int64]string)
+	mock := &client.MockClient{
+		UpdateCaseFunc: func(ctx context.Context, caseID int64, req *data.UpdateCaseRequest) (*data.Case, error) {
+			var content string
+			require.NoError(t, json.Unmarshal(req.CustomFields[bddScenarioField], &content))
+			mu.Lock()
+			defer mu.Unlock()
+			got[caseID] = content
+			return &data.Case{ID: caseID}, nil
+		},
+		AddBDDFunc: func(ctx context.Context, caseID int64, content string) (*data.BDD, error) {
+			t.Fatal("add_bdd creates cases and must not be used")
+			return nil, nil
+		},
+	}
+
+	cmd := newImportCmd(getClientForTests)
+	cmd.SetContext(setupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{dir})
+
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, map[int64]string{
+		10: "@C10\nFeature: Login\n",
+		11: "@smoke @C11\nFeature: Logout\n",
+	}, got)
+}
+
+func TestImportCmd_DryRun(t *testing.T) {
+	dir := writeFeatures(t, map[string]string{"a.feature": "@C1\nFeature: A\n"})
+	mock := &client.MockClient{
+		UpdateCaseFunc: func(ctx context.Context, caseID int64, req *data.UpdateCaseRequest) (*data.Case, error) {
+			t.Fatal("dry-run must not upload")
+			return nil, nil
+		},
+	}
+
+	cmd := newImportCmd(getClientForTests)
+	cmd.SetContext(setupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{dir, "--dry-run"})
+
+	require.NoError(t, cmd.Execute())
+}
+
+func TestImportCmd_Failures(t *testing.T) {
+	dir := writeFeatures(t, map[string]string{"a.feature": "@C1\nFeature: A\n"})
+	mock := &client.MockClient{
+		UpdateCaseFunc: func(ctx context.Context, caseID int64, req *data.UpdateCaseRequest) (*data.Case, error) {
+			return nil, fmt.Errorf("not a BDD case")
+		},
+	}
+
+	cmd := newImportCmd(getClientForTests)
+	cmd.SetContext(setupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{dir})
+
+	assert.ErrorContains(t, cmd.Execute(), "1 feature files failed")
+}
+
+func TestImportCmd_UpdatesCaseScenario(t *testing.T) {
+	dir := writeFeatures(t, map[string]string{"C10_login.feature": "@C10\nFeature: Login\n"})
+
+	var path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+		path, body = r.URL.String(), string(raw)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": 10}`))
+	}))
+	defer server.Close()
+	httpClient, err := client.NewClient(server.URL, "u", "k", false)
+	require.NoError(t, err)
+
+	cmd := newImportCmd(getClientForTests)
+	cmd.SetContext(setupTestCmd(t, &client.MockClient{UpdateCaseFunc: httpClient.UpdateCase}).Context())
+	cmd.SetArgs([]string{dir})
+
+	require.NoError(t, cmd.Execute())
+	assert.True(t, strings.HasSuffix(path, "update_case/10"), path)
+	assert.JSONEq(t, `{"custom_testrail_bdd_scenario": "@C10\nFeature: Login\n"}`, body)
+}
+
+func TestScanFeatureFiles_Problems(t *testing.T) {
+	dir := writeFeatures(t, map[string]string{
+		"a.feature": "@C1 @C2\nFeature: A\n",
+		"b.feature": "@C3\nFeature: B\n",
+		"c.feature": "@C3\nFeature: C\n",
+	})
+
+	files, problems, err := scanFeatureFiles(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, int64(3), files[0].CaseID)
+	require.Len(t, problems, 2)
+	assert.Contains(t, problems[0].Reason, "tagged with 2 cases")
+	assert.Contains(t, problems[1].Reason, "already taken")
+}
+
+func TestImportCmd_NoFeatures(t *testing.T) {
+	cmd := newImportCmd(getClientForTests)
+	cmd.SetContext(setupTestCmd(t, &client.MockClient{}).Context())
+	cmd.SetArgs([]string{t.TempDir()})
+
+	assert.ErrorContains(t, cmd.Execute(), "no tagged .feature files")
+}
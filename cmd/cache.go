@@ -0,0 +1,154 @@
+// cmd/cache.go
+// Response cache management: gotr cache stats|clear
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/cache"
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/paths"
+	"github.com/Korrnals/gotr/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// cacheDirPath locates the cache root; overridable in tests.
+var cacheDirPath = paths.CacheDirPath
+
+// cacheCmd is the parent "cache" command.
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the local response cache",
+	Long: `Manage the on-disk response cache in ~/.gotr/cache.
+
+Reference data — projects, suites, statuses, priorities, case fields,
+case types and templates — is cached per server and user so that
+interactive flows do not fetch it on every invocation. The cache is off by
+default; turn it on with cache.enabled: true.
+
+Only the add/update/delete calls of cached resources drop their entries.
+Other writes (for example label updates on cases) and changes made in the
+TestRail UI are seen only after the TTL expires or with --refresh.
+
+Lifetimes (config, Go durations; 0 disables a resource):
+	cache.ttl.projects     1h
+	cache.ttl.suites       15m
+	cache.ttl.statuses     24h (also priorities, case_fields, case_types, templates)
+	cache.ttl.cases        0   (set e.g. 10m to cache case lists)
+
+Bypass the cache per command with --no-cache;
+--refresh ignores cached entries and stores fresh responses.
+
+Examples:
+	gotr cache stats
+	gotr cache clear
+	gotr cache clear suites projects
+	gotr get suites 30 --refresh`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Override parent PersistentPreRunE (no-op)
+	},
+}
+
+// cacheStatsCmd prints per-resource cache statistics.
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show cache entries per resource",
+	Args:  cobra.NoArgs,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Override parent PersistentPreRunE (no-op)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := cacheDirPath()
+		if err != nil {
+			return err
+		}
+		stats, err := cache.Stats(root, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			return enc.Encode(stats)
+		}
+		if len(stats) == 0 {
+			ui.Infof(cmd.OutOrStdout(), "Cache is empty (%s)", root)
+			return nil
+		}
+		return printCacheStats(cmd.OutOrStdout(), stats)
+	},
+}
+
+func printCacheStats(w io.Writer, stats []cache.ResourceStats) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RESOURCE\tENTRIES\tEXPIRED\tSIZE\tOLDEST")
+	var entries, expired int
+	var size int64
+	for _, st := range stats {
+		oldest := "-"
+		if !st.Oldest.IsZero() {
+			oldest = st.Oldest.Local().Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\n", st.Resource, st.Entries, st.Expired, formatBytes(st.Bytes), oldest)
+		entries += st.Entries
+		expired += st.Expired
+		size += st.Bytes
+	}
+	fmt.Fprintf(tw, "TOTAL\t%d\t%d\t%s\t\n", entries, expired, formatBytes(size))
+	return tw.Flush()
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
+// cacheClearCmd removes cached entries.
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear [resource...]",
+	Short: "Remove cached responses (all or only the given resources)",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Override parent PersistentPreRunE (no-op)
+	},
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return cacheResourceNames(), cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := cacheDirPath()
+		if err != nil {
+			return err
+		}
+		removed, err := cache.Clear(root, args...)
+		if err != nil {
+			return err
+		}
+		ui.Successf(cmd.OutOrStdout(), "Removed %d cached responses", removed)
+		return nil
+	},
+}
+
+func cacheResourceNames() []string {
+	return []string{
+		client.CacheProjects, client.CacheSuites, client.CacheStatuses, client.CachePriorities,
+		client.CacheCaseFields, client.CacheCaseTypes, client.CacheTemplates, client.CacheCases,
+	}
+}
+
+func registerCacheCmd() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+
+	cacheStatsCmd.Flags().Bool("json", false, "Output as JSON")
+}
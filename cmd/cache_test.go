@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/cache"
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useTestCacheDir(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	orig := cacheDirPath
+	cacheDirPath = func() (string, error) { return root, nil }
+	t.Cleanup(func() { cacheDirPath = orig })
+	return root
+}
+
+func TestCacheStatsAndClear(t *testing.T) {
+	root := useTestCacheDir(t)
+	store := cache.New(root, cache.Scope("https://tr.example.com", "qa@example.com"))
+	require.NoError(t, store.Put(client.CacheProjects, "all", time.Hour, []int{1}))
+	require.NoError(t, store.Put(client.CacheSuites, "project:1", time.Hour, []int{2}))
+
+	var out bytes.Buffer
+	cacheStatsCmd.SetOut(&out)
+	require.NoError(t, cacheStatsCmd.RunE(cacheStatsCmd, nil))
+	assert.Contains(t, out.String(), "projects")
+	assert.Contains(t, out.String(), "suites")
+	assert.Contains(t, out.String(), "TOTAL")
+
+	out.Reset()
+	cacheClearCmd.SetOut(&out)
+	require.NoError(t, cacheClearCmd.RunE(cacheClearCmd, []string{client.CacheSuites}))
+	stats, err := cache.Stats(root, time.Now())
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, client.CacheProjects, stats[0].Resource)
+
+	require.NoError(t, cacheClearCmd.RunE(cacheClearCmd, nil))
+	stats, err = cache.Stats(root, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+}
+
+func TestWithCache(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	t.Setenv("HOME", t.TempDir())
+	inner := &client.MockClient{}
+
+	cli, err := withCache(inner, "https://tr.example.com", "qa@example.com")
+	require.NoError(t, err)
+	assert.Same(t, inner, cli, "the cache is off by default")
+
+	viper.Set("cache.enabled", true)
+	cli, err = withCache(inner, "https://tr.example.com", "qa@example.com")
+	require.NoError(t, err)
+	assert.IsType(t, &client.CachedClient{}, cli)
+
+	viper.Set("cache.ttl.suites", "soon")
+	_, err = withCache(inner, "https://tr.example.com", "qa@example.com")
+	assert.ErrorContains(t, err, "cache.ttl.suites")
+
+	viper.Set("no_cache", true)
+	cli, err = withCache(inner, "https://tr.example.com", "qa@example.com")
+	require.NoError(t, err)
+	assert.Same(t, inner, cli)
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", formatBytes(512))
+	assert.Equal(t, "1.5 KiB", formatBytes(1536))
+	assert.Equal(t, "2.0 MiB", formatBytes(2<<20))
+}
@@ -10,8 +10,10 @@ import (
 	"github.com/Korrnals/gotr/internal/interactive"
 	"github.com/Korrnals/gotr/internal/models/data"
 	"github.com/Korrnals/gotr/internal/output"
+	"github.com/Korrnals/gotr/internal/trash"
 	"github.com/Korrnals/gotr/internal/ui"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 func runBulkStatus[T any](cmd *cobra.Command, total int, fn func(context.Context) (T, error)) (T, error) {
@@ -120,7 +122,10 @@ func newBulkDeleteCmd(getClient GetClientFunc) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "delete <case_ids...>",
 		Short: "Bulk delete test cases",
-		Long:  `Deletes multiple test cases at once.`,
+		Long: `Deletes multiple test cases at once.
+
+The cases are saved to the trash first; undo with 'gotr trash restore'.
+Use --no-trash to delete without a copy.`,
 		Example: `  # Delete several cases
   gotr cases bulk delete 1,2,3 --suite-id=100
 
@@ -158,20 +163,34 @@ func newBulkDeleteCmd(getClient GetClientFunc) *cobra.Command {
 			}
 
 			cli := getClient(cmd)
+			quiet, _ := cmd.Flags().GetBool("quiet")
+			var entry *trash.Entry
+			if noTrash, _ := cmd.Flags().GetBool("no-trash"); !noTrash {
+				var err error
+				entry, err = trash.SaveBeforeDelete(cmd.Context(), fmt.Sprintf("%d cases", len(caseIDs)), viper.GetString("base_url"), quiet,
+					func(ctx context.Context) (*trash.Entry, error) { return trash.CaptureCases(ctx, cli, caseIDs...) })
+				if err != nil {
+					return err
+				}
+			}
+
 			_, err := runBulkStatus(cmd, len(caseIDs), func(ctx context.Context) (struct{}, error) {
 				return struct{}{}, cli.DeleteCases(ctx, suiteID, &req)
 			})
 			if err != nil {
+				trash.FinishDelete(entry, err, quiet)
 				return fmt.Errorf("failed to delete cases: %w", err)
 			}
 
 			ui.Successf(os.Stdout, "Deleted %d cases", len(caseIDs))
+			trash.FinishDelete(entry, nil, quiet)
 			return nil
 		},
 	}
 
 	cmd.Flags().Bool("dry-run", false, "Preview what will be deleted")
 	cmd.Flags().Int64("suite-id", 0, "Suite ID (required)")
+	cmd.Flags().Bool("no-trash", false, "Delete without saving a copy to the trash")
 
 	return cmd
 }
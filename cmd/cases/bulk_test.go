@@ -2,6 +2,7 @@ package cases
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -9,8 +10,10 @@ import (
 	"github.com/Korrnals/gotr/internal/interactive"
 	"github.com/Korrnals/gotr/internal/models/data"
 	"github.com/Korrnals/gotr/internal/output"
+	"github.com/Korrnals/gotr/internal/trash"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // ==================== Bulk Command Tests ====================
@@ -224,6 +227,58 @@ func TestBulkDeleteCmd_Success(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestBulkDeleteCmd_SavesToTrash(t *testing.T) {
+	root := t.TempDir()
+	original := trash.Dir
+	trash.Dir = func() (string, error) { return root, nil }
+	t.Cleanup(func() { trash.Dir = original })
+
+	mock := &client.MockClient{
+		GetCaseFunc: func(ctx context.Context, caseID int64) (*data.Case, error) {
+			if caseID == 3 {
+				return nil, nil
+			}
+			return &data.Case{ID: caseID, Title: "Case", SectionID: 10, SuiteID: 100}, nil
+		},
+		DeleteCasesFunc: func(ctx context.Context, suiteID int64, req *data.DeleteCasesRequest) error {
+			return nil
+		},
+	}
+
+	cmd := newBulkDeleteCmd(getClientForTests)
+	cmd.SetContext(setupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"1,2,3", "--suite-id=100"})
+	require.NoError(t, cmd.Execute())
+
+	list, err := trash.List(root)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, trash.KindCases, list[0].Kind)
+	assert.Equal(t, []int64{1, 2, 3}, list[0].ObjectIDs)
+	assert.Equal(t, 2, list[0].Counts.Cases)
+
+	// A failed delete drops the entry; --no-trash skips it.
+	mock.DeleteCasesFunc = func(ctx context.Context, suiteID int64, req *data.DeleteCasesRequest) error {
+		return errors.New("forbidden")
+	}
+	cmd = newBulkDeleteCmd(getClientForTests)
+	cmd.SetContext(setupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"1", "--suite-id=100"})
+	assert.Error(t, cmd.Execute())
+
+	mock.DeleteCasesFunc = func(ctx context.Context, suiteID int64, req *data.DeleteCasesRequest) error {
+		return nil
+	}
+	cmd = newBulkDeleteCmd(getClientForTests)
+	cmd.SetContext(setupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"1", "--suite-id=100", "--no-trash"})
+	require.NoError(t, cmd.Execute())
+
+	list, err = trash.List(root)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+}
+
 func TestBulkDeleteCmd_NoCaseIDs(t *testing.T) {
 	mock := &client.MockClient{}
 	cmd := newBulkDeleteCmd(getClientForTests)
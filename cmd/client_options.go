@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/cache"
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/debug"
+	"github.com/Korrnals/gotr/internal/paths"
+	"github.com/spf13/viper"
+)
+
+// ensureClientConfigDefaults registers defaults for HTTP client tuning keys.
+func ensureClientConfigDefaults() {
+	viper.SetDefault("retry.max_retries", 3)
+	viper.SetDefault("retry.initial_delay", "1s")
+	viper.SetDefault("retry.max_delay", "60s")
+	viper.SetDefault("retry.post", false)
+	viper.SetDefault("rate_limit", -1)       // -1 = auto: cloud→by plan tier, server→unlimited
+	viper.SetDefault("cache.enabled", false) // opt-in: only writes through CachedClient invalidate
+}
+
+// resolveClientOptions builds HTTP client options from config/env/flags.
+func resolveClientOptions(baseURL string) ([]client.ClientOption, error) {
+	ensureClientConfigDefaults()
+
+	opts := []client.ClientOption{}
+	if viper.GetBool("insecure") {
+		opts = append(opts, client.WithSkipTlsVerify(true)) // TLS verification is enabled by default
+	}
+
+	maxRetries := viper.GetInt("retry.max_retries")
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+
+	initialDelay, err := parseConfigDuration("retry.initial_delay")
+	if err != nil {
+		return nil, err
+	}
+	maxDelay, err := parseConfigDuration("retry.max_delay")
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts,
+		client.WithMaxRetries(maxRetries),
+		client.WithRetryBackoff(initialDelay, maxDelay),
+		client.WithRetryPost(viper.GetBool("retry.post")),
+		client.WithRateLimit(resolveRateLimit(baseURL)),
+	)
+
+	return opts, nil
+}
+
+// resolveRateLimit returns the process-wide request budget in req/min.
+// A negative rate_limit selects a value by deployment: TestRail Cloud
+// enforces a per-minute quota that depends on the plan, Server has none.
+func resolveRateLimit(baseURL string) int {
+	rateLimit := viper.GetInt("rate_limit")
+	if rateLimit >= 0 {
+		return rateLimit
+	}
+
+	if !strings.Contains(strings.ToLower(baseURL), ".testrail.io") {
+		return 0
+	}
+	if strings.EqualFold(strings.TrimSpace(viper.GetString("compare.cloud_tier")), "enterprise") {
+		return 300
+	}
+	return 180
+}
+
+// parseConfigDuration reads a duration-valued config key; empty means zero.
+func parseConfigDuration(key string) (time.Duration, error) {
+	text := strings.TrimSpace(viper.GetString(key))
+	if text == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s in config: %w", key, err)
+	}
+	return d, nil
+}
+
+// withCache wraps the client with the on-disk response cache when
+// cache.enabled is set and --no-cache is not given. TTLs come from cache.ttl.<resource>.
+func withCache(cli client.ClientInterface, baseURL, username string) (client.ClientInterface, error) {
+	ensureClientConfigDefaults()
+	if viper.GetBool("no_cache") || !viper.GetBool("cache.enabled") {
+		return cli, nil
+	}
+
+	root, err := paths.CacheDirPath()
+	if err != nil {
+		debug.DebugPrint("{cache} - disabled: %v", err)
+		return cli, nil
+	}
+
+	opts := []client.CacheOption{client.WithCacheRefresh(viper.GetBool("refresh"))}
+	for resource := range client.DefaultCacheTTLs {
+		key := "cache.ttl." + resource
+		if !viper.IsSet(key) {
+			continue
+		}
+		ttl, err := parseConfigDuration(key)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, client.WithCacheTTL(resource, ttl))
+	}
+
+	return client.NewCachedClient(cli, cache.New(root, cache.Scope(baseURL, username)), opts...), nil
+}
+
+// uncached strips the response cache from cli, for commands that must see the
+// current server state rather than a cached copy.
+func uncached(cli client.ClientInterface) client.ClientInterface {
+	if cached, ok := cli.(*client.CachedClient); ok {
+		return cached.Unwrap()
+	}
+	return cli
+}
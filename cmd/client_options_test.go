@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveClientOptions_Defaults(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	opts, err := resolveClientOptions("https://example.com")
+	require.NoError(t, err)
+	assert.Len(t, opts, 4)
+	assert.Equal(t, 3, viper.GetInt("retry.max_retries"))
+}
+
+func TestResolveClientOptions_InsecureAddsOption(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set("insecure", true)
+
+	opts, err := resolveClientOptions("https://example.com")
+	require.NoError(t, err)
+	assert.Len(t, opts, 5)
+}
+
+func TestResolveClientOptions_InvalidDuration(t *testing.T) {
+	tests := []struct {
+		key string
+	}{
+		{"retry.initial_delay"},
+		{"retry.max_delay"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			viper.Reset()
+			t.Cleanup(viper.Reset)
+			viper.Set(tt.key, "soon")
+
+			_, err := resolveClientOptions("https://example.com")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.key)
+		})
+	}
+}
+
+func TestRootPersistentPreRunE_InvalidRetryConfig(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	viper.Set("base_url", "https://example.com")
+	viper.Set("username", "qa@example.com")
+	viper.Set("api_key", "api-key")
+	viper.Set("retry.max_delay", "later")
+
+	cmd := &cobra.Command{Use: "test-cmd"}
+	cmd.Flags().Bool("quiet", false, "")
+	cmd.Flags().Bool("non-interactive", false, "")
+	cmd.SetContext(context.Background())
+
+	err := rootCmd.PersistentPreRunE(cmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "retry.max_delay")
+}
+
+func TestResolveRateLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		set      map[string]any
+		baseURL  string
+		expected int
+	}{
+		{"auto server is unlimited", nil, "https://testrail.example.local", 0},
+		{"auto cloud professional", nil, "https://acme.testrail.io", 180},
+		{"auto cloud enterprise", map[string]any{"compare.cloud_tier": "Enterprise"}, "https://acme.testrail.io", 300},
+		{"explicit value wins", map[string]any{"rate_limit": 90}, "https://acme.testrail.io", 90},
+		{"explicit zero disables", map[string]any{"rate_limit": 0}, "https://acme.testrail.io", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			t.Cleanup(viper.Reset)
+			ensureClientConfigDefaults()
+			for k, v := range tt.set {
+				viper.Set(k, v)
+			}
+
+			assert.Equal(t, tt.expected, resolveRateLimit(tt.baseURL))
+		})
+	}
+}
+
+func TestRootCmd_RateLimitFlagRegistered(t *testing.T) {
+	flag := rootCmd.PersistentFlags().Lookup("rate-limit")
+	require.NotNil(t, flag)
+	assert.Equal(t, "-1", flag.DefValue)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/Korrnals/gotr/cmd/analyze"
 	"github.com/Korrnals/gotr/cmd/attachments"
 	"github.com/Korrnals/gotr/cmd/bdds"
 	"github.com/Korrnals/gotr/cmd/cases"
@@ -45,8 +46,12 @@ func init() {
 	registerUpdateCmd()
 	registerExportCmd()
 	registerCompletionCmd()
+	registerCacheCmd()
+	registerSnapshotCmd()
+	registerTrashCmd()
 
 	// Register subpackage commands (pass GetClient* accessor)
+	analyze.Register(rootCmd, GetClient)
 	attachments.Register(rootCmd, GetClient)
 	bdds.Register(rootCmd, GetClient)
 	cases.Register(rootCmd, GetClient)
@@ -63,6 +68,7 @@ func init() {
 	result.Register(rootCmd, GetClientFromCtx)
 	roles.Register(rootCmd, GetClient)
 	sync.Register(rootCmd, GetClientFromCtx)
+	sync.SetProfileClientFunc(newProfileClient)
 	test.Register(rootCmd, GetClientFromCtx)
 	templates.Register(rootCmd, GetClient)
 	tests.Register(rootCmd, GetClient)
@@ -87,6 +93,8 @@ func initGlobalFlags() {
 	rootCmd.PersistentFlags().StringP("api-key", "k", "", "TestRail API key")
 	rootCmd.PersistentFlags().Bool("insecure", false, "Skip TLS certificate verification")
 	rootCmd.PersistentFlags().BoolP("config", "c", false, "Create default config file")
+	rootCmd.PersistentFlags().String("profile", "", "Connection profile from the config file (env: GOTR_PROFILE)")
+	rootCmd.PersistentFlags().Int("rate-limit", -1, "Requests per minute shared by all API calls. -1 = auto by deployment, 0 = unlimited")
 
 	// Hidden debug flag
 	rootCmd.PersistentFlags().BoolP("debug", "d", false, "Enable debug output")
@@ -98,6 +106,13 @@ func initGlobalFlags() {
 	// Non-interactive mode (CI/CD, scripting)
 	rootCmd.PersistentFlags().Bool("non-interactive", false, "Disable interactive prompts; fail if input required")
 
+	// Response cache (~/.gotr/cache)
+	rootCmd.PersistentFlags().Bool("no-cache", false, "Do not read or write the response cache")
+	rootCmd.PersistentFlags().Bool("refresh", false, "Ignore cached responses and refresh them from the server")
+
+	// Offline snapshot (gotr snapshot create)
+	rootCmd.PersistentFlags().String("snapshot", "", "Answer reads from a snapshot directory or archive instead of the server")
+
 	// Global output format
 	rootCmd.PersistentFlags().StringP("format", "f", "table", "Output format: table, json, csv, md, html")
 
@@ -107,6 +122,12 @@ func initGlobalFlags() {
 	must(viper.BindPFlag("api_key", rootCmd.PersistentFlags().Lookup("api-key")))
 	must(viper.BindPFlag("insecure", rootCmd.PersistentFlags().Lookup("insecure")))
 	must(viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug")))
+	must(viper.BindPFlag("rate_limit", rootCmd.PersistentFlags().Lookup("rate-limit")))
+	must(viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile")))
+	must(viper.BindPFlag("no_cache", rootCmd.PersistentFlags().Lookup("no-cache")))
+	must(viper.BindPFlag("refresh", rootCmd.PersistentFlags().Lookup("refresh")))
+	must(viper.BindPFlag("snapshot", rootCmd.PersistentFlags().Lookup("snapshot")))
+	must(rootCmd.RegisterFlagCompletionFunc("profile", completeProfileNames))
 }
 
 // ============================================
@@ -121,11 +142,18 @@ func registerConfigCmd() {
 	configCmd.AddCommand(configPathCmd)
 	configCmd.AddCommand(configViewCmd)
 	configCmd.AddCommand(configEditCmd)
+	configCmd.AddCommand(configProfileCmd)
+
+	// Profile subcommands
+	configProfileCmd.AddCommand(configProfileListCmd)
+	configProfileCmd.AddCommand(configProfileAddCmd)
+	configProfileCmd.AddCommand(configProfileUseCmd)
+	configProfileCmd.AddCommand(configProfileRemoveCmd)
 
 	// Shell completion for "gotr config "
 	configCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) == 0 {
-			return []string{"init", "path", "view", "edit"}, cobra.ShellCompDirectiveNoFileComp
+			return []string{"init", "path", "view", "edit", "profile"}, cobra.ShellCompDirectiveNoFileComp
 		}
 		return nil, cobra.ShellCompDirectiveNoFileComp
 	}
@@ -10,7 +10,7 @@ import (
 func TestConfigCmd_ValidArgsFunction(t *testing.T) {
 	if assert.NotNil(t, configCmd.ValidArgsFunction) {
 		items, directive := configCmd.ValidArgsFunction(configCmd, []string{}, "")
-		assert.ElementsMatch(t, []string{"init", "path", "view", "edit"}, items)
+		assert.ElementsMatch(t, []string{"init", "path", "view", "edit", "profile"}, items)
 		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
 
 		items, directive = configCmd.ValidArgsFunction(configCmd, []string{"init"}, "")
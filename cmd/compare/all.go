@@ -379,23 +379,36 @@ func executeCompareAll(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("HTTP client not initialized")
 	}
 
+	sides, err := openCompareSides(cmd, cli)
+	if err != nil {
+		return err
+	}
+	matching, err := matchOptionsFromFlags(cmd)
+	if err != nil {
+		return err
+	}
 	pid1, pid2, format, savePath, err := parseCommonFlags(cmd, cli)
 	if err != nil {
 		return err
 	}
 
-	project1Name, project2Name, err := GetProjectNames(ctx, cli, pid1, pid2)
+	cli, key1, key2 := sides.bind(pid1, pid2)
+	project1Name, project2Name, err := GetProjectNames(ctx, cli, key1, key2)
 	if err != nil {
 		return err
 	}
 
 	startTime := time.Now()
-	preloadedSuites, suitesPreloadErr := cli.GetSuitesParallel(ctx, []int64{pid1, pid2}, 2, nil)
+	preloadedSuites, suitesPreloadErr := cli.GetSuitesParallel(ctx, []int64{key1, key2}, 2, nil)
 	if suitesPreloadErr != nil {
 		preloadedSuites = nil
 	}
 
-	result, errs, interrupted := runCompareAllResources(ctx, cmd, cli, pid1, pid2, quiet, preloadedSuites)
+	result, errs, interrupted := runCompareAllResources(ctx, cmd, cli, key1, key2, quiet, preloadedSuites)
+	for _, entry := range resourceRegistry {
+		refineMatches(matching, *entry.field(result))
+	}
+	sides.finishAll(pid1, pid2, result)
 
 	elapsed := time.Since(startTime)
 	result.Meta = buildAllMeta(result, interrupted, errs, elapsed)
@@ -75,6 +75,16 @@ Supported fields:
   title, priority_id, type_id, milestone_id, refs, 
 	custom_preconds, custom_steps, custom_expected, and more.
 
+Fields joined with "+" form a composite key, e.g. refs+title or
+section_path+title (the full section path of the case, root first).
+--normalize ignores whitespace, case and punctuation edits; --fuzzy 0.85
+reports unmatched cases that are at least 85% similar as near matches.
+
+With --deep, each pair of common cases is also diffed field by field
+(steps, preconditions, expected results, priority, type, refs, labels and
+custom fields). JSON/YAML/CSV output lists the changes per case; the
+terminal shows a unified diff.
+
 Examples:
 	# Compare cases by title
   gotr compare cases --pid1 30 --pid2 31
@@ -82,6 +92,12 @@ Examples:
 	# Compare by priority
   gotr compare cases --pid1 30 --pid2 31 --field priority_id
 
+	# Match by section path and title, report near-identical titles
+  gotr compare cases --pid1 30 --pid2 31 --field section_path+title --normalize all --fuzzy 0.85
+
+	# Show where the copied cases drifted
+  gotr compare cases --pid1 30 --pid2 31 --deep
+
 	# Save result to the default file
   gotr compare cases --pid1 30 --pid2 31 --save
 
@@ -96,6 +112,14 @@ Examples:
 			}
 
 			// Parse flags
+			sides, err := openCompareSides(cmd, cli)
+			if err != nil {
+				return err
+			}
+			matching, err := matchOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
 			pid1, pid2, format, savePath, err := parseCommonFlags(cmd, cli)
 			if err != nil {
 				return err
@@ -106,9 +130,14 @@ Examples:
 			if field == "" {
 				field = "title"
 			}
+			key, err := parseCaseKey(field)
+			if err != nil {
+				return err
+			}
 
 			// Get project names
-			project1Name, project2Name, err := GetProjectNames(ctx, cli, pid1, pid2)
+			cli, key1, key2 := sides.bind(pid1, pid2)
+			project1Name, project2Name, err := GetProjectNames(ctx, cli, key1, key2)
 			if err != nil {
 				return err
 			}
@@ -116,11 +145,31 @@ Examples:
 			// Start timer
 			startTime := time.Now()
 
+			// Keep the loaded cases for a field-level diff of common pairs
+			// and for matching by other fields than the title
+			deep, _ := cmd.Flags().GetBool("deep")
+			var rec *caseRecorder
+			if deep || !isTitleKey(key) {
+				rec = newCaseRecorder(cli)
+				cli = rec
+			}
+
 			// Execute comparison
-			result, execStats, err := compareCasesInternal(ctx, cmd, cli, pid1, pid2, field)
+			result, execStats, err := compareCasesInternal(ctx, cmd, cli, key1, key2, field)
 			if err != nil {
 				return err
 			}
+			if isTitleKey(key) {
+				refineMatches(matching, result)
+			} else if err := rematchCases(ctx, cli, result, rec, key, matching); err != nil {
+				return err
+			}
+			changed := 0
+			if deep {
+				changed = attachCaseChanges(result, rec)
+				result.Deep = true
+			}
+			sides.finish(pid1, pid2, result)
 
 			elapsed := time.Since(startTime)
 
@@ -128,9 +177,15 @@ Examples:
 			if err := PrintCompareResult(cmd, *result, project1Name, project2Name, format, savePath); err != nil {
 				return err
 			}
+			if deep && savePath == "" && format == "table" {
+				printCaseChanges(os.Stdout, *result)
+			}
 
 			// Print statistics
 			quiet, _ := cmd.Flags().GetBool("quiet")
+			if deep && !quiet {
+				ui.Infof(os.Stderr, "Deep comparison: %d of %d common cases differ", changed, len(result.Common))
+			}
 			if !quiet {
 				PrintCasesStatsWithErrors(
 					pid1,
@@ -149,7 +204,8 @@ Examples:
 
 	// Add flags
 	addCommonFlags(cmd)
-	cmd.Flags().String("field", "title", "Field to compare by (title, priority_id, etc.)")
+	cmd.Flags().String("field", "title", "Field(s) to match cases by: title, refs+title, section_path+title, priority_id, custom_*, ...")
+	cmd.Flags().Bool("deep", false, "Also diff the fields (steps, preconditions, expected results, priority, type, refs, labels, custom fields) of common cases")
 
 	return cmd
 }
@@ -201,6 +257,7 @@ func compareCasesInternal(ctx context.Context, cmd *cobra.Command, cli client.Cl
 	if err != nil {
 		return nil, execStats, err
 	}
+	applyRateLimitFlag(cmd, cli)
 
 	debug.DebugPrint("[Compare] RuntimeConfig: parallelSuites=%d, parallelPages=%d, rateLimit=%d, pageRetries=%d, retryAttempts=%d, retryWorkers=%d, retryDelay=%s",
 		runtimeConfig.ParallelSuites, runtimeConfig.ParallelPages, runtimeConfig.RateLimit,
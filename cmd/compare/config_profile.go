@@ -2,9 +2,15 @@ package compare
 
 import (
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/concurrent"
+	"github.com/Korrnals/gotr/internal/debug"
+	"github.com/Korrnals/gotr/internal/ui"
+	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
@@ -214,6 +220,55 @@ func deriveRateLimitByProfile(deployment, cloudTier string) int {
 	return 180
 }
 
+// applyRateLimitFlag makes an explicit compare --rate-limit govern cli. When
+// the client already limits all its requests (rate_limit, automatic on
+// Cloud), the parallel fetches drop their own bucket, so the flag retunes
+// that shared limiter instead of being ignored. --rate-limit 0 cannot lift
+// the shared limit and only warns.
+func applyRateLimitFlag(cmd *cobra.Command, cli client.ClientInterface) {
+	if cmd == nil {
+		return
+	}
+	flag := cmd.Flags().Lookup("rate-limit")
+	if flag == nil || !flag.Changed {
+		return
+	}
+	limiter := sharedRateLimiter(cli)
+	if limiter == nil {
+		return
+	}
+
+	rateLimit, _ := cmd.Flags().GetInt("rate-limit")
+	switch {
+	case rateLimit > 0:
+		debug.DebugPrint("[Compare] --rate-limit %d replaces the shared limit of %.0f req/min", rateLimit, limiter.CurrentRate())
+		limiter.SetRate(rateLimit)
+	case rateLimit == 0:
+		quiet, _ := cmd.Flags().GetBool("quiet")
+		if !quiet {
+			ui.Warningf(os.Stderr, "--rate-limit 0 does not lift the global limit of %.0f req/min; set rate_limit: 0 in the config to disable it", limiter.CurrentRate())
+		}
+	}
+}
+
+// sharedRateLimiter returns the limiter applied to every request of cli, or
+// nil when there is none (rate limiting off, snapshot or test clients).
+// Wrappers (response cache, --deep recorder, snapshot sides) are unwrapped
+// down to the HTTP client.
+func sharedRateLimiter(cli client.ClientInterface) *concurrent.AdaptiveRateLimiter {
+	for cli != nil {
+		if httpClient, ok := cli.(*client.HTTPClient); ok {
+			return httpClient.RateLimiter()
+		}
+		wrapper, ok := cli.(interface{ Unwrap() client.ClientInterface })
+		if !ok {
+			return nil
+		}
+		cli = wrapper.Unwrap()
+	}
+	return nil
+}
+
 func isFlagProvided(flags map[string]any, key string) bool {
 	_, ok := flags[key]
 	return ok
@@ -4,8 +4,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDeriveRateLimitByProfile(t *testing.T) {
@@ -144,4 +147,57 @@ func TestResolveCompareCasesRuntimeConfig_InvalidDurations(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestApplyRateLimitFlag(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{Use: "cases"}
+		cmd.Flags().Int("rate-limit", -1, "")
+		cmd.Flags().Bool("quiet", true, "")
+		return cmd
+	}
+
+	t.Run("explicit value replaces the shared limit", func(t *testing.T) {
+		cli, err := client.NewClient("https://example.testrail.io", "u", "k", false, client.WithRateLimit(180))
+		require.NoError(t, err)
+		cmd := newCmd()
+		require.NoError(t, cmd.Flags().Set("rate-limit", "60"))
+
+		applyRateLimitFlag(cmd, cli)
+		assert.InDelta(t, 60.0, cli.RateLimiter().CurrentRate(), 0.0001)
+	})
+
+	t.Run("default keeps the shared limit", func(t *testing.T) {
+		cli, err := client.NewClient("https://example.testrail.io", "u", "k", false, client.WithRateLimit(180))
+		require.NoError(t, err)
+
+		applyRateLimitFlag(newCmd(), cli)
+		assert.InDelta(t, 180.0, cli.RateLimiter().CurrentRate(), 0.0001)
+	})
+
+	t.Run("zero cannot lift the shared limit", func(t *testing.T) {
+		cli, err := client.NewClient("https://example.testrail.io", "u", "k", false, client.WithRateLimit(180))
+		require.NoError(t, err)
+		cmd := newCmd()
+		require.NoError(t, cmd.Flags().Set("rate-limit", "0"))
 
+		applyRateLimitFlag(cmd, cli)
+		assert.InDelta(t, 180.0, cli.RateLimiter().CurrentRate(), 0.0001)
+	})
+
+	t.Run("wrapped clients are unwrapped to the shared limiter", func(t *testing.T) {
+		cli, err := client.NewClient("https://example.testrail.io", "u", "k", false, client.WithRateLimit(180))
+		require.NoError(t, err)
+		cmd := newCmd()
+		require.NoError(t, cmd.Flags().Set("rate-limit", "60"))
+
+		// --deep over a live side compared against a snapshot
+		sided := &sidedClient{ClientInterface: &client.MockClient{}, second: cli, source1: "snapshot"}
+		applyRateLimitFlag(cmd, newCaseRecorder(sided))
+		assert.InDelta(t, 60.0, cli.RateLimiter().CurrentRate(), 0.0001)
+	})
+
+	t.Run("clients without a shared limiter are left alone", func(t *testing.T) {
+		cmd := newCmd()
+		require.NoError(t, cmd.Flags().Set("rate-limit", "60"))
+		assert.NotPanics(t, func() { applyRateLimitFlag(cmd, &client.MockClient{}) })
+	})
+}
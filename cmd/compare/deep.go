@@ -0,0 +1,270 @@
+package compare
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/concurrency"
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// FieldChange is one field that differs between two matched cases.
+type FieldChange struct {
+	Field  string `json:"field" yaml:"field"`
+	First  string `json:"first" yaml:"first"`
+	Second string `json:"second" yaml:"second"`
+}
+
+// hasChanges reports whether any common item carries field changes.
+func (r CompareResult) hasChanges() bool {
+	for _, item := range r.Common {
+		if len(item.Changes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// caseRecorder keeps the full cases returned while compare loads a project,
+// by project key, so --deep can diff matched pairs without a second load.
+type caseRecorder struct {
+	client.ClientInterface
+	mu    sync.Mutex
+	cases map[int64]map[int64]data.Case
+}
+
+func newCaseRecorder(cli client.ClientInterface) *caseRecorder {
+	return &caseRecorder{ClientInterface: cli, cases: make(map[int64]map[int64]data.Case)}
+}
+
+// Unwrap returns the client the recorder reads through.
+func (r *caseRecorder) Unwrap() client.ClientInterface {
+	return r.ClientInterface
+}
+
+func (r *caseRecorder) record(projectID int64, cases data.GetCasesResponse) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	byID := r.cases[projectID]
+	if byID == nil {
+		byID = make(map[int64]data.Case, len(cases))
+		r.cases[projectID] = byID
+	}
+	for _, c := range cases {
+		byID[c.ID] = c
+	}
+}
+
+func (r *caseRecorder) lookup(projectID, caseID int64) (data.Case, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.cases[projectID][caseID]
+	return c, ok
+}
+
+func (r *caseRecorder) GetCases(ctx context.Context, projectID, suiteID, sectionID int64) (data.GetCasesResponse, error) {
+	cases, err := r.ClientInterface.GetCases(ctx, projectID, suiteID, sectionID)
+	r.record(projectID, cases)
+	return cases, err
+}
+
+func (r *caseRecorder) GetCasesPage(ctx context.Context, projectID, suiteID int64, offset, limit int) (data.GetCasesResponse, error) {
+	cases, err := r.ClientInterface.GetCasesPage(ctx, projectID, suiteID, offset, limit)
+	r.record(projectID, cases)
+	return cases, err
+}
+
+func (r *caseRecorder) GetCasesParallelCtx(ctx context.Context, projectID int64, suiteIDs []int64, config *concurrency.ControllerConfig) (data.GetCasesResponse, *concurrency.ExecutionResult, error) {
+	cases, result, err := r.ClientInterface.GetCasesParallelCtx(ctx, projectID, suiteIDs, config)
+	r.record(projectID, cases)
+	return cases, result, err
+}
+
+// attachCaseChanges fills Changes of every common pair from the recorded
+// cases and returns the number of pairs that differ.
+func attachCaseChanges(result *CompareResult, rec *caseRecorder) int {
+	changed := 0
+	for i := range result.Common {
+		item := &result.Common[i]
+		c1, ok1 := rec.lookup(result.Project1ID, item.ID1)
+		c2, ok2 := rec.lookup(result.Project2ID, item.ID2)
+		if !ok1 || !ok2 {
+			continue
+		}
+		item.Changes = diffCaseFields(c1, c2)
+		if len(item.Changes) > 0 {
+			changed++
+		}
+	}
+	return changed
+}
+
+// diffCaseFields compares the content of two cases field by field. Custom
+// fields are compared by name, in sorted order, after the built-in ones.
+func diffCaseFields(a, b data.Case) []FieldChange {
+	var changes []FieldChange
+	add := func(field, first, second string) {
+		if first != second {
+			changes = append(changes, FieldChange{Field: field, First: first, Second: second})
+		}
+	}
+
+	add("preconditions", a.CustomPreconds, b.CustomPreconds)
+	add("steps", caseSteps(a), caseSteps(b))
+	add("expected", caseExpected(a), caseExpected(b))
+	add("priority_id", strconv.FormatInt(a.PriorityID, 10), strconv.FormatInt(b.PriorityID, 10))
+	add("type_id", strconv.FormatInt(a.TypeID, 10), strconv.FormatInt(b.TypeID, 10))
+	add("refs", a.Refs, b.Refs)
+	add("labels", caseLabels(a), caseLabels(b))
+
+	custom1, custom2 := customValues(a), customValues(b)
+	names := make([]string, 0, len(custom1)+len(custom2))
+	for name := range custom1 {
+		names = append(names, name)
+	}
+	for name := range custom2 {
+		if _, ok := custom1[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		add(name, custom1[name], custom2[name])
+	}
+	return changes
+}
+
+// caseSteps renders the steps of a case as text, one numbered line per
+// separated step, or the plain custom_steps field.
+func caseSteps(c data.Case) string {
+	if len(c.CustomStepsSeparated) == 0 {
+		return c.CustomSteps
+	}
+	lines := make([]string, len(c.CustomStepsSeparated))
+	for i, s := range c.CustomStepsSeparated {
+		lines[i] = fmt.Sprintf("%d. %s", i+1, s.Content)
+		if s.SharedStepID != 0 {
+			lines[i] = fmt.Sprintf("%d. [shared step %d]", i+1, s.SharedStepID)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// caseExpected renders the expected results, numbered like caseSteps.
+func caseExpected(c data.Case) string {
+	if len(c.CustomStepsSeparated) == 0 {
+		return c.CustomExpected
+	}
+	var lines []string
+	for i, s := range c.CustomStepsSeparated {
+		if s.Expected != "" {
+			lines = append(lines, fmt.Sprintf("%d. %s", i+1, s.Expected))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// caseLabels returns the label names sorted and comma-separated.
+func caseLabels(c data.Case) string {
+	names := make([]string, len(c.Labels))
+	for i, l := range c.Labels {
+		names[i] = l.Name
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// customValues returns the custom_* fields of a case as text, by name.
+func customValues(c data.Case) map[string]string {
+	values := make(map[string]string, len(c.CustomFields)+3)
+	for _, name := range c.CustomFields.Keys() {
+		if v := c.CustomFields.String(name); v != "" {
+			values[name] = v
+		}
+	}
+	if c.CustomAutomationType != 0 {
+		values["custom_automation_type"] = strconv.FormatInt(c.CustomAutomationType, 10)
+	}
+	if c.CustomMission != "" {
+		values["custom_mission"] = c.CustomMission
+	}
+	if c.CustomGoals != "" {
+		values["custom_goals"] = c.CustomGoals
+	}
+	return values
+}
+
+// printCaseChanges renders every changed pair as a unified text diff.
+func printCaseChanges(w io.Writer, result CompareResult) {
+	for _, item := range result.Common {
+		if len(item.Changes) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n--- P%d #%d %s\n", result.Project1ID, item.ID1, item.Name)
+		fmt.Fprintf(w, "+++ P%d #%d %s\n", result.Project2ID, item.ID2, item.Name)
+		for _, ch := range item.Changes {
+			fmt.Fprintf(w, "@@ %s @@\n", ch.Field)
+			for _, line := range diffLines(ch.First, ch.Second) {
+				fmt.Fprintln(w, line)
+			}
+		}
+	}
+}
+
+// diffLines returns a line diff of a and b, each line prefixed with " "
+// (kept), "-" (only in a) or "+" (only in b), from a longest common subsequence.
+func diffLines(a, b string) []string {
+	la, lb := splitLines(a), splitLines(b)
+	n, m := len(la), len(lb)
+
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if la[i] == lb[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	out := make([]string, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case la[i] == lb[j]:
+			out = append(out, " "+la[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, "-"+la[i])
+			i++
+		default:
+			out = append(out, "+"+lb[j])
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		out = append(out, "-"+la[i])
+	}
+	for ; j < m; j++ {
+		out = append(out, "+"+lb[j])
+	}
+	return out
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
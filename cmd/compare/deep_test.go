@@ -0,0 +1,133 @@
+package compare
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffCaseFields(t *testing.T) {
+	a := data.Case{
+		Title:          "Login",
+		PriorityID:     2,
+		Refs:           "TR-1",
+		CustomPreconds: "User exists",
+		CustomStepsSeparated: []data.Step{
+			{Content: "Open page", Expected: "Form shown"},
+			{Content: "Submit"},
+		},
+		Labels:       []data.Label{{Name: "smoke"}, {Name: "auth"}},
+		CustomFields: data.CustomFieldValues{"custom_area": json.RawMessage(`"web"`)},
+	}
+	b := a
+	b.PriorityID = 4
+	b.CustomStepsSeparated = []data.Step{
+		{Content: "Open page", Expected: "Form shown"},
+		{Content: "Submit form"},
+	}
+	b.Labels = []data.Label{{Name: "auth"}, {Name: "smoke"}}
+	b.CustomFields = data.CustomFieldValues{"custom_area": json.RawMessage(`"mobile"`), "custom_owner": json.RawMessage(`"qa"`)}
+
+	changes := diffCaseFields(a, b)
+	fields := make([]string, len(changes))
+	for i, ch := range changes {
+		fields[i] = ch.Field
+	}
+	assert.Equal(t, []string{"steps", "priority_id", "custom_area", "custom_owner"}, fields,
+		"labels are compared as a set, unchanged fields are left out")
+	assert.Equal(t, "1. Open page\n2. Submit", changes[0].First)
+	assert.Equal(t, FieldChange{Field: "custom_owner", First: "", Second: "qa"}, changes[3])
+
+	assert.Empty(t, diffCaseFields(a, a))
+}
+
+func TestDiffLines(t *testing.T) {
+	assert.Equal(t, []string{" a", "-b", "+B", " c", "+d"}, diffLines("a\nb\nc", "a\nB\nc\nd"))
+	assert.Equal(t, []string{"+x"}, diffLines("", "x"))
+}
+
+func TestCompareCasesDeep(t *testing.T) {
+	mock := &client.MockClient{
+		GetProjectFunc: func(ctx context.Context, projectID int64) (*data.GetProjectResponse, error) {
+			return &data.GetProjectResponse{ID: projectID, Name: "P"}, nil
+		},
+		GetSuitesFunc: func(ctx context.Context, projectID int64) (data.GetSuitesResponse, error) {
+			return data.GetSuitesResponse{{ID: projectID * 10}}, nil
+		},
+		GetCasesFunc: func(ctx context.Context, projectID, suiteID, sectionID int64) (data.GetCasesResponse, error) {
+			return data.GetCasesResponse{
+				{ID: projectID*100 + 1, Title: "Login", Refs: "TR-" + map[int64]string{1: "1", 2: "2"}[projectID]},
+				{ID: projectID*100 + 2, Title: "Logout"},
+			}, nil
+		},
+	}
+	SetGetClientForTests(func(*cobra.Command) client.ClientInterface { return mock })
+	t.Cleanup(func() { SetGetClientForTests(nil) })
+
+	out := filepath.Join(t.TempDir(), "cases.json")
+	cmd := newCasesCmd()
+	addPersistentFlagsForTests(cmd)
+	cmd.SetArgs([]string{"--pid1", "1", "--pid2", "2", "--deep", "--quiet", "--save-to", out})
+	require.NoError(t, cmd.Execute())
+
+	b, err := os.ReadFile(out)
+	require.NoError(t, err)
+	var result CompareResult
+	require.NoError(t, json.Unmarshal(b, &result))
+	require.Len(t, result.Common, 2)
+	for _, item := range result.Common {
+		if item.Name == "Login" {
+			assert.Equal(t, []FieldChange{{Field: "refs", First: "TR-1", Second: "TR-2"}}, item.Changes)
+		} else {
+			assert.Empty(t, item.Changes)
+		}
+	}
+
+	var buf bytes.Buffer
+	printCaseChanges(&buf, result)
+	assert.Contains(t, buf.String(), "--- P1 #101 Login\n+++ P2 #201 Login\n@@ refs @@\n-TR-1\n+TR-2\n")
+	assert.NotContains(t, buf.String(), "Logout")
+}
+
+func TestWriteCompareCSV_Deep(t *testing.T) {
+	result := CompareResult{Common: []CommonItemInfo{
+		{Name: "Login", ID1: 1, ID2: 2, Changes: []FieldChange{{Field: "refs", First: "TR-1", Second: "TR-2"}}},
+	}}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	require.NoError(t, writeCompareCSV(w, result))
+	w.Flush()
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, "Value Project 2", rows[0][6])
+	assert.Equal(t, []string{"Common", "Login", "1", "2", "", "", ""}, rows[1])
+	assert.Equal(t, []string{"Changed", "Login", "1", "2", "refs", "TR-1", "TR-2"}, rows[2])
+}
+
+func TestWriteCompareCSV_DeepWithoutChanges(t *testing.T) {
+	result := CompareResult{Deep: true, Common: []CommonItemInfo{{Name: "Login", ID1: 1, ID2: 2}}}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	require.NoError(t, writeCompareCSV(w, result))
+	w.Flush()
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Len(t, rows[0], 7, "the deep columns do not depend on the data")
+	assert.Equal(t, []string{"Common", "Login", "1", "2", "", "", ""}, rows[1])
+}
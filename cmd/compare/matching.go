@@ -0,0 +1,212 @@
+package compare
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/flags"
+	"github.com/Korrnals/gotr/internal/match"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/Korrnals/gotr/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// NearMatch pairs an item only in the first project with a similar item
+// only in the second one, for review. Both items also stay in the
+// only_in_first/only_in_second lists.
+type NearMatch struct {
+	Name1 string  `json:"name1" yaml:"name1"`
+	ID1   int64   `json:"id1" yaml:"id1"`
+	Name2 string  `json:"name2" yaml:"name2"`
+	ID2   int64   `json:"id2" yaml:"id2"`
+	Score float64 `json:"score" yaml:"score"`
+}
+
+// matchOptionsFromFlags reads --normalize, --fuzzy and --fuzzy-algo the
+// same way sync does. Names are always compared case-insensitively, as
+// without these flags.
+func matchOptionsFromFlags(cmd *cobra.Command) (match.Options, error) {
+	opts, err := flags.MatchOptionsFromFlags(cmd)
+	opts.Normalize.Case = true
+	return opts, err
+}
+
+// refineMatches re-matches the items left in only_in_first/only_in_second
+// with the normalization and similarity threshold of opts: equal names move
+// to common, similar ones are reported as near matches.
+func refineMatches(opts match.Options, results ...*CompareResult) {
+	if opts.Normalize == (match.Normalize{Case: true}) && opts.Threshold <= 0 {
+		return
+	}
+	for _, r := range results {
+		if r == nil || (len(r.OnlyInFirst) == 0 && len(r.OnlyInSecond) == 0) {
+			continue
+		}
+		res, err := match.Match(nameItems(r.OnlyInFirst), nameItems(r.OnlyInSecond), opts)
+		if err != nil {
+			continue // options are validated by matchOptionsFromFlags
+		}
+		applyMatchResult(r, res, false)
+	}
+}
+
+func nameItems(items []ItemInfo) []match.Item {
+	out := make([]match.Item, len(items))
+	for i, it := range items {
+		out[i] = match.Item{ID: it.ID, Name: it.Name, Key: it.Name}
+	}
+	return out
+}
+
+// applyMatchResult stores res in r; with replace the lists are rebuilt from
+// res, otherwise the exact pairs of res are added to the existing ones.
+func applyMatchResult(r *CompareResult, res match.Result, replace bool) {
+	if replace {
+		r.Common = nil
+	}
+	for _, p := range res.Exact {
+		r.Common = append(r.Common, CommonItemInfo{
+			Name:     p.First.Name,
+			ID1:      p.First.ID,
+			ID2:      p.Second.ID,
+			IDsMatch: p.First.ID == p.Second.ID,
+		})
+	}
+	r.OnlyInFirst = itemInfos(res.OnlyInFirst)
+	r.OnlyInSecond = itemInfos(res.OnlyInSecond)
+	r.NearMatches = nil
+	if res.NearTruncated {
+		ui.Warningf(os.Stderr, "Near matching stopped after %d comparisons; some near matches are not listed", match.DefaultMaxComparisons)
+	}
+	for _, p := range res.Near {
+		r.NearMatches = append(r.NearMatches, NearMatch{
+			Name1: p.First.Name, ID1: p.First.ID,
+			Name2: p.Second.Name, ID2: p.Second.ID,
+			Score: p.Score,
+		})
+	}
+}
+
+func itemInfos(items []match.Item) []ItemInfo {
+	if len(items) == 0 {
+		return nil
+	}
+	out := make([]ItemInfo, len(items))
+	for i, it := range items {
+		out[i] = ItemInfo{ID: it.ID, Name: it.Name}
+	}
+	return out
+}
+
+// caseKeySectionPath is the key field holding the section path of a case.
+const caseKeySectionPath = "section_path"
+
+// parseCaseKey parses the --field of 'compare cases': one or more of title,
+// section_path, the getFieldValue fields and custom_* joined with "+".
+func parseCaseKey(spec string) (match.Key, error) {
+	key, err := match.ParseKey(spec)
+	if err != nil {
+		return nil, fmt.Errorf("--field: %w", err)
+	}
+	for _, f := range key {
+		if f == caseKeySectionPath || strings.HasPrefix(f, "custom_") {
+			continue
+		}
+		if getFieldValue(data.Case{}, f) == "<unknown field>" {
+			return nil, fmt.Errorf("--field: unknown case field %q", f)
+		}
+	}
+	return key, nil
+}
+
+// isTitleKey reports whether key is the default title-only key, which the
+// regular case analysis already handles.
+func isTitleKey(key match.Key) bool {
+	return len(key) == 1 && key[0] == "title"
+}
+
+// rematchCases rebuilds the lists of a cases result from the recorded cases,
+// matching them by key instead of by title.
+func rematchCases(ctx context.Context, cli client.ClientInterface, result *CompareResult, rec *caseRecorder, key match.Key, opts match.Options) error {
+	first, err := caseItems(ctx, cli, rec, result.Project1ID, key)
+	if err != nil {
+		return err
+	}
+	second, err := caseItems(ctx, cli, rec, result.Project2ID, key)
+	if err != nil {
+		return err
+	}
+	res, err := match.Match(first, second, opts)
+	if err != nil {
+		return err
+	}
+	applyMatchResult(result, res, true)
+	return nil
+}
+
+// caseItems returns the recorded cases of a project as match items, in ID
+// order, keyed by key.
+func caseItems(ctx context.Context, cli client.ClientInterface, rec *caseRecorder, projectID int64, key match.Key) ([]match.Item, error) {
+	cases := rec.all(projectID)
+
+	var paths map[int64]string
+	if key.Has(caseKeySectionPath) {
+		var err error
+		if paths, err = casePaths(ctx, cli, projectID, cases); err != nil {
+			return nil, err
+		}
+	}
+
+	items := make([]match.Item, len(cases))
+	for i, c := range cases {
+		items[i] = match.Item{ID: c.ID, Name: c.Title, Key: key.Value(func(field string) string {
+			return caseKeyValue(c, field, paths)
+		})}
+	}
+	return items, nil
+}
+
+// casePaths loads the sections of every suite the cases belong to and
+// returns their paths by section ID.
+func casePaths(ctx context.Context, cli client.ClientInterface, projectID int64, cases []data.Case) (map[int64]string, error) {
+	suites := make(map[int64]bool)
+	for _, c := range cases {
+		suites[c.SuiteID] = true
+	}
+	var sections data.GetSectionsResponse
+	for suiteID := range suites {
+		s, err := cli.GetSections(ctx, projectID, suiteID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sections of suite %d: %w", suiteID, err)
+		}
+		sections = append(sections, s...)
+	}
+	return match.SectionPaths(sections, " > "), nil
+}
+
+func caseKeyValue(c data.Case, field string, paths map[int64]string) string {
+	switch {
+	case field == caseKeySectionPath:
+		return paths[c.SectionID]
+	case getFieldValue(c, field) != "<unknown field>":
+		return getFieldValue(c, field)
+	default:
+		return customValues(c)[field]
+	}
+}
+
+// all returns the recorded cases of a project sorted by ID.
+func (r *caseRecorder) all(projectID int64) []data.Case {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cases := make([]data.Case, 0, len(r.cases[projectID]))
+	for _, c := range r.cases[projectID] {
+		cases = append(cases, c)
+	}
+	sort.Slice(cases, func(i, j int) bool { return cases[i].ID < cases[j].ID })
+	return cases
+}
@@ -0,0 +1,104 @@
+package compare
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/match"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefineMatches(t *testing.T) {
+	result := &CompareResult{
+		OnlyInFirst:  []ItemInfo{{ID: 1, Name: "Login - SSO"}, {ID: 2, Name: "Checkout with coupon"}},
+		OnlyInSecond: []ItemInfo{{ID: 10, Name: "login sso"}, {ID: 20, Name: "Checkout with coupons"}},
+		Common:       []CommonItemInfo{{Name: "Logout", ID1: 3, ID2: 3, IDsMatch: true}},
+	}
+
+	refineMatches(match.Options{Normalize: match.Normalize{Case: true}}, result)
+	assert.Len(t, result.Common, 1, "nothing to do without --normalize or --fuzzy")
+
+	refineMatches(match.Options{
+		Normalize: match.Normalize{Case: true, Punctuation: true},
+		Threshold: 0.9,
+	}, result)
+	require.Len(t, result.Common, 2)
+	assert.Equal(t, CommonItemInfo{Name: "Login - SSO", ID1: 1, ID2: 10}, result.Common[1])
+	require.Len(t, result.NearMatches, 1)
+	assert.Equal(t, "Checkout with coupons", result.NearMatches[0].Name2)
+	assert.Equal(t, []ItemInfo{{ID: 2, Name: "Checkout with coupon"}}, result.OnlyInFirst)
+}
+
+func TestMatchOptionsFromFlags(t *testing.T) {
+	cmd := &cobra.Command{}
+	addPersistentFlagsForTests(cmd)
+	opts, err := matchOptionsFromFlags(cmd)
+	require.NoError(t, err)
+	assert.True(t, opts.Normalize.Case, "names are always compared case-insensitively")
+
+	require.NoError(t, cmd.Flags().Set("fuzzy", "2"))
+	_, err = matchOptionsFromFlags(cmd)
+	assert.ErrorContains(t, err, "--fuzzy")
+
+	require.NoError(t, cmd.Flags().Set("normalize", "accents"))
+	_, err = matchOptionsFromFlags(cmd)
+	assert.ErrorContains(t, err, "--normalize")
+}
+
+func TestCompareCases_SectionPathKey(t *testing.T) {
+	mock := &client.MockClient{
+		GetProjectFunc: func(ctx context.Context, projectID int64) (*data.GetProjectResponse, error) {
+			return &data.GetProjectResponse{ID: projectID, Name: "P"}, nil
+		},
+		GetSuitesFunc: func(ctx context.Context, projectID int64) (data.GetSuitesResponse, error) {
+			return data.GetSuitesResponse{{ID: projectID * 10}}, nil
+		},
+		GetSectionsFunc: func(ctx context.Context, projectID, suiteID int64) (data.GetSectionsResponse, error) {
+			return data.GetSectionsResponse{
+				{ID: projectID*100 + 1, Name: "Auth"},
+				{ID: projectID*100 + 2, Name: "Admin", ParentID: projectID*100 + 1},
+			}, nil
+		},
+		GetCasesFunc: func(ctx context.Context, projectID, suiteID, sectionID int64) (data.GetCasesResponse, error) {
+			if projectID == 1 {
+				return data.GetCasesResponse{
+					{ID: 11, Title: "Smoke", SuiteID: 10, SectionID: 101},
+					{ID: 12, Title: "Smoke", SuiteID: 10, SectionID: 102},
+				}, nil
+			}
+			return data.GetCasesResponse{{ID: 21, Title: "smoke", SuiteID: 20, SectionID: 202}}, nil
+		},
+	}
+
+	result := runCompareToFile(t, newCasesCmd(), mock, "--pid1", "1", "--pid2", "2", "--field", "section_path+title")
+
+	require.Len(t, result.Common, 1)
+	assert.Equal(t, CommonItemInfo{Name: "Smoke", ID1: 12, ID2: 21}, result.Common[0])
+	assert.Equal(t, []ItemInfo{{ID: 11, Name: "Smoke"}}, result.OnlyInFirst)
+	assert.Empty(t, result.OnlyInSecond)
+
+	cmd := newCasesCmd()
+	addPersistentFlagsForTests(cmd)
+	cmd.SetArgs([]string{"--pid1", "1", "--pid2", "2", "--field", "title+colour"})
+	assert.ErrorContains(t, cmd.Execute(), `unknown case field "colour"`)
+}
+
+func TestWriteCompareCSV_NearMatches(t *testing.T) {
+	result := CompareResult{NearMatches: []NearMatch{{Name1: "Login", ID1: 1, Name2: "Log in", ID2: 2, Score: 0.83}}}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	require.NoError(t, writeCompareCSV(w, result))
+	w.Flush()
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, []string{"Near match 0.83", "Login ≈ Log in", "1", "2"}, rows[1])
+}
@@ -4,7 +4,9 @@ import (
 	"time"
 
 	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/match"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // GetClientInterfaceFunc is the function type used to obtain an API client.
@@ -50,15 +52,35 @@ Examples:
 	gotr compare cases --pid1 30 --pid2 31
 	gotr compare all --pid1 30 --pid2 31 --save
 	gotr compare all --pid1 30 --pid2 31 --save-to result.json
+	gotr compare cases --pid1 30 --snapshot2 release-1.4.tar.gz
+	gotr compare all --snapshot1 release-1.3.tar.gz --snapshot2 release-1.4.tar.gz
 `,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Two snapshots need no server: answer the remaining reads from
+			// the first one, as the global --snapshot flag would.
+			s1, _ := cmd.Flags().GetString("snapshot1")
+			s2, _ := cmd.Flags().GetString("snapshot2")
+			if s1 != "" && s2 != "" && viper.GetString("snapshot") == "" {
+				viper.Set("snapshot", s1)
+			}
+			if rootCmd.PersistentPreRunE != nil {
+				return rootCmd.PersistentPreRunE(cmd, args)
+			}
+			return nil
+		},
 	}
 
 	// Add persistent flags FIRST (before subcommands) for completion to work
 	Cmd.PersistentFlags().StringP("pid1", "1", "", "First project ID (required)")
 	Cmd.PersistentFlags().StringP("pid2", "2", "", "Second project ID (required)")
+	Cmd.PersistentFlags().String("snapshot1", "", "Read the first side from a snapshot instead of the server")
+	Cmd.PersistentFlags().String("snapshot2", "", "Read the second side from a snapshot instead of the server")
+	Cmd.PersistentFlags().String("normalize", "", "Also ignore these edits when matching names (case is always ignored): whitespace,punctuation | all")
+	Cmd.PersistentFlags().Float64("fuzzy", 0, "Report unmatched items with a name similarity of at least this (0..1) as near matches; 0 = off")
+	Cmd.PersistentFlags().String("fuzzy-algo", match.Levenshtein, "Similarity for --fuzzy: levenshtein | jaccard")
 	Cmd.PersistentFlags().Bool("save", false, "Save result to file (default: ~/.gotr/exports/)")
 	Cmd.PersistentFlags().String("save-to", "", "Save result to the specified file")
-	Cmd.PersistentFlags().Int("rate-limit", -1, "API rate limit per minute. -1 = auto by profile/deployment, 0 = unlimited, >0 = fixed value.")
+	Cmd.PersistentFlags().Int("rate-limit", -1, "API rate limit per minute; an explicit value >0 also replaces the global limit. -1 = auto by profile/deployment, 0 = unlimited, >0 = fixed value.")
 	Cmd.PersistentFlags().Int("parallel-suites", 10, "Maximum number of parallel suites")
 	Cmd.PersistentFlags().Int("parallel-pages", 6, "Maximum number of parallel pages per suite")
 	Cmd.PersistentFlags().Int("page-retries", 5, "Number of retries per page during initial loading")
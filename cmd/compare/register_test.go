@@ -6,6 +6,7 @@ import (
 
 	"github.com/Korrnals/gotr/internal/client"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -38,7 +39,7 @@ func TestRegister_AttachesCommandWithFlagsAndSubcommands(t *testing.T) {
 	assert.Equal(t, Cmd, root.Commands()[0])
 
 	for _, name := range []string{
-		"pid1", "pid2", "save", "save-to", "rate-limit",
+		"pid1", "pid2", "snapshot1", "snapshot2", "save", "save-to", "rate-limit",
 		"parallel-suites", "parallel-pages", "page-retries",
 		"timeout", "retry-attempts", "retry-workers", "retry-delay",
 	} {
@@ -75,3 +76,27 @@ func TestSetGetClientForTests_OverridesGetter(t *testing.T) {
 	got := getClient(cmd)
 	assert.Equal(t, expected, got)
 }
+
+func TestRegister_TwoSnapshotsNeedNoServer(t *testing.T) {
+	origCmd := Cmd
+	t.Cleanup(func() {
+		Cmd = origCmd
+		viper.Reset()
+	})
+	viper.Reset()
+
+	var seen string
+	root := &cobra.Command{Use: "root", PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		seen = viper.GetString("snapshot")
+		return nil
+	}}
+	Register(root, getClient)
+
+	require.NoError(t, Cmd.ParseFlags([]string{"--snapshot1", "old.tar.gz"}))
+	require.NoError(t, Cmd.PersistentPreRunE(Cmd, nil))
+	assert.Empty(t, seen, "one snapshot still compares against the server")
+
+	require.NoError(t, Cmd.ParseFlags([]string{"--snapshot2", "new.tar.gz"}))
+	require.NoError(t, Cmd.PersistentPreRunE(Cmd, nil))
+	assert.Equal(t, "old.tar.gz", seen)
+}
@@ -40,13 +40,22 @@ Examples:
 			}
 
 			// Parse flags
+			sides, err := openCompareSides(cmd, cli)
+			if err != nil {
+				return err
+			}
+			matching, err := matchOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
 			pid1, pid2, format, savePath, err := parseCommonFlags(cmd, cli)
 			if err != nil {
 				return err
 			}
 
 			// Get project names
-			project1Name, project2Name, err := GetProjectNames(ctx, cli, pid1, pid2)
+			cli, key1, key2 := sides.bind(pid1, pid2)
+			project1Name, project2Name, err := GetProjectNames(ctx, cli, key1, key2)
 			if err != nil {
 				return err
 			}
@@ -56,10 +65,12 @@ Examples:
 
 			// Compare sections
 			quiet, _ := cmd.Flags().GetBool("quiet")
-			result, err := compareSectionsInternal(ctx, cmd, cli, pid1, pid2, quiet)
+			result, err := compareSectionsInternal(ctx, cmd, cli, key1, key2, quiet)
 			if err != nil {
 				return err
 			}
+			refineMatches(matching, result)
+			sides.finish(pid1, pid2, result)
 
 			elapsed := time.Since(startTime)
 
@@ -123,6 +134,7 @@ func compareSectionsInternalWithSuites(ctx context.Context, cmd *cobra.Command,
 	if err != nil {
 		return nil, err
 	}
+	applyRateLimitFlag(cmd, cli)
 
 	task1 := operation.AddTask(fmt.Sprintf("P%d (%d suites)", pid1, len(suites1)), taskTotal(len(suites1)))
 	task2 := operation.AddTask(fmt.Sprintf("P%d (%d suites)", pid2, len(suites2)), taskTotal(len(suites2)))
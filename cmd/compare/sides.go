@@ -0,0 +1,290 @@
+package compare
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/concurrency"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/Korrnals/gotr/internal/snapshot"
+	"github.com/spf13/cobra"
+)
+
+// compareSides holds where each side of a comparison is read from: the
+// server (live) or a snapshot given with --snapshot1/--snapshot2.
+type compareSides struct {
+	first, second    client.ClientInterface
+	source1, source2 string // "" for the server
+}
+
+// openCompareSides opens the snapshots given with --snapshot1/--snapshot2.
+// A missing --pid1/--pid2 defaults to the only project of its snapshot.
+func openCompareSides(cmd *cobra.Command, live client.ClientInterface) (*compareSides, error) {
+	sides := &compareSides{first: live, second: live}
+	for i, side := range []struct {
+		cli    *client.ClientInterface
+		source *string
+	}{{&sides.first, &sides.source1}, {&sides.second, &sides.source2}} {
+		n := strconv.Itoa(i + 1)
+		path, _ := cmd.Flags().GetString("snapshot" + n)
+		if path == "" {
+			continue
+		}
+		snap, err := snapshot.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("--snapshot%s: %w", n, err)
+		}
+		*side.cli = snap
+		*side.source = snapshotSource(snap)
+
+		ids := snap.Snapshot().Manifest.ProjectIDs
+		if pid, _ := cmd.Flags().GetString("pid" + n); pid == "" {
+			if len(ids) != 1 {
+				return nil, fmt.Errorf("specify --pid%s: snapshot %s holds projects %s", n, path, joinIDs(ids))
+			}
+			if err := cmd.Flags().Set("pid"+n, strconv.FormatInt(ids[0], 10)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return sides, nil
+}
+
+// snapshotSource describes a snapshot side for output.
+func snapshotSource(snap *snapshot.Client) string {
+	return fmt.Sprintf("snapshot %s (%s)", snap.Path(),
+		snap.Snapshot().Manifest.CreatedAt.Local().Format("2006-01-02 15:04"))
+}
+
+func joinIDs(ids []int64) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatInt(id, 10)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// bind returns the client and the keys the compare code addresses the two
+// sides by. Without snapshots that is the live client and the project IDs.
+// When both sides share a project ID but not a source, the second side gets
+// the key -pid2 so the client can tell them apart; finish puts the real IDs
+// back on the results.
+func (s *compareSides) bind(pid1, pid2 int64) (cli client.ClientInterface, key1, key2 int64) {
+	if s.source1 == "" && s.source2 == "" {
+		return s.first, pid1, pid2
+	}
+	key2 = pid2
+	if pid1 == pid2 && s.source1 != s.source2 {
+		key2 = -pid2
+	}
+	return &sidedClient{
+		ClientInterface: s.first,
+		second:          s.second,
+		key2:            key2,
+		pid2:            pid2,
+		source1:         s.source1,
+		source2:         s.source2,
+	}, pid1, key2
+}
+
+// finish restores the real project IDs on results computed with the keys
+// from bind and records the source of each side.
+func (s *compareSides) finish(pid1, pid2 int64, results ...*CompareResult) {
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		r.Project1ID, r.Project2ID = pid1, pid2
+		r.Source1, r.Source2 = sourceLabel(s.source1, s.source2)
+	}
+}
+
+// finishAll is finish for every resource of a 'compare all' result.
+func (s *compareSides) finishAll(pid1, pid2 int64, result *allResult) {
+	for _, entry := range resourceRegistry {
+		s.finish(pid1, pid2, *entry.field(result))
+	}
+}
+
+// sourceLabel names both sources once a snapshot is involved, and neither
+// otherwise, so live-only output is unchanged.
+func sourceLabel(source1, source2 string) (string, string) {
+	if source1 == "" && source2 == "" {
+		return "", ""
+	}
+	live := func(s string) string {
+		if s == "" {
+			return "live"
+		}
+		return s
+	}
+	return live(source1), live(source2)
+}
+
+// sidedClient answers the project-scoped reads used by compare from the
+// client of the side the project key belongs to. Everything else goes to
+// the first side.
+type sidedClient struct {
+	client.ClientInterface
+	second           client.ClientInterface
+	key2, pid2       int64
+	source1, source2 string
+}
+
+// Unwrap returns the live side, whose limiter governs the server traffic;
+// with two snapshots it is the first side.
+func (c *sidedClient) Unwrap() client.ClientInterface {
+	if c.source1 != "" && c.source2 == "" {
+		return c.second
+	}
+	return c.ClientInterface
+}
+
+// route returns the client and the real project ID for a key.
+func (c *sidedClient) route(key int64) (client.ClientInterface, int64) {
+	if key == c.key2 {
+		return c.second, c.pid2
+	}
+	return c.ClientInterface, key
+}
+
+func (c *sidedClient) GetProject(ctx context.Context, projectID int64) (*data.GetProjectResponse, error) {
+	cli, pid := c.route(projectID)
+	p, err := cli.GetProject(ctx, pid)
+	if err != nil || p == nil {
+		return p, err
+	}
+	source := c.source1
+	if projectID == c.key2 {
+		source = c.source2
+	}
+	if source != "" {
+		labelled := *p
+		labelled.Name = fmt.Sprintf("%s [%s]", p.Name, source)
+		return &labelled, nil
+	}
+	return p, nil
+}
+
+func (c *sidedClient) GetSuites(ctx context.Context, projectID int64) (data.GetSuitesResponse, error) {
+	cli, pid := c.route(projectID)
+	return cli.GetSuites(ctx, pid)
+}
+
+func (c *sidedClient) GetSuitesParallel(ctx context.Context, projectIDs []int64, workers int, monitor client.ProgressMonitor) (map[int64]data.GetSuitesResponse, error) {
+	result := make(map[int64]data.GetSuitesResponse, len(projectIDs))
+	var firstErr error
+	for _, key := range projectIDs {
+		cli, pid := c.route(key)
+		suites, err := cli.GetSuitesParallel(ctx, []int64{pid}, workers, monitor)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		if s, ok := suites[pid]; ok {
+			result[key] = s
+		}
+	}
+	return result, firstErr
+}
+
+func (c *sidedClient) GetCases(ctx context.Context, projectID, suiteID, sectionID int64) (data.GetCasesResponse, error) {
+	cli, pid := c.route(projectID)
+	return cli.GetCases(ctx, pid, suiteID, sectionID)
+}
+
+func (c *sidedClient) GetCasesFiltered(ctx context.Context, projectID int64, filter data.CaseFilter) (data.GetCasesResponse, error) {
+	cli, pid := c.route(projectID)
+	return cli.GetCasesFiltered(ctx, pid, filter)
+}
+
+func (c *sidedClient) GetCasesPage(ctx context.Context, projectID, suiteID int64, offset, limit int) (data.GetCasesResponse, error) {
+	cli, pid := c.route(projectID)
+	return cli.GetCasesPage(ctx, pid, suiteID, offset, limit)
+}
+
+func (c *sidedClient) GetCasesParallel(ctx context.Context, projectID int64, suiteIDs []int64, workers int, monitor client.ProgressMonitor) (map[int64]data.GetCasesResponse, error) {
+	cli, pid := c.route(projectID)
+	return cli.GetCasesParallel(ctx, pid, suiteIDs, workers, monitor)
+}
+
+func (c *sidedClient) GetCasesForSuitesParallel(ctx context.Context, projectID int64, suiteIDs []int64, workers int, monitor client.ProgressMonitor) (data.GetCasesResponse, error) {
+	cli, pid := c.route(projectID)
+	return cli.GetCasesForSuitesParallel(ctx, pid, suiteIDs, workers, monitor)
+}
+
+func (c *sidedClient) GetCasesParallelCtx(ctx context.Context, projectID int64, suiteIDs []int64, config *concurrency.ControllerConfig) (data.GetCasesResponse, *concurrency.ExecutionResult, error) {
+	cli, pid := c.route(projectID)
+	return cli.GetCasesParallelCtx(ctx, pid, suiteIDs, config)
+}
+
+func (c *sidedClient) DiffCasesData(ctx context.Context, pid1, pid2 int64, field string) (*data.DiffCasesResponse, error) {
+	cli1, id1 := c.route(pid1)
+	cases1, err := cli1.GetCases(ctx, id1, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cases for project %d: %w", id1, err)
+	}
+	cli2, id2 := c.route(pid2)
+	cases2, err := cli2.GetCases(ctx, id2, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cases for project %d: %w", id2, err)
+	}
+	return client.DiffCases(cases1, cases2, field), nil
+}
+
+func (c *sidedClient) GetSections(ctx context.Context, projectID, suiteID int64) (data.GetSectionsResponse, error) {
+	cli, pid := c.route(projectID)
+	return cli.GetSections(ctx, pid, suiteID)
+}
+
+func (c *sidedClient) GetSectionsParallelCtx(ctx context.Context, projectID int64, suiteIDs []int64, config *concurrency.ControllerConfig) (data.GetSectionsResponse, error) {
+	cli, pid := c.route(projectID)
+	return cli.GetSectionsParallelCtx(ctx, pid, suiteIDs, config)
+}
+
+func (c *sidedClient) GetSharedSteps(ctx context.Context, projectID int64) (data.GetSharedStepsResponse, error) {
+	cli, pid := c.route(projectID)
+	return cli.GetSharedSteps(ctx, pid)
+}
+
+func (c *sidedClient) GetRuns(ctx context.Context, projectID int64) (data.GetRunsResponse, error) {
+	cli, pid := c.route(projectID)
+	return cli.GetRuns(ctx, pid)
+}
+
+func (c *sidedClient) GetPlans(ctx context.Context, projectID int64) (data.GetPlansResponse, error) {
+	cli, pid := c.route(projectID)
+	return cli.GetPlans(ctx, pid)
+}
+
+func (c *sidedClient) GetMilestones(ctx context.Context, projectID int64) ([]data.Milestone, error) {
+	cli, pid := c.route(projectID)
+	return cli.GetMilestones(ctx, pid)
+}
+
+func (c *sidedClient) GetTemplates(ctx context.Context, projectID int64) (data.GetTemplatesResponse, error) {
+	cli, pid := c.route(projectID)
+	return cli.GetTemplates(ctx, pid)
+}
+
+func (c *sidedClient) GetConfigs(ctx context.Context, projectID int64) (data.GetConfigsResponse, error) {
+	cli, pid := c.route(projectID)
+	return cli.GetConfigs(ctx, pid)
+}
+
+func (c *sidedClient) GetLabels(ctx context.Context, projectID int64) (data.GetLabelsResponse, error) {
+	cli, pid := c.route(projectID)
+	return cli.GetLabels(ctx, pid)
+}
+
+func (c *sidedClient) GetDatasets(ctx context.Context, projectID int64) (data.GetDatasetsResponse, error) {
+	cli, pid := c.route(projectID)
+	return cli.GetDatasets(ctx, pid)
+}
+
+func (c *sidedClient) GetGroups(ctx context.Context, projectID int64) (data.GetGroupsResponse, error) {
+	cli, pid := c.route(projectID)
+	return cli.GetGroups(ctx, pid)
+}
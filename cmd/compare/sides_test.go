@@ -0,0 +1,152 @@
+package compare
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/Korrnals/gotr/internal/snapshot"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// projectWithCases is a mock server holding project 30 with one suite of the
+// given case titles.
+func projectWithCases(titles ...string) *client.MockClient {
+	cases := make(data.GetCasesResponse, len(titles))
+	for i, title := range titles {
+		cases[i] = data.Case{ID: int64(i + 1), Title: title, SuiteID: 5}
+	}
+	return &client.MockClient{
+		GetProjectFunc: func(ctx context.Context, projectID int64) (*data.GetProjectResponse, error) {
+			return &data.GetProjectResponse{ID: projectID, Name: "Shop"}, nil
+		},
+		GetSuitesFunc: func(ctx context.Context, projectID int64) (data.GetSuitesResponse, error) {
+			return data.GetSuitesResponse{{ID: 5, Name: "Regression"}}, nil
+		},
+		GetCasesFunc: func(ctx context.Context, projectID, suiteID, sectionID int64) (data.GetCasesResponse, error) {
+			return cases, nil
+		},
+	}
+}
+
+// saveSnapshot captures project 30 of srv into a temp directory.
+func saveSnapshot(t *testing.T, srv client.ClientInterface) string {
+	t.Helper()
+	snap, err := snapshot.Create(context.Background(), srv, []int64{30})
+	require.NoError(t, err)
+	dst := filepath.Join(t.TempDir(), "snap")
+	require.NoError(t, snap.Save(dst))
+	return dst
+}
+
+func runCompareToFile(t *testing.T, cmd *cobra.Command, live client.ClientInterface, args ...string) CompareResult {
+	t.Helper()
+	SetGetClientForTests(func(*cobra.Command) client.ClientInterface { return live })
+	t.Cleanup(func() { SetGetClientForTests(nil) })
+
+	out := filepath.Join(t.TempDir(), "result.json")
+	addPersistentFlagsForTests(cmd)
+	cmd.SetArgs(append(args, "--quiet", "--save-to", out))
+	cmd.SetContext(context.Background())
+	require.NoError(t, cmd.Execute())
+
+	b, err := os.ReadFile(out)
+	require.NoError(t, err)
+	var result CompareResult
+	require.NoError(t, json.Unmarshal(b, &result))
+	return result
+}
+
+func TestCompareCases_LiveAgainstSnapshot(t *testing.T) {
+	old := saveSnapshot(t, projectWithCases("Login", "Logout"))
+	live := projectWithCases("Login", "Checkout")
+
+	result := runCompareToFile(t, newCasesCmd(), live, "--snapshot1", old, "--pid2", "30")
+
+	assert.Equal(t, int64(30), result.Project1ID)
+	assert.Equal(t, int64(30), result.Project2ID, "the real ID is reported, not the internal key")
+	assert.Contains(t, result.Source1, "snapshot "+old)
+	assert.Equal(t, "live", result.Source2)
+	require.Len(t, result.OnlyInFirst, 1)
+	assert.Equal(t, "Logout", result.OnlyInFirst[0].Name)
+	require.Len(t, result.OnlyInSecond, 1)
+	assert.Equal(t, "Checkout", result.OnlyInSecond[0].Name)
+	require.Len(t, result.Common, 1)
+	assert.Equal(t, "Login", result.Common[0].Name)
+}
+
+func TestCompareSimple_TwoSnapshots(t *testing.T) {
+	srv := projectWithCases()
+	srv.GetMilestonesFunc = func(ctx context.Context, projectID int64) ([]data.Milestone, error) {
+		return []data.Milestone{{ID: 1, Name: "1.3"}}, nil
+	}
+	old := saveSnapshot(t, srv)
+	srv.GetMilestonesFunc = func(ctx context.Context, projectID int64) ([]data.Milestone, error) {
+		return []data.Milestone{{ID: 1, Name: "1.3"}, {ID: 2, Name: "1.4"}}, nil
+	}
+	current := saveSnapshot(t, srv)
+
+	// With two snapshots the compare pre-run serves the rest from the first one.
+	live, err := snapshot.Open(old)
+	require.NoError(t, err)
+	cmd := newSimpleCompareCmd("milestones", "milestones", "", "", fetchMilestoneItems)
+	result := runCompareToFile(t, cmd, live, "--snapshot1", old, "--snapshot2", current)
+
+	assert.Equal(t, int64(30), result.Project1ID, "pids default to the snapshot's only project")
+	assert.Empty(t, result.OnlyInFirst)
+	require.Len(t, result.OnlyInSecond, 1)
+	assert.Equal(t, "1.4", result.OnlyInSecond[0].Name)
+	assert.Contains(t, result.Source2, current)
+}
+
+func TestOpenCompareSides(t *testing.T) {
+	cmd := &cobra.Command{}
+	addPersistentFlagsForTests(cmd)
+	live := &client.MockClient{}
+
+	sides, err := openCompareSides(cmd, live)
+	require.NoError(t, err)
+	cli, key1, key2 := sides.bind(30, 30)
+	assert.Same(t, live, cli, "without snapshots the live client is used as is")
+	assert.Equal(t, [2]int64{30, 30}, [2]int64{key1, key2})
+
+	require.NoError(t, cmd.Flags().Set("snapshot2", filepath.Join(t.TempDir(), "missing")))
+	_, err = openCompareSides(cmd, live)
+	assert.ErrorContains(t, err, "--snapshot2")
+}
+
+func TestSidedClient_RoutesByKey(t *testing.T) {
+	first := projectWithCases("A")
+	second := projectWithCases("B", "C")
+	sides := &compareSides{first: first, second: second, source2: "snapshot s"}
+
+	cli, key1, key2 := sides.bind(30, 30)
+	assert.Equal(t, int64(-30), key2, "same project on both sides gets an alias key")
+
+	cases, err := cli.GetCases(context.Background(), key1, 0, 0)
+	require.NoError(t, err)
+	assert.Len(t, cases, 1)
+	cases, err = cli.GetCases(context.Background(), key2, 0, 0)
+	require.NoError(t, err)
+	assert.Len(t, cases, 2)
+
+	p, err := cli.GetProject(context.Background(), key2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(30), p.ID)
+	assert.Equal(t, "Shop [snapshot s]", p.Name)
+
+	suites, err := cli.GetSuitesParallel(context.Background(), []int64{key1, key2}, 2, nil)
+	require.NoError(t, err)
+	assert.Contains(t, suites, key2)
+
+	result := &CompareResult{Project1ID: key1, Project2ID: key2}
+	sides.finish(30, 30, result)
+	assert.Equal(t, int64(30), result.Project2ID)
+	assert.Equal(t, "live", result.Source1)
+}
@@ -30,22 +30,33 @@ func newSimpleCompareCmd(resource, use, short, long string, fetchFn FetchFunc) *
 				return fmt.Errorf("HTTP client not initialized")
 			}
 
+			sides, err := openCompareSides(cmd, cli)
+			if err != nil {
+				return err
+			}
+			matching, err := matchOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
 			pid1, pid2, format, savePath, err := parseCommonFlags(cmd, cli)
 			if err != nil {
 				return err
 			}
 
-			project1Name, project2Name, err := GetProjectNames(ctx, cli, pid1, pid2)
+			cli, key1, key2 := sides.bind(pid1, pid2)
+			project1Name, project2Name, err := GetProjectNames(ctx, cli, key1, key2)
 			if err != nil {
 				return err
 			}
 
 			startTime := time.Now()
 
-			result, err := compareSimpleInternal(ctx, cli, pid1, pid2, resource, fetchFn, quiet)
+			result, err := compareSimpleInternal(ctx, cli, key1, key2, resource, fetchFn, quiet)
 			if err != nil {
 				return fmt.Errorf("comparison error %s: %w", resource, err)
 			}
+			refineMatches(matching, result)
+			sides.finish(pid1, pid2, result)
 
 			elapsed := time.Since(startTime)
 
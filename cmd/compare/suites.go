@@ -38,13 +38,22 @@ Examples:
 			}
 
 			// Parse flags
+			sides, err := openCompareSides(cmd, cli)
+			if err != nil {
+				return err
+			}
+			matching, err := matchOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
 			pid1, pid2, format, savePath, err := parseCommonFlags(cmd, cli)
 			if err != nil {
 				return err
 			}
 
 			// Get project names
-			project1Name, project2Name, err := GetProjectNames(ctx, cli, pid1, pid2)
+			cli, key1, key2 := sides.bind(pid1, pid2)
+			project1Name, project2Name, err := GetProjectNames(ctx, cli, key1, key2)
 			if err != nil {
 				return err
 			}
@@ -54,10 +63,12 @@ Examples:
 			quiet, _ := cmd.Flags().GetBool("quiet")
 
 			// Compare suites
-			result, err := compareSuitesInternal(ctx, cli, pid1, pid2, quiet)
+			result, err := compareSuitesInternal(ctx, cli, key1, key2, quiet)
 			if err != nil {
 				return fmt.Errorf("suites comparison error: %w", err)
 			}
+			refineMatches(matching, result)
+			sides.finish(pid1, pid2, result)
 
 			elapsed := time.Since(startTime)
 
@@ -13,6 +13,11 @@ func addPersistentFlagsForTests(cmd *cobra.Command) {
 	cmd.Flags().StringP("pid2", "2", "", "Second project ID")
 	cmd.Flags().StringP("format", "f", "table", "Output format")
 	cmd.Flags().BoolP("quiet", "q", false, "Suppress informational output")
+	cmd.Flags().String("snapshot1", "", "First side snapshot")
+	cmd.Flags().String("snapshot2", "", "Second side snapshot")
+	cmd.Flags().String("normalize", "", "Name normalization")
+	cmd.Flags().Float64("fuzzy", 0, "Near match threshold")
+	cmd.Flags().String("fuzzy-algo", "levenshtein", "Similarity algorithm")
 	cmd.Flags().Bool("save", false, "Save result")
 	cmd.Flags().String("save-to", "", "Save to a specific file")
 	cmd.Flags().Int("rate-limit", -1, "")
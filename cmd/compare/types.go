@@ -58,6 +58,9 @@ type CommonItemInfo struct {
 	ID1      int64  `json:"id1" yaml:"id1"`
 	ID2      int64  `json:"id2" yaml:"id2"`
 	IDsMatch bool   `json:"ids_match" yaml:"ids_match"`
+	// Changes lists the fields that differ between the two items; filled in
+	// by 'compare cases --deep' only.
+	Changes []FieldChange `json:"changes,omitempty" yaml:"changes,omitempty"`
 }
 
 // CompareResult represents the result of comparing resources between two projects
@@ -69,6 +72,16 @@ type CompareResult struct {
 	OnlyInFirst  []ItemInfo       `json:"only_in_first" yaml:"only_in_first"`
 	OnlyInSecond []ItemInfo       `json:"only_in_second" yaml:"only_in_second"`
 	Common       []CommonItemInfo `json:"common" yaml:"common"`
+	// Source1 and Source2 name where each side was read from ("live" or a
+	// snapshot); set only when --snapshot1/--snapshot2 is used.
+	Source1 string `json:"source1,omitempty" yaml:"source1,omitempty"`
+	Source2 string `json:"source2,omitempty" yaml:"source2,omitempty"`
+	// NearMatches pairs similar items of the two only-in lists; set only
+	// when --fuzzy is used.
+	NearMatches []NearMatch `json:"near_matches,omitempty" yaml:"near_matches,omitempty"`
+	// Deep is set when the common items were diffed field by field
+	// (--deep), so that no Changes means the items are equal.
+	Deep bool `json:"deep,omitempty" yaml:"deep,omitempty"`
 }
 
 // GetProjectNames retrieves project names for both project IDs
@@ -249,6 +262,11 @@ func printTable(result CompareResult, project1Name, project2Name string) error {
 	// Table 4: ID Mapping (for items with different IDs)
 	printIDMappingTable(result.Common)
 
+	// Table 5: Near matches (only with --fuzzy)
+	if len(result.NearMatches) > 0 {
+		printNearMatchTable(result.NearMatches, result.Project1ID, result.Project2ID)
+	}
+
 	return nil
 }
 
@@ -392,6 +410,40 @@ func printIDMappingTable(items []CommonItemInfo) {
 	fmt.Println()
 }
 
+// printNearMatchTable prints the similar items of the two only-in lists
+func printNearMatchTable(items []NearMatch, project1ID, project2ID int64) {
+	scoreWidth := 6
+	id1Width := 10
+	id2Width := 10
+	nameWidth := 60
+
+	widths := []int{scoreWidth, id1Width, id2Width, nameWidth}
+	totalInnerWidth := scoreWidth + id1Width + id2Width + nameWidth + 3*len(widths) - 1
+
+	printHorizontalBorder("┌", "┬", "┐", widths)
+	printHeader("Near matches (review)", totalInnerWidth)
+	printSeparator(widths)
+	printRow([]string{
+		"Score",
+		fmt.Sprintf("ID proj %d", project1ID),
+		fmt.Sprintf("ID proj %d", project2ID),
+		"Names",
+	}, widths)
+	printSeparator(widths)
+
+	for _, item := range items {
+		printRow([]string{
+			fmt.Sprintf("%.2f", item.Score),
+			fmt.Sprintf("%d", item.ID1),
+			fmt.Sprintf("%d", item.ID2),
+			item.Name1 + " ≈ " + item.Name2,
+		}, widths)
+	}
+
+	printHorizontalBorder("└", "┴", "┘", widths)
+	fmt.Println()
+}
+
 // printJSON prints the result as JSON.
 //
 // Deprecated: use ui.JSON(cmd, result) directly in new code
@@ -419,33 +471,7 @@ func printCSV(result CompareResult) error {
 	writer := newCompareCSVWriter(os.Stdout)
 	defer writer.Flush()
 
-	// Header
-	if err := writer.Write([]string{"Type", "Name", "ID Project 1", "ID Project 2"}); err != nil {
-		return err
-	}
-
-	// Only in first
-	for _, item := range result.OnlyInFirst {
-		if err := writer.Write([]string{"Only in Project 1", item.Name, fmt.Sprintf("%d", item.ID), ""}); err != nil {
-			return err
-		}
-	}
-
-	// Only in second
-	for _, item := range result.OnlyInSecond {
-		if err := writer.Write([]string{"Only in Project 2", item.Name, "", fmt.Sprintf("%d", item.ID)}); err != nil {
-			return err
-		}
-	}
-
-	// Common
-	for _, item := range result.Common {
-		if err := writer.Write([]string{"Common", item.Name, fmt.Sprintf("%d", item.ID1), fmt.Sprintf("%d", item.ID2)}); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return writeCompareCSV(writer, result)
 }
 
 // saveCompareResult saves the result to a file
@@ -484,28 +510,63 @@ func saveCSV(result CompareResult, savePath string) error {
 	writer := newCompareCSVWriter(file)
 	defer writer.Flush()
 
+	return writeCompareCSV(writer, result)
+}
+
+// writeCompareCSV writes the result rows. Results of a deep comparison get
+// three more columns, even when nothing differs, and a "Changed" row per
+// differing field of a common item.
+func writeCompareCSV(writer compareCSVWriter, result CompareResult) error {
+	deep := result.Deep || result.hasChanges()
+	row := func(cells ...string) error {
+		if deep {
+			for len(cells) < 7 {
+				cells = append(cells, "")
+			}
+		}
+		return writer.Write(cells)
+	}
+
 	// Header
-	if err := writer.Write([]string{"Type", "Name", "ID Project 1", "ID Project 2"}); err != nil {
+	header := []string{"Type", "Name", "ID Project 1", "ID Project 2"}
+	if deep {
+		header = append(header, "Field", "Value Project 1", "Value Project 2")
+	}
+	if err := writer.Write(header); err != nil {
 		return err
 	}
 
 	// Only in first
 	for _, item := range result.OnlyInFirst {
-		if err := writer.Write([]string{"Only in Project 1", item.Name, fmt.Sprintf("%d", item.ID), ""}); err != nil {
+		if err := row("Only in Project 1", item.Name, fmt.Sprintf("%d", item.ID), ""); err != nil {
 			return err
 		}
 	}
 
 	// Only in second
 	for _, item := range result.OnlyInSecond {
-		if err := writer.Write([]string{"Only in Project 2", item.Name, "", fmt.Sprintf("%d", item.ID)}); err != nil {
+		if err := row("Only in Project 2", item.Name, "", fmt.Sprintf("%d", item.ID)); err != nil {
 			return err
 		}
 	}
 
 	// Common
 	for _, item := range result.Common {
-		if err := writer.Write([]string{"Common", item.Name, fmt.Sprintf("%d", item.ID1), fmt.Sprintf("%d", item.ID2)}); err != nil {
+		id1, id2 := fmt.Sprintf("%d", item.ID1), fmt.Sprintf("%d", item.ID2)
+		if err := row("Common", item.Name, id1, id2); err != nil {
+			return err
+		}
+		for _, ch := range item.Changes {
+			if err := row("Changed", item.Name, id1, id2, ch.Field, ch.First, ch.Second); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Near matches
+	for _, item := range result.NearMatches {
+		if err := row(fmt.Sprintf("Near match %.2f", item.Score), item.Name1+" ≈ "+item.Name2,
+			fmt.Sprintf("%d", item.ID1), fmt.Sprintf("%d", item.ID2)); err != nil {
 			return err
 		}
 	}
@@ -9,7 +9,9 @@ import (
 	"github.com/Korrnals/gotr/internal/interactive"
 	"github.com/Korrnals/gotr/internal/models/data"
 	"github.com/Korrnals/gotr/internal/output"
+	"github.com/Korrnals/gotr/internal/trash"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // deleteCmd deletes resources via DELETE/POST requests.
@@ -29,6 +31,11 @@ Supported endpoints:
 For milestones use: gotr milestones delete
 For plans use: gotr plans delete
 
+Before deleting a project, suite, section, case or run, its full subtree
+(including the shared steps its cases use) is saved to the trash
+(~/.gotr/trash); undo with 'gotr trash restore'. 'delete shared-step' keeps
+no copy. Use --no-trash to delete without a copy.
+
 Examples:
   gotr delete project 1
   gotr delete case 12345
@@ -41,6 +48,7 @@ Dry-run mode:
 
 func init() {
 	deleteCmd.Flags().Bool("dry-run", false, "Show what would be executed without making changes")
+	deleteCmd.Flags().Bool("no-trash", false, "Delete without saving a copy to the trash")
 }
 
 func runDelete(cmd *cobra.Command, args []string) error {
@@ -81,7 +89,26 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		return runDeleteDryRun(dr, endpoint, id)
 	}
 
-	// Route by endpoint
+	// Keep a copy of the subtree for 'gotr trash restore'
+	var entry *trash.Entry
+	if noTrash, _ := cmd.Flags().GetBool("no-trash"); !noTrash {
+		if capture := captureForDelete(cli, endpoint, id); capture != nil {
+			quiet, _ := cmd.Flags().GetBool("quiet")
+			entry, err = trash.SaveBeforeDelete(ctx, fmt.Sprintf("%s %d", endpoint, id), viper.GetString("base_url"), quiet, capture)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = deleteEndpoint(ctx, cli, endpoint, id)
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	trash.FinishDelete(entry, err, quiet)
+	return err
+}
+
+// deleteEndpoint routes the delete request by endpoint.
+func deleteEndpoint(ctx context.Context, cli client.ClientInterface, endpoint string, id int64) error {
 	switch endpoint {
 	case "project":
 		return cli.DeleteProject(ctx, id)
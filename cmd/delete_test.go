@@ -24,6 +24,8 @@ func setupDeleteTest(t *testing.T, mock *client.MockClient) *cobra.Command {
 
 	cmd.Flags().Bool("dry-run", false, "Show what would be executed without making real changes")
 	cmd.Flags().Bool("soft", false, "Soft delete (where supported)")
+	cmd.Flags().Bool("no-trash", false, "Delete without saving a copy to the trash")
+	useTempTrash(t)
 
 	ctx := context.WithValue(context.Background(), httpClientKey, mock)
 	cmd.SetContext(ctx)
@@ -0,0 +1,96 @@
+// cmd/doctor.go
+// Live server diagnostics: gotr doctor
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Korrnals/gotr/internal/selftest"
+	"github.com/Korrnals/gotr/internal/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the connection to the TestRail server",
+	Long: `Check the configured TestRail server from this machine.
+
+Checks performed:
+  - DNS resolution of the server host
+  - TLS handshake, certificate trust and expiry (warns 30 days ahead)
+  - API enabled and answering with JSON
+  - Authentication with the configured username and API key
+  - Round-trip latency percentiles (p50/p90/p99 of 10 requests)
+  - Pagination style of list endpoints (flat or paginated)
+  - Rate-limit headers of the server and the rate_limit of gotr
+  - Role of the current user (get_user_by_email, get_roles)
+
+Checks that need a working API are skipped when the server is unreachable
+or rejects the credentials. The report has the same format as 'gotr self-test'.
+
+Examples:
+  # Diagnose the active connection
+  gotr doctor
+
+  # Diagnose another profile, as JSON
+  gotr doctor --profile staging --json`,
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().Bool("json", false, "Output results as JSON")
+	doctorCmd.Flags().Bool("failures-only", false, "Show only failed checks")
+}
+
+// onlineCheckers returns the live server checks of the active connection.
+var onlineCheckers = func(cmd *cobra.Command) ([]selftest.Checker, error) {
+	if viper.GetString("snapshot") != "" {
+		return nil, fmt.Errorf("online checks need the server: run without --snapshot")
+	}
+
+	baseURL := viper.GetString("base_url")
+	apiKey := viper.GetString("password")
+	if apiKey == "" {
+		apiKey = viper.GetString("api_key")
+	}
+	server := &selftest.Server{
+		BaseURL:   baseURL,
+		Username:  viper.GetString("username"),
+		APIKey:    apiKey,
+		Insecure:  viper.GetBool("insecure"),
+		RateLimit: resolveRateLimit(baseURL),
+	}
+	return selftest.OnlineCheckers(server, GetClient(cmd)), nil
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	checkers, err := onlineCheckers(cmd)
+	if err != nil {
+		return err
+	}
+
+	report, err := ui.RunWithStatus(cmd.Context(), ui.StatusConfig{
+		Title:  "Checking the TestRail server",
+		Writer: os.Stderr,
+		Quiet:  false,
+	}, func(ctx context.Context) (*selftest.Report, error) {
+		return runCheckers(checkers), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	if jsonOutput {
+		return outputJSON(report)
+	}
+
+	failuresOnly, _ := cmd.Flags().GetBool("failures-only")
+	return printReport(report, failuresOnly)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/Korrnals/gotr/internal/selftest"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubOnlineCheckers(t *testing.T) {
+	t.Helper()
+	original := onlineCheckers
+	t.Cleanup(func() { onlineCheckers = original })
+	onlineCheckers = func(cmd *cobra.Command) ([]selftest.Checker, error) {
+		return []selftest.Checker{stubChecker{name: "online-check", cat: "Network"}}, nil
+	}
+}
+
+func TestRunDoctor_JSON(t *testing.T) {
+	stubOnlineCheckers(t)
+
+	cmd := &cobra.Command{Use: "doctor"}
+	cmd.Flags().Bool("json", true, "")
+	cmd.Flags().Bool("failures-only", false, "")
+
+	out := captureStdout(t, func() {
+		require.NoError(t, runDoctor(cmd, nil))
+	})
+	assert.Contains(t, out, `"online-check"`)
+	assert.Contains(t, out, `"health": "PASS"`)
+}
+
+func TestRunSelfTest_Online(t *testing.T) {
+	stubOnlineCheckers(t)
+	original := selfTestCheckers
+	defer func() { selfTestCheckers = original }()
+	selfTestCheckers = func() []selftest.Checker {
+		return []selftest.Checker{stubChecker{name: "local-check", cat: "System"}}
+	}
+
+	cmd := &cobra.Command{Use: "self-test"}
+	cmd.Flags().Bool("json", true, "")
+	cmd.Flags().Bool("failures-only", false, "")
+	cmd.Flags().Bool("online", true, "")
+
+	out := captureStdout(t, func() {
+		require.NoError(t, runSelfTest(cmd, nil))
+	})
+	assert.Contains(t, out, `"local-check"`)
+	assert.Contains(t, out, `"online-check"`)
+}
+
+func TestOnlineCheckers_Snapshot(t *testing.T) {
+	viper.Set("snapshot", "snap.tar.gz")
+	defer viper.Set("snapshot", "")
+
+	_, err := onlineCheckers(&cobra.Command{})
+	assert.ErrorContains(t, err, "without --snapshot")
+}
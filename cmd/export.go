@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"github.com/Korrnals/gotr/internal/debug"
 	"github.com/Korrnals/gotr/internal/interactive"
 	"github.com/Korrnals/gotr/internal/output"
+	"github.com/Korrnals/gotr/internal/snapshot"
 	"github.com/Korrnals/gotr/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -38,11 +40,6 @@ Examples:
 			return err
 		}
 
-		httpClient, ok := GetClient(cmd).(*client.HTTPClient)
-		if !ok {
-			return fmt.Errorf("export requires full HTTP client (not available with mock)")
-		}
-
 		// Build full endpoint path and query parameters
 		fullEndpoint, queryParams, err := buildRequestParams(endpoint, mainID, cmd)
 		if err != nil {
@@ -52,6 +49,11 @@ Examples:
 		debug.DebugPrint("{exportCmd} - Final endpoint: %s", fullEndpoint)
 		debug.DebugPrint("{exportCmd} - Query params: %v", queryParams)
 
+		fetch, saveToFile, err := exportSource(GetClient(cmd), fullEndpoint, queryParams)
+		if err != nil {
+			return err
+		}
+
 		// Flags
 		quiet, _ := cmd.Flags().GetBool("quiet")
 		saveFlag, _ := cmd.Flags().GetBool("save")
@@ -61,15 +63,7 @@ Examples:
 			Title:  "Exporting data",
 			Writer: os.Stderr,
 			Quiet:  quiet,
-		}, func(ctx context.Context) (client.ResponseData, error) {
-			start := time.Now()
-			resp, err := httpClient.Get(ctx, fullEndpoint, queryParams)
-			if err != nil {
-				return client.ResponseData{}, err
-			}
-			defer resp.Body.Close()
-			return httpClient.ReadResponse(ctx, resp, time.Since(start), "json")
-		})
+		}, fetch)
 		if err != nil {
 			return fmt.Errorf("response reading error: %w", err)
 		}
@@ -93,7 +87,7 @@ Examples:
 			if mainID != "" {
 				filename = fmt.Sprintf("%s/%s_%s_%s.json", exportDir, resource, mainID, time.Now().Format("20060102_150405"))
 			}
-			if err := httpClient.SaveResponseToFile(ctx, data, filename, "json"); err != nil {
+			if err := saveToFile(ctx, data, filename); err != nil {
 				return fmt.Errorf("file export error %s: %w", filename, err)
 			}
 			if !quiet {
@@ -105,6 +99,49 @@ Examples:
 	},
 }
 
+// exportSource returns how to fetch the endpoint and save the response: over
+// HTTP, or from the --snapshot dump when running offline.
+func exportSource(cli client.ClientInterface, endpoint string, query map[string]string) (
+	fetch func(context.Context) (client.ResponseData, error),
+	save func(context.Context, client.ResponseData, string) error,
+	err error,
+) {
+	switch c := uncached(cli).(type) {
+	case *client.HTTPClient:
+		fetch = func(ctx context.Context) (client.ResponseData, error) {
+			start := time.Now()
+			resp, err := c.Get(ctx, endpoint, query)
+			if err != nil {
+				return client.ResponseData{}, err
+			}
+			defer resp.Body.Close()
+			return c.ReadResponse(ctx, resp, time.Since(start), "json")
+		}
+		save = func(ctx context.Context, data client.ResponseData, filename string) error {
+			return c.SaveResponseToFile(ctx, data, filename, "json")
+		}
+		return fetch, save, nil
+	case *snapshot.Client:
+		fetch = func(ctx context.Context) (client.ResponseData, error) {
+			body, err := c.Endpoint(ctx, endpoint, query)
+			if err != nil {
+				return client.ResponseData{}, err
+			}
+			return client.ResponseData{Status: "200 OK", StatusCode: 200, Body: body, Timestamp: time.Now()}, nil
+		}
+		save = func(ctx context.Context, data client.ResponseData, filename string) error {
+			b, err := json.MarshalIndent(data.Body, "", "  ")
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(filename, b, 0o644)
+		}
+		return fetch, save, nil
+	default:
+		return nil, nil, fmt.Errorf("export requires full HTTP client (not available with mock)")
+	}
+}
+
 func resolveExportInputs(cmd *cobra.Command, args []string) (resource, endpoint, id string, err error) {
 	ctx := cmd.Context()
 	p := interactive.PrompterFromContext(ctx)
@@ -31,6 +31,11 @@ Optional parameters:
 	Suite ID — --suite-id flag (required for projects with multiple suites)
 	Section ID — --section-id flag
 	All suites — --all-suites flag (get cases from all project suites)
+	Server-side filters — --created-after/--created-before/--created-by,
+	  --updated-after/--updated-before/--updated-by, --milestone-id,
+	  --priority-id, --type-id, --template-id, --refs, --title-contains
+	  (dates: YYYY-MM-DD, RFC 3339, Unix time or an age like 7d;
+	  IDs: comma-separated lists)
 
 Examples:
 	# Automatic suite selection (if single) or interactive selection
@@ -44,6 +49,9 @@ Examples:
 
 	# Filter by section
 	gotr get cases 30 --suite-id 20069 --section-id 100
+
+	# High-priority cases changed in the last week
+	gotr get cases 30 --suite-id 20069 --priority-id 3,4 --updated-after 7d
 `,
 		RunE: func(command *cobra.Command, args []string) error {
 			start := time.Now()
@@ -76,13 +84,17 @@ Examples:
 				}
 			}
 
-			sectionID, _ := command.Flags().GetInt64("section-id")
+			filter, err := flags.CaseFilterFromFlags(command)
+			if err != nil {
+				return err
+			}
+			filter.SectionID, _ = command.Flags().GetInt64("section-id")
 			allSuites, _ := command.Flags().GetBool("all-suites")
 			suiteID, _ := command.Flags().GetInt64("suite-id")
 
 			// Use the explicit suite-id if provided
 			if suiteID != 0 {
-				return fetchAndOutputCases(ctx, command, cli, projectID, suiteID, sectionID, start)
+				return fetchAndOutputCases(ctx, command, cli, projectID, suiteID, filter, start)
 			}
 
 			// Fetch suites for the project
@@ -97,13 +109,13 @@ Examples:
 
 			// If --all-suites, collect cases from every suite
 			if allSuites {
-				return fetchCasesFromAllSuites(ctx, command, cli, projectID, suites, sectionID, start)
+				return fetchCasesFromAllSuites(ctx, command, cli, projectID, suites, filter, start)
 			}
 
 			// Single suite — use it automatically
 			if len(suites) == 1 {
 				ui.Infof(os.Stdout, "Project has one suite (ID: %d), using automatically...", suites[0].ID)
-				return fetchAndOutputCases(ctx, command, cli, projectID, suites[0].ID, sectionID, start)
+				return fetchAndOutputCases(ctx, command, cli, projectID, suites[0].ID, filter, start)
 			}
 
 			// Multiple suites — interactive selection
@@ -112,7 +124,7 @@ Examples:
 				return err
 			}
 
-			return fetchAndOutputCases(ctx, command, cli, projectID, selectedSuiteID, sectionID, start)
+			return fetchAndOutputCases(ctx, command, cli, projectID, selectedSuiteID, filter, start)
 		},
 	}
 
@@ -120,6 +132,7 @@ Examples:
 	cmd.Flags().Int64("section-id", 0, "Section ID (optional)")
 	cmd.Flags().Bool("all-suites", false, "Get cases from all project suites")
 	cmd.Flags().String("project-id", "", "Project ID (alternative to positional argument)")
+	flags.AddCaseFilterFlags(cmd)
 
 	return cmd
 }
@@ -193,9 +206,10 @@ func newCaseCmd(getClient func(*cobra.Command) client.ClientInterface) *cobra.Co
 	}
 }
 
-// fetchAndOutputCases retrieves cases and outputs the result.
-func fetchAndOutputCases(ctx context.Context, cmd *cobra.Command, cli client.ClientInterface, projectID, suiteID, sectionID int64, start time.Time) error {
-	cases, err := cli.GetCases(ctx, projectID, suiteID, sectionID)
+// fetchAndOutputCases retrieves the suite's cases matching the filter and outputs the result.
+func fetchAndOutputCases(ctx context.Context, cmd *cobra.Command, cli client.ClientInterface, projectID, suiteID int64, filter data.CaseFilter, start time.Time) error {
+	filter.SuiteID = suiteID
+	cases, err := cli.GetCasesFiltered(ctx, projectID, filter)
 	if err != nil {
 		return err
 	}
@@ -204,7 +218,7 @@ func fetchAndOutputCases(ctx context.Context, cmd *cobra.Command, cli client.Cli
 }
 
 // fetchCasesFromAllSuites retrieves cases from all suites in the project.
-func fetchCasesFromAllSuites(ctx context.Context, cmd *cobra.Command, cli client.ClientInterface, projectID int64, suites data.GetSuitesResponse, sectionID int64, start time.Time) error {
+func fetchCasesFromAllSuites(ctx context.Context, cmd *cobra.Command, cli client.ClientInterface, projectID int64, suites data.GetSuitesResponse, filter data.CaseFilter, start time.Time) error {
 	quiet, _ := cmd.Flags().GetBool("quiet")
 	op := ui.NewOperation(ui.StatusConfig{
 		Title:  fmt.Sprintf("Loading cases from %d suites...", len(suites)),
@@ -216,7 +230,8 @@ func fetchCasesFromAllSuites(ctx context.Context, cmd *cobra.Command, cli client
 
 	allCases := make(data.GetCasesResponse, 0)
 	for _, suite := range suites {
-		cases, err := cli.GetCases(ctx, projectID, suite.ID, sectionID)
+		filter.SuiteID = suite.ID
+		cases, err := cli.GetCasesFiltered(ctx, projectID, filter)
 		if err != nil {
 			task.Error(err)
 			task.Increment()
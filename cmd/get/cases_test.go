@@ -587,3 +587,37 @@ func TestCaseCmd_NilClient(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "HTTP client not initialized")
 }
+
+func TestCasesCmd_ServerSideFilters(t *testing.T) {
+	mock := &client.MockClient{
+		GetCasesFilteredFunc: func(ctx context.Context, projectID int64, filter data.CaseFilter) (data.GetCasesResponse, error) {
+			assert.Equal(t, int64(30), projectID)
+			assert.Equal(t, map[string]string{
+				"suite_id":       "20069",
+				"section_id":     "7",
+				"priority_id":    "3,4",
+				"created_before": "1704067200",
+				"filter":         "login",
+			}, filter.Query())
+			return data.GetCasesResponse{{ID: 1, Title: "Login works"}}, nil
+		},
+	}
+
+	cmd := newCasesCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"30", "--suite-id", "20069", "--section-id", "7",
+		"--priority-id", "3,4", "--created-before", "2024-01-01T00:00:00Z", "--title-contains", "login"})
+
+	err := cmd.Execute()
+	assert.NoError(t, err)
+}
+
+func TestCasesCmd_InvalidFilterDate(t *testing.T) {
+	cmd := newCasesCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, &client.MockClient{}).Context())
+	cmd.SetArgs([]string{"30", "--suite-id", "1", "--updated-after", "yesterday"})
+
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "--updated-after")
+}
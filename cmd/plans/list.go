@@ -19,11 +19,24 @@ func newListCmd(getClient GetClientFunc) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list [project_id]",
 		Short: "List test plans",
-		Long:  `Lists all test plans for a project.`,
+		Long: `Lists all test plans for a project.
+
+Server-side filters narrow the list before it is downloaded:
+--created-after/--created-before (YYYY-MM-DD, RFC 3339, Unix time or an
+age like 7d), --created-by, --is-completed and --milestone-id
+(comma-separated ID lists).`,
 		Example: `  # List project plans
-  gotr plans list 1`,
+  gotr plans list 1
+
+  # Completed plans of milestones 3 and 4
+  gotr plans list 1 --is-completed --milestone-id 3,4`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			filter, err := flags.PlanFilterFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
 			var projectID int64
 			if len(args) > 0 {
 				var err error
@@ -50,7 +63,7 @@ func newListCmd(getClient GetClientFunc) *cobra.Command {
 				Writer: os.Stderr,
 				Quiet:  quiet,
 			}, func(ctx context.Context) (data.GetPlansResponse, error) {
-				return cli.GetPlans(ctx, projectID)
+				return cli.GetPlansFiltered(ctx, projectID, filter)
 			})
 			if err != nil {
 				return fmt.Errorf("failed to list plans: %w", err)
@@ -62,6 +75,7 @@ func newListCmd(getClient GetClientFunc) *cobra.Command {
 	}
 
 	output.AddFlag(cmd)
+	flags.AddPlanFilterFlags(cmd)
 
 	return cmd
 }
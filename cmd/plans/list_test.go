@@ -110,3 +110,24 @@ func TestListCmd_NoArgs_Interactive(t *testing.T) {
 	err := cmd.Execute()
 	assert.NoError(t, err)
 }
+
+func TestListCmd_ServerSideFilters(t *testing.T) {
+	mock := &client.MockClient{
+		GetPlansFilteredFunc: func(ctx context.Context, projectID int64, filter data.PlanFilter) (data.GetPlansResponse, error) {
+			assert.Equal(t, int64(1), projectID)
+			assert.Equal(t, map[string]string{
+				"is_completed": "1",
+				"milestone_id": "3,4",
+				"created_by":   "9",
+			}, filter.Query())
+			return data.GetPlansResponse{{ID: 100, Name: "Plan 1"}}, nil
+		},
+	}
+
+	cmd := newListCmd(getClientForTests)
+	cmd.SetContext(setupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"1", "--is-completed", "--milestone-id", "3,4", "--created-by", "9"})
+
+	err := cmd.Execute()
+	assert.NoError(t, err)
+}
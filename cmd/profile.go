@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/debug"
+	"github.com/Korrnals/gotr/internal/models/config"
+	"github.com/Korrnals/gotr/internal/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// activeProfileName returns the selected profile:
+// --profile flag > GOTR_PROFILE env > current_profile in config. Empty means none.
+func activeProfileName() string {
+	if name := strings.TrimSpace(viper.GetString("profile")); name != "" {
+		return name
+	}
+	if name := strings.TrimSpace(os.Getenv(config.ProfileEnvVar)); name != "" {
+		return name
+	}
+	return strings.TrimSpace(viper.GetString(config.CurrentProfileKey))
+}
+
+// applyActiveProfile merges the selected profile into the config layer, so its
+// values override top-level file settings while flags and env still win.
+func applyActiveProfile() error {
+	name := activeProfileName()
+	if name == "" {
+		return nil
+	}
+
+	profiles := viper.GetStringMap(config.ProfilesKey)
+	raw, ok := profiles[config.NormalizeProfileName(name)]
+	if !ok {
+		return fmt.Errorf("profile %q not found in config\n"+
+			"Available profiles: %s\n"+
+			"Add one with 'gotr config profile add %s --url ... -u ... -k ...'",
+			name, strings.Join(profileNames(), ", "), name)
+	}
+	values, ok := raw.(map[string]any)
+	if !ok {
+		return fmt.Errorf("profile %q in config must be a mapping", name)
+	}
+
+	debug.DebugPrint("{profile} - Applying profile %q", name)
+	return viper.MergeConfigMap(values)
+}
+
+// newProfileClient creates an HTTP client for a named profile, independent of
+// the active one. Retry and rate-limit settings come from the top-level config;
+// each client gets its own limiter since profiles usually point to different servers.
+func newProfileClient(name string) (client.ClientInterface, error) {
+	sub := viper.Sub(config.ProfilesKey + "." + config.NormalizeProfileName(name))
+	if sub == nil {
+		return nil, fmt.Errorf("profile %q not found in config\n"+
+			"Available profiles: %s", name, strings.Join(profileNames(), ", "))
+	}
+
+	baseURL := sub.GetString("base_url")
+	username := sub.GetString("username")
+	apiKey := sub.GetString("api_key")
+	if baseURL == "" || username == "" || apiKey == "" {
+		return nil, fmt.Errorf("profile %q must define base_url, username and api_key", name)
+	}
+
+	opts, err := resolveClientOptions(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, client.WithSkipTlsVerify(sub.GetBool("insecure")))
+
+	debug.DebugPrint("{profile} - Creating client for profile %q (%s)", name, baseURL)
+	httpClient, err := client.NewClient(baseURL, username, apiKey, viper.GetBool("debug"), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for profile %q: %w", name, err)
+	}
+	return withCache(httpClient, baseURL, username)
+}
+
+// profileNames lists profile names known to viper (used for completion).
+func profileNames() []string {
+	set := config.ProfileSet{Profiles: map[string]config.Profile{}}
+	for name := range viper.GetStringMap(config.ProfilesKey) {
+		set.Profiles[name] = config.Profile{}
+	}
+	return set.Names()
+}
+
+// completeProfileNames is a cobra completion function for profile names.
+func completeProfileNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return profileNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// profileConfigFile returns the config file that profile commands edit.
+func profileConfigFile() (*config.Config, error) {
+	if used := viper.ConfigFileUsed(); used != "" {
+		return config.New(used), nil
+	}
+	cfg, err := config.Default()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine config path: %w", err)
+	}
+	return cfg, nil
+}
+
+// configProfileCmd is the parent "config profile" command.
+var configProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage named connection profiles",
+	Long: `Manage named connection profiles stored in the config file.
+
+Each profile holds its own base_url, username, api_key, insecure flag and
+optional compare settings. Select a profile per command with --profile,
+via the GOTR_PROFILE environment variable, or make it the default with
+'gotr config profile use'.
+
+Examples:
+	gotr config profile add staging --url https://staging.example.local -u qa@example.com -k KEY
+	gotr config profile use staging
+	gotr --profile production get projects`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Override parent PersistentPreRunE (no-op)
+	},
+}
+
+// configProfileListCmd prints all profiles and marks the active one.
+var configProfileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured profiles",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Override parent PersistentPreRunE (no-op)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := profileConfigFile()
+		if err != nil {
+			return err
+		}
+		set, err := cfg.LoadProfiles()
+		if err != nil {
+			return err
+		}
+		if len(set.Profiles) == 0 {
+			ui.Info(cmd.OutOrStdout(), "No profiles configured.")
+			ui.Info(cmd.OutOrStdout(), "Add one with: gotr config profile add <name> --url ... -u ... -k ...")
+			return nil
+		}
+
+		active := activeProfileName()
+		if active == "" {
+			active = set.Current
+		}
+		return printProfiles(cmd.OutOrStdout(), set, active)
+	},
+}
+
+func printProfiles(w io.Writer, set config.ProfileSet, active string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "\tNAME\tBASE URL\tUSERNAME\tINSECURE")
+	for _, name := range set.Names() {
+		p := set.Profiles[name]
+		marker := ""
+		if strings.EqualFold(name, active) {
+			marker = "*"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%v\n", marker, name, p.BaseURL, p.Username, p.Insecure)
+	}
+	return tw.Flush()
+}
+
+// configProfileAddCmd adds or replaces a profile.
+var configProfileAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add or replace a profile",
+	Long: `Adds a profile to the config file, or replaces an existing one with the same name.
+Connection values are taken from the global --url, --username, --api-key
+and --insecure flags.
+
+Example:
+	gotr config profile add production --url https://yourcompany.testrail.io -u qa@example.com -k KEY`,
+	Args: cobra.ExactArgs(1),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Override parent PersistentPreRunE (no-op)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		baseURL, _ := cmd.Flags().GetString("url")
+		username, _ := cmd.Flags().GetString("username")
+		apiKey, _ := cmd.Flags().GetString("api-key")
+		insecure, _ := cmd.Flags().GetBool("insecure")
+
+		if strings.TrimSpace(baseURL) == "" || strings.TrimSpace(username) == "" || strings.TrimSpace(apiKey) == "" {
+			return fmt.Errorf("--url, --username and --api-key are required")
+		}
+
+		cfg, err := profileConfigFile()
+		if err != nil {
+			return err
+		}
+		if err := cfg.AddProfile(name, config.Profile{
+			BaseURL:  baseURL,
+			Username: username,
+			APIKey:   apiKey,
+			Insecure: insecure,
+		}); err != nil {
+			return err
+		}
+
+		ui.Infof(cmd.OutOrStdout(), "Profile %q saved to %s", config.NormalizeProfileName(name), cfg.Path)
+		return nil
+	},
+}
+
+// configProfileUseCmd sets current_profile.
+var configProfileUseCmd = &cobra.Command{
+	Use:               "use <name>",
+	Short:             "Make a profile the default",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProfileNames,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Override parent PersistentPreRunE (no-op)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := profileConfigFile()
+		if err != nil {
+			return err
+		}
+		if err := cfg.UseProfile(args[0]); err != nil {
+			return err
+		}
+		ui.Infof(cmd.OutOrStdout(), "Default profile set to %q", config.NormalizeProfileName(args[0]))
+		return nil
+	},
+}
+
+// configProfileRemoveCmd deletes a profile.
+var configProfileRemoveCmd = &cobra.Command{
+	Use:               "remove <name>",
+	Aliases:           []string{"rm"},
+	Short:             "Remove a profile",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProfileNames,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Override parent PersistentPreRunE (no-op)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := profileConfigFile()
+		if err != nil {
+			return err
+		}
+		if err := cfg.RemoveProfile(args[0]); err != nil {
+			return err
+		}
+		ui.Infof(cmd.OutOrStdout(), "Profile %q removed", config.NormalizeProfileName(args[0]))
+		return nil
+	},
+}
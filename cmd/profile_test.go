@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const profileTestConfig = `base_url: "https://main.example.com"
+username: "main@example.com"
+api_key: "main-key"
+current_profile: staging
+profiles:
+  staging:
+    base_url: "https://staging.example.com"
+    username: "staging@example.com"
+    api_key: "staging-key"
+    insecure: true
+  prod:
+    base_url: "https://acme.testrail.io"
+    username: "prod@example.com"
+    api_key: "prod-key"
+    compare:
+      cloud_tier: enterprise
+`
+
+func loadProfileTestConfig(t *testing.T) string {
+	t.Helper()
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	t.Setenv(config.ProfileEnvVar, "")
+
+	path := filepath.Join(t.TempDir(), "default.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(profileTestConfig), 0o600))
+	viper.SetConfigFile(path)
+	require.NoError(t, viper.ReadInConfig())
+	viper.Set("no_cache", true) // assert on the bare HTTP client
+	return path
+}
+
+func TestActiveProfileName_Priority(t *testing.T) {
+	loadProfileTestConfig(t)
+	assert.Equal(t, "staging", activeProfileName())
+
+	t.Setenv(config.ProfileEnvVar, "prod")
+	assert.Equal(t, "prod", activeProfileName())
+
+	viper.Set("profile", "other")
+	assert.Equal(t, "other", activeProfileName())
+}
+
+func TestApplyActiveProfile_OverridesFileValues(t *testing.T) {
+	loadProfileTestConfig(t)
+	viper.Set("profile", "prod")
+
+	require.NoError(t, applyActiveProfile())
+	assert.Equal(t, "https://acme.testrail.io", viper.GetString("base_url"))
+	assert.Equal(t, "prod@example.com", viper.GetString("username"))
+	assert.Equal(t, "enterprise", viper.GetString("compare.cloud_tier"))
+}
+
+func TestApplyActiveProfile_NoProfileKeepsTopLevel(t *testing.T) {
+	loadProfileTestConfig(t)
+	viper.Set(config.CurrentProfileKey, "")
+
+	require.NoError(t, applyActiveProfile())
+	assert.Equal(t, "https://main.example.com", viper.GetString("base_url"))
+}
+
+func TestApplyActiveProfile_UnknownProfile(t *testing.T) {
+	loadProfileTestConfig(t)
+	viper.Set("profile", "ghost")
+
+	err := applyActiveProfile()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `profile "ghost" not found`)
+	assert.Contains(t, err.Error(), "prod, staging")
+}
+
+func TestRootPersistentPreRunE_UsesProfile(t *testing.T) {
+	loadProfileTestConfig(t)
+
+	cmd := &cobra.Command{Use: "test-cmd"}
+	cmd.Flags().Bool("quiet", false, "")
+	cmd.Flags().Bool("non-interactive", false, "")
+	cmd.SetContext(context.Background())
+
+	require.NoError(t, rootCmd.PersistentPreRunE(cmd, nil))
+	assert.IsType(t, &client.HTTPClient{}, cmd.Context().Value(httpClientKey))
+	assert.Equal(t, "https://staging.example.com", viper.GetString("base_url"))
+	assert.True(t, viper.GetBool("insecure"))
+}
+
+func TestCompleteProfileNames(t *testing.T) {
+	loadProfileTestConfig(t)
+
+	names, directive := completeProfileNames(rootCmd, nil, "")
+	assert.Equal(t, []string{"prod", "staging"}, names)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
+
+func TestConfigProfileCommands(t *testing.T) {
+	path := loadProfileTestConfig(t)
+
+	run := func(c *cobra.Command, args ...string) (string, error) {
+		var out bytes.Buffer
+		c.SetOut(&out)
+		t.Cleanup(func() { c.SetOut(nil) })
+		err := c.RunE(c, args)
+		return out.String(), err
+	}
+
+	out, err := run(configProfileListCmd)
+	require.NoError(t, err)
+	assert.Contains(t, out, "prod")
+	assert.Contains(t, out, "*  staging")
+
+	addCmd := &cobra.Command{Use: "add", RunE: configProfileAddCmd.RunE}
+	addCmd.Flags().String("url", "", "")
+	addCmd.Flags().String("username", "", "")
+	addCmd.Flags().String("api-key", "", "")
+	addCmd.Flags().Bool("insecure", false, "")
+
+	_, err = run(addCmd, "qa")
+	assert.ErrorContains(t, err, "are required")
+
+	require.NoError(t, addCmd.Flags().Set("url", "https://qa.example.com"))
+	require.NoError(t, addCmd.Flags().Set("username", "qa@example.com"))
+	require.NoError(t, addCmd.Flags().Set("api-key", "qa-key"))
+	_, err = run(addCmd, "qa")
+	require.NoError(t, err)
+
+	_, err = run(configProfileUseCmd, "qa")
+	require.NoError(t, err)
+
+	_, err = run(configProfileRemoveCmd, "staging")
+	require.NoError(t, err)
+
+	set, err := config.New(path).LoadProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, "qa", set.Current)
+	assert.Equal(t, []string{"prod", "qa"}, set.Names())
+
+	_, err = run(configProfileUseCmd, "ghost")
+	assert.Error(t, err)
+}
+
+func TestConfigProfileList_Empty(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	t.Setenv("HOME", t.TempDir())
+
+	var out bytes.Buffer
+	configProfileListCmd.SetOut(&out)
+	t.Cleanup(func() { configProfileListCmd.SetOut(nil) })
+
+	require.NoError(t, configProfileListCmd.RunE(configProfileListCmd, nil))
+	assert.Contains(t, out.String(), "No profiles configured")
+}
+
+func TestRootCmd_ProfileFlagRegistered(t *testing.T) {
+	flag := rootCmd.PersistentFlags().Lookup("profile")
+	require.NotNil(t, flag)
+	assert.Equal(t, "", flag.DefValue)
+}
+
+func TestNewProfileClient(t *testing.T) {
+	loadProfileTestConfig(t)
+
+	cli, err := newProfileClient("prod")
+	require.NoError(t, err)
+	httpClient, ok := cli.(*client.HTTPClient)
+	require.True(t, ok)
+	assert.NotNil(t, httpClient.RateLimiter(), "cloud profile gets its own limiter")
+
+	cli, err = newProfileClient("staging")
+	require.NoError(t, err)
+	assert.Nil(t, cli.(*client.HTTPClient).RateLimiter())
+
+	_, err = newProfileClient("missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "prod, staging")
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/Korrnals/gotr/internal/flags"
 	"github.com/Korrnals/gotr/internal/interactive"
+	"github.com/Korrnals/gotr/internal/models/data"
 	"github.com/Korrnals/gotr/internal/output"
 	"github.com/Korrnals/gotr/internal/ui"
 	"github.com/spf13/cobra"
@@ -20,13 +21,18 @@ func newRunCmd(getClient GetClientFunc) *cobra.Command {
 		Short: "Run report generation from a template",
 		Long: `Runs report generation using the specified template.
 
-Returns the report ID, download URL, and generation status.
-To check report readiness status, run the command again.`,
+Returns the report page and its HTML/PDF download URLs.
+With --wait the command fetches the PDF (or HTML) link until the server
+serves the report and exits non-zero on error or timeout; --download also
+saves the generated report.`,
 		Example: `  # Run report generation
   gotr reports run 42
 
   # Save result to file
-  gotr reports run 42 -o report_result.json`,
+  gotr reports run 42 -o report_result.json
+
+  # Generate and download the report in one step (e.g. in a nightly job)
+  gotr reports run 42 --download ./reports/ --poll-interval 10s --wait-timeout 1h`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cli := getClient(cmd)
@@ -67,19 +73,23 @@ To check report readiness status, run the command again.`,
 			resp, err := ui.RunWithStatus(ctx, ui.StatusConfig{
 				Title:  "Running report...",
 				Writer: os.Stderr,
-			}, func(ctx context.Context) (any, error) {
+			}, func(ctx context.Context) (*data.RunReportResponse, error) {
 				return cli.RunReport(ctx, templateID)
 			})
 			if err != nil {
 				return fmt.Errorf("failed to run report: %w", err)
 			}
 
-			_, err = output.Output(cmd, resp, "reports", "json")
-			return err
+			resp, waitErr := finishReport(cmd, cli, resp)
+			if _, err := output.Output(cmd, resp, "reports", "json"); err != nil {
+				return err
+			}
+			return waitErr
 		},
 	}
 
 	cmd.Flags().Bool("dry-run", false, "Show what would be done without running generation")
+	addWaitFlags(cmd)
 	output.AddFlag(cmd)
 
 	return cmd
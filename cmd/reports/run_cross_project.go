@@ -7,6 +7,7 @@ import (
 
 	"github.com/Korrnals/gotr/internal/flags"
 	"github.com/Korrnals/gotr/internal/interactive"
+	"github.com/Korrnals/gotr/internal/models/data"
 	"github.com/Korrnals/gotr/internal/output"
 	"github.com/Korrnals/gotr/internal/ui"
 	"github.com/spf13/cobra"
@@ -21,12 +22,18 @@ func newRunCrossProjectCmd(getClient GetClientFunc) *cobra.Command {
 		Long: `Runs cross-project report generation using the specified template.
 
 Cross-project reports span multiple TestRail projects.
-Returns the report ID, download URL, and generation status.`,
+Returns the report page and its HTML/PDF download URLs.
+With --wait the command fetches the PDF (or HTML) link until the server
+serves the report and exits non-zero on error or timeout; --download also
+saves the generated report.`,
 		Example: `  # Run a cross-project report
   gotr reports run-cross-project 42
 
   # Save result to file
-  gotr reports run-cross-project 42 -o cross_project_report.json`,
+  gotr reports run-cross-project 42 -o cross_project_report.json
+
+  # Wait for the report and save it
+  gotr reports run-cross-project 42 --download ./cross_project_report.pdf`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cli := getClient(cmd)
@@ -67,19 +74,23 @@ Returns the report ID, download URL, and generation status.`,
 			resp, err := ui.RunWithStatus(ctx, ui.StatusConfig{
 				Title:  "Running cross-project report...",
 				Writer: os.Stderr,
-			}, func(ctx context.Context) (any, error) {
+			}, func(ctx context.Context) (*data.RunReportResponse, error) {
 				return cli.RunCrossProjectReport(ctx, templateID)
 			})
 			if err != nil {
 				return fmt.Errorf("failed to run cross-project report: %w", err)
 			}
 
-			_, err = output.Output(cmd, resp, "reports", "json")
-			return err
+			resp, waitErr := finishReport(cmd, cli, resp)
+			if _, err := output.Output(cmd, resp, "reports", "json"); err != nil {
+				return err
+			}
+			return waitErr
 		},
 	}
 
 	cmd.Flags().Bool("dry-run", false, "Show what would be done without running generation")
+	addWaitFlags(cmd)
 	output.AddFlag(cmd)
 
 	return cmd
@@ -0,0 +1,192 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/Korrnals/gotr/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// reportStatusCompleted is set on the response once the report is served.
+const reportStatusCompleted = "completed"
+
+// addWaitFlags adds the flags that wait for a started report and save it.
+func addWaitFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("wait", false, "Wait until the report download link serves the generated report")
+	cmd.Flags().Duration("poll-interval", 5*time.Second, "Interval between download attempts with --wait")
+	cmd.Flags().Duration("wait-timeout", 30*time.Minute, "Give up waiting after this long")
+	cmd.Flags().String("download", "", "Save the generated report to this file or directory (implies --wait)")
+}
+
+// finishReport applies --wait and --download to a started report and
+// returns its latest state. run_report has no status endpoint, so waiting
+// means fetching the report_pdf (or report_html) link until the server
+// serves it; --wait alone discards the content.
+func finishReport(cmd *cobra.Command, cli client.ClientInterface, resp *data.RunReportResponse) (*data.RunReportResponse, error) {
+	wait, _ := cmd.Flags().GetBool("wait")
+	download, _ := cmd.Flags().GetString("download")
+	if !wait && download == "" {
+		return resp, nil
+	}
+	var link, ext string
+	if resp != nil {
+		link, ext = reportLink(resp)
+	}
+	if link == "" {
+		return resp, fmt.Errorf("cannot wait for the report: the response has no report_pdf or report_html URL")
+	}
+
+	ctx := cmd.Context()
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	interval, _ := cmd.Flags().GetDuration("poll-interval")
+	timeout, _ := cmd.Flags().GetDuration("wait-timeout")
+	if interval <= 0 {
+		return resp, fmt.Errorf("--poll-interval must be positive")
+	}
+
+	var file string
+	var f *os.File
+	if download != "" {
+		var err error
+		file, f, err = createReportFile(download, reportFileName(resp, link, ext))
+		if err != nil {
+			return resp, err
+		}
+	}
+
+	n, err := ui.RunWithStatus(ctx, ui.StatusConfig{
+		Title:  fmt.Sprintf("Waiting for report %s...", link),
+		Writer: os.Stderr,
+		Quiet:  quiet,
+	}, func(ctx context.Context) (int64, error) {
+		return awaitReport(ctx, cli, link, reportMediaType(ext), f, interval, timeout)
+	})
+	if f != nil {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(file)
+		}
+	}
+	if err != nil {
+		return resp, err
+	}
+
+	done := *resp
+	done.Status = reportStatusCompleted
+	if file != "" && !quiet {
+		ui.Successf(os.Stderr, "Report saved to %s (%d bytes)", file, n)
+	}
+	return &done, nil
+}
+
+// awaitReport downloads the report at link into f (or discards it when f is
+// nil), retrying every interval while the server answers that it is still
+// generating it, for at most timeout. Other errors, such as a bad link or a
+// page of another media type than mediaType, end the wait.
+func awaitReport(ctx context.Context, cli client.ClientInterface, link, mediaType string, f *os.File, interval, timeout time.Duration) (int64, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		var w io.Writer = io.Discard
+		if f != nil {
+			if err := f.Truncate(0); err != nil {
+				return 0, err
+			}
+			if _, err := f.Seek(0, io.SeekStart); err != nil {
+				return 0, err
+			}
+			w = f
+		}
+
+		n, err := cli.DownloadReport(ctx, link, mediaType, w)
+		if err == nil {
+			return n, nil
+		}
+		if !errors.Is(err, client.ErrReportNotReady) {
+			return 0, fmt.Errorf("failed to download report: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return 0, fmt.Errorf("report not ready after %s: %w", timeout, err)
+			}
+			return 0, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// reportLink returns the download link of a started report and the file
+// extension it is saved with, preferring the PDF.
+func reportLink(resp *data.RunReportResponse) (link, ext string) {
+	switch {
+	case resp.ReportPDF != "":
+		return resp.ReportPDF, ".pdf"
+	case resp.ReportHTML != "":
+		return resp.ReportHTML, ".html"
+	}
+	return "", ""
+}
+
+// reportMediaType is the media type the report saved with ext must have.
+func reportMediaType(ext string) string {
+	if ext == ".html" {
+		return client.ReportMediaHTML
+	}
+	return client.ReportMediaPDF
+}
+
+// reportFileName is report_<id><ext>, with the ID taken from the response or
+// from the last element of the link (index.php?/reports/get_pdf/<id>).
+func reportFileName(resp *data.RunReportResponse, link, ext string) string {
+	if resp.ReportID != 0 {
+		return fmt.Sprintf("report_%d%s", resp.ReportID, ext)
+	}
+	if u, err := url.Parse(link); err == nil {
+		route := u.Path
+		if u.RawQuery != "" {
+			route = u.RawQuery
+		}
+		if id := path.Base(route); id != "" && id != "." && id != "/" {
+			return "report_" + id + ext
+		}
+	}
+	return "report" + ext
+}
+
+// createReportFile creates target, or name inside target when it is an
+// existing directory.
+func createReportFile(target, name string) (string, *os.File, error) {
+	if info, err := os.Stat(target); err == nil && info.IsDir() {
+		target = filepath.Join(target, name)
+	}
+	if dir := filepath.Dir(target); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return "", nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+	f, err := os.Create(target)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create %s: %w", target, err)
+	}
+	return target, f, nil
+}
@@ -0,0 +1,116 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Korrnals/gotr/cmd/internal/testhelper"
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pendingReport is a mock server whose report 1000 is served after `polls`
+// download attempts; until then the link answers as not ready. A non-nil
+// final error is returned instead of the report.
+func pendingReport(polls int, final error) (*client.MockClient, *int) {
+	checks := 0
+	started := &data.RunReportResponse{
+		ReportURL:  "https://testrail.example.com/index.php?/reports/view/1000",
+		ReportHTML: "https://testrail.example.com/index.php?/reports/get_html/1000",
+		ReportPDF:  "https://testrail.example.com/index.php?/reports/get_pdf/1000",
+	}
+	mock := &client.MockClient{
+		RunReportFunc: func(ctx context.Context, templateID int64) (*data.RunReportResponse, error) {
+			return started, nil
+		},
+		RunCrossProjectReportFunc: func(ctx context.Context, templateID int64) (*data.RunReportResponse, error) {
+			return started, nil
+		},
+		DownloadReportFunc: func(ctx context.Context, reportURL, mediaType string, w io.Writer) (int64, error) {
+			checks++
+			if reportURL != started.ReportPDF {
+				return 0, fmt.Errorf("unexpected report URL %s", reportURL)
+			}
+			if checks < polls {
+				_, _ = io.WriteString(w, "partial")
+				return 0, fmt.Errorf("error downloading report: %w", client.ErrReportNotReady)
+			}
+			if final != nil {
+				return 0, final
+			}
+			return io.Copy(w, strings.NewReader("%PDF report"))
+		},
+	}
+	return mock, &checks
+}
+
+func TestRunCmd_WaitAndDownload(t *testing.T) {
+	mock, checks := pendingReport(3, nil)
+	dir := t.TempDir()
+
+	cmd := newRunCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"42", "--download", dir, "--poll-interval", "1ms"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, 3, *checks)
+	b, err := os.ReadFile(filepath.Join(dir, "report_1000.pdf"))
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF report", string(b))
+}
+
+func TestRunCrossProjectCmd_DownloadFailed(t *testing.T) {
+	mock, checks := pendingReport(2, fmt.Errorf("error downloading report: API returned 403 Forbidden"))
+	target := filepath.Join(t.TempDir(), "report.pdf")
+
+	cmd := newRunCrossProjectCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"42", "--download", target, "--poll-interval", "1ms"})
+
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "403 Forbidden")
+	assert.Equal(t, 2, *checks, "errors other than not ready end the wait")
+	assert.NoFileExists(t, target)
+}
+
+func TestRunCmd_WaitTimeout(t *testing.T) {
+	mock, _ := pendingReport(1000, nil)
+
+	cmd := newRunCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"42", "--wait", "--poll-interval", "1ms", "--wait-timeout", "20ms"})
+
+	assert.ErrorContains(t, cmd.Execute(), "not ready after 20ms")
+}
+
+func TestRunCmd_WithoutWaitDoesNotPoll(t *testing.T) {
+	mock, checks := pendingReport(1, nil)
+
+	cmd := newRunCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"42"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Zero(t, *checks)
+}
+
+func TestRunCmd_WaitWithoutLink(t *testing.T) {
+	mock := &client.MockClient{
+		RunReportFunc: func(ctx context.Context, templateID int64) (*data.RunReportResponse, error) {
+			return &data.RunReportResponse{}, nil
+		},
+	}
+
+	cmd := newRunCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"42", "--wait"})
+
+	assert.ErrorContains(t, cmd.Execute(), "no report_pdf or report_html URL")
+}
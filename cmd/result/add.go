@@ -2,13 +2,17 @@ package result
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/Korrnals/gotr/internal/client"
 	"github.com/Korrnals/gotr/internal/interactive"
 	"github.com/Korrnals/gotr/internal/models/data"
 	"github.com/Korrnals/gotr/internal/output"
+	"github.com/Korrnals/gotr/internal/service"
 	"github.com/Korrnals/gotr/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -30,6 +34,9 @@ Result statuses (standard):
 
 You can specify: comment, elapsed time, software version,
 defects (comma-separated), and user assignment.
+Custom result fields are set with --field (repeatable). Values of string,
+text, URL and date fields are always sent as strings; for other fields the
+value is used as JSON if it parses (42, true, [1,2]), otherwise as a string.
 
 Examples:
 	# Successfully passed test
@@ -41,6 +48,9 @@ Examples:
 	# With elapsed time and version
 	gotr result add 12345 --status-id 1 --elapsed "2m 30s" --version "v2.0.1"
 
+	# Custom result fields (custom_build=42 is sent as "42" for a string field)
+	gotr result add 12345 --status-id 1 --field custom_environment=staging --field custom_build=42
+
 	# Reassign to another user
 	gotr result add 12345 --status-id 2 --assigned-to 10 \\
 		--comment "Need re-test by another engineer"
@@ -98,6 +108,10 @@ Examples:
 				return nil
 			}
 
+			if err := quoteTextFieldValues(ctx, cli, req.CustomFields); err != nil {
+				return err
+			}
+
 			quiet, _ := cmd.Flags().GetBool("quiet")
 			result, err := ui.RunWithStatus(ctx, ui.StatusConfig{
 				Title:  "Adding result",
@@ -121,6 +135,7 @@ Examples:
 	cmd.Flags().String("elapsed", "", "Elapsed time (e.g. '1m 30s')")
 	cmd.Flags().String("defects", "", "Defect IDs (comma-separated)")
 	cmd.Flags().Int64("assigned-to", 0, "User ID for assignment")
+	cmd.Flags().StringArray("field", nil, "Custom result field as custom_name=value (repeatable)")
 	cmd.Flags().Bool("dry-run", false, "Show what would be executed without making actual changes")
 	_ = cmd.MarkFlagRequired("status-id")
 
@@ -197,6 +212,10 @@ Examples:
 				return nil
 			}
 
+			if err := quoteTextFieldValues(ctx, cli, req.CustomFields); err != nil {
+				return err
+			}
+
 			quiet, _ := cmd.Flags().GetBool("quiet")
 			result, err := ui.RunWithStatus(ctx, ui.StatusConfig{
 				Title:  "Adding result",
@@ -221,6 +240,7 @@ Examples:
 	cmd.Flags().String("elapsed", "", "Elapsed time")
 	cmd.Flags().String("defects", "", "Defect IDs (comma-separated)")
 	cmd.Flags().Int64("assigned-to", 0, "User ID for assignment")
+	cmd.Flags().StringArray("field", nil, "Custom result field as custom_name=value (repeatable)")
 	cmd.Flags().Bool("dry-run", false, "Show what would be executed without making actual changes")
 	_ = cmd.MarkFlagRequired("case-id")
 	_ = cmd.MarkFlagRequired("status-id")
@@ -253,6 +273,18 @@ The JSON file must contain an array of results:
 
 Both formats are supported: with test_id and with case_id.
 
+Custom result fields (see 'gotr result fields') are set as custom_* keys and
+checked against the result fields before sending. "attachments" lists files
+(relative to the current directory) uploaded to each created result:
+[
+  {
+    "case_id": 98765,
+    "status_id": 5,
+    "custom_environment": "staging",
+    "attachments": ["screenshots/login.png", "logs/login.log"]
+  }
+]
+
 Examples:
 	# Dry-run mode
 	gotr result add-bulk 12345 --results-file results.json --dry-run`,
@@ -292,12 +324,17 @@ Examples:
 
 			// Parse and submit results
 			results, err := svc.AddBulkResults(ctx, runID, fileData)
-			if err != nil {
+			var attErr *service.AttachmentsError
+			if err != nil && !errors.As(err, &attErr) {
 				return err
 			}
 
 			output.PrintSuccess(cmd, "Results added successfully:")
-			return output.OutputResultWithFlags(cmd, results)
+			if outErr := output.OutputResultWithFlags(cmd, results); outErr != nil {
+				return outErr
+			}
+			// The results exist; report the files that could not be attached
+			return err
 		},
 	}
 
@@ -321,17 +358,76 @@ func buildAddResultRequest(cmd *cobra.Command) (*data.AddResultRequest, error) {
 	elapsed, _ := cmd.Flags().GetString("elapsed")
 	defects, _ := cmd.Flags().GetString("defects")
 	assignedTo, _ := cmd.Flags().GetInt64("assigned-to")
+	fields, _ := cmd.Flags().GetStringArray("field")
+
+	custom, err := parseCustomFields(fields)
+	if err != nil {
+		return nil, err
+	}
 
 	return &data.AddResultRequest{
-		StatusID:   statusID,
-		Comment:    comment,
-		Version:    version,
-		Elapsed:    elapsed,
-		Defects:    defects,
-		AssignedTo: assignedTo,
+		StatusID:     statusID,
+		Comment:      comment,
+		Version:      version,
+		Elapsed:      elapsed,
+		Defects:      defects,
+		AssignedTo:   assignedTo,
+		CustomFields: custom,
 	}, nil
 }
 
+// parseCustomFields parses --field custom_name=value pairs. A value that is
+// valid JSON (42, true, [1,2], "42") is kept as is, anything else becomes a
+// string; quoteTextFieldValues later applies the result field types.
+func parseCustomFields(pairs []string) (data.CustomFieldValues, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	res := make(data.CustomFieldValues, len(pairs))
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --field %q: want custom_name=value", pair)
+		}
+		if !strings.HasPrefix(name, "custom_") {
+			name = "custom_" + name
+		}
+		if json.Valid([]byte(value)) {
+			res[name] = json.RawMessage(value)
+		} else {
+			quoted, _ := json.Marshal(value)
+			res[name] = quoted
+		}
+	}
+	return res, nil
+}
+
+// quoteTextFieldValues turns --field values of string, text, URL and date
+// result fields back into JSON strings, so custom_build=42 stays "42" when
+// custom_build is a string field. Unknown fields are left to validation.
+func quoteTextFieldValues(ctx context.Context, cli client.ClientInterface, values data.CustomFieldValues) error {
+	if len(values) == 0 {
+		return nil
+	}
+	fields, err := cli.GetResultFields(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get result fields: %w", err)
+	}
+	for _, f := range fields {
+		raw, ok := values[f.SystemName]
+		if !ok || (len(raw) > 0 && raw[0] == '"') || string(raw) == "null" {
+			continue
+		}
+		switch f.TypeID {
+		case data.CaseFieldTypeString, data.CaseFieldTypeText, data.CaseFieldTypeURL, data.CaseFieldTypeDate:
+			quoted, _ := json.Marshal(string(raw))
+			values[f.SystemName] = quoted
+		}
+	}
+	return nil
+}
+
 // Backward compatibility: exported vars for registration in result.go
 var (
 	addCmd     = newAddCmd(getClientSafe)
@@ -174,3 +174,72 @@ func TestAddBulkCmd_APIError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "not found")
 }
+
+func TestAddBulkCmd_CustomFieldsAndAttachments(t *testing.T) {
+	tmpDir := t.TempDir()
+	shot, runLog := filepath.Join(tmpDir, "shot.png"), filepath.Join(tmpDir, "run.log")
+	for _, f := range []string{shot, runLog} {
+		if err := os.WriteFile(f, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resultsFile := filepath.Join(tmpDir, "results.json")
+	jsonContent := fmt.Sprintf(`[
+		{"case_id": 201, "status_id": 5, "custom_env": "staging", "attachments": [%q, %q]},
+		{"case_id": 202, "status_id": 1}
+	]`, shot, runLog)
+	if err := os.WriteFile(resultsFile, []byte(jsonContent), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	attached := map[int64][]string{}
+	mock := &client.MockClient{
+		GetResultFieldsFunc: func(ctx context.Context) (data.GetResultFieldsResponse, error) {
+			return data.GetResultFieldsResponse{{SystemName: "custom_env", TypeID: data.CaseFieldTypeString, IsActive: true}}, nil
+		},
+		AddResultsForCasesFunc: func(ctx context.Context, runID int64, req *data.AddResultsForCasesRequest) (data.GetResultsResponse, error) {
+			assert.Equal(t, "staging", req.Results[0].CustomFields.String("custom_env"))
+			return []data.Result{{ID: 1}, {ID: 2}}, nil
+		},
+		AddAttachmentToResultFunc: func(ctx context.Context, resultID int64, filePath string) (*data.AttachmentResponse, error) {
+			attached[resultID] = append(attached[resultID], filePath)
+			if filePath == runLog {
+				return nil, fmt.Errorf("upload failed")
+			}
+			return &data.AttachmentResponse{}, nil
+		},
+	}
+
+	cmd := newAddBulkCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"12345", "--results-file", resultsFile})
+
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "1 attachments failed to upload")
+	assert.Equal(t, map[int64][]string{1: {shot, runLog}}, attached)
+}
+
+func TestAddBulkCmd_MissingAttachmentPostsNothing(t *testing.T) {
+	tmpDir := t.TempDir()
+	resultsFile := filepath.Join(tmpDir, "results.json")
+	jsonContent := `[{"case_id": 201, "status_id": 5, "attachments": ["no-such-shot.png"]}]`
+	if err := os.WriteFile(resultsFile, []byte(jsonContent), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	posted := false
+	mock := &client.MockClient{
+		AddResultsForCasesFunc: func(ctx context.Context, runID int64, req *data.AddResultsForCasesRequest) (data.GetResultsResponse, error) {
+			posted = true
+			return []data.Result{{ID: 1}}, nil
+		},
+	}
+
+	cmd := newAddBulkCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"12345", "--results-file", resultsFile})
+
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "no-such-shot.png")
+	assert.False(t, posted, "results are not posted when an attachment is missing")
+}
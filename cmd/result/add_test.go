@@ -57,6 +57,76 @@ func TestAddCmd_WithDefects(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestAddCmd_WithCustomFields(t *testing.T) {
+	mock := &client.MockClient{
+		GetResultFieldsFunc: func(ctx context.Context) (data.GetResultFieldsResponse, error) {
+			return data.GetResultFieldsResponse{
+				{SystemName: "custom_env", TypeID: data.CaseFieldTypeString, IsActive: true},
+				{SystemName: "custom_build", TypeID: data.CaseFieldTypeInteger, IsActive: true},
+			}, nil
+		},
+		AddResultFunc: func(ctx context.Context, testID int64, req *data.AddResultRequest) (*data.Result, error) {
+			assert.Equal(t, "staging", req.CustomFields.String("custom_env"))
+			assert.Equal(t, "42", req.CustomFields.String("custom_build"))
+			return &data.Result{ID: 1}, nil
+		},
+	}
+
+	cmd := newAddCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"12345", "--status-id", "1", "--field", "custom_env=staging", "--field", "build=42"})
+
+	require.NoError(t, cmd.Execute())
+}
+
+func TestAddCaseCmd_StringFieldKeepsNumericText(t *testing.T) {
+	mock := &client.MockClient{
+		GetResultFieldsFunc: func(ctx context.Context) (data.GetResultFieldsResponse, error) {
+			return data.GetResultFieldsResponse{
+				{SystemName: "custom_build", TypeID: data.CaseFieldTypeString, IsActive: true},
+				{SystemName: "custom_flag", TypeID: data.CaseFieldTypeCheckbox, IsActive: true},
+			}, nil
+		},
+		AddResultForCaseFunc: func(ctx context.Context, runID, caseID int64, req *data.AddResultRequest) (*data.Result, error) {
+			assert.JSONEq(t, `"42"`, string(req.CustomFields["custom_build"]))
+			assert.JSONEq(t, `true`, string(req.CustomFields["custom_flag"]))
+			return &data.Result{ID: 1}, nil
+		},
+	}
+
+	cmd := newAddCaseCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"100", "--case-id", "7", "--status-id", "1", "--field", "custom_build=42", "--field", "custom_flag=true"})
+
+	require.NoError(t, cmd.Execute())
+}
+
+func TestAddCmd_InvalidCustomField(t *testing.T) {
+	mock := &client.MockClient{
+		GetResultFieldsFunc: func(ctx context.Context) (data.GetResultFieldsResponse, error) {
+			return data.GetResultFieldsResponse{{SystemName: "custom_build", TypeID: data.CaseFieldTypeInteger, IsActive: true}}, nil
+		},
+	}
+
+	cmd := newAddCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"12345", "--status-id", "1", "--field", "custom_build=abc"})
+
+	assert.ErrorContains(t, cmd.Execute(), "custom_build: invalid value")
+}
+
+func TestParseCustomFields(t *testing.T) {
+	fields, err := parseCustomFields([]string{"env=staging", "custom_ids=[1,2]", "custom_flag=true", `custom_build="42"`})
+	require.NoError(t, err)
+	assert.JSONEq(t, `"staging"`, string(fields["custom_env"]))
+	assert.JSONEq(t, `"42"`, string(fields["custom_build"]))
+	assert.JSONEq(t, `[1,2]`, string(fields["custom_ids"]))
+	assert.JSONEq(t, `true`, string(fields["custom_flag"]))
+
+	_, err = parseCustomFields([]string{"novalue"})
+	assert.Error(t, err)
+}
+
 func TestAddCmd_DryRun(t *testing.T) {
 	mock := &client.MockClient{}
 
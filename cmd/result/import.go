@@ -0,0 +1,200 @@
+package result
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/Korrnals/gotr/internal/output"
+	"github.com/Korrnals/gotr/internal/service/importer"
+	"github.com/Korrnals/gotr/internal/ui"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// newImportCmd creates the 'result import' command group.
+func newImportCmd(getClient func(*cobra.Command) client.ClientInterface) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import test reports as results",
+		Long: `Imports results from test reports produced by CI tools.
+
+Subcommands:
+	junit    — JUnit/xUnit XML reports
+	cucumber — Cucumber JSON reports (one result per scenario)`,
+	}
+	cmd.AddCommand(newImportJUnitCmd(getClient))
+	cmd.AddCommand(newImportCucumberCmd(getClient))
+	return cmd
+}
+
+// newImportJUnitCmd creates the 'result import junit' command.
+// Endpoint: POST /add_results_for_cases/{run_id}
+func newImportJUnitCmd(getClient func(*cobra.Command) client.ClientInterface) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "junit <file...>",
+		Short: "Import JUnit XML reports into a run",
+		Long: `Parses JUnit/xUnit XML reports and adds a result for every test case
+that can be matched to a TestRail case.
+
+A test is matched to a case by (first match wins):
+	1. the case property (--case-property, default "test_id") — "C1234" or "1234"
+	2. the case pattern (--case-pattern) in the test name
+	3. the case pattern in the class name
+The default pattern finds a "C1234" token; the first capture group is the case ID.
+
+Outcomes map to statuses: passed=1, failed=5, skipped=4, error=5
+(override with --status, e.g. --status skipped=2).
+Failure messages and stack traces go into the comment, the test time into elapsed.
+Results are sent through add_results_for_cases in chunks of --chunk-size.
+
+Examples:
+	# Preview the case matches
+	gotr result import junit report.xml --run-id 123 --dry-run
+
+	# Import several reports
+	gotr result import junit build/test-results/*.xml --run-id 123
+
+	# Case IDs in a custom format: "[TR-1234] test name"
+	gotr result import junit report.xml --run-id 123 --case-pattern '\[TR-(\d+)\]'`,
+	}
+	setupImportReportCmd(cmd, importer.FormatJUnit, getClient)
+	return cmd
+}
+
+// newImportCucumberCmd creates the 'result import cucumber' command.
+// Endpoint: POST /add_results_for_cases/{run_id}
+func newImportCucumberCmd(getClient func(*cobra.Command) client.ClientInterface) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cucumber <file...>",
+		Short: "Import Cucumber JSON reports into a run",
+		Long: `Parses Cucumber JSON reports (--format json) and adds a result for every
+scenario that can be matched to a TestRail case.
+
+A scenario is matched to a case by (first match wins):
+	1. the case pattern (--case-pattern) in the scenario tags, e.g. @C1234
+	2. the case pattern in the feature tags (as written by 'gotr bdds export')
+	3. the case pattern in the scenario name, then in the feature name
+Background steps count towards every scenario of the feature.
+
+A failed step or hook fails the scenario, an ambiguous step is an error,
+pending and undefined steps skip it. Outcomes map to statuses: passed=1,
+failed=5, skipped=4, error=5 (override with --status).
+The failing step and its error message go into the comment, the sum of step
+durations into elapsed.
+
+Examples:
+	# Preview the case matches
+	gotr result import cucumber cucumber.json --run-id 123 --dry-run
+
+	# Import the report
+	gotr result import cucumber cucumber.json --run-id 123`,
+	}
+	setupImportReportCmd(cmd, importer.FormatCucumber, getClient)
+	return cmd
+}
+
+// setupImportReportCmd adds the import flags and the RunE that parses
+// reports of the given format and uploads the matched results.
+func setupImportReportCmd(cmd *cobra.Command, format string, getClient func(*cobra.Command) client.ClientInterface) {
+	cmd.Args = cobra.MinimumNArgs(1)
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		runID, _ := cmd.Flags().GetInt64("run-id")
+		if runID <= 0 {
+			return fmt.Errorf("--run-id is required")
+		}
+		pattern, _ := cmd.Flags().GetString("case-pattern")
+		property, _ := cmd.Flags().GetString("case-property")
+		overrides, _ := cmd.Flags().GetStringToInt64("status")
+		chunkSize, _ := cmd.Flags().GetInt("chunk-size")
+		isDryRun, _ := cmd.Flags().GetBool("dry-run")
+		quiet, _ := cmd.Flags().GetBool("quiet")
+
+		matcher, err := importer.NewMatcher(pattern, property)
+		if err != nil {
+			return err
+		}
+		statuses, err := importer.ParseStatuses(overrides)
+		if err != nil {
+			return err
+		}
+
+		tests, err := importer.ParseReports(format, args)
+		if err != nil {
+			return err
+		}
+
+		entries, matches := importer.BuildResults(tests, matcher, statuses)
+		unmatched := importer.Unmatched(matches)
+
+		if isDryRun {
+			printImportMatches(cmd, matches)
+			fmt.Fprintf(cmd.OutOrStdout(), "Tests: %d, matched: %d, unmatched: %d, cases: %d\n", len(matches), len(matches)-unmatched, unmatched, len(entries))
+			return nil
+		}
+
+		if len(entries) == 0 {
+			return fmt.Errorf("no tests matched a case (%d tests read)", len(tests))
+		}
+
+		cli := getClient(cmd)
+		if cli == nil {
+			return fmt.Errorf("HTTP client not initialized")
+		}
+
+		created, err := ui.RunWithStatus(cmd.Context(), ui.StatusConfig{
+			Title:  fmt.Sprintf("Uploading %d results to run %d", len(entries), runID),
+			Writer: os.Stderr,
+			Quiet:  quiet,
+		}, func(ctx context.Context) (data.GetResultsResponse, error) {
+			return importer.Upload(ctx, cli, runID, entries, chunkSize)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to import results (%d uploaded): %w", len(created), err)
+		}
+
+		output.PrintSuccess(cmd, "Imported %d results into run %d", len(created), runID)
+		if unmatched > 0 && !quiet {
+			ui.Warningf(os.Stderr, "%d tests without a case ID were skipped:", unmatched)
+			for _, m := range matches {
+				if m.CaseID == 0 {
+					fmt.Fprintf(os.Stderr, "  - %s\n", testLabel(m.Test))
+				}
+			}
+		}
+		return nil
+	}
+
+	cmd.Flags().Int64("run-id", 0, "Run to add the results to (required)")
+	cmd.Flags().String("case-pattern", importer.DefaultCasePattern, "Regex that finds the case ID in a test name (first capture group)")
+	cmd.Flags().String("case-property", importer.DefaultCaseProperty, "Test property that holds the case ID")
+	cmd.Flags().StringToInt64("status", nil, "Outcome to status ID overrides (e.g. skipped=2,error=4)")
+	cmd.Flags().Int("chunk-size", importer.DefaultChunkSize, "Results per add_results_for_cases request")
+	cmd.Flags().Bool("dry-run", false, "Show the case matches without uploading")
+	_ = cmd.MarkFlagRequired("run-id")
+}
+
+// printImportMatches prints how report tests were matched to cases.
+func printImportMatches(cmd *cobra.Command, matches []importer.Match) {
+	t := ui.NewTable(cmd)
+	t.AppendHeader(table.Row{"TEST", "CASE", "OUTCOME", "STATUS", "ELAPSED"})
+	for _, m := range matches {
+		caseID := "—"
+		if m.CaseID != 0 {
+			caseID = fmt.Sprintf("C%d", m.CaseID)
+		}
+		t.AppendRow(table.Row{testLabel(m.Test), caseID, m.Test.Outcome, m.StatusID, importer.FormatElapsed(m.Test.Duration)})
+	}
+	ui.Table(cmd, t)
+}
+
+func testLabel(t importer.TestResult) string {
+	if t.ClassName != "" {
+		return t.ClassName + "." + t.Name
+	}
+	return t.Name
+}
+
+var importCmd = newImportCmd(getClientSafe)
@@ -0,0 +1,130 @@
+package result
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Korrnals/gotr/cmd/internal/testhelper"
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const importJUnitReport = `<testsuite name="smoke">
+  <testcase name="C11 passes" time="2"/>
+  <testcase name="C12 fails"><failure message="boom">trace</failure></testcase>
+  <testcase name="unmatched"/>
+</testsuite>`
+
+func writeJUnitReport(t *testing.T) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "report.xml")
+	require.NoError(t, os.WriteFile(file, []byte(importJUnitReport), 0o644))
+	return file
+}
+
+func TestImportJUnitCmd_Success(t *testing.T) {
+	var got []data.ResultForCaseEntry
+	mock := &client.MockClient{
+		AddResultsForCasesFunc: func(ctx context.Context, runID int64, req *data.AddResultsForCasesRequest) (data.GetResultsResponse, error) {
+			assert.Equal(t, int64(42), runID)
+			got = append(got, req.Results...)
+			return make(data.GetResultsResponse, len(req.Results)), nil
+		},
+	}
+
+	cmd := newImportJUnitCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{writeJUnitReport(t), "--run-id", "42", "--chunk-size", "1"})
+
+	require.NoError(t, cmd.Execute())
+	require.Len(t, got, 2)
+	assert.Equal(t, int64(11), got[0].CaseID)
+	assert.Equal(t, int64(1), got[0].StatusID)
+	assert.Equal(t, "2s", got[0].Elapsed)
+	assert.Equal(t, int64(12), got[1].CaseID)
+	assert.Equal(t, int64(5), got[1].StatusID)
+	assert.Equal(t, "boom\n\ntrace", got[1].Comment)
+}
+
+func TestImportJUnitCmd_DryRun(t *testing.T) {
+	mock := &client.MockClient{
+		AddResultsForCasesFunc: func(ctx context.Context, runID int64, req *data.AddResultsForCasesRequest) (data.GetResultsResponse, error) {
+			t.Fatal("dry-run must not upload")
+			return nil, nil
+		},
+	}
+
+	cmd := newImportJUnitCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{writeJUnitReport(t), "--run-id", "42", "--dry-run"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "C11")
+	assert.Contains(t, out.String(), "unmatched")
+	assert.Contains(t, out.String(), "matched: 2, unmatched: 1")
+}
+
+func TestImportJUnitCmd_DryRun_SeveralTestsOfOneCase(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "report.xml")
+	require.NoError(t, os.WriteFile(file, []byte(`<testsuite name="smoke">
+  <testcase name="C11 first"/>
+  <testcase name="C11 second"/>
+  <testcase name="C11 third"><failure message="boom"/></testcase>
+</testsuite>`), 0o644))
+
+	cmd := newImportJUnitCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, &client.MockClient{}).Context())
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{file, "--run-id", "42", "--dry-run"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "Tests: 3, matched: 3, unmatched: 0, cases: 1")
+}
+
+func TestImportJUnitCmd_Errors(t *testing.T) {
+	cmd := newImportJUnitCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, &client.MockClient{}).Context())
+	cmd.SetArgs([]string{writeJUnitReport(t), "--run-id", "42", "--case-pattern", "C\\d+"})
+	assert.ErrorContains(t, cmd.Execute(), "capture group")
+
+	cmd = newImportJUnitCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, &client.MockClient{}).Context())
+	cmd.SetArgs([]string{writeJUnitReport(t), "--run-id", "42", "--case-property", "x", "--case-pattern", "ID-(\\d+)"})
+	assert.ErrorContains(t, cmd.Execute(), "no tests matched")
+}
+
+func TestImportCucumberCmd_Success(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "cucumber.json")
+	require.NoError(t, os.WriteFile(file, []byte(`[{"name": "Login", "tags": [{"name": "@C20"}], "elements": [
+  {"type": "scenario", "name": "ok", "steps": [{"keyword": "When ", "name": "x", "result": {"status": "passed", "duration": 3000000000}}]},
+  {"type": "scenario", "name": "bad", "tags": [{"name": "@C21"}], "steps": [{"keyword": "When ", "name": "y", "result": {"status": "failed", "error_message": "boom"}}]}
+]}]`), 0o644))
+
+	var got []data.ResultForCaseEntry
+	mock := &client.MockClient{
+		AddResultsForCasesFunc: func(ctx context.Context, runID int64, req *data.AddResultsForCasesRequest) (data.GetResultsResponse, error) {
+			got = append(got, req.Results...)
+			return make(data.GetResultsResponse, len(req.Results)), nil
+		},
+	}
+
+	cmd := newImportCucumberCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{file, "--run-id", "42"})
+
+	require.NoError(t, cmd.Execute())
+	require.Len(t, got, 2)
+	assert.Equal(t, int64(20), got[0].CaseID)
+	assert.Equal(t, "3s", got[0].Elapsed)
+	assert.Equal(t, int64(21), got[1].CaseID)
+	assert.Equal(t, int64(5), got[1].StatusID)
+	assert.Equal(t, "When y: failed\n\nboom", got[1].Comment)
+}
@@ -17,7 +17,7 @@ import (
 // newListCmd creates the 'result list' command.
 // Endpoint: GET /get_results_for_run/{run_id}
 func newListCmd(getClient func(*cobra.Command) client.ClientInterface) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "list [run-id]",
 		Short: "Get results for a test run",
 		Long: `Gets the list of results for the specified test run.
@@ -26,6 +26,11 @@ If run-id is not specified, an interactive selection will be offered:
 1. Select a project from the list
 2. Select a test run from the project
 
+Server-side filters narrow the results before they are downloaded:
+--created-after/--created-before (YYYY-MM-DD, RFC 3339, Unix time or an
+age like 7d), --created-by, --status-id (comma-separated ID lists) and
+--defects.
+
 Examples:
 	# Get results with interactive run selection
 	gotr result list
@@ -35,6 +40,9 @@ Examples:
 
 	# Save to file
 	gotr result list 12345 -o results.json
+
+	# Failed and blocked results of the last day
+	gotr result list 12345 --status-id 2,5 --created-after 24h
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cli := getClient(cmd)
@@ -45,8 +53,12 @@ Examples:
 
 			svc := newResultServiceFromInterface(cli)
 
+			filter, err := flags.ResultFilterFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
 			var runID int64
-			var err error
 
 			if len(args) > 0 {
 				// Explicit run-id provided
@@ -85,7 +97,7 @@ Examples:
 				Writer: os.Stderr,
 				Quiet:  quiet,
 			}, func(ctx context.Context) (data.GetResultsResponse, error) {
-				return svc.GetForRun(ctx, runID)
+				return svc.GetForRunFiltered(ctx, runID, filter)
 			})
 			if err != nil {
 				return fmt.Errorf("failed to get results: %w", err)
@@ -94,6 +106,10 @@ Examples:
 			return output.OutputResultWithFlags(cmd, results)
 		},
 	}
+
+	flags.AddResultFilterFlags(cmd)
+
+	return cmd
 }
 
 // Backward compatibility: exported var for registration in result.go
@@ -139,3 +139,23 @@ func TestListCmd_NegativeRunID(t *testing.T) {
 	err := cmd.Execute()
 	assert.Error(t, err)
 }
+
+func TestListCmd_Direct_ServerSideFilters(t *testing.T) {
+	mock := &client.MockClient{
+		GetResultsForRunFilteredFunc: func(ctx context.Context, runID int64, filter data.ResultFilter) (data.GetResultsResponse, error) {
+			assert.Equal(t, int64(12345), runID)
+			assert.Equal(t, map[string]string{
+				"status_id":      "2,5",
+				"defects_filter": "BUG-1",
+			}, filter.Query())
+			return data.GetResultsResponse{{ID: 2, TestID: 101, StatusID: 5}}, nil
+		},
+	}
+
+	cmd := newListCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"12345", "--status-id", "2,5", "--defects", "BUG-1"})
+
+	err := cmd.Execute()
+	assert.NoError(t, err)
+}
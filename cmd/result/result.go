@@ -24,6 +24,7 @@ Subcommands:
 	add        — add a result for a test
 	add-case   — add a result for a case in a run
 	add-bulk   — bulk add results
+	import     — import CI test reports (JUnit XML, Cucumber JSON)
 
 Examples:
 	# Get results with interactive run selection
@@ -40,6 +41,9 @@ Examples:
 
 	# Add a failed result with a defect
 	gotr result add 12345 --status-id 5 --comment "Found bug" --defects "BUG-123"
+
+	# Import a JUnit report
+	gotr result import junit report.xml --run-id 12345
 `,
 }
 
@@ -75,6 +79,7 @@ func Register(rootCmd *cobra.Command, clientFn GetClientFunc) {
 	Cmd.AddCommand(addCaseCmd)
 	Cmd.AddCommand(addBulkCmd)
 	Cmd.AddCommand(fieldsCmd)
+	Cmd.AddCommand(importCmd)
 
 	// Shared flags for all subcommands
 	for _, subCmd := range Cmd.Commands() {
@@ -20,6 +20,7 @@ type ResultServiceInterface interface {
 	GetForTest(ctx context.Context, testID int64) (data.GetResultsResponse, error)
 	GetForCase(ctx context.Context, runID, caseID int64) (data.GetResultsResponse, error)
 	GetForRun(ctx context.Context, runID int64) (data.GetResultsResponse, error)
+	GetForRunFiltered(ctx context.Context, runID int64, filter data.ResultFilter) (data.GetResultsResponse, error)
 	GetRunsForProject(ctx context.Context, projectID int64) (data.GetRunsResponse, error)
 	// AddBulkResults parses JSON and submits results (bulk operation).
 	AddBulkResults(ctx context.Context, runID int64, fileData []byte) (interface{}, error)
@@ -73,28 +74,75 @@ func (w *resultServiceWrapper) GetForRun(ctx context.Context, runID int64) (data
 	return w.svc.GetForRun(ctx, runID)
 }
 
+// GetForRunFiltered delegates filtered run result retrieval to the underlying result service.
+func (w *resultServiceWrapper) GetForRunFiltered(ctx context.Context, runID int64, filter data.ResultFilter) (data.GetResultsResponse, error) {
+	return w.svc.GetForRunFiltered(ctx, runID, filter)
+}
+
 // GetRunsForProject delegates run listing to the underlying result service.
 func (w *resultServiceWrapper) GetRunsForProject(ctx context.Context, projectID int64) (data.GetRunsResponse, error) {
 	return w.svc.GetRunsForProject(ctx, projectID)
 }
 
-// AddBulkResults parses JSON and submits results (bulk operation).
+// AddBulkResults parses JSON and submits results (bulk operation). Entries
+// with case_id go to add_results_for_cases, the others to add_results.
+// Files listed in "attachments" must all exist before anything is posted;
+// they are then uploaded to the created results, and failed uploads are
+// reported as *service.AttachmentsError along with the results.
 func (w *resultServiceWrapper) AddBulkResults(ctx context.Context, runID int64, fileData []byte) (interface{}, error) {
+	// Try parsing as an array with case_id
+	var caseResults []data.ResultForCaseEntry
+	if err := json.Unmarshal(fileData, &caseResults); err == nil && len(caseResults) > 0 && allHaveCaseID(caseResults) {
+		files := make([][]string, len(caseResults))
+		for i, r := range caseResults {
+			files[i] = r.Attachments
+		}
+		if err := service.CheckAttachmentFiles(files); err != nil {
+			return nil, err
+		}
+		req := &data.AddResultsForCasesRequest{Results: caseResults}
+		results, err := w.svc.AddResultsForCases(ctx, runID, req)
+		if err != nil {
+			return nil, err
+		}
+		return w.attach(ctx, results, files)
+	}
+
 	// Try parsing as an array with test_id
 	var testResults []data.ResultEntry
 	if err := json.Unmarshal(fileData, &testResults); err == nil && len(testResults) > 0 {
+		files := make([][]string, len(testResults))
+		for i, r := range testResults {
+			files[i] = r.Attachments
+		}
+		if err := service.CheckAttachmentFiles(files); err != nil {
+			return nil, err
+		}
 		req := &data.AddResultsRequest{Results: testResults}
-		return w.svc.AddResults(ctx, runID, req)
+		results, err := w.svc.AddResults(ctx, runID, req)
+		if err != nil {
+			return nil, err
+		}
+		return w.attach(ctx, results, files)
 	}
 
-	// Try parsing as an array with case_id
-	var caseResults []data.ResultForCaseEntry
-	if err := json.Unmarshal(fileData, &caseResults); err == nil && len(caseResults) > 0 {
-		req := &data.AddResultsForCasesRequest{Results: caseResults}
-		return w.svc.AddResultsForCases(ctx, runID, req)
+	return nil, fmt.Errorf("failed to parse JSON file: expected array with test_id or case_id")
+}
+
+func (w *resultServiceWrapper) attach(ctx context.Context, results data.GetResultsResponse, files [][]string) (interface{}, error) {
+	if _, err := w.svc.AttachFiles(ctx, results, files); err != nil {
+		return results, err
 	}
+	return results, nil
+}
 
-	return nil, fmt.Errorf("failed to parse JSON file: expected array with test_id or case_id")
+func allHaveCaseID(entries []data.ResultForCaseEntry) bool {
+	for _, e := range entries {
+		if e.CaseID <= 0 {
+			return false
+		}
+	}
+	return true
 }
 
 // newResultServiceFromInterface creates a result service from a client interface.
@@ -11,6 +11,7 @@ import (
 	"github.com/Korrnals/gotr/internal/debug"
 	"github.com/Korrnals/gotr/internal/interactive"
 	"github.com/Korrnals/gotr/internal/models/config"
+	"github.com/Korrnals/gotr/internal/snapshot"
 	"github.com/Korrnals/gotr/internal/ui"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -42,6 +43,26 @@ Supports browsing available endpoints, executing requests, and more.`,
 		// Set up Viper: env vars, flags, config files
 		viper.AutomaticEnv()
 
+		// Offline mode: answer reads from a snapshot, no server or credentials needed
+		if path := viper.GetString("snapshot"); path != "" {
+			snap, err := snapshot.Open(path)
+			if err != nil {
+				return err
+			}
+			manifest := snap.Snapshot().Manifest
+			debug.DebugPrint("{rootCmd} - Using snapshot %s (format %d, created %s)", path, manifest.FormatVersion, manifest.CreatedAt)
+			ui.Infof(os.Stderr, "Offline: reading from snapshot %s taken %s", path, manifest.CreatedAt.Local().Format("2006-01-02 15:04"))
+
+			ctx := context.WithValue(cmd.Context(), httpClientKey, client.ClientInterface(snap))
+			cmd.SetContext(withPrompter(ctx, cmd))
+			return nil
+		}
+
+		// Overlay the selected connection profile (if any) on top of file settings
+		if err := applyActiveProfile(); err != nil {
+			return err
+		}
+
 		// Read connection settings from Viper (config/env/flags)
 		baseURL := viper.GetString("base_url")
 		username := viper.GetString("username")
@@ -71,9 +92,9 @@ Supports browsing available endpoints, executing requests, and more.`,
 		debug.DebugPrint("{rootCmd} - Connecting to %s as %s", baseURL, username)
 
 		// Create the HTTP client with options
-		opts := []client.ClientOption{}
-		if insecure {
-			opts = append(opts, client.WithSkipTlsVerify(true)) // TLS verification is enabled by default
+		opts, err := resolveClientOptions(baseURL)
+		if err != nil {
+			return err
 		}
 
 		httpClient, err := client.NewClient(baseURL, username, apiKey, debugMode, opts...)
@@ -81,28 +102,34 @@ Supports browsing available endpoints, executing requests, and more.`,
 			return fmt.Errorf("failed to create client: %w", err)
 		}
 
+		cli, err := withCache(httpClient, baseURL, username)
+		if err != nil {
+			return err
+		}
+
 		debug.DebugPrint("{rootCmd} - Client created and stored in context")
 
 		// Store the client in context so it is available in all subcommands
-		ctx := context.WithValue(cmd.Context(), httpClientKey, httpClient)
-
-		// Inject Prompter into context (TerminalPrompter or NonInteractivePrompter)
-		nonInteractive, _ := cmd.Flags().GetBool("non-interactive")
-		var p interactive.Prompter
-		if nonInteractive {
-			p = interactive.NewNonInteractivePrompter()
-		} else {
-			p = interactive.NewTerminalPrompter()
-		}
-		ctx = interactive.WithPrompter(ctx, p)
-
-		cmd.SetContext(ctx)
+		ctx := context.WithValue(cmd.Context(), httpClientKey, cli)
+		cmd.SetContext(withPrompter(ctx, cmd))
 
 		return nil
 	},
 	// Run is intentionally omitted — cobra shows help by default.
 }
 
+// withPrompter injects the Prompter into ctx (TerminalPrompter or NonInteractivePrompter).
+func withPrompter(ctx context.Context, cmd *cobra.Command) context.Context {
+	nonInteractive, _ := cmd.Flags().GetBool("non-interactive")
+	var p interactive.Prompter
+	if nonInteractive {
+		p = interactive.NewNonInteractivePrompter()
+	} else {
+		p = interactive.NewTerminalPrompter()
+	}
+	return interactive.WithPrompter(ctx, p)
+}
+
 // Execute is called from main.go with a cancelable context (supports signal.NotifyContext).
 func Execute(ctx context.Context) {
 	rootCmd.SilenceUsage = true  // do not print usage on error
@@ -27,6 +27,14 @@ A test run is created based on a test suite. You can specify:
 - specific case_ids (if not all suite cases are needed)
 - config_ids for configuration testing
 
+With --from-report the run is built from CI test reports (JUnit XML): an open
+run with the same name, suite and milestone is reused (or created with the
+cases found in the reports), missing cases are added to it, the results are
+posted and, with --close, the run is closed. Results a reused run already has
+(same status and comment as the latest result of the case) are not posted
+again, so re-running the same job is safe.
+Cases are matched to tests as in 'gotr result import junit'.
+
 Examples:
 	# Create a run with minimal parameters
 	gotr run create 30 --suite-id 20069 --name "Smoke Tests"
@@ -39,6 +47,10 @@ Examples:
 	gotr run create 30 --suite-id 20069 --name "Critical Path" \\
 		--case-ids 123,456,789
 
+	# CI: create or reuse the run, post the JUnit results, close the run
+	gotr run create 30 --suite-id 20069 --milestone-id 7 --name "Nightly $(date +%F)" \
+		--from-report build/junit.xml --close
+
 	# Dry-run mode
 	gotr run create 30 --suite-id 20069 --name "Test" --dry-run`,
 		Args: cobra.MaximumNArgs(1),
@@ -100,7 +112,11 @@ Examples:
 				AssignedTo:  assignedTo,
 				CaseIDs:     caseIDs,
 				ConfigIDs:   configIDs,
-				IncludeAll:  includeAll,
+				IncludeAll:  &includeAll,
+			}
+
+			if reportFiles, _ := cmd.Flags().GetStringSlice("from-report"); len(reportFiles) > 0 {
+				return createFromReport(cmd, cli, projectID, req, reportFiles)
 			}
 
 			// Check dry-run mode
@@ -142,6 +158,7 @@ Examples:
 	cmd.Flags().Int64Slice("config-ids", nil, "List of configuration IDs (comma-separated)")
 	cmd.Flags().Bool("include-all", true, "Include all suite cases")
 	cmd.Flags().Bool("dry-run", false, "Show what would be executed without making actual changes")
+	addReportFlags(cmd)
 	_ = cmd.MarkFlagRequired("name")
 
 	return cmd
@@ -0,0 +1,159 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/Korrnals/gotr/internal/output"
+	"github.com/Korrnals/gotr/internal/service"
+	"github.com/Korrnals/gotr/internal/service/importer"
+	"github.com/Korrnals/gotr/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// reportRunOutcome is what createFromReport did to the run.
+type reportRunOutcome struct {
+	Run     *data.Run
+	Created bool
+	Added   []int64 // cases added to a reused run
+	Results data.GetResultsResponse
+	Skipped int // results already posted to a reused run
+}
+
+// addReportFlags adds the flags of 'run create --from-report'.
+func addReportFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("from-report", nil, "Test report files; the run gets the cases found in them and their results")
+	cmd.Flags().String("report-format", importer.FormatJUnit, "Report format: junit or cucumber")
+	cmd.Flags().String("case-pattern", importer.DefaultCasePattern, "Regex that finds the case ID in a test name (first capture group)")
+	cmd.Flags().String("case-property", importer.DefaultCaseProperty, "Test property that holds the case ID")
+	cmd.Flags().StringToInt64("status", nil, "Outcome to status ID overrides (e.g. skipped=2,error=4)")
+	cmd.Flags().Int("chunk-size", importer.DefaultChunkSize, "Results per add_results_for_cases request")
+	cmd.Flags().Bool("close", false, "Close the run after posting the results")
+	cmd.MarkFlagsMutuallyExclusive("case-ids", "from-report")
+}
+
+// createFromReport finds the open run with the same name, suite and
+// milestone or creates it with the cases from the reports, adds cases
+// missing from a reused run, posts the results and optionally closes the
+// run. Results already posted to a reused run with the same status and
+// comment are skipped, so running it again for the same report is safe.
+func createFromReport(cmd *cobra.Command, cli client.ClientInterface, projectID int64, req *data.AddRunRequest, files []string) error {
+	format, _ := cmd.Flags().GetString("report-format")
+	pattern, _ := cmd.Flags().GetString("case-pattern")
+	property, _ := cmd.Flags().GetString("case-property")
+	overrides, _ := cmd.Flags().GetStringToInt64("status")
+	chunkSize, _ := cmd.Flags().GetInt("chunk-size")
+	closeRun, _ := cmd.Flags().GetBool("close")
+	isDryRun, _ := cmd.Flags().GetBool("dry-run")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+
+	matcher, err := importer.NewMatcher(pattern, property)
+	if err != nil {
+		return err
+	}
+	statuses, err := importer.ParseStatuses(overrides)
+	if err != nil {
+		return err
+	}
+	tests, err := importer.ParseReports(format, files)
+	if err != nil {
+		return err
+	}
+	entries, matches := importer.BuildResults(tests, matcher, statuses)
+	if len(entries) == 0 {
+		return fmt.Errorf("no tests in the report matched a case (%d tests read)", len(tests))
+	}
+
+	unmatched := importer.Unmatched(matches)
+	caseIDs := importer.CaseIDs(entries)
+	includeAll := false
+	req.IncludeAll = &includeAll
+	req.CaseIDs = caseIDs
+
+	if isDryRun {
+		dr := output.NewDryRunPrinter("run create")
+		dr.PrintOperation(
+			fmt.Sprintf("Reuse open run %q or create it in Project %d", req.Name, projectID),
+			"POST",
+			fmt.Sprintf("/index.php?/api/v2/add_run/%d", projectID),
+			req,
+		)
+		dr.PrintSimple(
+			fmt.Sprintf("Add %d results for %d cases", len(entries), len(caseIDs)),
+			fmt.Sprintf("add_results_for_cases in chunks of %d; %d tests without a case ID are skipped", chunkSize, unmatched),
+		)
+		if closeRun {
+			dr.PrintSimple("Close Run", "close_run after the results are posted")
+		}
+		return nil
+	}
+
+	runSvc := service.NewRunService(cli)
+	resultSvc := service.NewResultService(cli)
+	res, err := ui.RunWithStatus(cmd.Context(), ui.StatusConfig{
+		Title:  "Publishing report results",
+		Writer: os.Stderr,
+		Quiet:  quiet,
+	}, func(ctx context.Context) (reportRunOutcome, error) {
+		run, created, err := runSvc.FindOrCreate(ctx, projectID, req)
+		if err != nil {
+			return reportRunOutcome{}, err
+		}
+		res := reportRunOutcome{Run: run, Created: created}
+		pending := entries
+		if !created {
+			if res.Added, err = runSvc.AddCases(ctx, run, caseIDs); err != nil {
+				return res, fmt.Errorf("failed to add cases to run %d: %w", run.ID, err)
+			}
+			tests, err := cli.GetTests(ctx, run.ID, nil)
+			if err != nil {
+				return res, fmt.Errorf("failed to get tests of run %d: %w", run.ID, err)
+			}
+			posted, err := cli.GetResultsForRun(ctx, run.ID)
+			if err != nil {
+				return res, fmt.Errorf("failed to get results of run %d: %w", run.ID, err)
+			}
+			pending, res.Skipped = importer.Unposted(entries, tests, posted)
+		}
+
+		res.Results, err = importer.Upload(ctx, resultSvc, run.ID, pending, chunkSize)
+		if err != nil {
+			return res, fmt.Errorf("failed to post results to run %d (%d posted): %w", run.ID, len(res.Results), err)
+		}
+
+		if closeRun {
+			closed, err := runSvc.Close(ctx, run.ID)
+			if err != nil {
+				return res, fmt.Errorf("failed to close run %d: %w", run.ID, err)
+			}
+			res.Run = closed
+		}
+		return res, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if res.Created {
+		output.PrintSuccess(cmd, "Test run created (ID: %d)", res.Run.ID)
+	} else {
+		output.PrintSuccess(cmd, "Reusing open test run (ID: %d)", res.Run.ID)
+	}
+	if len(res.Added) > 0 && !quiet {
+		ui.Infof(os.Stdout, "Added %d cases to the run", len(res.Added))
+	}
+	output.PrintSuccess(cmd, "Posted %d results", len(res.Results))
+	if res.Skipped > 0 && !quiet {
+		ui.Infof(os.Stdout, "Skipped %d results already posted to the run", res.Skipped)
+	}
+	if closeRun {
+		output.PrintSuccess(cmd, "Test run closed")
+	}
+	if unmatched > 0 && !quiet {
+		ui.Warningf(os.Stderr, "%d tests without a case ID were skipped", unmatched)
+	}
+	return output.OutputResultWithFlags(cmd, res.Run)
+}
@@ -0,0 +1,126 @@
+package run
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Korrnals/gotr/cmd/internal/testhelper"
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeRunReport(t *testing.T) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "junit.xml")
+	report := `<testsuite name="ci">
+  <testcase name="C2 second"/>
+  <testcase name="C1 first"><failure message="boom"/></testcase>
+  <testcase name="no case"/>
+</testsuite>`
+	require.NoError(t, os.WriteFile(file, []byte(report), 0o644))
+	return file
+}
+
+func TestCreateCmd_FromReport_CreatesRun(t *testing.T) {
+	var posted []data.ResultForCaseEntry
+	closed := false
+	mock := &client.MockClient{
+		GetRunsFunc: func(ctx context.Context, projectID int64) (data.GetRunsResponse, error) {
+			return data.GetRunsResponse{{ID: 9, Name: "Nightly", SuiteID: 20069, IsCompleted: true}}, nil
+		},
+		AddRunFunc: func(ctx context.Context, projectID int64, req *data.AddRunRequest) (*data.Run, error) {
+			assert.NotNil(t, req.IncludeAll)
+			assert.False(t, *req.IncludeAll)
+			assert.Equal(t, []int64{1, 2}, req.CaseIDs)
+			return &data.Run{ID: 10, Name: req.Name}, nil
+		},
+		AddResultsForCasesFunc: func(ctx context.Context, runID int64, req *data.AddResultsForCasesRequest) (data.GetResultsResponse, error) {
+			assert.Equal(t, int64(10), runID)
+			posted = append(posted, req.Results...)
+			return make(data.GetResultsResponse, len(req.Results)), nil
+		},
+		CloseRunFunc: func(ctx context.Context, runID int64) (*data.Run, error) {
+			closed = true
+			return &data.Run{ID: runID, IsCompleted: true}, nil
+		},
+	}
+
+	cmd := newCreateCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"30", "--suite-id", "20069", "--name", "Nightly", "--from-report", writeRunReport(t), "--close"})
+
+	require.NoError(t, cmd.Execute())
+	require.Len(t, posted, 2)
+	assert.Equal(t, int64(5), posted[1].StatusID)
+	assert.True(t, closed)
+}
+
+func TestCreateCmd_FromReport_ReusesOpenRun(t *testing.T) {
+	var update *data.UpdateRunRequest
+	var posted []data.ResultForCaseEntry
+	mock := &client.MockClient{
+		GetRunsFilteredFunc: func(ctx context.Context, projectID int64, filter data.RunFilter) (data.GetRunsResponse, error) {
+			assert.Equal(t, map[string]string{"is_completed": "0", "suite_id": "20069"}, filter.Query())
+			return data.GetRunsResponse{
+				{ID: 8, Name: "Nightly", SuiteID: 20069, MilestoneID: 3},
+				{ID: 9, Name: "Nightly", SuiteID: 20069},
+			}, nil
+		},
+		AddRunFunc: func(ctx context.Context, projectID int64, req *data.AddRunRequest) (*data.Run, error) {
+			t.Fatal("open run must be reused")
+			return nil, nil
+		},
+		GetTestsFunc: func(ctx context.Context, runID int64, filters map[string]string) ([]data.Test, error) {
+			assert.Equal(t, int64(9), runID)
+			return []data.Test{{ID: 100, CaseID: 2}, {ID: 101, CaseID: 5}}, nil
+		},
+		UpdateRunFunc: func(ctx context.Context, runID int64, req *data.UpdateRunRequest) (*data.Run, error) {
+			update = req
+			return &data.Run{ID: runID}, nil
+		},
+		GetResultsForRunFunc: func(ctx context.Context, runID int64) (data.GetResultsResponse, error) {
+			return data.GetResultsResponse{{ID: 1, TestID: 100, StatusID: 1}}, nil
+		},
+		AddResultsForCasesFunc: func(ctx context.Context, runID int64, req *data.AddResultsForCasesRequest) (data.GetResultsResponse, error) {
+			assert.Equal(t, int64(9), runID)
+			posted = append(posted, req.Results...)
+			return make(data.GetResultsResponse, len(req.Results)), nil
+		},
+	}
+
+	cmd := newCreateCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"30", "--suite-id", "20069", "--name", "Nightly", "--from-report", writeRunReport(t)})
+
+	require.NoError(t, cmd.Execute())
+	require.NotNil(t, update)
+	require.NotNil(t, update.IncludeAll)
+	assert.False(t, *update.IncludeAll)
+	assert.Equal(t, []int64{1, 2, 5}, update.CaseIDs)
+	require.Len(t, posted, 1, "the passed result of C2 is already in the run")
+	assert.Equal(t, int64(1), posted[0].CaseID)
+}
+
+func TestCreateCmd_FromReport_DryRunAndConflicts(t *testing.T) {
+	mock := &client.MockClient{
+		GetRunsFunc: func(ctx context.Context, projectID int64) (data.GetRunsResponse, error) {
+			t.Fatal("dry-run must not call the API")
+			return nil, nil
+		},
+	}
+	report := writeRunReport(t)
+
+	cmd := newCreateCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"30", "--suite-id", "20069", "--name", "Nightly", "--from-report", report, "--dry-run"})
+	require.NoError(t, cmd.Execute())
+
+	cmd = newCreateCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"30", "--suite-id", "20069", "--name", "Nightly", "--from-report", report, "--case-ids", "1"})
+	assert.Error(t, cmd.Execute())
+}
@@ -171,7 +171,8 @@ func TestCreateCmd_WithIncludeAllFalse(t *testing.T) {
 	mock := &client.MockClient{
 		AddRunFunc: func(ctx context.Context, projectID int64, req *data.AddRunRequest) (*data.Run, error) {
 			assert.Equal(t, int64(30), projectID)
-			assert.False(t, req.IncludeAll)
+			assert.NotNil(t, req.IncludeAll)
+			assert.False(t, *req.IncludeAll)
 			return &data.Run{ID: 129}, nil
 		},
 	}
@@ -54,6 +54,11 @@ func (w *runServiceWrapper) GetByProject(ctx context.Context, projectID int64) (
 	return w.svc.GetByProject(ctx, projectID)
 }
 
+// GetByProjectFiltered delegates filtered project run listing to the underlying run service.
+func (w *runServiceWrapper) GetByProjectFiltered(ctx context.Context, projectID int64, filter data.RunFilter) (data.GetRunsResponse, error) {
+	return w.svc.GetByProjectFiltered(ctx, projectID, filter)
+}
+
 // newRunServiceFromInterface creates a service from a client interface.
 func newRunServiceFromInterface(cli client.ClientInterface) *runServiceWrapper {
 	return &runServiceWrapper{svc: service.NewRunService(cli)}
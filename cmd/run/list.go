@@ -3,7 +3,9 @@ package run
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
+	"sort"
 
 	"github.com/Korrnals/gotr/internal/client"
 	"github.com/Korrnals/gotr/internal/flags"
@@ -26,6 +28,11 @@ ID, name, description, test statistics (passed/failed/blocked).
 
 If project-id is not specified, an interactive selection from the project list will be offered.
 
+Server-side filters narrow the list before it is downloaded:
+--created-after/--created-before (YYYY-MM-DD, RFC 3339, Unix time or an
+age like 7d), --created-by, --is-completed, --milestone-id, --suite-id
+(comma-separated ID lists) and --refs.
+
 Examples:
 	# Get project runs list (with interactive selection)
 	gotr run list
@@ -36,6 +43,9 @@ Examples:
 	# Save to file for further processing
 	gotr run list 30 -o runs.json
 
+	# Active runs of milestone 5 created in the last two weeks
+	gotr run list 30 --is-completed=false --milestone-id 5 --created-after 14d
+
 	# Dry-run mode
 	gotr run list 30 --dry-run
 `,
@@ -65,6 +75,11 @@ Examples:
 				}
 			}
 
+			filter, err := flags.RunFilterFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
 			// Check dry-run mode
 			isDryRun, _ := cmd.Flags().GetBool("dry-run")
 			if isDryRun {
@@ -72,7 +87,7 @@ Examples:
 				dr.PrintOperation(
 					fmt.Sprintf("List Runs for Project %d", projectID),
 					"GET",
-					fmt.Sprintf("/index.php?/api/v2/get_runs/%d", projectID),
+					withQuery(fmt.Sprintf("/index.php?/api/v2/get_runs/%d", projectID), filter.Query()),
 					nil,
 				)
 				return nil
@@ -84,7 +99,7 @@ Examples:
 				Writer: os.Stderr,
 				Quiet:  quiet,
 			}, func(ctx context.Context) (data.GetRunsResponse, error) {
-				return svc.GetByProject(ctx, projectID)
+				return svc.GetByProjectFiltered(ctx, projectID, filter)
 			})
 			if err != nil {
 				return fmt.Errorf("failed to get test runs list: %w", err)
@@ -95,9 +110,23 @@ Examples:
 	}
 
 	cmd.Flags().Bool("dry-run", false, "Show what would be executed without making actual changes")
+	flags.AddRunFilterFlags(cmd)
 
 	return cmd
 }
 
+// withQuery appends filter parameters to a TestRail endpoint in key order.
+func withQuery(endpoint string, query map[string]string) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		endpoint += "&" + k + "=" + url.QueryEscape(query[k])
+	}
+	return endpoint
+}
+
 // listCmd is the exported command.
 var listCmd = newListCmd(getClientSafe)
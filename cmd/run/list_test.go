@@ -206,3 +206,29 @@ func TestListCmd_InvalidProjectIDFormat(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid project_id")
 }
+
+func TestListCmd_ServerSideFilters(t *testing.T) {
+	mock := &client.MockClient{
+		GetRunsFilteredFunc: func(ctx context.Context, projectID int64, filter data.RunFilter) (data.GetRunsResponse, error) {
+			assert.Equal(t, int64(30), projectID)
+			assert.Equal(t, map[string]string{
+				"is_completed": "0",
+				"milestone_id": "5",
+				"suite_id":     "1,2",
+			}, filter.Query())
+			return data.GetRunsResponse{{ID: 1, Name: "Run 1"}}, nil
+		},
+	}
+
+	cmd := newListCmd(testhelper.GetClientForTests)
+	cmd.SetContext(testhelper.SetupTestCmd(t, mock).Context())
+	cmd.SetArgs([]string{"30", "--is-completed=false", "--milestone-id", "5", "--suite-id", "1,2"})
+
+	err := cmd.Execute()
+	assert.NoError(t, err)
+}
+
+func TestWithQuery(t *testing.T) {
+	got := withQuery("/index.php?/api/v2/get_runs/30", map[string]string{"suite_id": "1,2", "is_completed": "0"})
+	assert.Equal(t, "/index.php?/api/v2/get_runs/30&is_completed=0&suite_id=1%2C2", got)
+}
@@ -30,6 +30,10 @@ Checks performed:
   - All unit tests (runs go test ./...)
   - Code coverage metrics
 
+With --online, the live server checks of 'gotr doctor' are added:
+DNS, TLS, API access, authentication, latency, user role, pagination
+and rate limits.
+
 Reports are saved to: ~/.testrail/selftest/
 
 Examples:
@@ -40,7 +44,10 @@ Examples:
   gotr self-test --json
 
   # Show only failed checks
-  gotr self-test --failures-only`,
+  gotr self-test --failures-only
+
+  # Include the live server checks
+  gotr self-test --online`,
 	RunE: runSelfTest,
 }
 
@@ -50,13 +57,19 @@ func init() {
 	selfTestCmd.Flags().Bool("json", false, "Output results as JSON")
 	selfTestCmd.Flags().Bool("failures-only", false, "Show only failed checks")
 	selfTestCmd.Flags().Bool("include-skipped", false, "Include skipped checks in output")
+	selfTestCmd.Flags().Bool("online", false, "Also check the live TestRail server (see 'gotr doctor')")
 }
 
 var buildSelfTestReport = func() *selftest.Report {
+	return runCheckers(selfTestCheckers())
+}
+
+// runCheckers runs checkers in order and fills the report meta information.
+func runCheckers(checkers []selftest.Checker) *selftest.Report {
 	runner := selftest.NewRunner()
 
 	// Register checks (order matters for the report)
-	for _, checker := range selfTestCheckers() {
+	for _, checker := range checkers {
 		runner.Register(checker)
 	}
 
@@ -88,12 +101,23 @@ var selfTestCheckers = func() []selftest.Checker {
 }
 
 func runSelfTest(cmd *cobra.Command, args []string) error {
+	build := buildSelfTestReport
+	if online, _ := cmd.Flags().GetBool("online"); online {
+		checkers, err := onlineCheckers(cmd)
+		if err != nil {
+			return err
+		}
+		build = func() *selftest.Report {
+			return runCheckers(append(selfTestCheckers(), checkers...))
+		}
+	}
+
 	report, err := ui.RunWithStatus(cmd.Context(), ui.StatusConfig{
 		Title:  "Running self-tests",
 		Writer: os.Stderr,
 		Quiet:  false,
 	}, func(ctx context.Context) (*selftest.Report, error) {
-		return buildSelfTestReport(), nil
+		return build(), nil
 	})
 	if err != nil {
 		return err
@@ -121,6 +145,12 @@ func outputHuman(report *selftest.Report, failuresOnly bool) error {
 		fmt.Fprintf(os.Stderr, "Detailed reports saved to: %s/latest.log\n\n", selftestDir)
 	}
 
+	return printReport(report, failuresOnly)
+}
+
+// printReport prints the report, optionally only its failed and warning
+// checks, and returns an error if any check failed.
+func printReport(report *selftest.Report, failuresOnly bool) error {
 	// Filter if needed
 	checks := report.Checks
 	if failuresOnly {
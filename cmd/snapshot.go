@@ -0,0 +1,142 @@
+// cmd/snapshot.go
+// Offline snapshots: gotr snapshot create|info
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/snapshot"
+	"github.com/Korrnals/gotr/internal/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// snapshotCmd is the parent "snapshot" command.
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Capture projects for offline use",
+	Long: `Capture projects into a versioned snapshot and work against it offline.
+
+A snapshot holds a project's suites, sections, cases, shared steps,
+milestones, runs, plans (with entries), templates, configurations and
+labels, plus server-wide reference data (statuses, priorities, case and
+result fields, case types, users). It is a directory of JSON files with a
+manifest.json, or a .tar.gz archive of the same.
+
+Pass --snapshot <path> to any command to answer reads from the snapshot
+instead of the server — no connection or credentials needed. get, compare
+and export work as usual; writes fail with a clear error, and data a
+snapshot does not hold (tests, results, attachments, reports) is reported
+as "not in snapshot".
+
+Examples:
+	gotr snapshot create --project-id 30
+	gotr snapshot create --project-id 30 --project-id 31 -o weekly.tar.gz
+	gotr snapshot info weekly.tar.gz
+	gotr --snapshot weekly.tar.gz get cases 30 --suite-id 20069
+	gotr --snapshot weekly.tar.gz compare cases --pid1 30 --pid2 31`,
+}
+
+// snapshotCreateCmd captures projects from the server.
+var snapshotCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Capture projects into a snapshot directory or archive",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectIDs, _ := cmd.Flags().GetInt64Slice("project-id")
+		if len(projectIDs) == 0 {
+			return fmt.Errorf("--project-id is required")
+		}
+		dst, _ := cmd.Flags().GetString("output")
+		if dst == "" {
+			dst = defaultSnapshotName(projectIDs, time.Now())
+		}
+		quiet, _ := cmd.Flags().GetBool("quiet")
+
+		// A snapshot must reflect the server, not the response cache
+		cli := uncached(GetClient(cmd))
+		snap, err := ui.RunWithStatus(cmd.Context(), ui.StatusConfig{
+			Title:  "Capturing snapshot",
+			Writer: os.Stderr,
+			Quiet:  quiet,
+		}, func(ctx context.Context) (*snapshot.Snapshot, error) {
+			return snapshot.Create(ctx, cli, projectIDs)
+		})
+		if err != nil {
+			return fmt.Errorf("snapshot failed: %w", err)
+		}
+		snap.Manifest.BaseURL = viper.GetString("base_url")
+		snap.Manifest.Generator = "gotr " + Version
+
+		if err := snap.Save(dst); err != nil {
+			return fmt.Errorf("save snapshot: %w", err)
+		}
+		ui.Successf(cmd.OutOrStdout(), "Snapshot saved to %s", dst)
+		return printSnapshotInfo(cmd.OutOrStdout(), snap)
+	},
+}
+
+// snapshotInfoCmd prints the manifest and per-project counts of a snapshot.
+var snapshotInfoCmd = &cobra.Command{
+	Use:   "info <path>",
+	Short: "Show what a snapshot contains",
+	Args:  cobra.ExactArgs(1),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Override parent PersistentPreRunE (no-op)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snap, err := snapshot.Load(args[0])
+		if err != nil {
+			return err
+		}
+		return printSnapshotInfo(cmd.OutOrStdout(), snap)
+	},
+}
+
+// defaultSnapshotName is snapshot_<ids>_<timestamp> in the current directory.
+func defaultSnapshotName(projectIDs []int64, now time.Time) string {
+	ids := make([]string, len(projectIDs))
+	for i, id := range projectIDs {
+		ids[i] = strconv.FormatInt(id, 10)
+	}
+	return fmt.Sprintf("snapshot_%s_%s", strings.Join(ids, "-"), now.Format("20060102_150405"))
+}
+
+func printSnapshotInfo(w io.Writer, snap *snapshot.Snapshot) error {
+	m := snap.Manifest
+	fmt.Fprintf(w, "Format:  %d\n", m.FormatVersion)
+	fmt.Fprintf(w, "Created: %s\n", m.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	if m.BaseURL != "" {
+		fmt.Fprintf(w, "Server:  %s\n", m.BaseURL)
+	}
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PROJECT\tNAME\tSUITES\tSECTIONS\tCASES\tSHARED STEPS\tMILESTONES\tRUNS\tPLANS")
+	for _, id := range m.ProjectIDs {
+		p := snap.Projects[id]
+		if p == nil {
+			continue
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\n", id, p.Project.Name,
+			len(p.Suites), len(p.Sections), len(p.Cases), len(p.SharedSteps),
+			len(p.Milestones), len(p.Runs), len(p.Plans))
+	}
+	return tw.Flush()
+}
+
+func registerSnapshotCmd() {
+	rootCmd.AddCommand(snapshotCmd)
+	snapshotCmd.AddCommand(snapshotCreateCmd)
+	snapshotCmd.AddCommand(snapshotInfoCmd)
+
+	snapshotCreateCmd.Flags().Int64Slice("project-id", nil, "Project to capture (repeatable)")
+	snapshotCreateCmd.Flags().StringP("output", "o", "", "Destination directory, or archive when ending in .tar.gz")
+}
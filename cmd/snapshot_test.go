@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/cache"
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/interactive"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/Korrnals/gotr/internal/snapshot"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestSnapshot captures project 30 from a mock server into dir/snap.
+func writeTestSnapshot(t *testing.T) string {
+	t.Helper()
+	mock := &client.MockClient{
+		GetProjectFunc: func(ctx context.Context, projectID int64) (*data.GetProjectResponse, error) {
+			return &data.GetProjectResponse{ID: projectID, Name: "Shop"}, nil
+		},
+		GetSuitesFunc: func(ctx context.Context, projectID int64) (data.GetSuitesResponse, error) {
+			return data.GetSuitesResponse{{ID: 5, Name: "Main"}}, nil
+		},
+		GetCasesFunc: func(ctx context.Context, projectID, suiteID, sectionID int64) (data.GetCasesResponse, error) {
+			return data.GetCasesResponse{{ID: 1, Title: "Login", SuiteID: suiteID}}, nil
+		},
+	}
+
+	dst := filepath.Join(t.TempDir(), "snap")
+	cmd := snapshotCreateCmd
+	t.Cleanup(func() {
+		_ = cmd.Flags().Set("output", "")
+		_ = cmd.Flags().Lookup("project-id").Value.(pflag.SliceValue).Replace(nil)
+	})
+	require.NoError(t, cmd.Flags().Set("project-id", "30"))
+	require.NoError(t, cmd.Flags().Set("output", dst))
+	cmd.SetContext(context.WithValue(context.Background(), httpClientKey, client.ClientInterface(mock)))
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	require.NoError(t, cmd.RunE(cmd, nil))
+	assert.Contains(t, out.String(), "Shop")
+	return dst
+}
+
+func TestSnapshotCreateAndInfo(t *testing.T) {
+	dst := writeTestSnapshot(t)
+
+	var out bytes.Buffer
+	snapshotInfoCmd.SetOut(&out)
+	require.NoError(t, snapshotInfoCmd.RunE(snapshotInfoCmd, []string{dst}))
+	assert.Contains(t, out.String(), "Format:  1")
+	assert.Contains(t, out.String(), "Shop")
+}
+
+func TestSnapshotCreate_BypassesCache(t *testing.T) {
+	name := "Old"
+	mock := &client.MockClient{
+		GetProjectFunc: func(ctx context.Context, projectID int64) (*data.GetProjectResponse, error) {
+			return &data.GetProjectResponse{ID: projectID, Name: name}, nil
+		},
+		GetSuitesFunc: func(ctx context.Context, projectID int64) (data.GetSuitesResponse, error) {
+			return data.GetSuitesResponse{}, nil
+		},
+	}
+	cached := client.NewCachedClient(mock, cache.New(t.TempDir(), "scope"))
+	_, err := cached.GetProject(context.Background(), 30)
+	require.NoError(t, err)
+	name = "Shop"
+
+	cmd := snapshotCreateCmd
+	t.Cleanup(func() {
+		_ = cmd.Flags().Set("output", "")
+		_ = cmd.Flags().Lookup("project-id").Value.(pflag.SliceValue).Replace(nil)
+	})
+	require.NoError(t, cmd.Flags().Set("project-id", "30"))
+	require.NoError(t, cmd.Flags().Set("output", filepath.Join(t.TempDir(), "snap")))
+	cmd.SetContext(context.WithValue(context.Background(), httpClientKey, client.ClientInterface(cached)))
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	require.NoError(t, cmd.RunE(cmd, nil))
+	assert.Contains(t, out.String(), "Shop")
+	assert.NotContains(t, out.String(), "Old")
+}
+
+func TestRootPersistentPreRunE_Snapshot(t *testing.T) {
+	dst := writeTestSnapshot(t)
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	viper.Set("snapshot", dst) // no connection settings needed
+
+	cmd := &cobra.Command{Use: "test-cmd"}
+	cmd.Flags().Bool("quiet", true, "")
+	cmd.Flags().Bool("non-interactive", true, "")
+	cmd.SetContext(context.Background())
+
+	require.NoError(t, rootCmd.PersistentPreRunE(cmd, nil))
+	cli := GetClient(cmd)
+	require.IsType(t, &snapshot.Client{}, cli)
+	assert.True(t, interactive.HasPrompterInContext(cmd.Context()))
+
+	_, err := cli.AddCase(cmd.Context(), 1, &data.AddCaseRequest{Title: "x"})
+	assert.ErrorIs(t, err, snapshot.ErrReadOnly)
+
+	viper.Set("snapshot", filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, rootCmd.PersistentPreRunE(cmd, nil))
+}
+
+func TestExportFromSnapshot(t *testing.T) {
+	snap, err := snapshot.Open(writeTestSnapshot(t))
+	require.NoError(t, err)
+
+	fetch, save, err := exportSource(snap, "get_cases/30", map[string]string{"suite_id": "5"})
+	require.NoError(t, err)
+	resp, err := fetch(context.Background())
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "cases.json")
+	require.NoError(t, save(context.Background(), resp, file))
+	b, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"title": "Login"`)
+
+	_, _, err = exportSource(&client.MockClient{}, "get_cases/30", nil)
+	assert.Error(t, err)
+}
+
+func TestDefaultSnapshotName(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	assert.Equal(t, "snapshot_30-31_20260301_093000", defaultSnapshotName([]int64{30, 31}, now))
+}
@@ -26,6 +26,12 @@ Subcommands:
 	• suites       — migrate suites between projects
 	• sections     — migrate sections between suites
 
+Source and destination may live on different TestRail servers: pass
+--src-profile and/or --dst-profile with names from 'gotr config profile list'.
+Users, priorities, case types, templates and statuses are then matched by
+name (users by email) and their IDs translated; custom fields and their
+options are matched by system name.
+
 Logs and mapping are saved in the directory: .testrail (log files are in .testrail/logs/)
 
 Examples:
@@ -37,11 +43,25 @@ Examples:
 	gotr sync full --src-project 30 --src-suite 20069 --dst-project 31 --dst-suite 19859 --approve --save-mapping
 	gotr sync cases --src-project 30 --src-suite 20069 --dst-project 31 --dst-suite 19859 --mapping shared_steps_mapping.json --dry-run
 	gotr sync shared-steps --src-project 30 --src-suite 20069 --dst-project 31 --approve --output shared_steps_mapping.json
+
+	# Cross-instance: from the staging server to production
+	gotr sync full --src-profile staging --dst-profile production --src-project 3 --src-suite 12 --dst-project 7 --dst-suite 40
 `,
 }
 
 var clientAccessor *client.Accessor
 
+// ProfileClientFunc builds a client for a named connection profile
+// (used by --src-profile and --dst-profile).
+type ProfileClientFunc func(name string) (client.ClientInterface, error)
+
+var profileClientFn ProfileClientFunc
+
+// SetProfileClientFunc sets the factory for profile-based clients.
+func SetProfileClientFunc(fn ProfileClientFunc) {
+	profileClientFn = fn
+}
+
 // SetGetClientForTests sets getClient for tests.
 func SetGetClientForTests(fn GetClientFunc) {
 	if clientAccessor == nil {
@@ -105,21 +125,37 @@ func Register(rootCmd *cobra.Command, clientFn GetClientFunc) {
 	Cmd.AddCommand(suitesCmd)
 	Cmd.AddCommand(sectionsCmd)
 
+	// Cross-instance flags (source and destination on different servers)
+	for _, c := range []*cobra.Command{casesCmd, sharedStepsCmd, sectionsCmd, fullCmd, suitesCmd} {
+		c.Flags().String("src-profile", "", "Connection profile of the source TestRail instance")
+		c.Flags().String("dst-profile", "", "Connection profile of the destination TestRail instance")
+	}
+
+	// Duplicate detection tuning (see internal/match)
+	for _, c := range []*cobra.Command{casesCmd, sharedStepsCmd, fullCmd} {
+		addMatchFlags(c)
+	}
+
 	// Flags for sync cases
 	casesCmd.Flags().Int64("src-project", 0, "Source project ID (copy from)")
 	casesCmd.Flags().Int64("src-suite", 0, "Source suite ID")
 	casesCmd.Flags().Int64("dst-project", 0, "Destination project ID (copy to)")
 	casesCmd.Flags().Int64("dst-suite", 0, "Destination suite ID")
-	casesCmd.Flags().String("compare-field", "title", "Field for duplicate detection")
+	casesCmd.Flags().String("compare-field", "title", "Field(s) for duplicate detection: title, refs+title, section_path+title, ...")
 	casesCmd.Flags().String("mapping-file", "", "Mapping file for shared_step_id replacement")
+	casesCmd.Flags().String("case-mapping", "", "Case mapping from an earlier run; mapped cases are updated in place")
+	casesCmd.Flags().String("field-mapping", "", "YAML file with manual custom field/option mapping")
 	casesCmd.Flags().Bool("dry-run", false, "Preview without importing")
 	casesCmd.Flags().String("output", "", "Additional JSON file with results")
+	casesCmd.Flags().Bool("incremental", false, "Sync only cases changed since the last run (state in ~/.gotr/sync)")
+	casesCmd.Flags().Bool("approve", false, "Auto-approve confirmation")
+	casesCmd.Flags().Bool("with-attachments", false, "Copy attachments of newly created cases")
 
 	// Flags for sync shared-steps
 	sharedStepsCmd.Flags().Int64("src-project", 0, "Source project ID")
 	sharedStepsCmd.Flags().Int64("src-suite", 0, "Source suite ID")
 	sharedStepsCmd.Flags().Int64("dst-project", 0, "Destination project ID")
-	sharedStepsCmd.Flags().String("compare-field", "title", "Field for duplicate detection")
+	sharedStepsCmd.Flags().String("compare-field", "title", "Field(s) for duplicate detection, e.g. title")
 	sharedStepsCmd.Flags().Bool("approve", false, "Auto-approve confirmation")
 	sharedStepsCmd.Flags().Bool("save-mapping", false, "Save mapping automatically")
 	sharedStepsCmd.Flags().Bool("save-filtered", false, "Save filtered list automatically")
@@ -140,9 +176,12 @@ func Register(rootCmd *cobra.Command, clientFn GetClientFunc) {
 	fullCmd.Flags().Int64("src-suite", 0, "Source suite ID")
 	fullCmd.Flags().Int64("dst-project", 0, "Destination project ID")
 	fullCmd.Flags().Int64("dst-suite", 0, "Destination suite ID")
-	fullCmd.Flags().String("compare-field", "title", "Field for duplicate detection")
+	fullCmd.Flags().String("compare-field", "title", "Field(s) for case and shared step duplicate detection: title, refs+title, section_path+title, ...")
 	fullCmd.Flags().Bool("approve", false, "Auto-approve confirmation")
 	fullCmd.Flags().Bool("save-mapping", false, "Save mapping automatically")
 	fullCmd.Flags().Bool("save-filtered", false, "Save filtered list automatically")
+	fullCmd.Flags().String("case-mapping", "", "Case mapping from an earlier run; mapped cases are updated in place")
+	fullCmd.Flags().String("field-mapping", "", "YAML file with manual custom field/option mapping")
+	fullCmd.Flags().Bool("with-attachments", false, "Copy attachments of newly created cases")
 	fullCmd.Flags().Bool("dry-run", false, "Preview without importing")
 }
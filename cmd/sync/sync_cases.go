@@ -11,6 +11,7 @@ import (
 	"github.com/Korrnals/gotr/internal/interactive"
 	"github.com/Korrnals/gotr/internal/models/data"
 	"github.com/Korrnals/gotr/internal/paths"
+	"github.com/Korrnals/gotr/internal/service/migration"
 	"github.com/Korrnals/gotr/internal/ui"
 
 	"github.com/spf13/cobra"
@@ -24,6 +25,17 @@ var casesCmd = &cobra.Command{
 Features:
 • Automatic interactive selection of projects and suites (if flags are not specified)
 • Support for shared_step_id replacement via mapping file
+• Cases are placed into the destination sections mapped from their source sections
+  (missing sections are created)
+• Custom fields are matched by system name and dropdown options by label
+  (override with a YAML --field-mapping file); fields missing or not assigned
+  on the destination project are reported before import
+• Incremental mode (--incremental): only cases changed since the last run are
+  synced; mapped cases are updated in place, new ones created. The state is kept
+  in ~/.gotr/sync/, so the command can run unattended (with --approve) from cron
+• The case mapping is saved after import; pass it back with --case-mapping
+  to update already migrated cases in place instead of duplicating them
+• Attachments of newly created cases are copied with --with-attachments
 • Interactive confirmation before import
 • Dry-run mode (without creating objects)
 • Saving JSON result log
@@ -42,11 +54,20 @@ Examples:
 
 	# With mapping file and dry-run
 	gotr sync cases --src-project 30 --src-suite 20069 --dst-project 31 --dst-suite 19859 --mapping-file mapping.json --dry-run
+
+	# Nightly incremental sync (e.g. from cron)
+	gotr sync cases --src-project 30 --src-suite 20069 --dst-project 31 --dst-suite 19859 --incremental --approve --quiet
+
+	# Re-run: update cases migrated earlier in place
+	gotr sync cases --src-project 30 --src-suite 20069 --dst-project 31 --dst-suite 19859 --case-mapping .testrail/logs/mapping_cases_2026-01-01_10-00-00.json
 `,
 
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cli := getClientInterface(cmd)
 		ctx := cmd.Context()
+		srcCli, dstCli, err := resolveSyncClients(cmd)
+		if err != nil {
+			return err
+		}
 
 		srcProject, _ := cmd.Flags().GetInt64("src-project")
 		srcSuite, _ := cmd.Flags().GetInt64("src-suite")
@@ -57,13 +78,21 @@ Examples:
 		quiet, _ := cmd.Flags().GetBool("quiet")
 		outputFile, _ := cmd.Flags().GetString("output")
 		mappingFile, _ := cmd.Flags().GetString("mapping-file")
+		caseMappingFile, _ := cmd.Flags().GetString("case-mapping")
+		fieldMappingFile, _ := cmd.Flags().GetString("field-mapping")
+		incremental, _ := cmd.Flags().GetBool("incremental")
+		autoApprove, _ := cmd.Flags().GetBool("approve")
+		withAttachments, _ := cmd.Flags().GetBool("with-attachments")
+		matching, err := syncMatchOptions(cmd)
+		if err != nil {
+			return err
+		}
 
 		p := interactive.PrompterFromContext(ctx)
-		var err error
 
 		// Interactive source project selection
 		if srcProject == 0 {
-			srcProject, err = interactive.SelectProject(ctx, p, cli, "Select SOURCE project (copy from):")
+			srcProject, err = interactive.SelectProject(ctx, p, srcCli, "Select SOURCE project (copy from):")
 			if err != nil {
 				return err
 			}
@@ -71,7 +100,7 @@ Examples:
 
 		// Interactive source suite selection
 		if srcSuite == 0 {
-			srcSuite, err = interactive.SelectSuiteForProject(ctx, p, cli, srcProject, "Select SOURCE suite:")
+			srcSuite, err = interactive.SelectSuiteForProject(ctx, p, srcCli, srcProject, "Select SOURCE suite:")
 			if err != nil {
 				return err
 			}
@@ -79,7 +108,7 @@ Examples:
 
 		// Interactive destination project selection
 		if dstProject == 0 {
-			dstProject, err = interactive.SelectProject(ctx, p, cli, "Select DESTINATION project (copy to):")
+			dstProject, err = interactive.SelectProject(ctx, p, dstCli, "Select DESTINATION project (copy to):")
 			if err != nil {
 				return err
 			}
@@ -87,7 +116,7 @@ Examples:
 
 		// Interactive destination suite selection
 		if dstSuite == 0 {
-			dstSuite, err = interactive.SelectSuiteForProject(ctx, p, cli, dstProject, "Select DESTINATION suite:")
+			dstSuite, err = interactive.SelectSuiteForProject(ctx, p, dstCli, dstProject, "Select DESTINATION suite:")
 			if err != nil {
 				return err
 			}
@@ -106,11 +135,13 @@ Examples:
 		}
 
 		// Create migration object
-		m, err := newMigration(cli, srcProject, srcSuite, dstProject, dstSuite, compareField, logDir)
+		m, err := newMigration(dstCli, srcProject, srcSuite, dstProject, dstSuite, compareField, logDir)
 		if err != nil {
 			return err
 		}
 		defer m.Close()
+		m.SetSourceClient(srcCli)
+		m.SetMatching(matching)
 
 		op := newSyncOperation("Sync cases", quiet)
 		defer op.Finish()
@@ -129,12 +160,43 @@ Examples:
 			ui.Warning(os.Stdout, "mapping not loaded — shared_step_id will NOT be replaced")
 		}
 
+		if caseMappingFile != "" {
+			if err := m.LoadCaseMappingFromFile(caseMappingFile); err != nil {
+				return err
+			}
+			ui.Infof(os.Stdout, "Case mapping loaded: %d entries", m.CaseMapping().Count)
+		}
+		if fieldMappingFile != "" {
+			overrides, err := migration.LoadFieldOverrides(fieldMappingFile)
+			if err != nil {
+				return err
+			}
+			m.SetFieldOverrides(overrides)
+		}
+
+		// Incremental mode: seed mappings from the previous run's state
+		var stateFile string
+		var state *migration.SyncState
+		var since time.Time
+		if incremental {
+			stateFile, state, err = loadSyncState(cmd, m, srcProject, srcSuite, dstProject, dstSuite)
+			if err != nil {
+				return err
+			}
+			if state != nil {
+				since = state.LastSync
+				ui.Infof(os.Stdout, "Incremental sync: changes since %s", state.LastSync.Format(time.RFC3339))
+			} else {
+				ui.Info(os.Stdout, "Incremental sync: no previous state, syncing all cases")
+			}
+		}
+
 		op.Phase("Loading cases")
 		loaded, err := runSyncStatus(ctx, "Loading cases...", quiet, func(ctx context.Context) (struct {
 			Source data.GetCasesResponse
 			Target data.GetCasesResponse
 		}, error) {
-			sourceCases, targetCases, err := m.FetchCasesData(ctx)
+			sourceCases, targetCases, err := m.FetchCasesUpdatedSince(ctx, since)
 			if err != nil {
 				return struct {
 					Source data.GetCasesResponse
@@ -151,7 +213,12 @@ Examples:
 		}
 		sourceCases := loaded.Source
 		targetCases := loaded.Target
+		// The next checkpoint is the newest server updated_on seen
+		checkpoint := migration.LatestCaseUpdate(sourceCases, since)
 
+		if err := m.PrepareMatching(ctx); err != nil {
+			return err
+		}
 		filtered, err := m.FilterCases(sourceCases, targetCases)
 		if err != nil {
 			return err
@@ -177,24 +244,41 @@ Examples:
 			}
 		}
 
+		// Report custom fields that cannot be written before any writes
+		problems, err := m.CheckCaseFields(ctx, filtered)
+		if err != nil {
+			return err
+		}
+		if err := reportFieldProblems(problems); err != nil {
+			return err
+		}
+
 		if dryRun {
 			ui.Info(os.Stdout, "Dry-run: import NOT performed (safe).")
 			saveLog(logFile, matches, filtered, nil, m.Mapping(), quiet)
 			return nil
 		}
 
-		op.Phase("Awaiting confirmation")
-		ui.Infof(os.Stdout, "Confirm import of %d new cases...", len(filtered))
-		ok, err := p.Confirm("Continue?", false)
-		if err != nil {
-			return err
-		}
-		if !ok {
-			ui.Canceled(os.Stdout)
-			saveLog(logFile, matches, filtered, nil, m.Mapping(), quiet)
+		if incremental && len(filtered) == 0 {
+			ui.Info(os.Stdout, "No new or changed cases")
+			saveSyncState(stateFile, m.SyncState(checkpoint), quiet)
 			return nil
 		}
 
+		op.Phase("Awaiting confirmation")
+		if !autoApprove {
+			ui.Infof(os.Stdout, "Confirm import of %d new cases...", len(filtered))
+			ok, err := p.Confirm("Continue?", false)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				ui.Canceled(os.Stdout)
+				saveLog(logFile, matches, filtered, nil, m.Mapping(), quiet)
+				return nil
+			}
+		}
+
 		op.Phase("Importing cases")
 		imported, err := runSyncStatus(ctx, fmt.Sprintf("Importing %d cases...", len(filtered)), quiet, func(ctx context.Context) (struct {
 			IDs    []int64
@@ -219,6 +303,9 @@ Examples:
 		importErrors := imported.Errors
 
 		ui.Successf(os.Stdout, "Import complete: %d new cases", len(createdIDs))
+		if updated := m.UpdatedCases(); updated > 0 {
+			ui.Infof(os.Stdout, "Updated in place: %d cases", updated)
+		}
 
 		if len(importErrors) > 0 {
 			ui.Error(os.Stdout, "Errors:")
@@ -227,8 +314,26 @@ Examples:
 			}
 		}
 
+		if withAttachments {
+			copyAttachments(ctx, m, quiet)
+		}
+
 		// Save log and mapping
 		saveLog(logFile, matches, filtered, importErrors, m.Mapping(), quiet)
+		if err := m.ExportCaseMapping(logDir); err != nil {
+			ui.Warningf(os.Stderr, "Failed to save case mapping: %v", err)
+		} else if !quiet && m.CaseMapping().Count > 0 {
+			ui.Infof(os.Stdout, "Case mapping saved to %s", logDir)
+		}
+
+		if incremental {
+			next := m.SyncState(checkpoint)
+			if len(importErrors) > 0 {
+				// Keep the previous checkpoint so that failed cases are retried.
+				next.LastSync = since
+			}
+			saveSyncState(stateFile, next, quiet)
+		}
 
 		return nil
 	},
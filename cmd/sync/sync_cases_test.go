@@ -104,6 +104,55 @@ func TestSyncCases_Confirm_TriggersAddCase(t *testing.T) {
 	assert.True(t, addCalled, "AddCase should be called after confirmation")
 }
 
+// TestSyncCases_DuplicatesMatchCaseSensitively verifies that a target case
+// differing only in case is not a duplicate unless --normalize case is set
+func TestSyncCases_DuplicatesMatchCaseSensitively(t *testing.T) {
+	for _, tc := range []struct {
+		normalize string
+		added     []string
+	}{
+		{normalize: "", added: []string{"Login"}},
+		{normalize: "case", added: nil},
+	} {
+		t.Run("normalize="+tc.normalize, func(t *testing.T) {
+			var added []string
+			mock := &client.MockClient{
+				GetCasesFunc: func(ctx context.Context, projectID, suiteID, sectionID int64) (data.GetCasesResponse, error) {
+					if projectID == 1 {
+						return data.GetCasesResponse{{ID: 1, Title: "Login"}}, nil
+					}
+					return data.GetCasesResponse{{ID: 200, Title: "login"}}, nil
+				},
+				AddCaseFunc: func(ctx context.Context, suiteID int64, r *data.AddCaseRequest) (*data.Case, error) {
+					added = append(added, r.Title)
+					return &data.Case{ID: 100, Title: r.Title}, nil
+				},
+			}
+
+			old := newMigration
+			defer func() { newMigration = old }()
+			newMigration = newMigrationFactoryFromMock(t, mock)
+
+			resetCasesFlags()
+			addMatchFlags(casesCmd)
+			cmd := casesCmd
+			SetTestClient(cmd, mock)
+			cmd.Flags().Set("src-project", "1")
+			cmd.Flags().Set("src-suite", "10")
+			cmd.Flags().Set("dst-project", "2")
+			cmd.Flags().Set("dst-suite", "20")
+			cmd.Flags().Set("normalize", tc.normalize)
+			cmd.Flags().Set("output", filepath.Join(t.TempDir(), "log.json"))
+
+			p := interactive.NewMockPrompter().WithConfirmResponses(true)
+			cmd.SetContext(interactive.WithPrompter(context.Background(), p))
+
+			assert.NoError(t, cmd.RunE(cmd, []string{}))
+			assert.Equal(t, tc.added, added)
+		})
+	}
+}
+
 func TestSyncCases_NoFlags_NonInteractive_Error(t *testing.T) {
 	addCalled := false
 
@@ -9,6 +9,7 @@ func addSyncFlags(c *cobra.Command) {
 	c.Flags().Int64("dst-project", 0, "Destination project ID")
 	c.Flags().Int64("dst-suite", 0, "Destination suite ID")
 	c.Flags().String("compare-field", "title", "Field for duplicate detection")
+	addMatchFlags(c)
 	c.Flags().Bool("dry-run", false, "Preview without importing")
 	c.Flags().BoolP("approve", "y", false, "Auto-approve confirmation")
 	c.Flags().BoolP("save-mapping", "m", false, "Save mapping automatically")
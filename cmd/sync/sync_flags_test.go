@@ -3,6 +3,7 @@ package sync
 import (
 	"testing"
 
+	"github.com/Korrnals/gotr/internal/match"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
@@ -20,6 +21,9 @@ func TestAddSyncFlags(t *testing.T) {
 		"dst-project",
 		"dst-suite",
 		"compare-field",
+		"normalize",
+		"fuzzy",
+		"fuzzy-algo",
 		"dry-run",
 		"approve",
 		"save-mapping",
@@ -118,3 +122,29 @@ func TestAddSyncFlags_SetValues(t *testing.T) {
 	output, _ := cmd.Flags().GetString("output")
 	assert.Equal(t, "output.json", output)
 }
+
+func TestSyncMatchOptions(t *testing.T) {
+	cmd := &cobra.Command{}
+	addSyncFlags(cmd)
+
+	opts, err := syncMatchOptions(cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, match.Options{Algorithm: match.Levenshtein}, opts,
+		"duplicates match exactly, case included, by default")
+
+	cmd.Flags().Set("normalize", "ws,case")
+	cmd.Flags().Set("fuzzy", "0.9")
+	opts, err = syncMatchOptions(cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, match.Normalize{Whitespace: true, Case: true}, opts.Normalize)
+	assert.Equal(t, 0.9, opts.Threshold)
+
+	cmd.Flags().Set("fuzzy-algo", "soundex")
+	_, err = syncMatchOptions(cmd)
+	assert.ErrorContains(t, err, "soundex")
+
+	cmd.Flags().Set("fuzzy-algo", "jaccard")
+	cmd.Flags().Set("compare-field", "refs+")
+	_, err = syncMatchOptions(cmd)
+	assert.ErrorContains(t, err, "--compare-field")
+}
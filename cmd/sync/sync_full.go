@@ -6,6 +6,7 @@ import (
 
 	"github.com/Korrnals/gotr/internal/interactive"
 	"github.com/Korrnals/gotr/internal/paths"
+	"github.com/Korrnals/gotr/internal/service/migration"
 	"github.com/Korrnals/gotr/internal/ui"
 
 	"github.com/spf13/cobra"
@@ -19,7 +20,11 @@ var fullCmd = &cobra.Command{
 Features:
 • Automatic interactive selection of projects and suites
 • Executes two-stage migration in a single call
-• Saves mapping automatically (with --save-mapping)
+• Places cases into the destination sections mapped from their source sections
+• Saves mapping automatically (with --save-mapping), including the case mapping
+• Updates cases from an earlier run in place (with --case-mapping)
+• Maps custom fields and dropdown options (manual overrides with --field-mapping)
+• Copies attachments of newly created cases (with --with-attachments)
 
 Examples:
 	# Fully interactive mode
@@ -30,8 +35,11 @@ Examples:
 `,
 
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cli := getClientInterface(cmd)
 		ctx := cmd.Context()
+		srcCli, dstCli, err := resolveSyncClients(cmd)
+		if err != nil {
+			return err
+		}
 
 		srcProject, _ := cmd.Flags().GetInt64("src-project")
 		srcSuite, _ := cmd.Flags().GetInt64("src-suite")
@@ -43,13 +51,19 @@ Examples:
 		autoApprove, _ := cmd.Flags().GetBool("approve")
 		autoSaveMapping, _ := cmd.Flags().GetBool("save-mapping")
 		autoSaveFiltered, _ := cmd.Flags().GetBool("save-filtered")
+		caseMappingFile, _ := cmd.Flags().GetString("case-mapping")
+		fieldMappingFile, _ := cmd.Flags().GetString("field-mapping")
+		withAttachments, _ := cmd.Flags().GetBool("with-attachments")
+		matching, err := syncMatchOptions(cmd)
+		if err != nil {
+			return err
+		}
 
 		p := interactive.PrompterFromContext(ctx)
-		var err error
 
 		// Interactive source project selection
 		if srcProject == 0 {
-			srcProject, err = interactive.SelectProject(ctx, p, cli, "Select SOURCE project:")
+			srcProject, err = interactive.SelectProject(ctx, p, srcCli, "Select SOURCE project:")
 			if err != nil {
 				return err
 			}
@@ -57,7 +71,7 @@ Examples:
 
 		// Interactive source suite selection
 		if srcSuite == 0 {
-			srcSuite, err = interactive.SelectSuiteForProject(ctx, p, cli, srcProject, "Select SOURCE suite:")
+			srcSuite, err = interactive.SelectSuiteForProject(ctx, p, srcCli, srcProject, "Select SOURCE suite:")
 			if err != nil {
 				return err
 			}
@@ -65,7 +79,7 @@ Examples:
 
 		// Interactive destination project selection
 		if dstProject == 0 {
-			dstProject, err = interactive.SelectProject(ctx, p, cli, "Select DESTINATION project:")
+			dstProject, err = interactive.SelectProject(ctx, p, dstCli, "Select DESTINATION project:")
 			if err != nil {
 				return err
 			}
@@ -73,7 +87,7 @@ Examples:
 
 		// Interactive destination suite selection
 		if dstSuite == 0 {
-			dstSuite, err = interactive.SelectSuiteForProject(ctx, p, cli, dstProject, "Select DESTINATION suite:")
+			dstSuite, err = interactive.SelectSuiteForProject(ctx, p, dstCli, dstProject, "Select DESTINATION suite:")
 			if err != nil {
 				return err
 			}
@@ -83,15 +97,40 @@ Examples:
 		if err != nil {
 			return err
 		}
-		m, err := newMigration(cli, srcProject, srcSuite, dstProject, dstSuite, compareField, logDir)
+		m, err := newMigration(dstCli, srcProject, srcSuite, dstProject, dstSuite, compareField, logDir)
 		if err != nil {
 			return err
 		}
 		defer m.Close()
+		m.SetSourceClient(srcCli)
+		m.SetMatching(matching)
+		if caseMappingFile != "" {
+			if err := m.LoadCaseMappingFromFile(caseMappingFile); err != nil {
+				return err
+			}
+		}
+		if fieldMappingFile != "" {
+			overrides, err := migration.LoadFieldOverrides(fieldMappingFile)
+			if err != nil {
+				return err
+			}
+			m.SetFieldOverrides(overrides)
+		}
 
 		op := newSyncOperation("Full migration", quiet)
 defer op.Finish()
 
+		// Report custom fields that cannot be written before any writes
+		problems, err := runSyncStatus(ctx, "Checking custom fields...", quiet, func(ctx context.Context) ([]migration.FieldProblem, error) {
+			return m.CheckSuiteCaseFields(ctx)
+		})
+		if err != nil {
+			return err
+		}
+		if err := reportFieldProblems(problems); err != nil {
+			return err
+		}
+
 		// Step 1) Migrate shared steps (Fetch → Filter → Import)
 		op.Phase("Step 1/2: shared steps")
 		_, err = runSyncStatus(ctx, "Migrating shared steps...", quiet, func(ctx context.Context) (struct{}, error) {
@@ -115,6 +154,10 @@ defer op.Finish()
 			return err
 		}
 
+		if withAttachments {
+			copyAttachments(ctx, m, quiet)
+		}
+
 		if autoSaveMapping {
 			_ = m.ExportMapping(logDir)
 		}
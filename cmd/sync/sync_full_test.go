@@ -208,3 +208,58 @@ func TestSyncFull_NoFlags_InteractiveSuccess(t *testing.T) {
 	assert.True(t, addShared, "AddSharedStep should be called after interactive selection")
 	assert.True(t, addCase, "AddCase should be called after interactive selection")
 }
+
+// TestSyncFull_BlockingFieldProblemAbortsBeforeWrites verifies that a required
+// destination field without a source counterpart stops the sync before any writes
+func TestSyncFull_BlockingFieldProblemAbortsBeforeWrites(t *testing.T) {
+	addShared := false
+	addCase := false
+
+	var required data.CaseFieldConfig
+	required.Context.IsGlobal = true
+	required.Options.IsRequired = true
+
+	dst := &client.MockClient{
+		GetCaseFieldsFunc: func(ctx context.Context) (data.GetCaseFieldsResponse, error) {
+			return data.GetCaseFieldsResponse{{SystemName: "custom_team", TypeID: data.CaseFieldTypeString, Configs: []data.CaseFieldConfig{required}}}, nil
+		},
+		AddSharedStepFunc: func(ctx context.Context, projectID int64, r *data.AddSharedStepRequest) (*data.SharedStep, error) {
+			addShared = true
+			return &data.SharedStep{ID: 100}, nil
+		},
+		AddCaseFunc: func(ctx context.Context, suiteID int64, r *data.AddCaseRequest) (*data.Case, error) {
+			addCase = true
+			return &data.Case{ID: 100}, nil
+		},
+	}
+	src := &client.MockClient{
+		GetCasesFunc: func(ctx context.Context, projectID, suiteID, sectionID int64) (data.GetCasesResponse, error) {
+			return data.GetCasesResponse{{ID: 1, Title: "Case1"}}, nil
+		},
+	}
+
+	old := newMigration
+	defer func() { newMigration = old }()
+	newMigration = newMigrationFactoryFromMock(t, dst)
+	oldProfile := profileClientFn
+	t.Cleanup(func() { profileClientFn = oldProfile })
+	SetProfileClientFunc(func(name string) (client.ClientInterface, error) {
+		return src, nil
+	})
+
+	resetFullFlags()
+	cmd := fullCmd
+	SetTestClient(cmd, dst)
+	cmd.Flags().String("src-profile", "", "")
+	cmd.Flags().Set("src-profile", "staging")
+	cmd.Flags().Set("src-project", "1")
+	cmd.Flags().Set("src-suite", "10")
+	cmd.Flags().Set("dst-project", "2")
+	cmd.Flags().Set("dst-suite", "20")
+	cmd.Flags().Set("approve", "true")
+
+	err := cmd.RunE(cmd, []string{})
+	assert.ErrorContains(t, err, "custom field(s) required by the destination")
+	assert.False(t, addShared, "AddSharedStep must not be called")
+	assert.False(t, addCase, "AddCase must not be called")
+}
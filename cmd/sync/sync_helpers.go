@@ -2,15 +2,69 @@ package sync
 
 import (
 	"context"
+	"fmt"
 	"os"
 
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/flags"
+	"github.com/Korrnals/gotr/internal/match"
+	"github.com/Korrnals/gotr/internal/paths"
 	"github.com/Korrnals/gotr/internal/service/migration"
 	"github.com/Korrnals/gotr/internal/ui"
+	"github.com/spf13/cobra"
 )
 
 // newMigration is a test seam; defaults to migration.NewMigration.
 var newMigration = migration.NewMigration
 
+// resolveSyncClients returns the source and destination clients.
+// Without --src-profile/--dst-profile both are the command client.
+func resolveSyncClients(cmd *cobra.Command) (src, dst client.ClientInterface, err error) {
+	src = getClientInterface(cmd)
+	dst = src
+
+	srcProfile, _ := cmd.Flags().GetString("src-profile")
+	dstProfile, _ := cmd.Flags().GetString("dst-profile")
+	if srcProfile == "" && dstProfile == "" {
+		return src, dst, nil
+	}
+	if profileClientFn == nil {
+		return nil, nil, fmt.Errorf("--src-profile/--dst-profile are not supported in this build")
+	}
+
+	if srcProfile != "" {
+		if src, err = profileClientFn(srcProfile); err != nil {
+			return nil, nil, fmt.Errorf("source profile: %w", err)
+		}
+	}
+	if dstProfile != "" {
+		if dst, err = profileClientFn(dstProfile); err != nil {
+			return nil, nil, fmt.Errorf("destination profile: %w", err)
+		}
+	}
+	return src, dst, nil
+}
+
+// syncMatchOptions reads --compare-field, --normalize, --fuzzy and
+// --fuzzy-algo and checks them before anything is loaded. Unlike compare,
+// duplicates must match the --compare-field value exactly, case included,
+// unless --normalize says otherwise.
+func syncMatchOptions(cmd *cobra.Command) (match.Options, error) {
+	if field, _ := cmd.Flags().GetString("compare-field"); field != "" {
+		if _, err := match.ParseKey(field); err != nil {
+			return match.Options{}, fmt.Errorf("--compare-field: %w", err)
+		}
+	}
+	return flags.MatchOptionsFromFlags(cmd)
+}
+
+// addMatchFlags defines the duplicate detection tuning flags.
+func addMatchFlags(c *cobra.Command) {
+	c.Flags().String("normalize", "", "Ignore edits when detecting duplicates: whitespace,case,punctuation | all")
+	c.Flags().Float64("fuzzy", 0, "Log near duplicates with a similarity of at least this (0..1) for review; 0 = off")
+	c.Flags().String("fuzzy-algo", match.Levenshtein, "Similarity for --fuzzy: levenshtein | jaccard")
+}
+
 func newSyncOperation(title string, quiet bool) ui.Operation {
 	return ui.NewOperation(ui.StatusConfig{
 		Title:  title,
@@ -26,3 +80,77 @@ func runSyncStatus[T any](ctx context.Context, title string, quiet bool, fn func
 		Quiet:  quiet,
 	}, fn)
 }
+
+// loadSyncState loads the incremental sync state for the source/destination
+// pair and seeds the migration mappings from it. state is nil on the first run.
+func loadSyncState(cmd *cobra.Command, m *migration.Migration, srcProject, srcSuite, dstProject, dstSuite int64) (file string, state *migration.SyncState, err error) {
+	dir, err := paths.SyncDirPath()
+	if err != nil {
+		return "", nil, err
+	}
+	srcProfile, _ := cmd.Flags().GetString("src-profile")
+	dstProfile, _ := cmd.Flags().GetString("dst-profile")
+	file = migration.SyncStateFile(dir, srcProfile, srcProject, srcSuite, dstProfile, dstProject, dstSuite)
+
+	state, err = migration.LoadSyncState(file)
+	if err != nil {
+		return "", nil, err
+	}
+	m.ApplySyncState(state)
+	return file, state, nil
+}
+
+func saveSyncState(file string, state *migration.SyncState, quiet bool) {
+	if err := state.Save(file); err != nil {
+		ui.Warningf(os.Stderr, "Failed to save sync state %s: %v", file, err)
+		return
+	}
+	if !quiet {
+		ui.Infof(os.Stdout, "Sync state saved: %s", file)
+	}
+}
+
+// copyAttachments copies the attachments of the cases created in this run
+// and prints the per-file failures. Failures do not fail the command.
+func copyAttachments(ctx context.Context, m *migration.Migration, quiet bool) {
+	report, err := runSyncStatus(ctx, "Copying attachments...", quiet, m.CopyCaseAttachments)
+	if err != nil {
+		ui.Warningf(os.Stderr, "Attachments not copied: %v", err)
+		return
+	}
+
+	ui.Successf(os.Stdout, "Attachments copied: %d", report.Copied)
+	if len(report.Failures) > 0 {
+		ui.Warningf(os.Stdout, "Attachments failed: %d", len(report.Failures))
+		for _, f := range report.Failures {
+			fmt.Printf("  - %s\n", f)
+		}
+	}
+}
+
+// reportFieldProblems prints the custom field problems found before any
+// writes and fails if a problem blocks the migration.
+func reportFieldProblems(problems []migration.FieldProblem) error {
+	var warnings []migration.FieldProblem
+	for _, p := range problems {
+		if !p.Blocking {
+			warnings = append(warnings, p)
+		}
+	}
+	if len(warnings) > 0 {
+		ui.Warning(os.Stdout, "Custom fields that will not be migrated:")
+		for _, p := range warnings {
+			fmt.Printf("  - %s\n", p)
+		}
+	}
+
+	blocking := migration.BlockingFieldProblems(problems)
+	if len(blocking) == 0 {
+		return nil
+	}
+	ui.Error(os.Stdout, "Custom fields that block the migration:")
+	for _, p := range blocking {
+		fmt.Printf("  - %s\n", p)
+	}
+	return fmt.Errorf("%d custom field(s) required by the destination cannot be filled; map them with --field-mapping or make them optional", len(blocking))
+}
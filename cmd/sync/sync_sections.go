@@ -8,6 +8,7 @@ import (
 	"github.com/Korrnals/gotr/internal/interactive"
 	"github.com/Korrnals/gotr/internal/models/data"
 	"github.com/Korrnals/gotr/internal/paths"
+	"github.com/Korrnals/gotr/internal/service/migration"
 	"github.com/Korrnals/gotr/internal/ui"
 
 	"github.com/spf13/cobra"
@@ -20,7 +21,8 @@ var sectionsCmd = &cobra.Command{
 
 Features:
 • Automatic interactive selection of projects and suites
-• Duplicate filtering by name
+• Section tree preserved (parents created before children)
+• Duplicate filtering by full section path
 • Confirmation before import
 
 Examples:
@@ -32,8 +34,11 @@ Examples:
 `,
 
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cli := getClientInterface(cmd)
 		ctx := cmd.Context()
+		srcCli, dstCli, err := resolveSyncClients(cmd)
+		if err != nil {
+			return err
+		}
 
 		srcProject, _ := cmd.Flags().GetInt64("src-project")
 		srcSuite, _ := cmd.Flags().GetInt64("src-suite")
@@ -44,14 +49,13 @@ Examples:
 		quiet, _ := cmd.Flags().GetBool("quiet")
 		autoApprove, _ := cmd.Flags().GetBool("approve")
 
-		var err error
 		autoSaveMapping, _ := cmd.Flags().GetBool("save-mapping")
 
 		p := interactive.PrompterFromContext(ctx)
 
 		// Interactive source project selection
 		if srcProject == 0 {
-			srcProject, err = interactive.SelectProject(ctx, p, cli, "Select SOURCE project:")
+			srcProject, err = interactive.SelectProject(ctx, p, srcCli, "Select SOURCE project:")
 			if err != nil {
 				return err
 			}
@@ -59,7 +63,7 @@ Examples:
 
 		// Interactive source suite selection
 		if srcSuite == 0 {
-			srcSuite, err = interactive.SelectSuiteForProject(ctx, p, cli, srcProject, "Select SOURCE suite:")
+			srcSuite, err = interactive.SelectSuiteForProject(ctx, p, srcCli, srcProject, "Select SOURCE suite:")
 			if err != nil {
 				return err
 			}
@@ -67,7 +71,7 @@ Examples:
 
 		// Interactive destination project selection
 		if dstProject == 0 {
-			dstProject, err = interactive.SelectProject(ctx, p, cli, "Select DESTINATION project:")
+			dstProject, err = interactive.SelectProject(ctx, p, dstCli, "Select DESTINATION project:")
 			if err != nil {
 				return err
 			}
@@ -75,7 +79,7 @@ Examples:
 
 		// Interactive destination suite selection
 		if dstSuite == 0 {
-			dstSuite, err = interactive.SelectSuiteForProject(ctx, p, cli, dstProject, "Select DESTINATION suite:")
+			dstSuite, err = interactive.SelectSuiteForProject(ctx, p, dstCli, dstProject, "Select DESTINATION suite:")
 			if err != nil {
 				return err
 			}
@@ -85,11 +89,12 @@ Examples:
 		if err != nil {
 			return err
 		}
-		m, err := newMigration(cli, srcProject, srcSuite, dstProject, dstSuite, compareField, logDir)
+		m, err := newMigration(dstCli, srcProject, srcSuite, dstProject, dstSuite, compareField, logDir)
 		if err != nil {
 			return err
 		}
 		defer m.Close()
+		m.SetSourceClient(srcCli)
 
 		op := newSyncOperation("Sync sections", quiet)
 defer op.Finish()
@@ -128,6 +133,10 @@ defer op.Finish()
 
 		// Step 3) Handle dry-run
 		if dryRun {
+			if len(sourceSections) > 0 {
+				ui.Info(os.Stdout, "Section tree (+ create, = reuse existing):")
+				fmt.Fprint(os.Stdout, migration.FormatSectionTree(sourceSections, filtered))
+			}
 			ui.Info(os.Stdout, "Dry-run: import skipped")
 			return nil
 		}
@@ -162,7 +171,7 @@ defer op.Finish()
 		// Step 5) Save mapping if requested
 		if autoSaveMapping {
 			_ = m.ExportMapping(logDir)
-		} else if len(m.Mapping()) > 0 {
+		} else if m.SectionMapping().Count > 0 {
 			ok, err := p.Confirm("Save mapping?", false)
 			if err == nil && ok {
 				_ = m.ExportMapping(logDir)
@@ -35,8 +35,11 @@ Examples:
 `,
 
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cli := getClientInterface(cmd)
 		ctx := cmd.Context()
+		srcCli, dstCli, err := resolveSyncClients(cmd)
+		if err != nil {
+			return err
+		}
 
 		srcProject, _ := cmd.Flags().GetInt64("src-project")
 		srcSuite, _ := cmd.Flags().GetInt64("src-suite")
@@ -47,13 +50,16 @@ Examples:
 		quiet, _ := cmd.Flags().GetBool("quiet")
 		autoSaveMapping, _ := cmd.Flags().GetBool("save-mapping")
 		autoSaveFiltered, _ := cmd.Flags().GetBool("save-filtered")
+		matching, err := syncMatchOptions(cmd)
+		if err != nil {
+			return err
+		}
 
 		p := interactive.PrompterFromContext(ctx)
-		var err error
 
 		// Interactive source project selection
 		if srcProject == 0 {
-			srcProject, err = interactive.SelectProject(ctx, p, cli, "Select SOURCE project (copy shared steps from):")
+			srcProject, err = interactive.SelectProject(ctx, p, srcCli, "Select SOURCE project (copy shared steps from):")
 			if err != nil {
 				return err
 			}
@@ -67,7 +73,7 @@ Examples:
 				return err
 			}
 			if specifySuite {
-				srcSuite, err = interactive.SelectSuiteForProject(ctx, p, cli, srcProject, "Select SOURCE suite:")
+				srcSuite, err = interactive.SelectSuiteForProject(ctx, p, srcCli, srcProject, "Select SOURCE suite:")
 				if err != nil {
 					return err
 				}
@@ -76,7 +82,7 @@ Examples:
 
 		// Interactive destination project selection
 		if dstProject == 0 {
-			dstProject, err = interactive.SelectProject(ctx, p, cli, "Select DESTINATION project (copy shared steps to):")
+			dstProject, err = interactive.SelectProject(ctx, p, dstCli, "Select DESTINATION project (copy shared steps to):")
 			if err != nil {
 				return err
 			}
@@ -88,11 +94,13 @@ Examples:
 			return err
 		}
 		// Step 1) Initialize migration object (logging, client, parameters)
-		m, err := newMigration(cli, srcProject, srcSuite, dstProject, 0, compareField, logDir)
+		m, err := newMigration(dstCli, srcProject, srcSuite, dstProject, 0, compareField, logDir)
 		if err != nil {
 			return err
 		}
 		defer m.Close()
+		m.SetSourceClient(srcCli)
+		m.SetMatching(matching)
 
 		op := newSyncOperation("Sync shared steps", quiet)
 defer op.Finish()
@@ -35,8 +35,11 @@ Flags:
 	--save-mapping   Save mapping
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cli := getClientInterface(cmd)
 		ctx := cmd.Context()
+		srcCli, dstCli, err := resolveSyncClients(cmd)
+		if err != nil {
+			return err
+		}
 
 		srcProject, _ := cmd.Flags().GetInt64("src-project")
 		dstProject, _ := cmd.Flags().GetInt64("dst-project")
@@ -56,11 +59,12 @@ Flags:
 		if err != nil {
 			return err
 		}
-		m, err := newMigration(cli, srcProject, 0, dstProject, 0, compareField, logDir)
+		m, err := newMigration(dstCli, srcProject, 0, dstProject, 0, compareField, logDir)
 		if err != nil {
 			return err
 		}
 		defer m.Close()
+		m.SetSourceClient(srcCli)
 
 		op := newSyncOperation("Sync suites", quiet)
 defer op.Finish()
@@ -130,7 +134,7 @@ defer op.Finish()
 		// Step 4) Save mapping if requested
 		if autoSaveMapping {
 			_ = m.ExportMapping(logDir)
-		} else if len(m.Mapping()) > 0 {
+		} else if m.SuiteMapping().Count > 0 {
 			ok, err := p.Confirm("Save mapping?", false)
 			if err == nil && ok {
 				_ = m.ExportMapping(logDir)
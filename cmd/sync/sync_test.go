@@ -340,3 +340,63 @@ func TestSetGetClientForTests_NilAccessorInitially(t *testing.T) {
 	SetGetClientForTests(fn)
 	assert.NotNil(t, clientAccessor)
 }
+
+// ==================== Tests for resolveSyncClients ====================
+
+func newProfileFlagsCmd(t *testing.T, base client.ClientInterface) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.Flags().String("src-profile", "", "")
+	cmd.Flags().String("dst-profile", "", "")
+	cmd.SetContext(context.Background())
+	SetTestClient(cmd, base)
+	return cmd
+}
+
+func TestResolveSyncClients_NoProfiles_SameClient(t *testing.T) {
+	base := &client.MockClient{}
+	cmd := newProfileFlagsCmd(t, base)
+
+	src, dst, err := resolveSyncClients(cmd)
+	assert.NoError(t, err)
+	assert.Same(t, base, src)
+	assert.Same(t, base, dst)
+}
+
+func TestResolveSyncClients_WithProfiles(t *testing.T) {
+	base := &client.MockClient{}
+	staging := &client.MockClient{}
+	old := profileClientFn
+	t.Cleanup(func() { profileClientFn = old })
+
+	SetProfileClientFunc(func(name string) (client.ClientInterface, error) {
+		if name == "staging" {
+			return staging, nil
+		}
+		return nil, assert.AnError
+	})
+
+	cmd := newProfileFlagsCmd(t, base)
+	assert.NoError(t, cmd.Flags().Set("src-profile", "staging"))
+
+	src, dst, err := resolveSyncClients(cmd)
+	assert.NoError(t, err)
+	assert.Same(t, staging, src)
+	assert.Same(t, base, dst)
+
+	assert.NoError(t, cmd.Flags().Set("dst-profile", "unknown"))
+	_, _, err = resolveSyncClients(cmd)
+	assert.ErrorContains(t, err, "destination profile")
+}
+
+func TestResolveSyncClients_NoFactory(t *testing.T) {
+	old := profileClientFn
+	t.Cleanup(func() { profileClientFn = old })
+	profileClientFn = nil
+
+	cmd := newProfileFlagsCmd(t, &client.MockClient{})
+	assert.NoError(t, cmd.Flags().Set("dst-profile", "prod"))
+
+	_, _, err := resolveSyncClients(cmd)
+	assert.Error(t, err)
+}
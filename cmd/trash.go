@@ -0,0 +1,375 @@
+// cmd/trash.go
+// Recycle bin of deleted objects: gotr trash list|show|restore|purge
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/flags"
+	"github.com/Korrnals/gotr/internal/output"
+	"github.com/Korrnals/gotr/internal/trash"
+	"github.com/Korrnals/gotr/internal/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// trashCmd is the parent "trash" command.
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "Restore objects removed with gotr delete",
+	Long: `Before 'gotr delete' and 'gotr cases bulk delete' remove anything, they
+save the full subtree of the object to ~/.gotr/trash/<timestamp>/:
+
+  case, cases  the cases with their steps and referenced shared steps, and
+               their tests and results in the runs of their suite
+  section      the section, its subsections and their cases, with the same
+               run history
+  suite        the suite, its sections and cases, and the runs created from
+               it (test plan runs included) with their tests and results
+  run          the run with its tests and results
+  project      all of the above for the whole project, plus its milestones
+               and shared steps
+
+'gotr trash restore' recreates the objects in their original place. TestRail
+assigns new IDs, so restore prints the new→old ID map. Results are added in
+their original order under your user. The results of restored cases go back
+to their runs when those are still open; closed runs and runs of test plans
+cannot take them, which the delete warns about. Runs of test plans are
+restored as standalone runs. Result custom fields, case labels and test plans
+are not kept. Use --no-trash on the delete commands to skip the copy.
+
+Examples:
+	gotr trash list
+	gotr trash show 20261017-141503
+	gotr trash restore 20261017-141503
+	gotr trash purge --older-than 30d`,
+}
+
+// trashListCmd lists the trash entries.
+var trashListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List deleted objects kept in the trash",
+	Args:  cobra.NoArgs,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Override parent PersistentPreRunE (no-op)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := trash.Dir()
+		if err != nil {
+			return err
+		}
+		list, err := trash.List(root)
+		if err != nil {
+			return err
+		}
+		if len(list) == 0 {
+			ui.Infof(cmd.OutOrStdout(), "The trash is empty")
+			return nil
+		}
+		return printTrashList(cmd.OutOrStdout(), list)
+	},
+}
+
+// trashShowCmd prints one trash entry.
+var trashShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show what a trash entry contains",
+	Args:  cobra.ExactArgs(1),
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Override parent PersistentPreRunE (no-op)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := trash.Dir()
+		if err != nil {
+			return err
+		}
+		entry, err := trash.Load(root, args[0])
+		if err != nil {
+			return err
+		}
+		if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			return enc.Encode(struct {
+				Manifest trash.Manifest `json:"manifest"`
+				Content  trash.Content  `json:"content"`
+			}{entry.Manifest, entry.Content})
+		}
+		return printTrashEntry(cmd.OutOrStdout(), entry)
+	},
+}
+
+// trashRestoreCmd recreates the objects of a trash entry.
+var trashRestoreCmd = &cobra.Command{
+	Use:   "restore <id>",
+	Short: "Recreate the objects of a trash entry",
+	Long: `Recreates the deleted objects of a trash entry through the API and prints
+the new→old ID map. The map is also kept in the entry ('gotr trash show').
+
+Objects go back to their original place: cases to their section, sections
+to their parent section and suite, suites and runs to their project. That
+place must still exist, unless it is part of the entry (e.g. the sections
+of a restored suite).
+
+An entry is restored once; --force restores it again (creating a second
+copy) or into a server other than the one it was deleted from.`,
+	Example: `  # Preview, then restore
+  gotr trash restore 20261017-141503 --dry-run
+  gotr trash restore 20261017-141503`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTrashRestore,
+}
+
+// trashPurgeCmd removes trash entries for good.
+var trashPurgeCmd = &cobra.Command{
+	Use:   "purge [id...]",
+	Short: "Remove trash entries permanently",
+	Example: `  # Remove one entry
+  gotr trash purge 20261017-141503
+
+  # Remove entries older than 30 days, or all of them
+  gotr trash purge --older-than 30d
+  gotr trash purge --all`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Override parent PersistentPreRunE (no-op)
+	},
+	RunE: runTrashPurge,
+}
+
+func runTrashRestore(cmd *cobra.Command, args []string) error {
+	root, err := trash.Dir()
+	if err != nil {
+		return err
+	}
+	entry, err := trash.Load(root, args[0])
+	if err != nil {
+		return err
+	}
+	m := entry.Manifest
+
+	force, _ := cmd.Flags().GetBool("force")
+	if m.Restored != nil && !force {
+		return fmt.Errorf("trash entry %s was already restored on %s: use --force to restore it again",
+			m.ID, m.Restored.At.Local().Format("2006-01-02 15:04:05"))
+	}
+	if baseURL := viper.GetString("base_url"); m.BaseURL != "" && baseURL != m.BaseURL && !force {
+		return fmt.Errorf("trash entry %s was deleted from %s, not %s: use --force to restore it here",
+			m.ID, m.BaseURL, baseURL)
+	}
+
+	if isDryRun, _ := cmd.Flags().GetBool("dry-run"); isDryRun {
+		dr := output.NewDryRunPrinter("trash restore")
+		dr.PrintSimple(fmt.Sprintf("Restore %s %s", m.Kind, trashObjectIDs(m.ObjectIDs)),
+			fmt.Sprintf("%q: %s", m.Title, trashCounts(m.Counts)))
+		return nil
+	}
+
+	cli := GetClient(cmd)
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	var skipped []string
+	idMap, err := ui.RunWithStatus(cmd.Context(), ui.StatusConfig{
+		Title:  fmt.Sprintf("Restoring %s %q", m.Kind, m.Title),
+		Writer: os.Stderr,
+		Quiet:  quiet,
+	}, func(ctx context.Context) (idMap []trash.Mapping, err error) {
+		idMap, skipped, err = trash.Restore(ctx, cli, entry)
+		return idMap, err
+	})
+	for _, s := range skipped {
+		ui.Warningf(os.Stderr, "%s", s)
+	}
+	if len(idMap) > 0 {
+		if perr := printIDMap(cmd.OutOrStdout(), idMap); perr != nil && err == nil {
+			err = perr
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("restore of %s failed (objects above were created): %w", m.ID, err)
+	}
+
+	entry.Manifest.Restored = &trash.Restored{At: time.Now().UTC(), IDMap: idMap}
+	if err := entry.SaveManifest(root); err != nil {
+		return fmt.Errorf("restored, but failed to update trash entry %s: %w", m.ID, err)
+	}
+	if !quiet {
+		ui.Successf(os.Stderr, "Restored %s %q (%d objects)", m.Kind, m.Title, len(idMap))
+	}
+	return nil
+}
+
+func runTrashPurge(cmd *cobra.Command, args []string) error {
+	all, _ := cmd.Flags().GetBool("all")
+	olderThan, _ := cmd.Flags().GetString("older-than")
+	if len(args) == 0 && !all && olderThan == "" {
+		return fmt.Errorf("specify entry IDs, --older-than or --all")
+	}
+	if len(args) > 0 && (all || olderThan != "") {
+		return fmt.Errorf("entry IDs cannot be combined with --older-than or --all")
+	}
+	before, err := flags.ParseTime(olderThan)
+	if err != nil {
+		return fmt.Errorf("--older-than: %w", err)
+	}
+
+	root, err := trash.Dir()
+	if err != nil {
+		return err
+	}
+	ids := args
+	if len(ids) == 0 {
+		list, err := trash.List(root)
+		if err != nil {
+			return err
+		}
+		for _, m := range list {
+			if all || m.CreatedAt.Before(before) {
+				ids = append(ids, m.ID)
+			}
+		}
+	}
+
+	for _, id := range ids {
+		if err := trash.Remove(root, id); err != nil {
+			return err
+		}
+	}
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	if !quiet {
+		ui.Successf(cmd.OutOrStdout(), "Purged %d trash entries", len(ids))
+	}
+	return nil
+}
+
+// captureForDelete returns the capture of a 'gotr delete' endpoint, or nil
+// for endpoints without one (shared-step).
+func captureForDelete(cli client.ClientInterface, endpoint string, id int64) func(context.Context) (*trash.Entry, error) {
+	switch endpoint {
+	case "project":
+		return func(ctx context.Context) (*trash.Entry, error) { return trash.CaptureProject(ctx, cli, id) }
+	case "suite":
+		return func(ctx context.Context) (*trash.Entry, error) { return trash.CaptureSuite(ctx, cli, id) }
+	case "section":
+		return func(ctx context.Context) (*trash.Entry, error) { return trash.CaptureSection(ctx, cli, id) }
+	case "case":
+		return func(ctx context.Context) (*trash.Entry, error) { return trash.CaptureCases(ctx, cli, id) }
+	case "run":
+		return func(ctx context.Context) (*trash.Entry, error) { return trash.CaptureRun(ctx, cli, id) }
+	}
+	return nil
+}
+
+func printTrashList(w io.Writer, list []trash.Manifest) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tDELETED\tKIND\tOBJECTS\tTITLE\tCONTENTS\tRESTORED")
+	for _, m := range list {
+		restored := "-"
+		if m.Restored != nil {
+			restored = m.Restored.At.Local().Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", m.ID, m.CreatedAt.Local().Format("2006-01-02 15:04"),
+			m.Kind, trashObjectIDs(m.ObjectIDs), m.Title, trashCounts(m.Counts), restored)
+	}
+	return tw.Flush()
+}
+
+func printTrashEntry(w io.Writer, e *trash.Entry) error {
+	m := e.Manifest
+	fmt.Fprintf(w, "ID:       %s\n", m.ID)
+	fmt.Fprintf(w, "Deleted:  %s\n", m.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	if m.BaseURL != "" {
+		fmt.Fprintf(w, "Server:   %s\n", m.BaseURL)
+	}
+	fmt.Fprintf(w, "Object:   %s %s %q\n", m.Kind, trashObjectIDs(m.ObjectIDs), m.Title)
+	if m.ProjectID != 0 {
+		fmt.Fprintf(w, "Project:  %d\n", m.ProjectID)
+	}
+	if m.SuiteID != 0 {
+		fmt.Fprintf(w, "Suite:    %d\n", m.SuiteID)
+	}
+	fmt.Fprintf(w, "Contents: %s\n", trashCounts(m.Counts))
+
+	if len(e.Content.Cases) > 0 {
+		fmt.Fprintln(w)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "CASE\tSECTION\tSTEPS\tTITLE")
+		for _, c := range e.Content.Cases {
+			fmt.Fprintf(tw, "C%d\t%d\t%d\t%s\n", c.ID, c.SectionID, len(c.CustomStepsSeparated), c.Title)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if m.Restored != nil {
+		fmt.Fprintf(w, "\nRestored on %s:\n", m.Restored.At.Local().Format("2006-01-02 15:04:05"))
+		return printIDMap(w, m.Restored.IDMap)
+	}
+	return nil
+}
+
+// printIDMap prints the new→old ID map of a restore.
+func printIDMap(w io.Writer, idMap []trash.Mapping) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TYPE\tNEW ID\tOLD ID")
+	for _, m := range idMap {
+		fmt.Fprintf(tw, "%s\t%d\t%d\n", m.Type, m.NewID, m.OldID)
+	}
+	return tw.Flush()
+}
+
+func trashObjectIDs(ids []int64) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = strconv.FormatInt(id, 10)
+	}
+	return strings.Join(s, ",")
+}
+
+// trashCounts describes the contents of an entry, e.g. "1 section, 12 cases".
+func trashCounts(c trash.Counts) string {
+	var parts []string
+	add := func(n int, name string) {
+		if n == 1 {
+			name = strings.TrimSuffix(name, "s")
+		}
+		if n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, name))
+		}
+	}
+	add(c.Milestones, "milestones")
+	add(c.SharedSteps, "shared steps")
+	add(c.Suites, "suites")
+	add(c.Sections, "sections")
+	add(c.Cases, "cases")
+	add(c.Runs, "runs")
+	add(c.Tests, "tests")
+	add(c.Results, "results")
+	add(c.HistoryRuns, "history runs")
+	if len(parts) == 0 {
+		return "empty"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func registerTrashCmd() {
+	rootCmd.AddCommand(trashCmd)
+	trashCmd.AddCommand(trashListCmd)
+	trashCmd.AddCommand(trashShowCmd)
+	trashCmd.AddCommand(trashRestoreCmd)
+	trashCmd.AddCommand(trashPurgeCmd)
+
+	trashShowCmd.Flags().Bool("json", false, "Print the manifest and the saved objects as JSON")
+	trashRestoreCmd.Flags().Bool("dry-run", false, "Show what would be restored without making changes")
+	trashRestoreCmd.Flags().Bool("force", false, "Restore an entry again, or into another server")
+	trashPurgeCmd.Flags().Bool("all", false, "Remove all entries")
+	trashPurgeCmd.Flags().String("older-than", "", "Remove entries deleted before this date or age (e.g. 30d)")
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/Korrnals/gotr/internal/trash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useTempTrash points the trash at a temporary directory for the test.
+func useTempTrash(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	original := trash.Dir
+	trash.Dir = func() (string, error) { return root, nil }
+	t.Cleanup(func() { trash.Dir = original })
+	return root
+}
+
+// runServer serves run 50 with one failed and one passed test.
+func runServer() *client.MockClient {
+	return &client.MockClient{
+		GetRunFunc: func(ctx context.Context, runID int64) (*data.Run, error) {
+			return &data.Run{ID: runID, Name: "Nightly", ProjectID: 30, SuiteID: 5}, nil
+		},
+		GetTestsFunc: func(ctx context.Context, runID int64, filters map[string]string) ([]data.Test, error) {
+			if runID == 50 {
+				return []data.Test{{ID: 500, CaseID: 100}, {ID: 501, CaseID: 101}}, nil
+			}
+			return []data.Test{{ID: 900, CaseID: 100}, {ID: 901, CaseID: 101}}, nil
+		},
+		GetResultsForRunFunc: func(ctx context.Context, runID int64) (data.GetResultsResponse, error) {
+			return data.GetResultsResponse{{ID: 1, TestID: 500, StatusID: 5}, {ID: 2, TestID: 501, StatusID: 1}}, nil
+		},
+	}
+}
+
+func TestDelete_SavesToTrashAndRestores(t *testing.T) {
+	mock := runServer()
+	deleted := false
+	mock.DeleteRunFunc = func(ctx context.Context, runID int64) error {
+		deleted = true
+		return nil
+	}
+
+	cmd := setupDeleteTest(t, mock)
+	cmd.SetArgs([]string{"run", "50"})
+	require.NoError(t, cmd.Execute())
+	assert.True(t, deleted)
+
+	root, _ := trash.Dir()
+	list, err := trash.List(root)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	entry := list[0]
+	assert.Equal(t, trash.KindRun, entry.Kind)
+	assert.Equal(t, "Nightly", entry.Title)
+	assert.Equal(t, trash.Counts{Runs: 1, Tests: 2, Results: 2}, entry.Counts)
+
+	var out bytes.Buffer
+	trashListCmd.SetOut(&out)
+	require.NoError(t, trashListCmd.RunE(trashListCmd, nil))
+	assert.Contains(t, out.String(), entry.ID)
+	assert.Contains(t, out.String(), "1 run, 2 tests, 2 results")
+
+	var results []data.ResultForCaseEntry
+	mock.AddRunFunc = func(ctx context.Context, projectID int64, req *data.AddRunRequest) (*data.Run, error) {
+		assert.Equal(t, int64(30), projectID)
+		assert.Equal(t, []int64{100, 101}, req.CaseIDs)
+		return &data.Run{ID: 90}, nil
+	}
+	mock.AddResultsForCasesFunc = func(ctx context.Context, runID int64, req *data.AddResultsForCasesRequest) (data.GetResultsResponse, error) {
+		results = req.Results
+		return nil, nil
+	}
+
+	out.Reset()
+	trashRestoreCmd.SetOut(&out)
+	trashRestoreCmd.SetContext(context.WithValue(context.Background(), httpClientKey, client.ClientInterface(mock)))
+	require.NoError(t, trashRestoreCmd.RunE(trashRestoreCmd, []string{entry.ID}))
+	assert.Contains(t, out.String(), "TYPE  NEW ID  OLD ID")
+	assert.Regexp(t, `run\s+90\s+50`, out.String())
+	assert.Regexp(t, `test\s+901\s+501`, out.String())
+	assert.Len(t, results, 2)
+
+	// Restored once; a second restore needs --force.
+	err = trashRestoreCmd.RunE(trashRestoreCmd, []string{entry.ID})
+	assert.ErrorContains(t, err, "already restored")
+
+	out.Reset()
+	trashShowCmd.SetOut(&out)
+	require.NoError(t, trashShowCmd.RunE(trashShowCmd, []string{entry.ID}))
+	assert.Contains(t, out.String(), "Object:   run 50 \"Nightly\"")
+	assert.Contains(t, out.String(), "Restored on")
+}
+
+func TestDelete_TrashEntryDroppedWhenDeleteFails(t *testing.T) {
+	mock := runServer()
+	mock.DeleteRunFunc = func(ctx context.Context, runID int64) error {
+		return errors.New("forbidden")
+	}
+
+	cmd := setupDeleteTest(t, mock)
+	cmd.SetArgs([]string{"run", "50"})
+	assert.ErrorContains(t, cmd.Execute(), "forbidden")
+
+	root, _ := trash.Dir()
+	list, err := trash.List(root)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestDelete_CaptureErrorKeepsObject(t *testing.T) {
+	mock := runServer()
+	mock.GetResultsForRunFunc = func(ctx context.Context, runID int64) (data.GetResultsResponse, error) {
+		return nil, errors.New("timeout")
+	}
+	mock.DeleteRunFunc = func(ctx context.Context, runID int64) error {
+		t.Fatal("run deleted although it could not be saved")
+		return nil
+	}
+
+	cmd := setupDeleteTest(t, mock)
+	cmd.SetArgs([]string{"run", "50"})
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "--no-trash")
+
+	deleted := false
+	mock.DeleteRunFunc = func(ctx context.Context, runID int64) error {
+		deleted = true
+		return nil
+	}
+	cmd = setupDeleteTest(t, mock)
+	cmd.SetArgs([]string{"run", "50", "--no-trash"})
+	require.NoError(t, cmd.Execute())
+	assert.True(t, deleted)
+}
+
+func TestTrashPurge(t *testing.T) {
+	root := useTempTrash(t)
+	for _, id := range []int64{1, 2} {
+		e, err := trash.CaptureRun(context.Background(), runServer(), id)
+		require.NoError(t, err)
+		require.NoError(t, e.Save(root))
+	}
+	list, err := trash.List(root)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	t.Cleanup(func() {
+		_ = trashPurgeCmd.Flags().Set("all", "false")
+		_ = trashPurgeCmd.Flags().Set("older-than", "")
+	})
+	assert.ErrorContains(t, trashPurgeCmd.RunE(trashPurgeCmd, nil), "--older-than or --all")
+
+	require.NoError(t, trashPurgeCmd.Flags().Set("older-than", "1d"))
+	require.NoError(t, trashPurgeCmd.RunE(trashPurgeCmd, nil))
+	list, _ = trash.List(root)
+	assert.Len(t, list, 2, "entries are newer than a day")
+
+	require.NoError(t, trashPurgeCmd.Flags().Set("older-than", ""))
+	require.NoError(t, trashPurgeCmd.RunE(trashPurgeCmd, []string{list[0].ID}))
+	list, _ = trash.List(root)
+	assert.Len(t, list, 1)
+
+	require.NoError(t, trashPurgeCmd.Flags().Set("all", "true"))
+	require.NoError(t, trashPurgeCmd.RunE(trashPurgeCmd, nil))
+	list, _ = trash.List(root)
+	assert.Empty(t, list)
+
+	require.NoError(t, trashPurgeCmd.Flags().Set("all", "false"))
+	assert.ErrorIs(t, trashPurgeCmd.RunE(trashPurgeCmd, []string{"missing"}), trash.ErrNotFound)
+}
@@ -0,0 +1,200 @@
+// internal/cache/cache.go
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// entry is the on-disk envelope of one cached response.
+type entry struct {
+	Key       string          `json:"key"`
+	StoredAt  time.Time       `json:"stored_at"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Store reads and writes cache entries for one scope under a root directory.
+type Store struct {
+	dir string // root/scope
+	now func() time.Time
+}
+
+// New returns a store for the scope under root; the directory is created on
+// the first write.
+func New(root, scope string) *Store {
+	return &Store{dir: filepath.Join(root, scope), now: time.Now}
+}
+
+// Scope derives a directory name from the server URL and user, so that
+// responses from different servers or accounts are never mixed.
+func Scope(baseURL, username string) string {
+	sum := sha256.Sum256([]byte(strings.TrimRight(strings.ToLower(baseURL), "/") + "\n" + strings.ToLower(username)))
+	return hex.EncodeToString(sum[:8])
+}
+
+func (s *Store) file(resource, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, resource, hex.EncodeToString(sum[:12])+".json")
+}
+
+// Get decodes a fresh entry into v. It reports false for missing, expired
+// or unreadable entries.
+func (s *Store) Get(resource, key string, v any) bool {
+	content, err := os.ReadFile(s.file(resource, key))
+	if err != nil {
+		return false
+	}
+	var e entry
+	if err := json.Unmarshal(content, &e); err != nil || e.Key != key {
+		return false
+	}
+	if !s.now().Before(e.ExpiresAt) {
+		return false
+	}
+	return json.Unmarshal(e.Data, v) == nil
+}
+
+// Put stores v for ttl. The file is written to a temporary name and renamed,
+// so concurrent readers never see a partial entry.
+func (s *Store) Put(resource, key string, ttl time.Duration, v any) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	content, err := json.Marshal(entry{Key: key, StoredAt: now, ExpiresAt: now.Add(ttl), Data: payload})
+	if err != nil {
+		return err
+	}
+
+	file := s.file(resource, key)
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// Invalidate drops every entry of a resource in this scope.
+func (s *Store) Invalidate(resource string) error {
+	err := os.RemoveAll(filepath.Join(s.dir, resource))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// ResourceStats summarizes the entries of one resource across all scopes.
+type ResourceStats struct {
+	Resource string    `json:"resource"`
+	Entries  int       `json:"entries"`
+	Expired  int       `json:"expired"`
+	Bytes    int64     `json:"bytes"`
+	Oldest   time.Time `json:"oldest"`
+}
+
+// Stats walks root and returns per-resource statistics sorted by name.
+// A missing root yields no statistics.
+func Stats(root string, now time.Time) ([]ResourceStats, error) {
+	byResource := make(map[string]*ResourceStats)
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		resource := filepath.Base(filepath.Dir(path))
+		st, ok := byResource[resource]
+		if !ok {
+			st = &ResourceStats{Resource: resource}
+			byResource[resource] = st
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		st.Entries++
+		st.Bytes += info.Size()
+
+		var e entry
+		content, err := os.ReadFile(path)
+		if err != nil || json.Unmarshal(content, &e) != nil || !now.Before(e.ExpiresAt) {
+			st.Expired++
+			return nil
+		}
+		if st.Oldest.IsZero() || e.StoredAt.Before(st.Oldest) {
+			st.Oldest = e.StoredAt
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache %s: %w", root, err)
+	}
+
+	res := make([]ResourceStats, 0, len(byResource))
+	for _, st := range byResource {
+		res = append(res, *st)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Resource < res[j].Resource })
+	return res, nil
+}
+
+// Clear removes cached entries under root: all of them, or only those of the
+// given resources. It returns the number of entries removed.
+func Clear(root string, resources ...string) (int, error) {
+	only := make(map[string]bool, len(resources))
+	for _, r := range resources {
+		only[r] = true
+	}
+
+	removed := 0
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+		if len(only) > 0 && !only[filepath.Base(filepath.Dir(path))] {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		removed++
+		return nil
+	})
+	if err != nil {
+		return removed, fmt.Errorf("failed to clear cache %s: %w", root, err)
+	}
+	return removed, nil
+}
@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_PutGet(t *testing.T) {
+	root := t.TempDir()
+	s := New(root, Scope("https://tr.example.com/", "qa@example.com"))
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	var got []string
+	assert.False(t, s.Get("projects", "all", &got), "empty store")
+
+	require.NoError(t, s.Put("projects", "all", time.Hour, []string{"a", "b"}))
+	assert.True(t, s.Get("projects", "all", &got))
+	assert.Equal(t, []string{"a", "b"}, got)
+	assert.False(t, s.Get("projects", "other", &got))
+
+	now = now.Add(time.Hour)
+	assert.False(t, s.Get("projects", "all", &got), "expired entry")
+}
+
+func TestStore_Invalidate(t *testing.T) {
+	root := t.TempDir()
+	s := New(root, "scope")
+	require.NoError(t, s.Put("suites", "project:1", time.Hour, 1))
+	require.NoError(t, s.Put("statuses", "all", time.Hour, 2))
+
+	require.NoError(t, s.Invalidate("suites"))
+	require.NoError(t, s.Invalidate("missing"))
+
+	var v int
+	assert.False(t, s.Get("suites", "project:1", &v))
+	assert.True(t, s.Get("statuses", "all", &v))
+}
+
+func TestScope(t *testing.T) {
+	assert.Equal(t, Scope("https://tr.example.com/", "QA@example.com"), Scope("https://TR.example.com", "qa@example.com"))
+	assert.NotEqual(t, Scope("https://tr.example.com", "a"), Scope("https://tr.example.com", "b"))
+}
+
+func TestStatsAndClear(t *testing.T) {
+	root := t.TempDir()
+	now := time.Now()
+	a := New(root, "a")
+	b := New(root, "b")
+	require.NoError(t, a.Put("projects", "all", time.Hour, 1))
+	require.NoError(t, b.Put("projects", "all", -time.Second, 1))
+	require.NoError(t, b.Put("statuses", "all", time.Hour, 1))
+
+	stats, err := Stats(root, now)
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, "projects", stats[0].Resource)
+	assert.Equal(t, 2, stats[0].Entries)
+	assert.Equal(t, 1, stats[0].Expired)
+	assert.Positive(t, stats[0].Bytes)
+
+	removed, err := Clear(root, "statuses")
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	removed, err = Clear(root)
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+
+	stats, err = Stats(root+"/missing", now)
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+}
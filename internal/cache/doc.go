@@ -0,0 +1,12 @@
+// Package cache is the on-disk response cache kept in ~/.gotr/cache.
+//
+// A [Store] keeps one JSON file per cached response, grouped by a scope (one
+// per TestRail server and user) and by resource (projects, suites, statuses,
+// ...). Every entry carries its own expiry, and a whole resource is dropped
+// at once with [Store.Invalidate] when it is written to. Cache failures are
+// never fatal: a broken or expired entry is simply a miss.
+//
+// The client-side decorator that decides what to cache lives in
+// internal/client ([client.CachedClient]); the `gotr cache` command uses
+// [Stats] and [Clear].
+package cache
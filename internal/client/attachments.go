@@ -46,6 +46,26 @@ func (c *HTTPClient) GetAttachment(ctx context.Context, attachmentID int64) (*da
 	return &attachment, nil
 }
 
+// DownloadAttachment streams the binary content of an attachment to w and
+// returns the number of bytes written. Unlike GetAttachment, the body is
+// not decoded: get_attachment returns the file itself.
+// https://support.testrail.com/hc/en-us/articles/7077990441108-Attachments#getattachment
+func (c *HTTPClient) DownloadAttachment(ctx context.Context, attachmentID int64, w io.Writer) (int64, error) {
+	endpoint := fmt.Sprintf("get_attachment/%d", attachmentID)
+	resp, err := c.Get(ctx, endpoint, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error downloading attachment %d: %w", attachmentID, err)
+	}
+	defer resp.Body.Close()
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("error reading attachment %d: %w", attachmentID, err)
+	}
+
+	return n, nil
+}
+
 // GetAttachmentsForCase fetches attachments for a test case.
 // https://support.testrail.com/hc/en-us/articles/7077990441108-Attachments#getattachmentsforcase
 func (c *HTTPClient) GetAttachmentsForCase(ctx context.Context, caseID int64) (data.GetAttachmentsResponse, error) {
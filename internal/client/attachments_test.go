@@ -694,3 +694,32 @@ func TestDeleteAttachment_RequestError(t *testing.T) {
 		t.Fatal("DeleteAttachment should return request error when server is closed")
 	}
 }
+
+func TestDownloadAttachment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.String(), "get_attachment/7") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("\x89PNG binary"))
+	}))
+	defer server.Close()
+
+	c, _ := NewClient(server.URL, "test", "test", false)
+
+	var buf strings.Builder
+	n, err := c.DownloadAttachment(context.Background(), 7, &buf)
+	if err != nil {
+		t.Fatalf("DownloadAttachment() error: %v", err)
+	}
+	if n != int64(len("\x89PNG binary")) || buf.String() != "\x89PNG binary" {
+		t.Fatalf("DownloadAttachment() = %d, %q", n, buf.String())
+	}
+
+	if _, err := c.DownloadAttachment(context.Background(), 8, &buf); err == nil {
+		t.Fatal("expected DownloadAttachment() non-OK error")
+	}
+}
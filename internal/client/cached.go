@@ -0,0 +1,329 @@
+// internal/client/cached.go
+// On-disk caching of reference data around ClientInterface.
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/cache"
+	"github.com/Korrnals/gotr/internal/debug"
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// Cached resources. Each is invalidated as a whole on any write to it.
+const (
+	CacheProjects   = "projects"
+	CacheSuites     = "suites"
+	CacheStatuses   = "statuses"
+	CachePriorities = "priorities"
+	CacheCaseFields = "case_fields"
+	CacheCaseTypes  = "case_types"
+	CacheTemplates  = "templates"
+	CacheCases      = "cases"
+)
+
+// DefaultCacheTTLs are the per-resource lifetimes used unless overridden.
+// Case lists are large and change often, so they are not cached by default.
+var DefaultCacheTTLs = map[string]time.Duration{
+	CacheProjects:   time.Hour,
+	CacheSuites:     15 * time.Minute,
+	CacheStatuses:   24 * time.Hour,
+	CachePriorities: 24 * time.Hour,
+	CacheCaseFields: 24 * time.Hour,
+	CacheCaseTypes:  24 * time.Hour,
+	CacheTemplates:  24 * time.Hour,
+	CacheCases:      0,
+}
+
+// CachedClient serves reference data (projects, suites, statuses,
+// priorities, case fields and types, templates and optionally case lists)
+// from an on-disk cache and forwards everything else to the wrapped client.
+// Writes through the client invalidate the affected resource.
+type CachedClient struct {
+	ClientInterface
+	store   *cache.Store
+	ttl     map[string]time.Duration
+	refresh bool
+}
+
+// CacheOption configures a CachedClient.
+type CacheOption func(*CachedClient)
+
+// WithCacheTTL sets the lifetime of a resource; zero or negative disables
+// caching of that resource.
+func WithCacheTTL(resource string, ttl time.Duration) CacheOption {
+	return func(c *CachedClient) {
+		c.ttl[resource] = ttl
+	}
+}
+
+// WithCacheRefresh ignores cached entries and stores fresh responses.
+func WithCacheRefresh(refresh bool) CacheOption {
+	return func(c *CachedClient) {
+		c.refresh = refresh
+	}
+}
+
+// NewCachedClient wraps inner with the cache store.
+func NewCachedClient(inner ClientInterface, store *cache.Store, opts ...CacheOption) *CachedClient {
+	c := &CachedClient{ClientInterface: inner, store: store, ttl: make(map[string]time.Duration, len(DefaultCacheTTLs))}
+	for k, v := range DefaultCacheTTLs {
+		c.ttl[k] = v
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Unwrap returns the wrapped client.
+func (c *CachedClient) Unwrap() ClientInterface {
+	return c.ClientInterface
+}
+
+// FreshReads returns cli with cached reads forced to the server, as with
+// --refresh: responses are still stored and writes still invalidate. Other
+// clients are returned as is.
+func FreshReads(cli ClientInterface) ClientInterface {
+	c, ok := cli.(*CachedClient)
+	if !ok || c.refresh {
+		return cli
+	}
+	fresh := *c
+	fresh.refresh = true
+	return &fresh
+}
+
+// cachedCall returns the cached value of resource/key or fetches and stores it.
+// Cache errors never fail the call.
+func cachedCall[T any](c *CachedClient, resource, key string, fetch func() (T, error)) (T, error) {
+	ttl := c.ttl[resource]
+	if ttl <= 0 {
+		return fetch()
+	}
+	var v T
+	if !c.refresh && c.store.Get(resource, key, &v) {
+		debug.DebugPrint("{cache} - hit %s %s", resource, key)
+		return v, nil
+	}
+	v, err := fetch()
+	if err != nil {
+		return v, err
+	}
+	if err := c.store.Put(resource, key, ttl, v); err != nil {
+		debug.DebugPrint("{cache} - failed to store %s %s: %v", resource, key, err)
+	}
+	return v, nil
+}
+
+// invalidate drops the resources after a successful write.
+func (c *CachedClient) invalidate(err error, resources ...string) {
+	if err != nil {
+		return
+	}
+	for _, r := range resources {
+		if err := c.store.Invalidate(r); err != nil {
+			debug.DebugPrint("{cache} - failed to invalidate %s: %v", r, err)
+		}
+	}
+}
+
+// queryKey renders query parameters in key order.
+func queryKey(q map[string]string) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + "=" + q[k]
+	}
+	return strings.Join(parts, "&")
+}
+
+// --- cached reads ---
+
+func (c *CachedClient) GetProjects(ctx context.Context) (data.GetProjectsResponse, error) {
+	return cachedCall(c, CacheProjects, "all", func() (data.GetProjectsResponse, error) {
+		return c.ClientInterface.GetProjects(ctx)
+	})
+}
+
+func (c *CachedClient) GetProject(ctx context.Context, projectID int64) (*data.GetProjectResponse, error) {
+	return cachedCall(c, CacheProjects, fmt.Sprintf("project:%d", projectID), func() (*data.GetProjectResponse, error) {
+		return c.ClientInterface.GetProject(ctx, projectID)
+	})
+}
+
+func (c *CachedClient) GetSuites(ctx context.Context, projectID int64) (data.GetSuitesResponse, error) {
+	return cachedCall(c, CacheSuites, fmt.Sprintf("project:%d", projectID), func() (data.GetSuitesResponse, error) {
+		return c.ClientInterface.GetSuites(ctx, projectID)
+	})
+}
+
+func (c *CachedClient) GetStatuses(ctx context.Context) (data.GetStatusesResponse, error) {
+	return cachedCall(c, CacheStatuses, "all", func() (data.GetStatusesResponse, error) {
+		return c.ClientInterface.GetStatuses(ctx)
+	})
+}
+
+func (c *CachedClient) GetPriorities(ctx context.Context) (data.GetPrioritiesResponse, error) {
+	return cachedCall(c, CachePriorities, "all", func() (data.GetPrioritiesResponse, error) {
+		return c.ClientInterface.GetPriorities(ctx)
+	})
+}
+
+func (c *CachedClient) GetCaseFields(ctx context.Context) (data.GetCaseFieldsResponse, error) {
+	return cachedCall(c, CacheCaseFields, "all", func() (data.GetCaseFieldsResponse, error) {
+		return c.ClientInterface.GetCaseFields(ctx)
+	})
+}
+
+func (c *CachedClient) GetCaseTypes(ctx context.Context) (data.GetCaseTypesResponse, error) {
+	return cachedCall(c, CacheCaseTypes, "all", func() (data.GetCaseTypesResponse, error) {
+		return c.ClientInterface.GetCaseTypes(ctx)
+	})
+}
+
+func (c *CachedClient) GetTemplates(ctx context.Context, projectID int64) (data.GetTemplatesResponse, error) {
+	return cachedCall(c, CacheTemplates, fmt.Sprintf("project:%d", projectID), func() (data.GetTemplatesResponse, error) {
+		return c.ClientInterface.GetTemplates(ctx, projectID)
+	})
+}
+
+func (c *CachedClient) GetCases(ctx context.Context, projectID, suiteID, sectionID int64) (data.GetCasesResponse, error) {
+	key := fmt.Sprintf("project:%d:suite:%d:section:%d", projectID, suiteID, sectionID)
+	return cachedCall(c, CacheCases, key, func() (data.GetCasesResponse, error) {
+		return c.ClientInterface.GetCases(ctx, projectID, suiteID, sectionID)
+	})
+}
+
+func (c *CachedClient) GetCasesFiltered(ctx context.Context, projectID int64, filter data.CaseFilter) (data.GetCasesResponse, error) {
+	key := fmt.Sprintf("project:%d?%s", projectID, queryKey(filter.Query()))
+	return cachedCall(c, CacheCases, key, func() (data.GetCasesResponse, error) {
+		return c.ClientInterface.GetCasesFiltered(ctx, projectID, filter)
+	})
+}
+
+// --- invalidating writes ---
+
+func (c *CachedClient) AddProject(ctx context.Context, req *data.AddProjectRequest) (*data.GetProjectResponse, error) {
+	resp, err := c.ClientInterface.AddProject(ctx, req)
+	c.invalidate(err, CacheProjects)
+	return resp, err
+}
+
+func (c *CachedClient) UpdateProject(ctx context.Context, projectID int64, req *data.UpdateProjectRequest) (*data.GetProjectResponse, error) {
+	resp, err := c.ClientInterface.UpdateProject(ctx, projectID, req)
+	c.invalidate(err, CacheProjects)
+	return resp, err
+}
+
+func (c *CachedClient) DeleteProject(ctx context.Context, projectID int64) error {
+	err := c.ClientInterface.DeleteProject(ctx, projectID)
+	c.invalidate(err, CacheProjects, CacheSuites, CacheTemplates, CacheCases)
+	return err
+}
+
+func (c *CachedClient) AddSuite(ctx context.Context, projectID int64, req *data.AddSuiteRequest) (*data.Suite, error) {
+	resp, err := c.ClientInterface.AddSuite(ctx, projectID, req)
+	c.invalidate(err, CacheSuites)
+	return resp, err
+}
+
+func (c *CachedClient) UpdateSuite(ctx context.Context, suiteID int64, req *data.UpdateSuiteRequest) (*data.Suite, error) {
+	resp, err := c.ClientInterface.UpdateSuite(ctx, suiteID, req)
+	c.invalidate(err, CacheSuites)
+	return resp, err
+}
+
+func (c *CachedClient) DeleteSuite(ctx context.Context, suiteID int64) error {
+	err := c.ClientInterface.DeleteSuite(ctx, suiteID)
+	c.invalidate(err, CacheSuites, CacheCases)
+	return err
+}
+
+func (c *CachedClient) AddCaseField(ctx context.Context, req *data.AddCaseFieldRequest) (*data.AddCaseFieldResponse, error) {
+	resp, err := c.ClientInterface.AddCaseField(ctx, req)
+	c.invalidate(err, CacheCaseFields)
+	return resp, err
+}
+
+func (c *CachedClient) AddCase(ctx context.Context, sectionID int64, req *data.AddCaseRequest) (*data.Case, error) {
+	resp, err := c.ClientInterface.AddCase(ctx, sectionID, req)
+	c.invalidate(err, CacheCases)
+	return resp, err
+}
+
+func (c *CachedClient) UpdateCase(ctx context.Context, caseID int64, req *data.UpdateCaseRequest) (*data.Case, error) {
+	resp, err := c.ClientInterface.UpdateCase(ctx, caseID, req)
+	c.invalidate(err, CacheCases)
+	return resp, err
+}
+
+func (c *CachedClient) DeleteCase(ctx context.Context, caseID int64) error {
+	err := c.ClientInterface.DeleteCase(ctx, caseID)
+	c.invalidate(err, CacheCases)
+	return err
+}
+
+func (c *CachedClient) UpdateCases(ctx context.Context, suiteID int64, req *data.UpdateCasesRequest) (*data.GetCasesResponse, error) {
+	resp, err := c.ClientInterface.UpdateCases(ctx, suiteID, req)
+	c.invalidate(err, CacheCases)
+	return resp, err
+}
+
+func (c *CachedClient) DeleteCases(ctx context.Context, suiteID int64, req *data.DeleteCasesRequest) error {
+	err := c.ClientInterface.DeleteCases(ctx, suiteID, req)
+	c.invalidate(err, CacheCases)
+	return err
+}
+
+func (c *CachedClient) CopyCasesToSection(ctx context.Context, sectionID int64, req *data.CopyCasesRequest) error {
+	err := c.ClientInterface.CopyCasesToSection(ctx, sectionID, req)
+	c.invalidate(err, CacheCases)
+	return err
+}
+
+func (c *CachedClient) MoveCasesToSection(ctx context.Context, sectionID int64, req *data.MoveCasesRequest) error {
+	err := c.ClientInterface.MoveCasesToSection(ctx, sectionID, req)
+	c.invalidate(err, CacheCases)
+	return err
+}
+
+func (c *CachedClient) DeleteSection(ctx context.Context, sectionID int64) error {
+	err := c.ClientInterface.DeleteSection(ctx, sectionID)
+	c.invalidate(err, CacheCases)
+	return err
+}
+
+func (c *CachedClient) AddBDD(ctx context.Context, caseID int64, content string) (*data.BDD, error) {
+	resp, err := c.ClientInterface.AddBDD(ctx, caseID, content)
+	c.invalidate(err, CacheCases)
+	return resp, err
+}
+
+// Shared steps are returned inside the steps of the cases that use them.
+func (c *CachedClient) UpdateSharedStep(ctx context.Context, stepID int64, req *data.UpdateSharedStepRequest) (*data.SharedStep, error) {
+	resp, err := c.ClientInterface.UpdateSharedStep(ctx, stepID, req)
+	c.invalidate(err, CacheCases)
+	return resp, err
+}
+
+func (c *CachedClient) DeleteSharedStep(ctx context.Context, stepID int64, keepInCases int) error {
+	err := c.ClientInterface.DeleteSharedStep(ctx, stepID, keepInCases)
+	c.invalidate(err, CacheCases)
+	return err
+}
+
+// Labels are returned with the cases they are attached to.
+func (c *CachedClient) UpdateLabel(ctx context.Context, labelID int64, req data.UpdateLabelRequest) (*data.Label, error) {
+	resp, err := c.ClientInterface.UpdateLabel(ctx, labelID, req)
+	c.invalidate(err, CacheCases)
+	return resp, err
+}
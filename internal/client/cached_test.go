@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/cache"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedClient_ServesFromCache(t *testing.T) {
+	calls := 0
+	inner := &MockClient{
+		GetStatusesFunc: func(ctx context.Context) (data.GetStatusesResponse, error) {
+			calls++
+			return data.GetStatusesResponse{{ID: 1, Name: "passed"}}, nil
+		},
+	}
+	store := cache.New(t.TempDir(), "scope")
+	ctx := context.Background()
+
+	c := NewCachedClient(inner, store)
+	for i := 0; i < 2; i++ {
+		statuses, err := c.GetStatuses(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "passed", statuses[0].Name)
+	}
+	assert.Equal(t, 1, calls)
+
+	// A new process reuses the entry; --refresh bypasses it.
+	_, err := NewCachedClient(inner, store).GetStatuses(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	_, err = NewCachedClient(inner, store, WithCacheRefresh(true)).GetStatuses(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestFreshReads(t *testing.T) {
+	calls := 0
+	inner := &MockClient{
+		GetSuitesFunc: func(ctx context.Context, projectID int64) (data.GetSuitesResponse, error) {
+			calls++
+			return data.GetSuitesResponse{{ID: int64(calls)}}, nil
+		},
+	}
+	c := NewCachedClient(inner, cache.New(t.TempDir(), "scope"))
+	ctx := context.Background()
+
+	_, _ = c.GetSuites(ctx, 1)
+	suites, err := FreshReads(c).GetSuites(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, int64(2), suites[0].ID)
+
+	// The fresh response replaced the cached one; c itself still caches.
+	suites, err = c.GetSuites(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, int64(2), suites[0].ID)
+
+	assert.Same(t, inner, FreshReads(inner))
+}
+
+func TestCachedClient_WriteInvalidates(t *testing.T) {
+	calls := 0
+	inner := &MockClient{
+		GetSuitesFunc: func(ctx context.Context, projectID int64) (data.GetSuitesResponse, error) {
+			calls++
+			return data.GetSuitesResponse{{ID: int64(calls)}}, nil
+		},
+		AddSuiteFunc: func(ctx context.Context, projectID int64, req *data.AddSuiteRequest) (*data.Suite, error) {
+			return &data.Suite{ID: 9}, nil
+		},
+	}
+	c := NewCachedClient(inner, cache.New(t.TempDir(), "scope"))
+	ctx := context.Background()
+
+	_, _ = c.GetSuites(ctx, 1)
+	_, _ = c.GetSuites(ctx, 1)
+	assert.Equal(t, 1, calls)
+
+	_, err := c.AddSuite(ctx, 1, &data.AddSuiteRequest{Name: "New"})
+	require.NoError(t, err)
+	suites, err := c.GetSuites(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, int64(2), suites[0].ID)
+}
+
+func TestCachedClient_SharedStepAndLabelWritesInvalidateCases(t *testing.T) {
+	writes := map[string]func(ctx context.Context, c *CachedClient) error{
+		"update shared step": func(ctx context.Context, c *CachedClient) error {
+			_, err := c.UpdateSharedStep(ctx, 5, &data.UpdateSharedStepRequest{})
+			return err
+		},
+		"delete shared step": func(ctx context.Context, c *CachedClient) error {
+			return c.DeleteSharedStep(ctx, 5, 0)
+		},
+		"update label": func(ctx context.Context, c *CachedClient) error {
+			_, err := c.UpdateLabel(ctx, 7, data.UpdateLabelRequest{})
+			return err
+		},
+	}
+	for name, write := range writes {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			inner := &MockClient{
+				GetCasesFunc: func(ctx context.Context, projectID, suiteID, sectionID int64) (data.GetCasesResponse, error) {
+					calls++
+					return data.GetCasesResponse{{ID: int64(calls)}}, nil
+				},
+				UpdateSharedStepFunc: func(ctx context.Context, stepID int64, req *data.UpdateSharedStepRequest) (*data.SharedStep, error) {
+					return &data.SharedStep{ID: stepID}, nil
+				},
+				DeleteSharedStepFunc: func(ctx context.Context, stepID int64, keepInCases int) error {
+					return nil
+				},
+				UpdateLabelFunc: func(ctx context.Context, labelID int64, req data.UpdateLabelRequest) (*data.Label, error) {
+					return &data.Label{ID: labelID}, nil
+				},
+			}
+			c := NewCachedClient(inner, cache.New(t.TempDir(), "scope"), WithCacheTTL(CacheCases, time.Hour))
+			ctx := context.Background()
+
+			_, _ = c.GetCases(ctx, 1, 2, 0)
+			_, _ = c.GetCases(ctx, 1, 2, 0)
+			require.Equal(t, 1, calls)
+
+			require.NoError(t, write(ctx, c))
+			cases, err := c.GetCases(ctx, 1, 2, 0)
+			require.NoError(t, err)
+			assert.Equal(t, 2, calls)
+			assert.Equal(t, int64(2), cases[0].ID)
+		})
+	}
+}
+
+func TestCachedClient_ErrorsAndDisabledResources(t *testing.T) {
+	casesCalls, projectsCalls := 0, 0
+	inner := &MockClient{
+		GetCasesFunc: func(ctx context.Context, projectID, suiteID, sectionID int64) (data.GetCasesResponse, error) {
+			casesCalls++
+			return data.GetCasesResponse{{ID: 1}}, nil
+		},
+		GetProjectsFunc: func(ctx context.Context) (data.GetProjectsResponse, error) {
+			projectsCalls++
+			return nil, errors.New("boom")
+		},
+	}
+	c := NewCachedClient(inner, cache.New(t.TempDir(), "scope"))
+	ctx := context.Background()
+
+	// Cases are not cached by default.
+	_, _ = c.GetCases(ctx, 1, 2, 0)
+	_, _ = c.GetCases(ctx, 1, 2, 0)
+	assert.Equal(t, 2, casesCalls)
+
+	// Errors are not cached.
+	_, err := c.GetProjects(ctx)
+	assert.Error(t, err)
+	_, err = c.GetProjects(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, 2, projectsCalls)
+
+	c = NewCachedClient(inner, cache.New(t.TempDir(), "scope"), WithCacheTTL(CacheCases, time.Minute))
+	_, _ = c.GetCasesFiltered(ctx, 1, data.CaseFilter{SuiteID: 2})
+	_, _ = c.GetCasesFiltered(ctx, 1, data.CaseFilter{SuiteID: 2})
+	assert.Equal(t, 3, casesCalls)
+	assert.Same(t, inner, c.Unwrap())
+}
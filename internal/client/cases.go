@@ -66,6 +66,17 @@ func (c *HTTPClient) GetCases(ctx context.Context, projectID, suiteID, sectionID
 	return c.GetCasesWithProgress(ctx, projectID, suiteID, sectionID, nil)
 }
 
+// GetCasesFiltered fetches all cases of a project that match the filter;
+// the filter is applied by TestRail, so only matching cases are transferred.
+func (c *HTTPClient) GetCasesFiltered(ctx context.Context, projectID int64, filter data.CaseFilter) (data.GetCasesResponse, error) {
+	endpoint := fmt.Sprintf("get_cases/%d", projectID)
+	cases, err := fetchAllPages[data.Case](ctx, c, endpoint, filter.Query(), "cases")
+	if err != nil {
+		return nil, fmt.Errorf("request error GetCases for project %d: %w", projectID, err)
+	}
+	return data.GetCasesResponse(cases), nil
+}
+
 // GetCasesPage fetches a single page of cases at the given offset/limit.
 // Useful for targeted retries of failed pages without re-fetching everything.
 func (c *HTTPClient) GetCasesPage(ctx context.Context, projectID, suiteID int64, offset, limit int) (data.GetCasesResponse, error) {
@@ -376,6 +387,12 @@ func (c *HTTPClient) DiffCasesData(ctx context.Context, pid1, pid2 int64, field
 		}
 	}
 
+	return DiffCases(cases1, cases2, field), nil
+}
+
+// DiffCases compares two case lists by case ID: cases present on one side
+// only, and cases whose value of field differs.
+func DiffCases(cases1, cases2 data.GetCasesResponse, field string) *data.DiffCasesResponse {
 	firstCases := make(map[int64]data.Case)
 	for _, c := range cases1 {
 		firstCases[c.ID] = c
@@ -415,7 +432,7 @@ func (c *HTTPClient) DiffCasesData(ctx context.Context, pid1, pid2 int64, field
 		}
 	}
 
-	return diffResult, nil
+	return diffResult
 }
 
 // casesEqualByField compares two cases by the specified field.
@@ -489,9 +506,7 @@ func (c *HTTPClient) GetCasesParallelCtx(
 		return data.GetCasesResponse{}, &concurrency.ExecutionResult{Cases: []data.Case{}}, nil
 	}
 
-	if config == nil {
-		config = concurrency.DefaultControllerConfig()
-	}
+	config = c.parallelConfig(config)
 
 	// Create tasks from suiteIDs
 	tasks := make([]concurrency.SuiteTask, len(suiteIDs))
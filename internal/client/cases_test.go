@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Korrnals/gotr/internal/concurrency"
 	"github.com/Korrnals/gotr/internal/models/data"
@@ -1241,3 +1242,29 @@ func TestCaseDeleteCopyMove_EdgeSuccessBranches(t *testing.T) {
 	assert.NoError(t, client.CopyCasesToSection(ctx, 303, &data.CopyCasesRequest{CaseIDs: []int64{7}}))
 	assert.NoError(t, client.MoveCasesToSection(ctx, 304, &data.MoveCasesRequest{CaseIDs: []int64{8}, SuiteID: 90}))
 }
+
+func TestGetCasesFiltered(t *testing.T) {
+	client, server := mockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.String(), "get_cases/10")
+		q := r.URL.Query()
+		assert.Equal(t, "3", q.Get("suite_id"))
+		assert.Equal(t, "1,2", q.Get("priority_id"))
+		assert.Equal(t, "1700000000", q.Get("updated_after"))
+		assert.Equal(t, "TR-1", q.Get("refs"))
+		assert.False(t, q.Has("refs_filter"))
+		assert.Equal(t, "0", q.Get("offset"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"offset":0,"limit":250,"size":1,"cases":[{"id":7,"title":"t"}]}`))
+	})
+	defer server.Close()
+
+	cases, err := client.GetCasesFiltered(context.Background(), 10, data.CaseFilter{
+		SuiteID:      3,
+		PriorityIDs:  []int64{1, 2},
+		UpdatedAfter: time.Unix(1700000000, 0),
+		Refs:         "TR-1",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, cases, 1)
+	assert.Equal(t, int64(7), cases[0].ID)
+}
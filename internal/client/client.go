@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Korrnals/gotr/internal/concurrent"
 	"github.com/Korrnals/gotr/internal/debug"
 )
 
@@ -21,6 +22,7 @@ const apiPrefix = "index.php?/api/v2/"
 type HTTPClient struct {
 	client  *http.Client
 	baseURL *url.URL
+	limiter *concurrent.AdaptiveRateLimiter
 }
 
 // options holds internal client configuration (unexported).
@@ -28,6 +30,11 @@ type options struct {
 	insecure            bool
 	timeout             time.Duration
 	tlsHandshakeTimeout time.Duration
+	maxRetries          int
+	retryInitialDelay   time.Duration
+	retryMaxDelay       time.Duration
+	retryPost           bool
+	limiter             *concurrent.AdaptiveRateLimiter
 }
 
 // authTransport automatically injects Basic Auth into every outgoing request.
@@ -57,6 +64,10 @@ var defaultOptions = options{
 	insecure:            false,
 	timeout:             30 * time.Second,
 	tlsHandshakeTimeout: 10 * time.Second,
+	maxRetries:          0,
+	retryInitialDelay:   defaultRetryInitialDelay,
+	retryMaxDelay:       defaultRetryMaxDelay,
+	retryPost:           false,
 }
 
 // ClientOption is a functional option for configuring NewClient.
@@ -76,6 +87,48 @@ func WithTimeout(duration time.Duration) ClientOption {
 	}
 }
 
+// WithMaxRetries sets how many times a request is re-sent after a
+// 429/502/503/504 response. Zero (the default) disables retries.
+func WithMaxRetries(n int) ClientOption {
+	return func(o *options) {
+		o.maxRetries = n
+	}
+}
+
+// WithRetryBackoff sets the initial and maximum delay between retries.
+// The delay doubles after each attempt; a Retry-After header takes precedence
+// but is still capped by maxDelay. Non-positive values keep the defaults.
+func WithRetryBackoff(initialDelay, maxDelay time.Duration) ClientOption {
+	return func(o *options) {
+		if initialDelay > 0 {
+			o.retryInitialDelay = initialDelay
+		}
+		if maxDelay > 0 {
+			o.retryMaxDelay = maxDelay
+		}
+	}
+}
+
+// WithRetryPost enables retries for POST requests.
+// Off by default: add_* endpoints are not idempotent and a retried POST
+// may create duplicates if the server processed the first attempt.
+func WithRetryPost(enabled bool) ClientOption {
+	return func(o *options) {
+		o.retryPost = enabled
+	}
+}
+
+// WithRateLimit limits all requests of the client to requestsPerMinute using
+// an adaptive token bucket. Zero or negative disables rate limiting.
+func WithRateLimit(requestsPerMinute int) ClientOption {
+	return func(o *options) {
+		o.limiter = nil
+		if requestsPerMinute > 0 {
+			o.limiter = concurrent.NewAdaptiveRateLimiter(requestsPerMinute)
+		}
+	}
+}
+
 // NewClient creates a new HTTP client for TestRail API calls with the given options.
 func NewClient(baseURLStr, username, apiKey string, debugMode bool, opts ...ClientOption) (*HTTPClient, error) {
 	// Parse URL; we rebuild with scheme+host only
@@ -120,11 +173,34 @@ func NewClient(baseURLStr, username, apiKey string, debugMode bool, opts ...Clie
 		MaxConnsPerHost:     0, // unlimited — concurrency governed by parallel settings
 		IdleConnTimeout:     90 * time.Second,
 	}
+	// Rate limiting sits closest to the wire so every retry attempt
+	// also takes a token from the shared bucket
+	var base http.RoundTripper = transport
+	if cfg.limiter != nil {
+		base = rateLimitTransport{
+			base:    base,
+			limiter: cfg.limiter,
+		}
+	}
+
+	// Retry transient failures below the auth layer so every attempt
+	// carries the same credentials and headers
+	if cfg.maxRetries > 0 {
+		base = retryTransport{
+			base:         base,
+			maxRetries:   cfg.maxRetries,
+			initialDelay: cfg.retryInitialDelay,
+			maxDelay:     max(cfg.retryMaxDelay, cfg.retryInitialDelay),
+			retryPost:    cfg.retryPost,
+			sleep:        sleepCtx,
+		}
+	}
+
 	// Wrap transport with Basic Auth injector
 	auth := authTransport{
 		username: username,
 		apiKey:   apiKey,
-		base:     transport,
+		base:     base,
 	}
 
 	return &HTTPClient{
@@ -133,6 +209,7 @@ func NewClient(baseURLStr, username, apiKey string, debugMode bool, opts ...Clie
 			Timeout:   cfg.timeout,
 		},
 		baseURL: cleanURL,
+		limiter: cfg.limiter,
 	}, nil
 }
 
@@ -12,6 +12,9 @@
 // composite [ClientInterface], which aggregates resource-specific interfaces
 // (ProjectsAPI, CasesAPI, RunsAPI, etc.) to support seamless mocking in tests.
 //
+// [CachedClient] decorates any ClientInterface with the on-disk response
+// cache for reference data (see package internal/cache).
+//
 // Commands interact through the [Accessor], which retrieves the client from
 // context and decouples client access from Cobra dependencies.
 //
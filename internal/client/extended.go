@@ -8,6 +8,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 
 	"github.com/Korrnals/gotr/internal/models/data"
 )
@@ -324,7 +325,8 @@ func (c *HTTPClient) DeleteVariable(ctx context.Context, variableID int64) error
 
 // ==================== BDDs API ====================
 
-// GetBDD fetches the BDD scenario for a case.
+// GetBDD fetches the BDD scenario for a case. get_bdd returns the .feature
+// text itself, not JSON, so the body becomes the Content as is.
 func (c *HTTPClient) GetBDD(ctx context.Context, caseID int64) (*data.BDD, error) {
 	endpoint := fmt.Sprintf("get_bdd/%d", caseID)
 	resp, err := c.Get(ctx, endpoint, nil)
@@ -333,11 +335,11 @@ func (c *HTTPClient) GetBDD(ctx context.Context, caseID int64) (*data.BDD, error
 	}
 	defer resp.Body.Close()
 
-	var bdd data.BDD
-	if err := json.NewDecoder(resp.Body).Decode(&bdd); err != nil {
-		return nil, fmt.Errorf("error decoding BDD: %w", err)
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("error reading BDD for case %d: %w", caseID, err)
 	}
-	return &bdd, nil
+	return &data.BDD{CaseID: caseID, Content: string(body)}, nil
 }
 
 // AddBDD adds a BDD scenario to a case.
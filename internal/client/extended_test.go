@@ -281,7 +281,7 @@ func TestHTTPExtendedDatasetsVariablesBDDLabels(t *testing.T) {
 			_, _ = w.Write([]byte(`{}`))
 		case strings.Contains(r.URL.String(), "get_bdd/10"):
 			w.WriteHeader(http.StatusOK)
-			_ = json.NewEncoder(w).Encode(data.BDD{ID: 1, CaseID: 10, Content: "Given"})
+			_, _ = w.Write([]byte("Feature: Login\n"))
 		case strings.Contains(r.URL.String(), "add_bdd/10"):
 			w.WriteHeader(http.StatusOK)
 			_ = json.NewEncoder(w).Encode(data.BDD{ID: 2, CaseID: 10, Content: "When"})
@@ -353,7 +353,7 @@ func TestHTTPExtendedDatasetsVariablesBDDLabels(t *testing.T) {
 	}
 
 	bdd, err := c.GetBDD(ctx, 10)
-	if err != nil || bdd.CaseID != 10 {
+	if err != nil || bdd.CaseID != 10 || bdd.Content != "Feature: Login\n" {
 		t.Fatalf("GetBDD() failed: %v, %+v", err, bdd)
 	}
 
@@ -713,9 +713,10 @@ func TestHTTPExtended_ErrorBranches(t *testing.T) {
 		})
 		defer s.Close()
 
-		_, err := c.GetBDD(context.Background(), 10)
-		if err == nil || !strings.Contains(err.Error(), "error decoding BDD") {
-			t.Fatalf("expected get bdd decode error, got: %v", err)
+		// get_bdd returns the feature text, which is never decoded
+		bdd, err := c.GetBDD(context.Background(), 10)
+		if err != nil || bdd.Content != `{"broken":` {
+			t.Fatalf("expected get bdd raw content, got: %v, %+v", err, bdd)
 		}
 
 		_, err = c.GetBDD(context.Background(), 11)
@@ -858,7 +859,7 @@ func TestHTTPExtended_SuccessCases(t *testing.T) {
 			_, _ = w.Write([]byte(`{}`))
 		case strings.Contains(r.URL.String(), "get_bdd/1"):
 			w.WriteHeader(http.StatusOK)
-			_ = json.NewEncoder(w).Encode(data.BDD{ID: 1, CaseID: 1, Content: "Given a user"})
+			_, _ = w.Write([]byte("Given a user"))
 		case strings.Contains(r.URL.String(), "add_bdd/1"):
 			w.WriteHeader(http.StatusOK)
 			_ = json.NewEncoder(w).Encode(data.BDD{ID: 2, CaseID: 1, Content: "When user clicks"})
@@ -978,8 +979,8 @@ func TestHTTPExtended_SuccessCases(t *testing.T) {
 
 	// BDD test
 	bdd, err := c.GetBDD(ctx, 1)
-	if err != nil || bdd.ID != 1 {
-		t.Errorf("GetBDD failed: %v, id=%d", err, bdd.ID)
+	if err != nil || bdd.Content != "Given a user" {
+		t.Errorf("GetBDD failed: %v, content=%q", err, bdd.Content)
 	}
 
 	bdd, err = c.AddBDD(ctx, 1, "When user clicks")
@@ -1222,7 +1223,7 @@ func TestParallel_ExtendedAPI_AllMethods(t *testing.T) {
 			switch {
 			case strings.Contains(r.URL.String(), "get_bdd/5"):
 				w.WriteHeader(http.StatusOK)
-				_ = json.NewEncoder(w).Encode(data.BDD{ID: 1, CaseID: 5, Content: "Given"})
+				_, _ = w.Write([]byte("Given"))
 			case strings.Contains(r.URL.String(), "add_bdd/5"):
 				w.WriteHeader(http.StatusOK)
 				_ = json.NewEncoder(w).Encode(data.BDD{ID: 99, CaseID: 5, Content: "When"})
@@ -1235,7 +1236,7 @@ func TestParallel_ExtendedAPI_AllMethods(t *testing.T) {
 		c, _ := NewClient(server.URL, "t", "t", false)
 		ctx := context.Background()
 
-		if b, err := c.GetBDD(ctx, 5); err != nil || b.ID != 1 {
+		if b, err := c.GetBDD(ctx, 5); err != nil || b.Content != "Given" {
 			t.Errorf("GetBDD: %v", err)
 		}
 		if b, err := c.AddBDD(ctx, 5, "When"); err != nil || b.ID != 99 {
@@ -1482,7 +1483,7 @@ func TestExtended_BDD_ErrorCases(t *testing.T) {
 		}
 		if strings.Contains(r.URL.String(), "get_bdd/1") {
 			w.WriteHeader(http.StatusOK)
-			_ = json.NewEncoder(w).Encode(data.BDD{ID: 1, CaseID: 1, Content: "BDD"})
+			_, _ = w.Write([]byte("Feature: BDD"))
 			return
 		}
 		w.WriteHeader(http.StatusNotFound)
@@ -1501,8 +1502,8 @@ func TestExtended_BDD_ErrorCases(t *testing.T) {
 	if err != nil {
 		t.Fatalf("GetBDD should work for valid ID: %v", err)
 	}
-	if bdd.ID != 1 {
-		t.Errorf("GetBDD returned wrong ID: %d", bdd.ID)
+	if bdd.CaseID != 1 || bdd.Content != "Feature: BDD" {
+		t.Errorf("GetBDD returned wrong BDD: %+v", bdd)
 	}
 }
 
@@ -4,6 +4,7 @@ package client
 
 import (
 	"context"
+	"io"
 
 	"github.com/Korrnals/gotr/internal/concurrency"
 	"github.com/Korrnals/gotr/internal/models/data"
@@ -27,6 +28,7 @@ type ProjectsAPI interface {
 // CasesAPI — test case operations.
 type CasesAPI interface {
 	GetCases(ctx context.Context, projectID, suiteID, sectionID int64) (data.GetCasesResponse, error)
+	GetCasesFiltered(ctx context.Context, projectID int64, filter data.CaseFilter) (data.GetCasesResponse, error)
 	GetCasesPage(ctx context.Context, projectID, suiteID int64, offset, limit int) (data.GetCasesResponse, error)
 	GetCase(ctx context.Context, caseID int64) (*data.Case, error)
 	AddCase(ctx context.Context, sectionID int64, req *data.AddCaseRequest) (*data.Case, error)
@@ -89,6 +91,7 @@ type SharedStepsAPI interface {
 // RunsAPI — test run operations.
 type RunsAPI interface {
 	GetRuns(ctx context.Context, projectID int64) (data.GetRunsResponse, error)
+	GetRunsFiltered(ctx context.Context, projectID int64, filter data.RunFilter) (data.GetRunsResponse, error)
 	GetRun(ctx context.Context, runID int64) (*data.Run, error)
 	AddRun(ctx context.Context, projectID int64, req *data.AddRunRequest) (*data.Run, error)
 	UpdateRun(ctx context.Context, runID int64, req *data.UpdateRunRequest) (*data.Run, error)
@@ -100,6 +103,7 @@ type RunsAPI interface {
 type ResultsAPI interface {
 	GetResults(ctx context.Context, testID int64) (data.GetResultsResponse, error)
 	GetResultsForRun(ctx context.Context, runID int64) (data.GetResultsResponse, error)
+	GetResultsForRunFiltered(ctx context.Context, runID int64, filter data.ResultFilter) (data.GetResultsResponse, error)
 	GetResultsForCase(ctx context.Context, runID, caseID int64) (data.GetResultsResponse, error)
 	AddResult(ctx context.Context, testID int64, req *data.AddResultRequest) (*data.Result, error)
 	AddResultForCase(ctx context.Context, runID, caseID int64, req *data.AddResultRequest) (*data.Result, error)
@@ -127,6 +131,7 @@ type MilestonesAPI interface {
 type PlansAPI interface {
 	GetPlan(ctx context.Context, planID int64) (*data.Plan, error)
 	GetPlans(ctx context.Context, projectID int64) (data.GetPlansResponse, error)
+	GetPlansFiltered(ctx context.Context, projectID int64, filter data.PlanFilter) (data.GetPlansResponse, error)
 	AddPlan(ctx context.Context, projectID int64, req *data.AddPlanRequest) (*data.Plan, error)
 	UpdatePlan(ctx context.Context, planID int64, req *data.UpdatePlanRequest) (*data.Plan, error)
 	ClosePlan(ctx context.Context, planID int64) (*data.Plan, error)
@@ -144,6 +149,7 @@ type AttachmentsAPI interface {
 	AddAttachmentToResult(ctx context.Context, resultID int64, filePath string) (*data.AttachmentResponse, error)
 	AddAttachmentToRun(ctx context.Context, runID int64, filePath string) (*data.AttachmentResponse, error)
 	DeleteAttachment(ctx context.Context, attachmentID int64) error
+	DownloadAttachment(ctx context.Context, attachmentID int64, w io.Writer) (int64, error)
 	GetAttachment(ctx context.Context, attachmentID int64) (*data.Attachment, error)
 	GetAttachmentsForCase(ctx context.Context, caseID int64) (data.GetAttachmentsResponse, error)
 	GetAttachmentsForPlan(ctx context.Context, planID int64) (data.GetAttachmentsResponse, error)
@@ -183,6 +189,7 @@ type ReportsAPI interface {
 	GetCrossProjectReports(ctx context.Context) (data.GetReportsResponse, error)
 	RunReport(ctx context.Context, templateID int64) (*data.RunReportResponse, error)
 	RunCrossProjectReport(ctx context.Context, templateID int64) (*data.RunReportResponse, error)
+	DownloadReport(ctx context.Context, reportURL, mediaType string, w io.Writer) (int64, error)
 }
 
 // GroupsAPI — group operations.
@@ -4,6 +4,7 @@ package client
 
 import (
 	"context"
+	"io"
 
 	"github.com/Korrnals/gotr/internal/concurrency"
 	"github.com/Korrnals/gotr/internal/models/data"
@@ -20,6 +21,7 @@ type MockClient struct {
 
 	// CasesAPI
 	GetCasesFunc           func(ctx context.Context, projectID int64, suiteID int64, sectionID int64) (data.GetCasesResponse, error)
+	GetCasesFilteredFunc   func(ctx context.Context, projectID int64, filter data.CaseFilter) (data.GetCasesResponse, error)
 	GetCasesPageFunc       func(ctx context.Context, projectID int64, suiteID int64, offset int, limit int) (data.GetCasesResponse, error)
 	GetCaseFunc            func(ctx context.Context, caseID int64) (*data.Case, error)
 	AddCaseFunc            func(ctx context.Context, sectionID int64, req *data.AddCaseRequest) (*data.Case, error)
@@ -67,21 +69,23 @@ type MockClient struct {
 	GetSharedStepHistoryFunc func(ctx context.Context, stepID int64) (*data.GetSharedStepHistoryResponse, error)
 
 	// RunsAPI
-	GetRunsFunc   func(ctx context.Context, projectID int64) (data.GetRunsResponse, error)
-	GetRunFunc    func(ctx context.Context, runID int64) (*data.Run, error)
-	AddRunFunc    func(ctx context.Context, projectID int64, req *data.AddRunRequest) (*data.Run, error)
-	UpdateRunFunc func(ctx context.Context, runID int64, req *data.UpdateRunRequest) (*data.Run, error)
-	CloseRunFunc  func(ctx context.Context, runID int64) (*data.Run, error)
-	DeleteRunFunc func(ctx context.Context, runID int64) error
+	GetRunsFunc         func(ctx context.Context, projectID int64) (data.GetRunsResponse, error)
+	GetRunsFilteredFunc func(ctx context.Context, projectID int64, filter data.RunFilter) (data.GetRunsResponse, error)
+	GetRunFunc          func(ctx context.Context, runID int64) (*data.Run, error)
+	AddRunFunc          func(ctx context.Context, projectID int64, req *data.AddRunRequest) (*data.Run, error)
+	UpdateRunFunc       func(ctx context.Context, runID int64, req *data.UpdateRunRequest) (*data.Run, error)
+	CloseRunFunc        func(ctx context.Context, runID int64) (*data.Run, error)
+	DeleteRunFunc       func(ctx context.Context, runID int64) error
 
 	// ResultsAPI
-	GetResultsFunc         func(ctx context.Context, testID int64) (data.GetResultsResponse, error)
-	GetResultsForRunFunc   func(ctx context.Context, runID int64) (data.GetResultsResponse, error)
-	GetResultsForCaseFunc  func(ctx context.Context, runID, caseID int64) (data.GetResultsResponse, error)
-	AddResultFunc          func(ctx context.Context, testID int64, req *data.AddResultRequest) (*data.Result, error)
-	AddResultForCaseFunc   func(ctx context.Context, runID, caseID int64, req *data.AddResultRequest) (*data.Result, error)
-	AddResultsFunc         func(ctx context.Context, runID int64, req *data.AddResultsRequest) (data.GetResultsResponse, error)
-	AddResultsForCasesFunc func(ctx context.Context, runID int64, req *data.AddResultsForCasesRequest) (data.GetResultsResponse, error)
+	GetResultsFunc               func(ctx context.Context, testID int64) (data.GetResultsResponse, error)
+	GetResultsForRunFunc         func(ctx context.Context, runID int64) (data.GetResultsResponse, error)
+	GetResultsForRunFilteredFunc func(ctx context.Context, runID int64, filter data.ResultFilter) (data.GetResultsResponse, error)
+	GetResultsForCaseFunc        func(ctx context.Context, runID, caseID int64) (data.GetResultsResponse, error)
+	AddResultFunc                func(ctx context.Context, testID int64, req *data.AddResultRequest) (*data.Result, error)
+	AddResultForCaseFunc         func(ctx context.Context, runID, caseID int64, req *data.AddResultRequest) (*data.Result, error)
+	AddResultsFunc               func(ctx context.Context, runID int64, req *data.AddResultsRequest) (data.GetResultsResponse, error)
+	AddResultsForCasesFunc       func(ctx context.Context, runID int64, req *data.AddResultsForCasesRequest) (data.GetResultsResponse, error)
 
 	// TestsAPI
 	GetTestFunc    func(ctx context.Context, testID int64) (*data.Test, error)
@@ -96,15 +100,16 @@ type MockClient struct {
 	DeleteMilestoneFunc func(ctx context.Context, milestoneID int64) error
 
 	// PlansAPI
-	GetPlanFunc         func(ctx context.Context, planID int64) (*data.Plan, error)
-	GetPlansFunc        func(ctx context.Context, projectID int64) (data.GetPlansResponse, error)
-	AddPlanFunc         func(ctx context.Context, projectID int64, req *data.AddPlanRequest) (*data.Plan, error)
-	UpdatePlanFunc      func(ctx context.Context, planID int64, req *data.UpdatePlanRequest) (*data.Plan, error)
-	ClosePlanFunc       func(ctx context.Context, planID int64) (*data.Plan, error)
-	DeletePlanFunc      func(ctx context.Context, planID int64) error
-	AddPlanEntryFunc    func(ctx context.Context, planID int64, req *data.AddPlanEntryRequest) (*data.Plan, error)
-	UpdatePlanEntryFunc func(ctx context.Context, planID int64, entryID string, req *data.UpdatePlanEntryRequest) (*data.Plan, error)
-	DeletePlanEntryFunc func(ctx context.Context, planID int64, entryID string) error
+	GetPlanFunc          func(ctx context.Context, planID int64) (*data.Plan, error)
+	GetPlansFunc         func(ctx context.Context, projectID int64) (data.GetPlansResponse, error)
+	GetPlansFilteredFunc func(ctx context.Context, projectID int64, filter data.PlanFilter) (data.GetPlansResponse, error)
+	AddPlanFunc          func(ctx context.Context, projectID int64, req *data.AddPlanRequest) (*data.Plan, error)
+	UpdatePlanFunc       func(ctx context.Context, planID int64, req *data.UpdatePlanRequest) (*data.Plan, error)
+	ClosePlanFunc        func(ctx context.Context, planID int64) (*data.Plan, error)
+	DeletePlanFunc       func(ctx context.Context, planID int64) error
+	AddPlanEntryFunc     func(ctx context.Context, planID int64, req *data.AddPlanEntryRequest) (*data.Plan, error)
+	UpdatePlanEntryFunc  func(ctx context.Context, planID int64, entryID string, req *data.UpdatePlanEntryRequest) (*data.Plan, error)
+	DeletePlanEntryFunc  func(ctx context.Context, planID int64, entryID string) error
 
 	// AttachmentsAPI
 	AddAttachmentToCaseFunc        func(ctx context.Context, caseID int64, filePath string) (*data.AttachmentResponse, error)
@@ -113,11 +118,12 @@ type MockClient struct {
 	AddAttachmentToResultFunc      func(ctx context.Context, resultID int64, filePath string) (*data.AttachmentResponse, error)
 	AddAttachmentToRunFunc         func(ctx context.Context, runID int64, filePath string) (*data.AttachmentResponse, error)
 	DeleteAttachmentFunc           func(ctx context.Context, attachmentID int64) error
+	DownloadAttachmentFunc         func(ctx context.Context, attachmentID int64, w io.Writer) (int64, error)
 	GetAttachmentFunc              func(ctx context.Context, attachmentID int64) (*data.Attachment, error)
 	GetAttachmentsForCaseFunc      func(ctx context.Context, caseID int64) (data.GetAttachmentsResponse, error)
 	GetAttachmentsForPlanFunc      func(ctx context.Context, planID int64) (data.GetAttachmentsResponse, error)
 	GetAttachmentsForPlanEntryFunc func(ctx context.Context, planID int64, entryID string) (data.GetAttachmentsResponse, error)
-	GetAttachmentsForProjectFunc    func(ctx context.Context, projectID int64) (data.GetAttachmentsResponse, error)
+	GetAttachmentsForProjectFunc   func(ctx context.Context, projectID int64) (data.GetAttachmentsResponse, error)
 	GetAttachmentsForRunFunc       func(ctx context.Context, runID int64) (data.GetAttachmentsResponse, error)
 	GetAttachmentsForTestFunc      func(ctx context.Context, testID int64) (data.GetAttachmentsResponse, error)
 
@@ -146,6 +152,7 @@ type MockClient struct {
 	GetCrossProjectReportsFunc func(ctx context.Context) (data.GetReportsResponse, error)
 	RunReportFunc              func(ctx context.Context, templateID int64) (*data.RunReportResponse, error)
 	RunCrossProjectReportFunc  func(ctx context.Context, templateID int64) (*data.RunReportResponse, error)
+	DownloadReportFunc         func(ctx context.Context, reportURL, mediaType string, w io.Writer) (int64, error)
 
 	// ExtendedAPI - Groups
 	GetGroupsFunc   func(ctx context.Context, projectID int64) (data.GetGroupsResponse, error)
@@ -243,6 +250,15 @@ func (m *MockClient) GetCases(ctx context.Context, projectID, suiteID, sectionID
 	return nil, nil
 }
 
+// GetCasesFiltered calls the configured mock implementation when it is set,
+// falling back to GetCasesFunc with the suite and section of the filter.
+func (m *MockClient) GetCasesFiltered(ctx context.Context, projectID int64, filter data.CaseFilter) (data.GetCasesResponse, error) {
+	if m.GetCasesFilteredFunc != nil {
+		return m.GetCasesFilteredFunc(ctx, projectID, filter)
+	}
+	return m.GetCases(ctx, projectID, filter.SuiteID, filter.SectionID)
+}
+
 // GetCasesPage calls the configured mock implementation when it is set.
 func (m *MockClient) GetCasesPage(ctx context.Context, projectID, suiteID int64, offset, limit int) (data.GetCasesResponse, error) {
 	if m.GetCasesPageFunc != nil {
@@ -578,6 +594,15 @@ func (m *MockClient) GetRuns(ctx context.Context, projectID int64) (data.GetRuns
 	return nil, nil
 }
 
+// GetRunsFiltered calls the configured mock implementation when it is set,
+// falling back to GetRunsFunc.
+func (m *MockClient) GetRunsFiltered(ctx context.Context, projectID int64, filter data.RunFilter) (data.GetRunsResponse, error) {
+	if m.GetRunsFilteredFunc != nil {
+		return m.GetRunsFilteredFunc(ctx, projectID, filter)
+	}
+	return m.GetRuns(ctx, projectID)
+}
+
 // GetRun calls the configured mock implementation when it is set.
 func (m *MockClient) GetRun(ctx context.Context, runID int64) (*data.Run, error) {
 	if m.GetRunFunc != nil {
@@ -637,6 +662,15 @@ func (m *MockClient) GetResultsForRun(ctx context.Context, runID int64) (data.Ge
 	return nil, nil
 }
 
+// GetResultsForRunFiltered calls the configured mock implementation when it
+// is set, falling back to GetResultsForRunFunc.
+func (m *MockClient) GetResultsForRunFiltered(ctx context.Context, runID int64, filter data.ResultFilter) (data.GetResultsResponse, error) {
+	if m.GetResultsForRunFilteredFunc != nil {
+		return m.GetResultsForRunFilteredFunc(ctx, runID, filter)
+	}
+	return m.GetResultsForRun(ctx, runID)
+}
+
 // GetResultsForCase calls the configured mock implementation when it is set.
 func (m *MockClient) GetResultsForCase(ctx context.Context, runID, caseID int64) (data.GetResultsResponse, error) {
 	if m.GetResultsForCaseFunc != nil {
@@ -766,6 +800,15 @@ func (m *MockClient) GetPlans(ctx context.Context, projectID int64) (data.GetPla
 	return nil, nil
 }
 
+// GetPlansFiltered calls the configured mock implementation when it is set,
+// falling back to GetPlansFunc.
+func (m *MockClient) GetPlansFiltered(ctx context.Context, projectID int64, filter data.PlanFilter) (data.GetPlansResponse, error) {
+	if m.GetPlansFilteredFunc != nil {
+		return m.GetPlansFilteredFunc(ctx, projectID, filter)
+	}
+	return m.GetPlans(ctx, projectID)
+}
+
 // AddPlan calls the configured mock implementation when it is set.
 func (m *MockClient) AddPlan(ctx context.Context, projectID int64, req *data.AddPlanRequest) (*data.Plan, error) {
 	if m.AddPlanFunc != nil {
@@ -873,6 +916,14 @@ func (m *MockClient) DeleteAttachment(ctx context.Context, attachmentID int64) e
 	return nil
 }
 
+// DownloadAttachment calls the configured mock implementation when it is set.
+func (m *MockClient) DownloadAttachment(ctx context.Context, attachmentID int64, w io.Writer) (int64, error) {
+	if m.DownloadAttachmentFunc != nil {
+		return m.DownloadAttachmentFunc(ctx, attachmentID, w)
+	}
+	return 0, nil
+}
+
 // GetAttachment calls the configured mock implementation when it is set.
 func (m *MockClient) GetAttachment(ctx context.Context, attachmentID int64) (*data.Attachment, error) {
 	if m.GetAttachmentFunc != nil {
@@ -1098,6 +1149,14 @@ func (m *MockClient) RunCrossProjectReport(ctx context.Context, templateID int64
 	return nil, nil
 }
 
+// DownloadReport calls the configured mock implementation when it is set.
+func (m *MockClient) DownloadReport(ctx context.Context, reportURL, mediaType string, w io.Writer) (int64, error) {
+	if m.DownloadReportFunc != nil {
+		return m.DownloadReportFunc(ctx, reportURL, mediaType, w)
+	}
+	return 0, nil
+}
+
 // ---------------------------------------------------------------------------
 // ExtendedAPI - Groups
 // ---------------------------------------------------------------------------
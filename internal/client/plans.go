@@ -38,6 +38,16 @@ func (c *HTTPClient) GetPlans(ctx context.Context, projectID int64) (data.GetPla
 	return data.GetPlansResponse(plans), nil
 }
 
+// GetPlansFiltered fetches the plans of a project that match the filter.
+func (c *HTTPClient) GetPlansFiltered(ctx context.Context, projectID int64, filter data.PlanFilter) (data.GetPlansResponse, error) {
+	endpoint := fmt.Sprintf("get_plans/%d", projectID)
+	plans, err := fetchAllPages[data.Plan](ctx, c, endpoint, filter.Query(), "plans")
+	if err != nil {
+		return nil, fmt.Errorf("error getting plans for project %d: %w", projectID, err)
+	}
+	return data.GetPlansResponse(plans), nil
+}
+
 // AddPlan creates a new test plan.
 // https://support.testrail.com/hc/en-us/articles/7077996481044-Plans#addplan
 func (c *HTTPClient) AddPlan(ctx context.Context, projectID int64, req *data.AddPlanRequest) (*data.Plan, error) {
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/concurrency"
+	"github.com/Korrnals/gotr/internal/concurrent"
+)
+
+// rateLimitTransport makes every outgoing request take a token from a shared
+// adaptive bucket. Server latency and 429 responses are fed back into the
+// bucket so the rate drops when TestRail pushes back and recovers afterwards.
+type rateLimitTransport struct {
+	base    http.RoundTripper
+	limiter *concurrent.AdaptiveRateLimiter
+}
+
+// RoundTrip waits for a token, executes the request and records the outcome.
+func (t rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.WaitCtx(req.Context()); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		t.limiter.RecordThrottle()
+	} else {
+		t.limiter.RecordResponseTime(time.Since(start))
+	}
+	return resp, nil
+}
+
+// RateLimiter returns the limiter shared by all requests of this client,
+// or nil if rate limiting is disabled.
+func (c *HTTPClient) RateLimiter() *concurrent.AdaptiveRateLimiter {
+	return c.limiter
+}
+
+// parallelConfig returns the controller config for a parallel fetch. When the
+// client has its own limiter every page request already takes a token from
+// that shared bucket, so the per-call RequestsPerMinute bucket is dropped
+// instead of limiting the same traffic twice. config itself is not modified.
+func (c *HTTPClient) parallelConfig(config *concurrency.ControllerConfig) *concurrency.ControllerConfig {
+	if config == nil {
+		config = concurrency.DefaultControllerConfig()
+	}
+	if c.limiter == nil {
+		return config
+	}
+	shared := *config
+	shared.RequestsPerMinute = 0
+	return &shared
+}
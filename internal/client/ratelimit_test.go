@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/concurrency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitTransport_InstalledByOption(t *testing.T) {
+	c, err := NewClient("https://example.com", "u", "k", false, WithRateLimit(120))
+	require.NoError(t, err)
+	require.NotNil(t, c.RateLimiter())
+
+	auth := c.client.Transport.(authTransport)
+	rl, ok := auth.base.(rateLimitTransport)
+	require.True(t, ok)
+	assert.Same(t, c.RateLimiter(), rl.limiter)
+}
+
+func TestRateLimitTransport_DisabledByDefault(t *testing.T) {
+	c, err := NewClient("https://example.com", "u", "k", false, WithRateLimit(0))
+	require.NoError(t, err)
+	assert.Nil(t, c.RateLimiter())
+}
+
+func TestParallelConfig_UsesSharedLimiter(t *testing.T) {
+	cfg := &concurrency.ControllerConfig{RequestsPerMinute: 120, MaxConcurrentSuites: 4}
+
+	limited, err := NewClient("https://example.com", "u", "k", false, WithRateLimit(600))
+	require.NoError(t, err)
+	got := limited.parallelConfig(cfg)
+	assert.Zero(t, got.RequestsPerMinute, "page requests already take tokens from the client bucket")
+	assert.Equal(t, 4, got.MaxConcurrentSuites)
+	assert.Equal(t, 120, cfg.RequestsPerMinute, "caller config must not change")
+
+	unlimited, err := NewClient("https://example.com", "u", "k", false, WithRateLimit(0))
+	require.NoError(t, err)
+	assert.Same(t, cfg, unlimited.parallelConfig(cfg))
+}
+
+func TestRateLimitTransport_ThrottleLowersRate(t *testing.T) {
+	var calls int32
+	server := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	defer server.Close()
+
+	c, err := NewClient(server.URL, "u", "k", false, WithRateLimit(600))
+	require.NoError(t, err)
+
+	before := c.RateLimiter().CurrentRate()
+	_, err = c.Get(context.Background(), "get_projects", nil)
+	require.Error(t, err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Less(t, c.RateLimiter().CurrentRate(), before)
+}
+
+func TestRateLimitTransport_RetriesTakeTokens(t *testing.T) {
+	var calls int32
+	var waits []int
+	server := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[]`))
+	})
+	defer server.Close()
+
+	c, err := NewClient(server.URL, "u", "k", false, WithRateLimit(600), WithMaxRetries(1))
+	require.NoError(t, err)
+
+	auth := c.client.Transport.(authTransport)
+	rt := auth.base.(retryTransport)
+	_, ok := rt.base.(rateLimitTransport)
+	require.True(t, ok, "rate limiter must sit below the retry transport")
+	rt.sleep = func(ctx context.Context, _ time.Duration) error {
+		waits = append(waits, 1)
+		return nil
+	}
+	auth.base = rt
+	c.client.Transport = auth
+
+	resp, err := c.Get(context.Background(), "get_projects", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Len(t, waits, 1)
+	assert.Less(t, c.RateLimiter().CurrentRate(), 600.0)
+}
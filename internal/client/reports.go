@@ -2,9 +2,15 @@
 package client
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
 
 	"github.com/Korrnals/gotr/internal/models/data"
 )
@@ -76,3 +82,84 @@ func (c *HTTPClient) GetCrossProjectReports(ctx context.Context) (data.GetReport
 	}
 	return reports, nil
 }
+
+// ErrReportNotReady is returned by DownloadReport while the server is still
+// generating the report; callers waiting for generation retry on it.
+var ErrReportNotReady = errors.New("report not ready")
+
+// Report media types accepted by DownloadReport.
+const (
+	ReportMediaPDF  = "application/pdf"
+	ReportMediaHTML = "text/html"
+)
+
+// DownloadReport streams the generated report at reportURL (report_html or
+// report_pdf of RunReport) into w and returns the number of bytes written.
+// The URL must point to the TestRail server of the client, since the
+// request carries its credentials; a relative URL is resolved against it.
+// A response of another media type than mediaType, such as an HTML login
+// page for a PDF, is an error and nothing is written.
+func (c *HTTPClient) DownloadReport(ctx context.Context, reportURL, mediaType string, w io.Writer) (int64, error) {
+	target, err := c.baseURL.Parse(reportURL)
+	if err != nil {
+		return 0, fmt.Errorf("invalid report URL %q: %w", reportURL, err)
+	}
+	if !sameHost(target, c.baseURL) {
+		return 0, fmt.Errorf("report URL %s is not on %s; refusing to send credentials", target.Redacted(), c.baseURL.Host)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error downloading report: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		err := c.formatAPIError(resp)
+		if reportPending(resp.StatusCode) {
+			err = fmt.Errorf("%w: %w", ErrReportNotReady, err)
+		}
+		return 0, fmt.Errorf("error downloading report: %w", err)
+	}
+
+	body := bufio.NewReader(resp.Body)
+	if got := responseMediaType(resp.Header.Get("Content-Type"), body); got != mediaType {
+		return 0, fmt.Errorf("error downloading report: server returned %s instead of %s (login or error page?)", got, mediaType)
+	}
+	n, err := io.Copy(w, body)
+	if err != nil {
+		return n, fmt.Errorf("error reading report: %w", err)
+	}
+	return n, nil
+}
+
+// reportPending reports whether a download status means the report is still
+// being generated. Anything else, such as 404 for a bad link, ends the wait.
+func reportPending(status int) bool {
+	switch status {
+	case http.StatusAccepted, http.StatusNoContent, http.StatusConflict, http.StatusTooEarly, http.StatusServiceUnavailable:
+		return true
+	}
+	return false
+}
+
+// responseMediaType is the media type of the Content-Type header, or of the
+// sniffed start of body when the header is missing.
+func responseMediaType(header string, body *bufio.Reader) string {
+	if header == "" {
+		head, _ := body.Peek(512)
+		header = http.DetectContentType(head)
+	}
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		return header
+	}
+	return mediaType
+}
+
+func sameHost(a, b *url.URL) bool {
+	return a.Scheme == b.Scheme && a.Host == b.Host
+}
@@ -4,6 +4,7 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -256,3 +257,62 @@ func TestHTTPGetCrossProjectReports(t *testing.T) {
 		}
 	})
 }
+
+func TestHTTPDownloadReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); !ok {
+			t.Fatalf("expected authenticated request")
+		}
+		switch r.URL.Path {
+		case "/pending.pdf":
+			w.WriteHeader(http.StatusAccepted)
+			return
+		case "/missing.pdf":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case "/forbidden.pdf":
+			w.WriteHeader(http.StatusForbidden)
+			return
+		case "/login.pdf":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte("<html>Log in</html>"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write([]byte("%PDF"))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL, "test", "test", false)
+
+	var buf strings.Builder
+	n, err := client.DownloadReport(context.Background(), server.URL+"/reports/7.pdf", ReportMediaPDF, &buf)
+	if err != nil {
+		t.Fatalf("DownloadReport() error = %v", err)
+	}
+	if n != 4 || buf.String() != "%PDF" {
+		t.Fatalf("DownloadReport() = %d %q, want 4 %%PDF", n, buf.String())
+	}
+
+	if _, err := client.DownloadReport(context.Background(), "/pending.pdf", ReportMediaPDF, &buf); !errors.Is(err, ErrReportNotReady) {
+		t.Fatalf("expected DownloadReport() to report 202 as not ready, got %v", err)
+	}
+
+	if _, err := client.DownloadReport(context.Background(), "/missing.pdf", ReportMediaPDF, &buf); err == nil || errors.Is(err, ErrReportNotReady) {
+		t.Fatalf("expected DownloadReport() to fail on 404 without retry, got %v", err)
+	}
+
+	if _, err := client.DownloadReport(context.Background(), "/forbidden.pdf", ReportMediaPDF, &buf); err == nil || errors.Is(err, ErrReportNotReady) {
+		t.Fatalf("expected DownloadReport() to fail on 403 without retry, got %v", err)
+	}
+
+	buf.Reset()
+	if _, err := client.DownloadReport(context.Background(), "/login.pdf", ReportMediaPDF, &buf); err == nil || buf.Len() != 0 {
+		t.Fatalf("expected DownloadReport() to reject an HTML page for a PDF, got %v (%d bytes written)", err, buf.Len())
+	}
+
+	if _, err := client.DownloadReport(context.Background(), "https://elsewhere.example/7.pdf", ReportMediaPDF, &buf); err == nil ||
+		!strings.Contains(err.Error(), "refusing to send credentials") {
+		t.Fatalf("expected DownloadReport() to refuse a foreign host, got %v", err)
+	}
+}
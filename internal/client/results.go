@@ -120,6 +120,16 @@ func (c *HTTPClient) GetResultsForRun(ctx context.Context, runID int64) (data.Ge
 	return data.GetResultsResponse(results), nil
 }
 
+// GetResultsForRunFiltered fetches the results of a run that match the filter.
+func (c *HTTPClient) GetResultsForRunFiltered(ctx context.Context, runID int64, filter data.ResultFilter) (data.GetResultsResponse, error) {
+	endpoint := fmt.Sprintf("get_results_for_run/%d", runID)
+	results, err := fetchAllPages[data.Result](ctx, c, endpoint, filter.Query(), "results")
+	if err != nil {
+		return nil, fmt.Errorf("request error GetResultsForRun for run %d: %w", runID, err)
+	}
+	return data.GetResultsResponse(results), nil
+}
+
 // GetResultsForCase fetches results for a case in a run.
 // https://support.testrail.com/hc/en-us/articles/7077874763156-Results#getresultsforcase
 func (c *HTTPClient) GetResultsForCase(ctx context.Context, runID, caseID int64) (data.GetResultsResponse, error) {
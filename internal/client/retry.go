@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/debug"
+)
+
+// Default retry settings applied when retries are enabled via WithMaxRetries.
+const (
+	defaultRetryInitialDelay = 1 * time.Second
+	defaultRetryMaxDelay     = 60 * time.Second
+)
+
+// retryTransport re-sends requests that failed with a transient server status
+// (429 Too Many Requests, 502, 503, 504). It honors the Retry-After header and
+// falls back to exponential backoff when the header is absent.
+//
+// Only idempotent methods (GET, HEAD) are retried unless retryPost is set:
+// TestRail add_* endpoints are not idempotent, and a 504 does not guarantee
+// that the server discarded the write.
+type retryTransport struct {
+	base         http.RoundTripper
+	maxRetries   int
+	initialDelay time.Duration
+	maxDelay     time.Duration
+	retryPost    bool
+	// sleep waits for d or until ctx is done; overridable in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// RoundTrip executes the request, retrying on transient failures.
+func (t retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.canRetry(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	delay := t.initialDelay
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if err != nil || !isRetryableStatus(resp.StatusCode) || attempt >= t.maxRetries {
+			return resp, err
+		}
+
+		wait := retryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if wait <= 0 {
+			wait = delay
+		}
+		if wait > t.maxDelay {
+			wait = t.maxDelay
+		}
+		delay = min(delay*2, t.maxDelay)
+
+		// Do not sleep past the request deadline (http.Client.Timeout covers
+		// all attempts) — hand the last response back so the caller gets
+		// a readable API error instead of a bare timeout.
+		if deadline, ok := req.Context().Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return resp, nil
+		}
+
+		debug.DebugPrint("{retryTransport} - %s %s returned %s, retry %d/%d in %v",
+			req.Method, req.URL.Path, resp.Status, attempt+1, t.maxRetries, wait)
+
+		// Drain so the connection can be reused by the next attempt
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBodySize))
+		resp.Body.Close()
+
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// canRetry reports whether the request is eligible for retries.
+func (t retryTransport) canRetry(req *http.Request) bool {
+	if t.maxRetries <= 0 {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		// A body that cannot be rewound cannot be re-sent
+		return t.retryPost && (req.Body == nil || req.Body == http.NoBody || req.GetBody != nil)
+	default:
+		return false
+	}
+}
+
+// isRetryableStatus reports whether the status code indicates a transient failure.
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter parses a Retry-After header value (delay-seconds or HTTP-date).
+// Returns 0 if the header is empty or malformed.
+func retryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// sleepCtx waits for d or until ctx is done.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
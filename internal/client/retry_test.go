@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noSleep replaces the retry transport's sleep and records requested waits.
+func noSleep(waits *[]time.Duration) func(context.Context, time.Duration) error {
+	return func(ctx context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return ctx.Err()
+	}
+}
+
+func newRetryTestClient(t *testing.T, handler http.HandlerFunc, waits *[]time.Duration, opts ...ClientOption) *HTTPClient {
+	t.Helper()
+	server := newMockServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(server.URL, "u", "k", false, opts...)
+	require.NoError(t, err)
+
+	auth := c.client.Transport.(authTransport)
+	rt, ok := auth.base.(retryTransport)
+	require.True(t, ok, "retry transport must be installed below authTransport")
+	rt.sleep = noSleep(waits)
+	auth.base = rt
+	c.client.Transport = auth
+	return c
+}
+
+func TestRetryTransport_RetriesThrottledGet(t *testing.T) {
+	var calls int32
+	var waits []time.Duration
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		assert.Equal(t, "u", user)
+		assert.Equal(t, "k", pass)
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[]`))
+	}, &waits, WithMaxRetries(3))
+
+	resp, err := c.Get(context.Background(), "get_projects", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, []time.Duration{7 * time.Second, 7 * time.Second}, waits)
+}
+
+func TestRetryTransport_ExponentialBackoffOn5xx(t *testing.T) {
+	var calls int32
+	var waits []time.Duration
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"error":"maintenance"}`))
+	}, &waits, WithMaxRetries(3), WithRetryBackoff(100*time.Millisecond, 250*time.Millisecond))
+
+	_, err := c.Get(context.Background(), "get_projects", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "maintenance")
+
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 250 * time.Millisecond}, waits)
+}
+
+func TestRetryTransport_DoesNotRetryNonTransientStatus(t *testing.T) {
+	var calls int32
+	var waits []time.Duration
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}, &waits, WithMaxRetries(3))
+
+	_, err := c.Get(context.Background(), "get_projects", nil)
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Empty(t, waits)
+}
+
+func TestRetryTransport_PostRequiresOptIn(t *testing.T) {
+	handler := func(calls *int32) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, `{"title":"x"}`, string(body))
+			if atomic.AddInt32(calls, 1) == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		var calls int32
+		var waits []time.Duration
+		c := newRetryTestClient(t, handler(&calls), &waits, WithMaxRetries(2))
+
+		_, err := c.Post(context.Background(), "add_case/1", strings.NewReader(`{"title":"x"}`), nil)
+		require.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("enabled replays body", func(t *testing.T) {
+		var calls int32
+		var waits []time.Duration
+		c := newRetryTestClient(t, handler(&calls), &waits, WithMaxRetries(2), WithRetryPost(true))
+
+		resp, err := c.Post(context.Background(), "add_case/1", strings.NewReader(`{"title":"x"}`), nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+}
+
+func TestRetryTransport_StopsOnContextCancel(t *testing.T) {
+	server := newMockServer(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	defer server.Close()
+
+	c, err := NewClient(server.URL, "u", "k", false, WithMaxRetries(5))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	auth := c.client.Transport.(authTransport)
+	rt := auth.base.(retryTransport)
+	rt.sleep = func(ctx context.Context, d time.Duration) error {
+		cancel()
+		return sleepCtx(ctx, d)
+	}
+	auth.base = rt
+	c.client.Transport = auth
+
+	_, err = c.Get(ctx, "get_projects", nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRetryTransport_ReturnsLastResponseWhenWaitExceedsDeadline(t *testing.T) {
+	var calls int32
+	var waits []time.Duration
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":"API rate limit exceeded"}`))
+	}, &waits, WithMaxRetries(3), WithTimeout(5*time.Second))
+
+	_, err := c.Get(context.Background(), "get_projects", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rate limit exceeded")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Empty(t, waits)
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{"empty", "", 0},
+		{"seconds", "30", 30 * time.Second},
+		{"negative", "-5", 0},
+		{"http date", now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{"past date", now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"garbage", "soon", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, retryAfter(tt.value, now))
+		})
+	}
+}
+
+func TestClientOptions_RetryDisabledByDefault(t *testing.T) {
+	c, err := NewClient("https://example.com", "u", "k", false)
+	require.NoError(t, err)
+
+	auth := c.client.Transport.(authTransport)
+	_, isHTTP := auth.base.(*http.Transport)
+	assert.True(t, isHTTP)
+}
@@ -39,6 +39,16 @@ func (c *HTTPClient) GetRuns(ctx context.Context, projectID int64) (data.GetRuns
 	return data.GetRunsResponse(runs), nil
 }
 
+// GetRunsFiltered fetches the runs of a project that match the filter.
+func (c *HTTPClient) GetRunsFiltered(ctx context.Context, projectID int64, filter data.RunFilter) (data.GetRunsResponse, error) {
+	endpoint := fmt.Sprintf("get_runs/%d", projectID)
+	runs, err := fetchAllPages[data.Run](ctx, c, endpoint, filter.Query(), "runs")
+	if err != nil {
+		return nil, fmt.Errorf("request error GetRuns for project %d: %w", projectID, err)
+	}
+	return data.GetRunsResponse(runs), nil
+}
+
 // AddRun creates a new test run.
 // https://support.testrail.com/hc/en-us/articles/7077816294684-Runs#addrun
 func (c *HTTPClient) AddRun(ctx context.Context, projectID int64, req *data.AddRunRequest) (*data.Run, error) {
@@ -502,3 +502,19 @@ func TestRuns_RequestErrors(t *testing.T) {
 		assert.Contains(t, err.Error(), "request error DeleteRun")
 	})
 }
+
+func TestGetRunsFiltered(t *testing.T) {
+	client, server := mockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.String(), "get_runs/10")
+		assert.Equal(t, "0", r.URL.Query().Get("is_completed"))
+		assert.Equal(t, "5", r.URL.Query().Get("milestone_id"))
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(data.GetRunsResponse{{ID: 1, Name: "run-1"}})
+	})
+	defer server.Close()
+
+	open := false
+	runs, err := client.GetRunsFiltered(context.Background(), 10, data.RunFilter{IsCompleted: &open, MilestoneIDs: []int64{5}})
+	assert.NoError(t, err)
+	assert.Len(t, runs, 1)
+}
@@ -41,6 +41,7 @@ func (c *HTTPClient) GetSectionsParallelCtx(
 	} else {
 		config.Normalize()
 	}
+	config = c.parallelConfig(config)
 
 	if config.Timeout > 0 {
 		var cancel context.CancelFunc
@@ -191,6 +191,49 @@ func (arl *AdaptiveRateLimiter) RecordResponseTime(d time.Duration) {
 	}
 }
 
+// RecordThrottle registers a 429 response and halves the current rate.
+// The rate may drop to 10% of the target; fast responses recorded via
+// RecordResponseTime bring it back up gradually.
+func (arl *AdaptiveRateLimiter) RecordThrottle() {
+	if arl == nil || arl.baseLimiter == nil {
+		return
+	}
+
+	arl.mu.Lock()
+	defer arl.mu.Unlock()
+
+	arl.currentRPS = max(arl.currentRPS*0.5, arl.targetRPS*0.1)
+	arl.baseLimiter.limiter.SetLimit(rate.Limit(arl.currentRPS))
+}
+
+// SetRate replaces the target rate with requestsPerMinute and starts from it,
+// for a caller that was given an explicit budget. It has no effect on an
+// unlimited limiter or for a non-positive rate.
+func (arl *AdaptiveRateLimiter) SetRate(requestsPerMinute int) {
+	if arl == nil || arl.baseLimiter == nil || requestsPerMinute <= 0 {
+		return
+	}
+
+	arl.mu.Lock()
+	defer arl.mu.Unlock()
+
+	arl.targetRPS = float64(requestsPerMinute) / 60.0
+	arl.currentRPS = arl.targetRPS
+	arl.responseTimes = arl.responseTimes[:0]
+	arl.baseLimiter.limiter.SetLimit(rate.Limit(arl.currentRPS))
+}
+
+// CurrentRate returns the current rate in requests per minute (0 = unlimited).
+func (arl *AdaptiveRateLimiter) CurrentRate() float64 {
+	if arl == nil || arl.baseLimiter == nil {
+		return 0
+	}
+
+	arl.mu.Lock()
+	defer arl.mu.Unlock()
+	return arl.currentRPS * 60
+}
+
 func averageDuration(durations []time.Duration) time.Duration {
 	if len(durations) == 0 {
 		return 0
@@ -210,8 +253,11 @@ func (arl *AdaptiveRateLimiter) adjustRate(avgResponseTime time.Duration) {
 	minRPS := arl.targetRPS * 0.5
 
 	if avgResponseTime > 10*time.Second {
-		// Genuinely overloaded — slow down moderately
-		arl.currentRPS = max(arl.currentRPS*0.85, minRPS)
+		// Genuinely overloaded — slow down moderately.
+		// Never raise a rate that RecordThrottle already pushed below the floor.
+		if arl.currentRPS > minRPS {
+			arl.currentRPS = max(arl.currentRPS*0.85, minRPS)
+		}
 	} else if avgResponseTime < 1*time.Second {
 		// Fast responses — speed up towards target
 		arl.currentRPS = min(arl.currentRPS*1.1, arl.targetRPS)
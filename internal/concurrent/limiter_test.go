@@ -262,6 +262,61 @@ func TestAdaptiveRateLimiter_AdjustRate_Branches(t *testing.T) {
 	})
 }
 
+func TestAdaptiveRateLimiter_RecordThrottle(t *testing.T) {
+	t.Run("halves current rate", func(t *testing.T) {
+		arl := NewAdaptiveRateLimiter(600) // targetRPS = 10
+
+		arl.RecordThrottle()
+
+		assert.InDelta(t, 5.0, arl.currentRPS, 0.0001)
+		assert.InDelta(t, 300.0, arl.CurrentRate(), 0.0001)
+	})
+
+	t.Run("clamped at ten percent of target", func(t *testing.T) {
+		arl := NewAdaptiveRateLimiter(600)
+		for i := 0; i < 10; i++ {
+			arl.RecordThrottle()
+		}
+
+		assert.InDelta(t, 1.0, arl.currentRPS, 0.0001)
+	})
+
+	t.Run("slow responses do not raise throttled rate", func(t *testing.T) {
+		arl := NewAdaptiveRateLimiter(600)
+		arl.currentRPS = 2.0
+
+		arl.adjustRate(11 * time.Second)
+
+		assert.InDelta(t, 2.0, arl.currentRPS, 0.0001)
+	})
+
+	t.Run("nil and unlimited are no-op", func(t *testing.T) {
+		var nilLimiter *AdaptiveRateLimiter
+		assert.NotPanics(t, nilLimiter.RecordThrottle)
+		assert.Zero(t, nilLimiter.CurrentRate())
+
+		unlimited := NewAdaptiveRateLimiter(0)
+		unlimited.RecordThrottle()
+		assert.Zero(t, unlimited.CurrentRate())
+	})
+}
+
+func TestAdaptiveRateLimiter_SetRate(t *testing.T) {
+	arl := NewAdaptiveRateLimiter(180)
+	arl.RecordThrottle()
+
+	arl.SetRate(60)
+	assert.InDelta(t, 60.0, arl.CurrentRate(), 0.0001)
+	assert.InDelta(t, 1.0, arl.targetRPS, 0.0001)
+
+	arl.SetRate(0)
+	assert.InDelta(t, 60.0, arl.CurrentRate(), 0.0001, "non-positive rate is ignored")
+
+	unlimited := NewAdaptiveRateLimiter(0)
+	unlimited.SetRate(60)
+	assert.Zero(t, unlimited.CurrentRate())
+}
+
 func TestAverageDuration(t *testing.T) {
 	durations := []time.Duration{
 		100 * time.Millisecond,
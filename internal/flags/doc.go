@@ -7,6 +7,10 @@
 // eliminate repetitive flag-reading boilerplate and enforce consistent
 // validation when resource IDs are required.
 //
+// Server-side list filters (created/updated dates, user, milestone and
+// status IDs) are registered with Add*FilterFlags and read back into the
+// typed data filters with *FilterFromFlags; dates go through [ParseTime].
+//
 // Key functions: [ParseID], [ParseIDFromArgs], [ValidateRequiredID],
-// [GetFlagInt64], [GetFlagString], [GetFlagBool].
+// [GetFlagInt64], [GetFlagString], [GetFlagBool], [ParseTime].
 package flags
@@ -0,0 +1,183 @@
+package flags
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/spf13/cobra"
+)
+
+// timeNow is the clock used for relative filter dates (tests replace it).
+var timeNow = time.Now
+
+// ParseTime parses a filter date: YYYY-MM-DD, RFC 3339, a Unix timestamp, or
+// an age relative to now such as 30m, 12h or 7d.
+func ParseTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if unix, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(unix, 0), nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return timeNow().AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return timeNow().Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q (want YYYY-MM-DD, RFC 3339, Unix time or an age like 7d)", s)
+}
+
+// getTime reads a date flag; unknown flags yield the zero time.
+func getTime(cmd *cobra.Command, name string) (time.Time, error) {
+	s, _ := cmd.Flags().GetString(name)
+	t, err := ParseTime(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("--%s: %w", name, err)
+	}
+	return t, nil
+}
+
+// getOptionalBool returns nil when the flag was not set.
+func getOptionalBool(cmd *cobra.Command, name string) *bool {
+	if !cmd.Flags().Changed(name) {
+		return nil
+	}
+	b, _ := cmd.Flags().GetBool(name)
+	return &b
+}
+
+func getIDs(cmd *cobra.Command, name string) []int64 {
+	ids, _ := cmd.Flags().GetInt64Slice(name)
+	return ids
+}
+
+// addCreatedFlags adds the created-after/before/by filters shared by all list endpoints.
+func addCreatedFlags(cmd *cobra.Command) {
+	cmd.Flags().String("created-after", "", "Only items created after the date (YYYY-MM-DD, RFC 3339, Unix time or age like 7d)")
+	cmd.Flags().String("created-before", "", "Only items created before the date")
+	cmd.Flags().Int64Slice("created-by", nil, "Only items created by the user IDs")
+}
+
+func getCreated(cmd *cobra.Command) (after, before time.Time, err error) {
+	if after, err = getTime(cmd, "created-after"); err != nil {
+		return
+	}
+	before, err = getTime(cmd, "created-before")
+	return
+}
+
+// AddCaseFilterFlags adds the get_cases server-side filter flags.
+func AddCaseFilterFlags(cmd *cobra.Command) {
+	addCreatedFlags(cmd)
+	cmd.Flags().String("updated-after", "", "Only cases updated after the date")
+	cmd.Flags().String("updated-before", "", "Only cases updated before the date")
+	cmd.Flags().Int64Slice("updated-by", nil, "Only cases last updated by the user IDs")
+	cmd.Flags().Int64Slice("milestone-id", nil, "Only cases linked to the milestone IDs")
+	cmd.Flags().Int64Slice("priority-id", nil, "Only cases with the priority IDs")
+	cmd.Flags().Int64Slice("type-id", nil, "Only cases with the case type IDs")
+	cmd.Flags().Int64Slice("template-id", nil, "Only cases with the template IDs")
+	cmd.Flags().String("refs", "", "Only cases referencing the ID (e.g. TR-1)")
+	cmd.Flags().String("title-contains", "", "Only cases whose title contains the text")
+}
+
+// CaseFilterFromFlags builds a case filter from AddCaseFilterFlags flags;
+// suite and section are left to the caller.
+func CaseFilterFromFlags(cmd *cobra.Command) (data.CaseFilter, error) {
+	var f data.CaseFilter
+	var err error
+	if f.CreatedAfter, f.CreatedBefore, err = getCreated(cmd); err != nil {
+		return f, err
+	}
+	if f.UpdatedAfter, err = getTime(cmd, "updated-after"); err != nil {
+		return f, err
+	}
+	if f.UpdatedBefore, err = getTime(cmd, "updated-before"); err != nil {
+		return f, err
+	}
+	f.CreatedBy = getIDs(cmd, "created-by")
+	f.UpdatedBy = getIDs(cmd, "updated-by")
+	f.MilestoneIDs = getIDs(cmd, "milestone-id")
+	f.PriorityIDs = getIDs(cmd, "priority-id")
+	f.TypeIDs = getIDs(cmd, "type-id")
+	f.TemplateIDs = getIDs(cmd, "template-id")
+	f.Refs, _ = cmd.Flags().GetString("refs")
+	f.Title, _ = cmd.Flags().GetString("title-contains")
+	return f, nil
+}
+
+// AddRunFilterFlags adds the get_runs server-side filter flags.
+func AddRunFilterFlags(cmd *cobra.Command) {
+	addCreatedFlags(cmd)
+	cmd.Flags().Bool("is-completed", false, "Only completed runs (--is-completed=false for active runs)")
+	cmd.Flags().Int64Slice("milestone-id", nil, "Only runs of the milestone IDs")
+	cmd.Flags().Int64Slice("suite-id", nil, "Only runs of the suite IDs")
+	cmd.Flags().String("refs", "", "Only runs referencing the ID (e.g. TR-1)")
+}
+
+// RunFilterFromFlags builds a run filter from AddRunFilterFlags flags.
+func RunFilterFromFlags(cmd *cobra.Command) (data.RunFilter, error) {
+	var f data.RunFilter
+	var err error
+	if f.CreatedAfter, f.CreatedBefore, err = getCreated(cmd); err != nil {
+		return f, err
+	}
+	f.CreatedBy = getIDs(cmd, "created-by")
+	f.IsCompleted = getOptionalBool(cmd, "is-completed")
+	f.MilestoneIDs = getIDs(cmd, "milestone-id")
+	f.SuiteIDs = getIDs(cmd, "suite-id")
+	f.Refs, _ = cmd.Flags().GetString("refs")
+	return f, nil
+}
+
+// AddPlanFilterFlags adds the get_plans server-side filter flags.
+func AddPlanFilterFlags(cmd *cobra.Command) {
+	addCreatedFlags(cmd)
+	cmd.Flags().Bool("is-completed", false, "Only completed plans (--is-completed=false for active plans)")
+	cmd.Flags().Int64Slice("milestone-id", nil, "Only plans of the milestone IDs")
+}
+
+// PlanFilterFromFlags builds a plan filter from AddPlanFilterFlags flags.
+func PlanFilterFromFlags(cmd *cobra.Command) (data.PlanFilter, error) {
+	var f data.PlanFilter
+	var err error
+	if f.CreatedAfter, f.CreatedBefore, err = getCreated(cmd); err != nil {
+		return f, err
+	}
+	f.CreatedBy = getIDs(cmd, "created-by")
+	f.IsCompleted = getOptionalBool(cmd, "is-completed")
+	f.MilestoneIDs = getIDs(cmd, "milestone-id")
+	return f, nil
+}
+
+// AddResultFilterFlags adds the get_results_for_run server-side filter flags.
+func AddResultFilterFlags(cmd *cobra.Command) {
+	addCreatedFlags(cmd)
+	cmd.Flags().Int64Slice("status-id", nil, "Only results with the status IDs")
+	cmd.Flags().String("defects", "", "Only results linked to the defect ID (e.g. BUG-1)")
+}
+
+// ResultFilterFromFlags builds a result filter from AddResultFilterFlags flags.
+func ResultFilterFromFlags(cmd *cobra.Command) (data.ResultFilter, error) {
+	var f data.ResultFilter
+	var err error
+	if f.CreatedAfter, f.CreatedBefore, err = getCreated(cmd); err != nil {
+		return f, err
+	}
+	f.CreatedBy = getIDs(cmd, "created-by")
+	f.StatusIDs = getIDs(cmd, "status-id")
+	f.Defects, _ = cmd.Flags().GetString("defects")
+	return f, nil
+}
@@ -0,0 +1,67 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"empty", "", time.Time{}, false},
+		{"date", "2024-01-02", time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local), false},
+		{"rfc3339", "2024-01-02T03:04:05Z", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), false},
+		{"unix", "1704067200", time.Unix(1704067200, 0), false},
+		{"days", "7d", now.AddDate(0, 0, -7), false},
+		{"hours", "12h", now.Add(-12 * time.Hour), false},
+		{"invalid", "yesterday", time.Time{}, true},
+		{"negative age", "-3h", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTime(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func TestRunFilterFromFlags(t *testing.T) {
+	cmd := &cobra.Command{}
+	AddRunFilterFlags(cmd)
+
+	f, err := RunFilterFromFlags(cmd)
+	assert.NoError(t, err)
+	assert.Nil(t, f.IsCompleted, "unset --is-completed must not filter")
+	assert.Empty(t, f.Query())
+
+	assert.NoError(t, cmd.ParseFlags([]string{"--is-completed=false", "--created-by", "1,2", "--refs", "TR-1"}))
+	f, err = RunFilterFromFlags(cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"is_completed": "0", "created_by": "1,2", "refs_filter": "TR-1"}, f.Query())
+}
+
+func TestCaseFilterFromFlags_InvalidDate(t *testing.T) {
+	cmd := &cobra.Command{}
+	AddCaseFilterFlags(cmd)
+	assert.NoError(t, cmd.ParseFlags([]string{"--created-after", "soon"}))
+
+	_, err := CaseFilterFromFlags(cmd)
+	assert.ErrorContains(t, err, "--created-after")
+}
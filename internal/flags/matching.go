@@ -0,0 +1,27 @@
+package flags
+
+import (
+	"fmt"
+
+	"github.com/Korrnals/gotr/internal/match"
+	"github.com/spf13/cobra"
+)
+
+// MatchOptionsFromFlags reads --normalize, --fuzzy and --fuzzy-algo, shared
+// by compare and sync. Case is folded only when --normalize asks for it;
+// callers that always ignore case set Normalize.Case themselves.
+func MatchOptionsFromFlags(cmd *cobra.Command) (match.Options, error) {
+	var opts match.Options
+	spec, _ := cmd.Flags().GetString("normalize")
+	normalize, err := match.ParseNormalize(spec)
+	if err != nil {
+		return opts, fmt.Errorf("--normalize: %w", err)
+	}
+	opts.Normalize = normalize
+	opts.Threshold, _ = cmd.Flags().GetFloat64("fuzzy")
+	opts.Algorithm, _ = cmd.Flags().GetString("fuzzy-algo")
+	if err := opts.Validate(); err != nil {
+		return opts, fmt.Errorf("--fuzzy: %w", err)
+	}
+	return opts, nil
+}
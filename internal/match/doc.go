@@ -0,0 +1,10 @@
+// Package match decides which items of two lists are the same thing, for
+// `gotr compare` and for duplicate detection in `gotr sync`.
+//
+// Items are matched by a key: one field or a composite such as
+// "section_path+title" ([Key]). Keys are compared after an optional
+// [Normalize] pass (whitespace, case, punctuation). Items left without an
+// exact partner can then be paired by similarity ([Options.Threshold]);
+// those near matches carry a score and are reported for review, never
+// treated as equal.
+package match
@@ -0,0 +1,429 @@
+// internal/match/match.go
+package match
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// Normalize selects the edits ignored when keys are compared.
+type Normalize struct {
+	Whitespace  bool // trim and collapse runs of whitespace
+	Case        bool // compare case-insensitively
+	Punctuation bool // drop punctuation and symbols
+}
+
+// ParseNormalize reads a comma-separated list of "whitespace" (or "ws"),
+// "case" and "punctuation" (or "punct"); "all" enables every option and ""
+// or "none" none of them.
+func ParseNormalize(spec string) (Normalize, error) {
+	var n Normalize
+	for _, part := range strings.Split(spec, ",") {
+		switch strings.ToLower(strings.TrimSpace(part)) {
+		case "", "none":
+		case "all":
+			n = Normalize{Whitespace: true, Case: true, Punctuation: true}
+		case "whitespace", "ws":
+			n.Whitespace = true
+		case "case":
+			n.Case = true
+		case "punctuation", "punct":
+			n.Punctuation = true
+		default:
+			return Normalize{}, fmt.Errorf("unknown normalization %q (use whitespace, case, punctuation, all or none)", part)
+		}
+	}
+	return n, nil
+}
+
+// Apply returns s with the selected edits applied.
+func (n Normalize) Apply(s string) string {
+	if n.Case {
+		s = strings.ToLower(s)
+	}
+	if n.Punctuation {
+		s = strings.Map(func(r rune) rune {
+			if unicode.IsPunct(r) || unicode.IsSymbol(r) {
+				return ' '
+			}
+			return r
+		}, s)
+	}
+	if n.Whitespace || n.Punctuation {
+		s = strings.Join(strings.Fields(s), " ")
+	}
+	return s
+}
+
+// keySep joins the fields of a composite key; it cannot appear in a value.
+const keySep = "\x1f"
+
+// Key is a matching key: one or more field names joined with "+", such as
+// "title", "refs+title" or "section_path+title".
+type Key []string
+
+// ParseKey splits a key spec into its fields.
+func ParseKey(spec string) (Key, error) {
+	var k Key
+	for _, part := range strings.Split(spec, "+") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			return nil, fmt.Errorf("invalid key %q: empty field", spec)
+		}
+		k = append(k, part)
+	}
+	return k, nil
+}
+
+// Has reports whether the key uses field.
+func (k Key) Has(field string) bool {
+	for _, f := range k {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Value builds the key of one item from its field values. An item whose
+// fields are all empty has no key and is never matched.
+func (k Key) Value(field func(name string) string) string {
+	parts := make([]string, len(k))
+	empty := true
+	for i, name := range k {
+		parts[i] = field(name)
+		if parts[i] != "" {
+			empty = false
+		}
+	}
+	if empty {
+		return ""
+	}
+	return strings.Join(parts, keySep)
+}
+
+// String returns the key spec.
+func (k Key) String() string { return strings.Join(k, "+") }
+
+// Item is one element of a list to match.
+type Item struct {
+	ID   int64
+	Name string // shown to the user
+	Key  string // compared
+}
+
+// Pair is a matched first/second item. Score is 1 for exact matches.
+type Pair struct {
+	First, Second Item
+	Score         float64
+}
+
+// Result splits two lists into exact matches, near matches and the rest.
+// Items of a near match also stay in OnlyInFirst/OnlyInSecond.
+type Result struct {
+	Exact        []Pair
+	Near         []Pair
+	OnlyInFirst  []Item
+	OnlyInSecond []Item
+	// NearTruncated is set when near matching stopped at MaxComparisons;
+	// Near then holds only the pairs found until that point.
+	NearTruncated bool
+}
+
+// Options control Match.
+type Options struct {
+	Normalize Normalize
+	// Threshold enables near matching: pairs left without an exact match
+	// whose similarity is at least Threshold (0..1) are reported. 0 disables.
+	Threshold float64
+	// Algorithm is the similarity measure, Levenshtein by default.
+	Algorithm string
+	// MaxComparisons caps the pairs scored for near matching;
+	// 0 means DefaultMaxComparisons.
+	MaxComparisons int
+}
+
+// DefaultMaxComparisons is the near matching budget when
+// Options.MaxComparisons is 0.
+const DefaultMaxComparisons = 500_000
+
+// Validate checks the threshold range and the algorithm name.
+func (o Options) Validate() error {
+	if o.Threshold < 0 || o.Threshold > 1 {
+		return fmt.Errorf("similarity threshold %v out of range (0..1)", o.Threshold)
+	}
+	_, err := similarityFunc(o.Algorithm)
+	return err
+}
+
+// Similarity algorithms.
+const (
+	Levenshtein = "levenshtein"
+	Jaccard     = "jaccard"
+)
+
+// Match pairs every first item with the second item of the same normalized
+// key. Several first items may share one second item (they are all
+// duplicates of it). Items without a key are left unmatched.
+func Match(first, second []Item, opts Options) (Result, error) {
+	similarity, err := similarityFunc(opts.Algorithm)
+	if err != nil {
+		return Result{}, err
+	}
+
+	byKey := make(map[string]Item, len(second))
+	for _, it := range second {
+		if k := opts.Normalize.Apply(it.Key); k != "" {
+			if _, dup := byKey[k]; !dup {
+				byKey[k] = it
+			}
+		}
+	}
+
+	var res Result
+	matched := make(map[int64]bool)
+	for _, it := range first {
+		other, ok := byKey[opts.Normalize.Apply(it.Key)]
+		if !ok {
+			res.OnlyInFirst = append(res.OnlyInFirst, it)
+			continue
+		}
+		res.Exact = append(res.Exact, Pair{First: it, Second: other, Score: 1})
+		matched[other.ID] = true
+	}
+	for _, it := range second {
+		if !matched[it.ID] {
+			res.OnlyInSecond = append(res.OnlyInSecond, it)
+		}
+	}
+
+	if opts.Threshold > 0 {
+		res.Near, res.NearTruncated = near(res.OnlyInFirst, res.OnlyInSecond, opts, similarity)
+	}
+	return res, nil
+}
+
+// near pairs the leftovers one to one, best score first. Keys are
+// normalized once, and only pairs that can reach the threshold are scored:
+// Jaccard needs a shared word, Levenshtein a close enough length. At most
+// limit pairs are scored; truncated reports whether that cut the search.
+func near(first, second []Item, opts Options, similarity func(a, b string) float64) (pairs []Pair, truncated bool) {
+	a := normalizedKeys(first, opts.Normalize)
+	b := normalizedKeys(second, opts.Normalize)
+	candidatesOf := candidateIndex(b, opts)
+	limit := opts.MaxComparisons
+	if limit <= 0 {
+		limit = DefaultMaxComparisons
+	}
+
+	type candidate struct {
+		i, j  int
+		score float64
+	}
+	var candidates []candidate
+	compared := 0
+outer:
+	for i, x := range a {
+		for _, j := range candidatesOf(x) {
+			if compared == limit {
+				truncated = true
+				break outer
+			}
+			compared++
+			if score := similarity(x.key, b[j].key); score >= opts.Threshold {
+				candidates = append(candidates, candidate{i: i, j: j, score: score})
+			}
+		}
+	}
+	sort.Slice(candidates, func(p, q int) bool {
+		cp, cq := candidates[p], candidates[q]
+		if cp.score != cq.score {
+			return cp.score > cq.score
+		}
+		if cp.i != cq.i {
+			return cp.i < cq.i
+		}
+		return cp.j < cq.j
+	})
+
+	usedFirst, usedSecond := make(map[int64]bool), make(map[int64]bool)
+	for _, c := range candidates {
+		x, y := a[c.i].item, b[c.j].item
+		if usedFirst[x.ID] || usedSecond[y.ID] {
+			continue
+		}
+		usedFirst[x.ID], usedSecond[y.ID] = true, true
+		pairs = append(pairs, Pair{First: x, Second: y, Score: c.score})
+	}
+	return pairs, truncated
+}
+
+// keyedItem is an item with its normalized key and the key length in runes.
+type keyedItem struct {
+	item  Item
+	key   string
+	runes int
+}
+
+// normalizedKeys returns the items that have a key, normalized with n.
+func normalizedKeys(items []Item, n Normalize) []keyedItem {
+	out := make([]keyedItem, 0, len(items))
+	for _, it := range items {
+		if k := n.Apply(it.Key); k != "" {
+			out = append(out, keyedItem{item: it, key: k, runes: utf8.RuneCountInString(k)})
+		}
+	}
+	return out
+}
+
+// candidateIndex indexes second and returns a function listing, in
+// ascending order, the indexes of the second items worth scoring against
+// one first item.
+func candidateIndex(second []keyedItem, opts Options) func(keyedItem) []int {
+	if strings.ToLower(opts.Algorithm) == Jaccard {
+		// A Jaccard score above 0 needs at least one shared word.
+		byToken := make(map[string][]int)
+		for j, it := range second {
+			for t := range tokens(it.key) {
+				byToken[t] = append(byToken[t], j)
+			}
+		}
+		return func(x keyedItem) []int {
+			seen := make(map[int]bool)
+			var out []int
+			for t := range tokens(x.key) {
+				for _, j := range byToken[t] {
+					if !seen[j] {
+						seen[j] = true
+						out = append(out, j)
+					}
+				}
+			}
+			sort.Ints(out)
+			return out
+		}
+	}
+
+	// The edit distance is at least the length difference, so a ratio of
+	// t needs t*la <= lb <= la/t.
+	byLength := make([]int, len(second))
+	for j := range byLength {
+		byLength[j] = j
+	}
+	sort.SliceStable(byLength, func(p, q int) bool { return second[byLength[p]].runes < second[byLength[q]].runes })
+	return func(x keyedItem) []int {
+		lo := int(math.Ceil(opts.Threshold * float64(x.runes)))
+		hi := math.MaxInt
+		if h := float64(x.runes) / opts.Threshold; h < float64(math.MaxInt) {
+			hi = int(h)
+		}
+		from := sort.Search(len(byLength), func(p int) bool { return second[byLength[p]].runes >= lo })
+		var out []int
+		for p := from; p < len(byLength) && second[byLength[p]].runes <= hi; p++ {
+			out = append(out, byLength[p])
+		}
+		sort.Ints(out)
+		return out
+	}
+}
+
+func similarityFunc(algo string) (func(a, b string) float64, error) {
+	switch strings.ToLower(algo) {
+	case "", Levenshtein:
+		return LevenshteinRatio, nil
+	case Jaccard:
+		return TokenJaccard, nil
+	default:
+		return nil, fmt.Errorf("unknown similarity algorithm %q (use %s or %s)", algo, Levenshtein, Jaccard)
+	}
+}
+
+// LevenshteinRatio is 1 minus the edit distance of a and b divided by the
+// length of the longer one, in runes: 1 for equal strings, 0 for nothing
+// in common.
+func LevenshteinRatio(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return 1 - float64(prev[len(rb)])/float64(longest)
+}
+
+// TokenJaccard is the Jaccard index of the sets of whitespace-separated
+// words of a and b: shared words divided by all distinct words.
+func TokenJaccard(a, b string) float64 {
+	ta, tb := tokens(a), tokens(b)
+	if len(ta) == 0 && len(tb) == 0 {
+		return 1
+	}
+	shared := 0
+	for t := range ta {
+		if tb[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+func tokens(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, t := range strings.FieldsFunc(s, func(r rune) bool { return unicode.IsSpace(r) || string(r) == keySep }) {
+		set[t] = true
+	}
+	return set
+}
+
+// SectionPaths returns the full name path (root → section) of every
+// section, names joined with sep. A parent that is not in the list starts a
+// new root.
+func SectionPaths(sections data.GetSectionsResponse, sep string) map[int64]string {
+	byID := make(map[int64]data.Section, len(sections))
+	for _, s := range sections {
+		byID[s.ID] = s
+	}
+
+	paths := make(map[int64]string, len(sections))
+	var resolve func(id int64, seen map[int64]bool) string
+	resolve = func(id int64, seen map[int64]bool) string {
+		if p, ok := paths[id]; ok {
+			return p
+		}
+		s := byID[id]
+		path := s.Name
+		if parent, ok := byID[s.ParentID]; ok && s.ParentID != 0 && !seen[parent.ID] {
+			seen[id] = true
+			path = resolve(parent.ID, seen) + sep + s.Name
+		}
+		paths[id] = path
+		return path
+	}
+
+	for _, s := range sections {
+		resolve(s.ID, map[int64]bool{})
+	}
+	return paths
+}
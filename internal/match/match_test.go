@@ -0,0 +1,119 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNormalize(t *testing.T) {
+	n, err := ParseNormalize("ws, case")
+	require.NoError(t, err)
+	assert.Equal(t, Normalize{Whitespace: true, Case: true}, n)
+
+	n, err = ParseNormalize("all")
+	require.NoError(t, err)
+	assert.Equal(t, Normalize{Whitespace: true, Case: true, Punctuation: true}, n)
+
+	n, err = ParseNormalize("")
+	require.NoError(t, err)
+	assert.Equal(t, Normalize{}, n)
+
+	_, err = ParseNormalize("accents")
+	assert.ErrorContains(t, err, "accents")
+}
+
+func TestNormalizeApply(t *testing.T) {
+	all := Normalize{Whitespace: true, Case: true, Punctuation: true}
+	assert.Equal(t, "login with sso", all.Apply("  Login — with SSO!  "))
+	assert.Equal(t, "a b", Normalize{Whitespace: true}.Apply(" a \t b "))
+	assert.Equal(t, "A.B", Normalize{}.Apply("A.B"))
+}
+
+func TestKey(t *testing.T) {
+	k, err := ParseKey("Section_Path + title")
+	require.NoError(t, err)
+	assert.Equal(t, Key{"section_path", "title"}, k)
+	assert.True(t, k.Has("title"))
+	assert.Equal(t, "section_path+title", k.String())
+
+	values := map[string]string{"section_path": "Auth", "title": "Login"}
+	assert.Equal(t, "Auth"+keySep+"Login", k.Value(func(f string) string { return values[f] }))
+	assert.Equal(t, "", k.Value(func(string) string { return "" }), "no key when every field is empty")
+
+	_, err = ParseKey("refs+")
+	assert.Error(t, err)
+}
+
+func TestMatch(t *testing.T) {
+	first := []Item{
+		{ID: 1, Name: "Login", Key: "Login"},
+		{ID: 2, Name: "Checkout with coupon", Key: "Checkout with coupon"},
+		{ID: 3, Name: "Export", Key: "Export"},
+		{ID: 4, Name: "", Key: ""},
+	}
+	second := []Item{
+		{ID: 10, Name: "login ", Key: "login "},
+		{ID: 20, Name: "Checkout with coupons", Key: "Checkout with coupons"},
+		{ID: 30, Name: "Import settings", Key: "Import settings"},
+	}
+
+	res, err := Match(first, second, Options{
+		Normalize: Normalize{Whitespace: true, Case: true},
+		Threshold: 0.8,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, res.Exact, 1)
+	assert.Equal(t, [2]int64{1, 10}, [2]int64{res.Exact[0].First.ID, res.Exact[0].Second.ID})
+	require.Len(t, res.Near, 1)
+	assert.Equal(t, [2]int64{2, 20}, [2]int64{res.Near[0].First.ID, res.Near[0].Second.ID})
+	assert.InDelta(t, 0.95, res.Near[0].Score, 0.01)
+	assert.Len(t, res.OnlyInFirst, 3, "near matches stay in the only-in lists")
+	assert.Len(t, res.OnlyInSecond, 2)
+
+	_, err = Match(first, second, Options{Algorithm: "soundex"})
+	assert.ErrorContains(t, err, "soundex")
+}
+
+func TestMatchNearLimits(t *testing.T) {
+	first := []Item{{ID: 1, Key: "login page"}, {ID: 2, Key: "export report"}}
+	second := []Item{{ID: 10, Key: "login pages"}, {ID: 20, Key: "export reports"}, {ID: 30, Key: "unrelated"}}
+
+	res, err := Match(first, second, Options{Threshold: 0.3, Algorithm: Jaccard})
+	require.NoError(t, err)
+	assert.Len(t, res.Near, 2)
+	assert.False(t, res.NearTruncated)
+
+	res, err = Match(first, second, Options{Threshold: 0.8, MaxComparisons: 1})
+	require.NoError(t, err)
+	assert.True(t, res.NearTruncated)
+	require.Len(t, res.Near, 1)
+	assert.Equal(t, [2]int64{1, 10}, [2]int64{res.Near[0].First.ID, res.Near[0].Second.ID})
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, LevenshteinRatio("abc", "abc"))
+	assert.InDelta(t, 1-3.0/7, LevenshteinRatio("kitten", "sitting"), 1e-9)
+	assert.Equal(t, 0.0, LevenshteinRatio("abc", "xyz"))
+
+	assert.InDelta(t, 2.0/4, TokenJaccard("open the page", "open page now"), 1e-9)
+	assert.Equal(t, 1.0, TokenJaccard("b a", "a b"))
+}
+
+func TestOptionsValidate(t *testing.T) {
+	assert.NoError(t, Options{Threshold: 0.9, Algorithm: Jaccard}.Validate())
+	assert.Error(t, Options{Threshold: 1.5}.Validate())
+	assert.Error(t, Options{Algorithm: "x"}.Validate())
+}
+
+func TestSectionPaths(t *testing.T) {
+	paths := SectionPaths(data.GetSectionsResponse{
+		{ID: 1, Name: "Root"},
+		{ID: 2, Name: "Child", ParentID: 1},
+		{ID: 3, Name: "Orphan", ParentID: 99},
+	}, " > ")
+	assert.Equal(t, map[int64]string{1: "Root", 2: "Root > Child", 3: "Orphan"}, paths)
+}
@@ -112,6 +112,46 @@ jq_format: %v
 # Enable gotr debug output.
 debug: %v
 
+# Named connection profiles (optional). Select one with --profile <name>,
+# the GOTR_PROFILE environment variable or current_profile below.
+# Profile values override the top-level connection settings above; a profile
+# may also carry its own compare section. Manage with 'gotr config profile'.
+#
+# current_profile: "staging"
+# profiles:
+#   staging:
+#     base_url: "https://staging.testrail.example.local"
+#     username: "qa@example.com"
+#     api_key: "staging_api_key"
+#     insecure: true
+#   production:
+#     base_url: "https://yourcompany.testrail.io"
+#     username: "qa@example.com"
+#     api_key: "production_api_key"
+#     compare:
+#       cloud_tier: "enterprise"
+
+# Requests per minute shared by every command (one token bucket per process).
+# The rate drops automatically on HTTP 429 and slow responses.
+#   -1 -> automatic: cloud by compare.cloud_tier (180/300), server unlimited
+#    0 -> rate limiting disabled
+#   >0 -> fixed value in req/min
+rate_limit: -1
+
+retry:
+  # How many times a request is re-sent after HTTP 429/502/503/504.
+  # 0 disables retries.
+  max_retries: 3
+
+  # Backoff between attempts (doubles each time, capped by max_delay).
+  # A Retry-After header from the server takes precedence.
+  initial_delay: "1s"
+  max_delay: "60s"
+
+  # Also retry POST requests. Off by default: add_* endpoints are not
+  # idempotent and a retried POST may create duplicates.
+  post: false
+
 compare:
   # Deployment mode for compare requests:
   #   auto   - attempt to detect from URL (cloud/server)
@@ -0,0 +1,249 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config keys holding named connection profiles.
+const (
+	ProfilesKey       = "profiles"
+	CurrentProfileKey = "current_profile"
+)
+
+// ProfileEnvVar selects the active profile when --profile is not given.
+const ProfileEnvVar = "GOTR_PROFILE"
+
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// Profile stores connection settings for one TestRail instance.
+// Compare holds optional overrides of the top-level compare section.
+type Profile struct {
+	BaseURL  string         `yaml:"base_url"`
+	Username string         `yaml:"username"`
+	APIKey   string         `yaml:"api_key"`
+	Insecure bool           `yaml:"insecure"`
+	Compare  map[string]any `yaml:"compare,omitempty"`
+}
+
+// ProfileSet is the profile section of a config file.
+type ProfileSet struct {
+	Current  string             `yaml:"current_profile"`
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// Names returns profile names in alphabetical order.
+func (s ProfileSet) Names() []string {
+	names := make([]string, 0, len(s.Profiles))
+	for name := range s.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NormalizeProfileName returns the stored form of a profile name. Viper
+// lowercases config keys, so profiles are stored and looked up in lower case.
+func NormalizeProfileName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// ValidateProfileName checks that a profile name is usable as a YAML key and CLI argument.
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '.', '-' or '_'", name)
+	}
+	return nil
+}
+
+// LoadProfiles reads the profile section of the config file, with names
+// normalized by NormalizeProfileName. A missing file yields an empty set.
+func (c *Config) LoadProfiles() (ProfileSet, error) {
+	set := ProfileSet{Profiles: map[string]Profile{}}
+
+	content, err := os.ReadFile(c.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return set, nil
+		}
+		return set, fmt.Errorf("failed to read config %s: %w", c.Path, err)
+	}
+
+	if err := yaml.Unmarshal(content, &set); err != nil {
+		return set, fmt.Errorf("failed to parse config %s: %w", c.Path, err)
+	}
+	profiles := make(map[string]Profile, len(set.Profiles))
+	for name, p := range set.Profiles {
+		profiles[NormalizeProfileName(name)] = p
+	}
+	set.Profiles = profiles
+	set.Current = NormalizeProfileName(set.Current)
+	return set, nil
+}
+
+// AddProfile adds or replaces a named profile, keeping the rest of the file
+// (including comments) intact. The name is stored in lower case and replaces
+// a profile whose name differs only in case.
+func (c *Config) AddProfile(name string, profile Profile) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	name = NormalizeProfileName(name)
+
+	var value yaml.Node
+	if err := value.Encode(profile); err != nil {
+		return fmt.Errorf("failed to encode profile %q: %w", name, err)
+	}
+
+	return c.editDocument(func(root *yaml.Node) error {
+		profiles := mappingValue(root, ProfilesKey)
+		if profiles == nil || profiles.Kind != yaml.MappingNode {
+			profiles = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			setMappingValue(root, ProfilesKey, profiles)
+		}
+		if key, ok := profileKey(profiles, name); ok && key != name {
+			deleteMappingKey(profiles, key)
+		}
+		setMappingValue(profiles, name, &value)
+		return nil
+	})
+}
+
+// UseProfile makes the named profile the default for subsequent runs.
+// Names are matched case-insensitively.
+func (c *Config) UseProfile(name string) error {
+	return c.editDocument(func(root *yaml.Node) error {
+		if _, ok := profileKey(mappingValue(root, ProfilesKey), name); !ok {
+			return fmt.Errorf("profile %q not found in %s", name, c.Path)
+		}
+		current := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: NormalizeProfileName(name)}
+		setMappingValue(root, CurrentProfileKey, current)
+		return nil
+	})
+}
+
+// RemoveProfile deletes a named profile; if it was the current one,
+// current_profile is cleared as well. Names are matched case-insensitively.
+func (c *Config) RemoveProfile(name string) error {
+	return c.editDocument(func(root *yaml.Node) error {
+		profiles := mappingValue(root, ProfilesKey)
+		key, ok := profileKey(profiles, name)
+		if !ok {
+			return fmt.Errorf("profile %q not found in %s", name, c.Path)
+		}
+		deleteMappingKey(profiles, key)
+		if current := mappingValue(root, CurrentProfileKey); current != nil && NormalizeProfileName(current.Value) == NormalizeProfileName(name) {
+			deleteMappingKey(root, CurrentProfileKey)
+		}
+		return nil
+	})
+}
+
+// profileKey returns the key of the profile named name, written in any case,
+// in the profiles mapping node.
+func profileKey(profiles *yaml.Node, name string) (string, bool) {
+	if profiles == nil || profiles.Kind != yaml.MappingNode {
+		return "", false
+	}
+	name = NormalizeProfileName(name)
+	for i := 0; i+1 < len(profiles.Content); i += 2 {
+		if key := profiles.Content[i].Value; NormalizeProfileName(key) == name {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// editDocument loads the config as a YAML node tree, applies fn to the root
+// mapping and writes the result back. A missing file is created from the template.
+func (c *Config) editDocument(fn func(root *yaml.Node) error) error {
+	content, err := os.ReadFile(c.Path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read config %s: %w", c.Path, err)
+		}
+		content = []byte(c.renderTemplate())
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return fmt.Errorf("failed to parse config %s: %w", c.Path, err)
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("config %s: top level must be a mapping", c.Path)
+	}
+
+	if err := fn(root); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(c.Path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", c.Path, err)
+	}
+	return nil
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue replaces the value for key or appends a new pair.
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		value,
+	)
+}
+
+// deleteMappingKey removes key from a mapping node; reports whether it existed.
+func deleteMappingKey(node *yaml.Node, key string) bool {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadProfiles_MissingFile(t *testing.T) {
+	cfg := New(filepath.Join(t.TempDir(), "missing.yaml"))
+
+	set, err := cfg.LoadProfiles()
+	require.NoError(t, err)
+	assert.Empty(t, set.Profiles)
+	assert.Empty(t, set.Current)
+}
+
+func TestLoadProfiles_ParsesSection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "default.yaml")
+	content := `base_url: "https://main.example.com"
+current_profile: prod
+profiles:
+  prod:
+    base_url: "https://acme.testrail.io"
+    username: "qa@example.com"
+    api_key: "k1"
+    compare:
+      cloud_tier: enterprise
+  staging:
+    base_url: "https://staging.local"
+    username: "qa@example.com"
+    api_key: "k2"
+    insecure: true
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	set, err := New(path).LoadProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, "prod", set.Current)
+	assert.Equal(t, []string{"prod", "staging"}, set.Names())
+	assert.True(t, set.Profiles["staging"].Insecure)
+	assert.Equal(t, "enterprise", set.Profiles["prod"].Compare["cloud_tier"])
+}
+
+func TestLoadProfiles_InvalidYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "default.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("profiles: [\n"), 0o600))
+
+	_, err := New(path).LoadProfiles()
+	assert.Error(t, err)
+}
+
+func TestAddUseRemoveProfile_PreservesComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "default.yaml")
+	cfg := New(path).WithDefaults()
+	require.NoError(t, cfg.Create())
+
+	require.NoError(t, cfg.AddProfile("staging", Profile{BaseURL: "https://staging.local", Username: "a@b.c", APIKey: "k", Insecure: true}))
+	require.NoError(t, cfg.AddProfile("prod", Profile{BaseURL: "https://acme.testrail.io", Username: "a@b.c", APIKey: "k2"}))
+	require.NoError(t, cfg.UseProfile("staging"))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# TestRail user API key.")
+	assert.Contains(t, string(content), "auto_retry_failed_pages: true")
+
+	set, err := cfg.LoadProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, "staging", set.Current)
+	assert.Equal(t, []string{"prod", "staging"}, set.Names())
+
+	// Replacing keeps a single entry
+	require.NoError(t, cfg.AddProfile("prod", Profile{BaseURL: "https://other.testrail.io", Username: "x@y.z", APIKey: "k3"}))
+	set, err = cfg.LoadProfiles()
+	require.NoError(t, err)
+	assert.Len(t, set.Profiles, 2)
+	assert.Equal(t, "https://other.testrail.io", set.Profiles["prod"].BaseURL)
+
+	require.NoError(t, cfg.RemoveProfile("staging"))
+	set, err = cfg.LoadProfiles()
+	require.NoError(t, err)
+	assert.Empty(t, set.Current, "removing current profile clears current_profile")
+	assert.Equal(t, []string{"prod"}, set.Names())
+
+	st, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), st.Mode().Perm())
+}
+
+func TestAddProfile_CreatesMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "default.yaml")
+	cfg := New(path)
+
+	require.NoError(t, cfg.AddProfile("qa", Profile{BaseURL: "https://qa.local", Username: "u", APIKey: "k"}))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(string(content), "profiles:"))
+}
+
+func TestProfiles_NamesAreCaseInsensitive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "default.yaml")
+	content := `current_profile: Prod
+profiles:
+  Prod:
+    base_url: "https://acme.testrail.io"
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	cfg := New(path)
+
+	set, err := cfg.LoadProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, "prod", set.Current)
+	assert.Equal(t, []string{"prod"}, set.Names())
+
+	// A name differing only in case replaces the existing profile
+	require.NoError(t, cfg.AddProfile("PROD", Profile{BaseURL: "https://other.testrail.io"}))
+	require.NoError(t, cfg.AddProfile("Staging", Profile{BaseURL: "https://staging.local"}))
+	require.NoError(t, cfg.UseProfile("STAGING"))
+
+	set, err = cfg.LoadProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, "staging", set.Current)
+	assert.Equal(t, []string{"prod", "staging"}, set.Names())
+	assert.Equal(t, "https://other.testrail.io", set.Profiles["prod"].BaseURL)
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "Staging:")
+	assert.Contains(t, string(raw), "current_profile: staging")
+
+	require.NoError(t, cfg.RemoveProfile("Staging"))
+	set, err = cfg.LoadProfiles()
+	require.NoError(t, err)
+	assert.Empty(t, set.Current, "removing current profile clears current_profile")
+	assert.Equal(t, []string{"prod"}, set.Names())
+}
+
+func TestProfileErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "default.yaml")
+	cfg := New(path)
+
+	assert.Error(t, cfg.AddProfile("bad name", Profile{}))
+	assert.Error(t, cfg.AddProfile("", Profile{}))
+	assert.ErrorContains(t, cfg.UseProfile("ghost"), `profile "ghost" not found`)
+	assert.ErrorContains(t, cfg.RemoveProfile("ghost"), `profile "ghost" not found`)
+
+	require.NoError(t, os.WriteFile(path, []byte("- a\n- b\n"), 0o600))
+	assert.ErrorContains(t, cfg.AddProfile("qa", Profile{}), "top level must be a mapping")
+}
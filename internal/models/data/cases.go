@@ -32,8 +32,41 @@ type Case struct {
 	CustomMission        string  `json:"custom_mission,omitempty"`
 	CustomGoals          string  `json:"custom_goals,omitempty"`
 	Labels               []Label `json:"labels,omitempty"` // Label from shared.go
-	// For fully custom fields (if TestRail returns an unknown field)
-	CustomFields json.RawMessage `json:"custom_fields,omitempty"`
+	// Site-specific custom_* fields not listed above (see CustomFieldValues)
+	CustomFields CustomFieldValues `json:"-"`
+}
+
+// UnmarshalJSON decodes a case and collects unmodeled custom_* fields into CustomFields.
+func (c *Case) UnmarshalJSON(data []byte) error {
+	type plain Case
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+	custom, err := decodeCustomFields(data, (*plain)(c))
+	if err != nil {
+		return err
+	}
+	c.CustomFields = custom
+	return nil
+}
+
+// MarshalJSON encodes a case with CustomFields as top-level keys.
+func (c Case) MarshalJSON() ([]byte, error) {
+	type plain Case
+	p := plain(c)
+	return encodeCustomFields(&p, c.CustomFields)
+}
+
+// ExtraColumns returns the custom fields as text, for tabular exports (CSV).
+func (c Case) ExtraColumns() map[string]string {
+	if len(c.CustomFields) == 0 {
+		return nil
+	}
+	cols := make(map[string]string, len(c.CustomFields))
+	for k := range c.CustomFields {
+		cols[k] = c.CustomFields.String(k)
+	}
+	return cols
 }
 
 // GetCasesResponse is the response for get_cases (paginated list of cases).
@@ -92,6 +125,29 @@ type AddCaseRequest struct {
 	Refs                 string `json:"refs,omitempty"`
 	MilestoneID          int64  `json:"milestone_id,omitempty"`
 	TemplateID           int64  `json:"template_id,omitempty"`
+	// Site-specific custom_* fields, sent as top-level keys
+	CustomFields CustomFieldValues `json:"-"`
+}
+
+// UnmarshalJSON decodes the request and collects unmodeled custom_* fields into CustomFields.
+func (r *AddCaseRequest) UnmarshalJSON(data []byte) error {
+	type plain AddCaseRequest
+	if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+		return err
+	}
+	custom, err := decodeCustomFields(data, (*plain)(r))
+	if err != nil {
+		return err
+	}
+	r.CustomFields = custom
+	return nil
+}
+
+// MarshalJSON encodes the request with CustomFields as top-level keys.
+func (r AddCaseRequest) MarshalJSON() ([]byte, error) {
+	type plain AddCaseRequest
+	p := plain(r)
+	return encodeCustomFields(&p, r.CustomFields)
 }
 
 // UpdateCaseRequest is the request for update_case (partial updates).
@@ -110,6 +166,29 @@ type UpdateCaseRequest struct {
 	SuiteID              *int64  `json:"suite_id,omitempty"`    // Move between suites
 	SectionID            *int64  `json:"section_id,omitempty"`  // Move between sections
 	TemplateID           *int64  `json:"template_id,omitempty"` // Change the template
+	// Site-specific custom_* fields, sent as top-level keys
+	CustomFields CustomFieldValues `json:"-"`
+}
+
+// UnmarshalJSON decodes the request and collects unmodeled custom_* fields into CustomFields.
+func (r *UpdateCaseRequest) UnmarshalJSON(data []byte) error {
+	type plain UpdateCaseRequest
+	if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+		return err
+	}
+	custom, err := decodeCustomFields(data, (*plain)(r))
+	if err != nil {
+		return err
+	}
+	r.CustomFields = custom
+	return nil
+}
+
+// MarshalJSON encodes the request with CustomFields as top-level keys.
+func (r UpdateCaseRequest) MarshalJSON() ([]byte, error) {
+	type plain UpdateCaseRequest
+	p := plain(r)
+	return encodeCustomFields(&p, r.CustomFields)
 }
 
 // CopyCasesRequest is the request for copy_cases_to_section.
@@ -142,30 +221,53 @@ type GetCaseTypesResponse []struct {
 	Name      string `json:"name"`
 }
 
-// GetCaseFieldsResponse is the response for get_case_fields.
-type GetCaseFieldsResponse []struct {
-	Configs []struct {
-		Context struct {
-			IsGlobal   bool    `json:"is_global"`
-			ProjectIDs []int64 `json:"project_ids,omitempty"`
-		} `json:"context"`
-		ID      string `json:"id"`
-		Options struct {
-			DefaultValue string `json:"default_value,omitempty"`
-			Format       string `json:"format,omitempty"`
-			IsRequired   bool   `json:"is_required"`
-			Rows         string `json:"rows,omitempty"`
-		} `json:"options"`
-	} `json:"configs"`
-	Description  string `json:"description"`
-	DisplayOrder int    `json:"display_order"`
-	ID           int64  `json:"id"`
-	Label        string `json:"label"`
-	Name         string `json:"name"`
-	SystemName   string `json:"system_name"`
-	TypeID       int64  `json:"type_id"`
+// Case field types (CaseField.TypeID).
+const (
+	CaseFieldTypeString      = 1
+	CaseFieldTypeInteger     = 2
+	CaseFieldTypeText        = 3
+	CaseFieldTypeURL         = 4
+	CaseFieldTypeCheckbox    = 5
+	CaseFieldTypeDropdown    = 6
+	CaseFieldTypeUser        = 7
+	CaseFieldTypeDate        = 8
+	CaseFieldTypeMilestone   = 9
+	CaseFieldTypeSteps       = 10
+	CaseFieldTypeStepResults = 11
+	CaseFieldTypeMultiSelect = 12
+)
+
+// CaseField is a custom case field returned by get_case_fields.
+type CaseField struct {
+	Configs      []CaseFieldConfig `json:"configs"`
+	Description  string            `json:"description"`
+	DisplayOrder int               `json:"display_order"`
+	ID           int64             `json:"id"`
+	Label        string            `json:"label"`
+	Name         string            `json:"name"`
+	SystemName   string            `json:"system_name"`
+	TypeID       int64             `json:"type_id"`
 }
 
+// CaseFieldConfig is one project context of a case field.
+type CaseFieldConfig struct {
+	Context struct {
+		IsGlobal   bool    `json:"is_global"`
+		ProjectIDs []int64 `json:"project_ids,omitempty"`
+	} `json:"context"`
+	ID      string `json:"id"`
+	Options struct {
+		DefaultValue string `json:"default_value,omitempty"`
+		Format       string `json:"format,omitempty"`
+		IsRequired   bool   `json:"is_required"`
+		Items        string `json:"items,omitempty"` // Dropdown/multi-select options, "id, label" per line
+		Rows         string `json:"rows,omitempty"`
+	} `json:"options"`
+}
+
+// GetCaseFieldsResponse is the response for get_case_fields.
+type GetCaseFieldsResponse []CaseField
+
 // AddCaseFieldRequest is the request for add_case_field.
 type AddCaseFieldRequest struct {
 	Type        string `json:"type"`
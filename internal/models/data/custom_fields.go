@@ -0,0 +1,137 @@
+// models/data/custom_fields.go
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// customFieldPrefix starts the JSON name of every TestRail custom field.
+const customFieldPrefix = "custom_"
+
+// CustomFieldValues holds custom fields that are not modeled as struct fields,
+// keyed by their JSON name (e.g. "custom_browser") with the raw JSON value.
+// TestRail sends them as top-level keys, so they are collected on decode and
+// written back as top-level keys on encode.
+type CustomFieldValues map[string]json.RawMessage
+
+// Keys returns the field names in sorted order.
+func (c CustomFieldValues) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// String returns a field value as plain text: strings are unquoted, null is
+// empty, anything else is compact JSON.
+func (c CustomFieldValues) String(key string) string {
+	raw := bytes.TrimSpace(c[key])
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
+
+// Clone returns a copy of the map, leaving out null values.
+func (c CustomFieldValues) Clone() CustomFieldValues {
+	if len(c) == 0 {
+		return nil
+	}
+	res := make(CustomFieldValues, len(c))
+	for k, v := range c {
+		if trimmed := bytes.TrimSpace(v); len(trimmed) == 0 || string(trimmed) == "null" {
+			continue
+		}
+		res[k] = append(json.RawMessage(nil), v...)
+	}
+	return res
+}
+
+// knownFieldsCache maps a struct type to the set of its JSON field names.
+var knownFieldsCache sync.Map
+
+func knownJSONFields(t reflect.Type) map[string]bool {
+	if cached, ok := knownFieldsCache.Load(t); ok {
+		return cached.(map[string]bool)
+	}
+	known := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			known[name] = true
+		}
+	}
+	knownFieldsCache.Store(t, known)
+	return known
+}
+
+// decodeCustomFields collects the custom_* keys of a JSON object that are not
+// fields of the struct type of v.
+func decodeCustomFields(data []byte, v any) (CustomFieldValues, error) {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	known := knownJSONFields(reflect.TypeOf(v).Elem())
+
+	var custom CustomFieldValues
+	for k, raw := range all {
+		if !strings.HasPrefix(k, customFieldPrefix) || known[k] {
+			continue
+		}
+		if custom == nil {
+			custom = make(CustomFieldValues)
+		}
+		custom[k] = raw
+	}
+	return custom, nil
+}
+
+// encodeCustomFields marshals v and appends the custom fields as top-level
+// keys in sorted order. Keys that are struct fields of v are skipped.
+func encodeCustomFields(v any, custom CustomFieldValues) ([]byte, error) {
+	base, err := json.Marshal(v)
+	if err != nil || len(custom) == 0 {
+		return base, err
+	}
+	known := knownJSONFields(reflect.TypeOf(v).Elem())
+
+	var buf bytes.Buffer
+	buf.Write(base[:len(base)-1]) // drop the closing brace
+	first := len(base) == 2       // "{}"
+	for _, k := range custom.Keys() {
+		if known[k] {
+			continue
+		}
+		value, err := json.Marshal(custom[k])
+		if err != nil {
+			return nil, fmt.Errorf("custom field %s: %w", k, err)
+		}
+		key, _ := json.Marshal(k)
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,110 @@
+package data
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCase_UnmarshalJSON_CollectsUnknownCustomFields(t *testing.T) {
+	raw := []byte(`{
+		"id": 7,
+		"title": "Login",
+		"custom_preconds": "user exists",
+		"custom_browser": 2,
+		"custom_platforms": [1, 3],
+		"custom_notes": "text",
+		"custom_empty": null,
+		"estimate": "1m"
+	}`)
+
+	var c Case
+	require.NoError(t, json.Unmarshal(raw, &c))
+
+	assert.Equal(t, int64(7), c.ID)
+	assert.Equal(t, "user exists", c.CustomPreconds)
+	assert.Equal(t, []string{"custom_browser", "custom_empty", "custom_notes", "custom_platforms"}, c.CustomFields.Keys())
+	assert.NotContains(t, c.CustomFields, "custom_preconds", "modeled fields stay in the struct")
+	assert.Equal(t, "2", c.CustomFields.String("custom_browser"))
+	assert.Equal(t, "[1,3]", c.CustomFields.String("custom_platforms"))
+	assert.Equal(t, "text", c.CustomFields.String("custom_notes"))
+	assert.Equal(t, "", c.CustomFields.String("custom_empty"))
+}
+
+func TestCase_MarshalJSON_RoundTrip(t *testing.T) {
+	c := Case{
+		ID:             7,
+		Title:          "Login",
+		CustomPreconds: "user exists",
+		CustomFields: CustomFieldValues{
+			"custom_browser":  json.RawMessage(`2`),
+			"custom_preconds": json.RawMessage(`"ignored"`),
+		},
+	}
+
+	out, err := json.Marshal(c)
+	require.NoError(t, err)
+
+	var m map[string]any
+	require.NoError(t, json.Unmarshal(out, &m))
+	assert.Equal(t, float64(2), m["custom_browser"])
+	assert.Equal(t, "user exists", m["custom_preconds"], "struct field wins over a colliding custom key")
+
+	var back Case
+	require.NoError(t, json.Unmarshal(out, &back))
+	assert.Equal(t, c.Title, back.Title)
+	assert.JSONEq(t, `2`, string(back.CustomFields["custom_browser"]))
+}
+
+func TestCase_MarshalJSON_InvalidRawValue(t *testing.T) {
+	_, err := json.Marshal(Case{CustomFields: CustomFieldValues{"custom_x": json.RawMessage(`{invalid`)}})
+	assert.Error(t, err)
+}
+
+func TestAddCaseRequest_MarshalJSON_EmitsCustomFields(t *testing.T) {
+	req := AddCaseRequest{Title: "T", CustomFields: CustomFieldValues{"custom_browser": json.RawMessage(`"Chrome"`)}}
+	out, err := json.Marshal(req)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `"custom_browser":"Chrome"`)
+
+	var back AddCaseRequest
+	require.NoError(t, json.Unmarshal(out, &back))
+	assert.Equal(t, "Chrome", back.CustomFields.String("custom_browser"))
+}
+
+func TestUpdateCaseRequest_MarshalJSON_EmptyBase(t *testing.T) {
+	out, err := json.Marshal(UpdateCaseRequest{CustomFields: CustomFieldValues{"custom_a": json.RawMessage(`1`)}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"custom_a":1}`, string(out))
+
+	out, err = json.Marshal(&UpdateCaseRequest{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(out))
+}
+
+func TestCustomFieldValues_CloneDropsNulls(t *testing.T) {
+	c := CustomFieldValues{"custom_a": json.RawMessage(`1`), "custom_b": json.RawMessage(`null`)}
+	clone := c.Clone()
+	assert.Equal(t, []string{"custom_a"}, clone.Keys())
+	assert.Nil(t, CustomFieldValues(nil).Clone())
+}
+
+func TestResultForCaseEntry_JSON_CustomFieldsAndAttachments(t *testing.T) {
+	var e ResultForCaseEntry
+	require.NoError(t, json.Unmarshal([]byte(`{"case_id": 5, "status_id": 1, "custom_env": "staging", "attachments": ["shot.png"]}`), &e))
+	assert.Equal(t, "staging", e.CustomFields.String("custom_env"))
+	assert.Equal(t, []string{"shot.png"}, e.Attachments)
+
+	out, err := json.Marshal(AddResultsForCasesRequest{Results: []ResultForCaseEntry{e}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"results": [{"case_id": 5, "status_id": 1, "custom_env": "staging"}]}`, string(out), "attachments stay local")
+}
+
+func TestAddResultForCaseRequest_MarshalJSON_EmitsCustomFields(t *testing.T) {
+	req := AddResultForCaseRequest{StatusID: 1, CustomFields: CustomFieldValues{"custom_build": json.RawMessage(`42`)}}
+	out, err := json.Marshal(req)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"status_id": 1, "custom_build": 42}`, string(out))
+}
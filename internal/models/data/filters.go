@@ -0,0 +1,159 @@
+// models/data/filters.go
+package data
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Server-side filters for list endpoints. Zero values are not sent; Query
+// returns the query parameters to add to the request (offset/limit are
+// added by the paginator).
+
+// CaseFilter filters get_cases.
+// https://support.testrail.com/hc/en-us/articles/7077292642580-Cases#getcases
+type CaseFilter struct {
+	SuiteID       int64
+	SectionID     int64
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	CreatedBy     []int64
+	UpdatedAfter  time.Time
+	UpdatedBefore time.Time
+	UpdatedBy     []int64
+	MilestoneIDs  []int64
+	PriorityIDs   []int64
+	TemplateIDs   []int64
+	TypeIDs       []int64
+	Refs          string // refs: a single reference ID, e.g. "TR-1"
+	Title         string // filter: only cases whose title contains the string
+}
+
+// Query returns the get_cases query parameters.
+func (f CaseFilter) Query() map[string]string {
+	q := make(map[string]string)
+	setID(q, "suite_id", f.SuiteID)
+	setID(q, "section_id", f.SectionID)
+	setTime(q, "created_after", f.CreatedAfter)
+	setTime(q, "created_before", f.CreatedBefore)
+	setIDs(q, "created_by", f.CreatedBy)
+	setTime(q, "updated_after", f.UpdatedAfter)
+	setTime(q, "updated_before", f.UpdatedBefore)
+	setIDs(q, "updated_by", f.UpdatedBy)
+	setIDs(q, "milestone_id", f.MilestoneIDs)
+	setIDs(q, "priority_id", f.PriorityIDs)
+	setIDs(q, "template_id", f.TemplateIDs)
+	setIDs(q, "type_id", f.TypeIDs)
+	setString(q, "refs", f.Refs)
+	setString(q, "filter", f.Title)
+	return q
+}
+
+// RunFilter filters get_runs.
+// https://support.testrail.com/hc/en-us/articles/7077816294684-Runs#getruns
+type RunFilter struct {
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	CreatedBy     []int64
+	IsCompleted   *bool
+	MilestoneIDs  []int64
+	SuiteIDs      []int64
+	Refs          string // refs_filter
+}
+
+// Query returns the get_runs query parameters.
+func (f RunFilter) Query() map[string]string {
+	q := make(map[string]string)
+	setTime(q, "created_after", f.CreatedAfter)
+	setTime(q, "created_before", f.CreatedBefore)
+	setIDs(q, "created_by", f.CreatedBy)
+	setBool(q, "is_completed", f.IsCompleted)
+	setIDs(q, "milestone_id", f.MilestoneIDs)
+	setIDs(q, "suite_id", f.SuiteIDs)
+	setString(q, "refs_filter", f.Refs)
+	return q
+}
+
+// PlanFilter filters get_plans.
+// https://support.testrail.com/hc/en-us/articles/7077996481044-Plans#getplans
+type PlanFilter struct {
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	CreatedBy     []int64
+	IsCompleted   *bool
+	MilestoneIDs  []int64
+}
+
+// Query returns the get_plans query parameters.
+func (f PlanFilter) Query() map[string]string {
+	q := make(map[string]string)
+	setTime(q, "created_after", f.CreatedAfter)
+	setTime(q, "created_before", f.CreatedBefore)
+	setIDs(q, "created_by", f.CreatedBy)
+	setBool(q, "is_completed", f.IsCompleted)
+	setIDs(q, "milestone_id", f.MilestoneIDs)
+	return q
+}
+
+// ResultFilter filters get_results_for_run.
+// https://support.testrail.com/hc/en-us/articles/7077874763156-Results#getresultsforrun
+type ResultFilter struct {
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	CreatedBy     []int64
+	StatusIDs     []int64
+	Defects       string // defects_filter: a single defect ID, e.g. "BUG-1"
+}
+
+// Query returns the get_results_for_run query parameters.
+func (f ResultFilter) Query() map[string]string {
+	q := make(map[string]string)
+	setTime(q, "created_after", f.CreatedAfter)
+	setTime(q, "created_before", f.CreatedBefore)
+	setIDs(q, "created_by", f.CreatedBy)
+	setIDs(q, "status_id", f.StatusIDs)
+	setString(q, "defects_filter", f.Defects)
+	return q
+}
+
+func setID(q map[string]string, key string, id int64) {
+	if id > 0 {
+		q[key] = strconv.FormatInt(id, 10)
+	}
+}
+
+// setIDs writes a comma-separated list, as TestRail expects for multi-value filters.
+func setIDs(q map[string]string, key string, ids []int64) {
+	if len(ids) == 0 {
+		return
+	}
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatInt(id, 10)
+	}
+	q[key] = strings.Join(parts, ",")
+}
+
+func setTime(q map[string]string, key string, t time.Time) {
+	if !t.IsZero() {
+		q[key] = strconv.FormatInt(t.Unix(), 10)
+	}
+}
+
+func setBool(q map[string]string, key string, b *bool) {
+	if b == nil {
+		return
+	}
+	if *b {
+		q[key] = "1"
+	} else {
+		q[key] = "0"
+	}
+}
+
+func setString(q map[string]string, key, s string) {
+	if s != "" {
+		q[key] = s
+	}
+}
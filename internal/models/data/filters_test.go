@@ -0,0 +1,44 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaseFilter_Query(t *testing.T) {
+	assert.Empty(t, CaseFilter{}.Query())
+
+	q := CaseFilter{
+		SuiteID:      2,
+		CreatedAfter: time.Unix(100, 0),
+		TypeIDs:      []int64{1, 3},
+		Refs:         "TR-1",
+		Title:        "login",
+	}.Query()
+	assert.Equal(t, map[string]string{
+		"suite_id":      "2",
+		"created_after": "100",
+		"type_id":       "1,3",
+		"refs":          "TR-1",
+		"filter":        "login",
+	}, q)
+}
+
+func TestCaseAndRunFilter_RefsKey(t *testing.T) {
+	// get_cases takes refs, get_runs takes refs_filter
+	assert.Equal(t, map[string]string{"refs": "TR-1"}, CaseFilter{Refs: "TR-1"}.Query())
+	assert.Equal(t, map[string]string{"refs_filter": "TR-1"}, RunFilter{Refs: "TR-1"}.Query())
+}
+
+func TestRunAndPlanFilter_IsCompleted(t *testing.T) {
+	done := true
+	assert.Equal(t, "1", RunFilter{IsCompleted: &done}.Query()["is_completed"])
+	assert.NotContains(t, PlanFilter{}.Query(), "is_completed")
+}
+
+func TestResultFilter_Query(t *testing.T) {
+	q := ResultFilter{StatusIDs: []int64{4, 5}, Defects: "BUG-1"}.Query()
+	assert.Equal(t, map[string]string{"status_id": "4,5", "defects_filter": "BUG-1"}, q)
+}
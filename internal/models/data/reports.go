@@ -22,7 +22,10 @@ type GetReportsResponse []ReportTemplate
 
 // RunReportResponse is the response for run_report.
 type RunReportResponse struct {
-	ReportID int64  `json:"report_id"`
-	URL      string `json:"url"`
-	Status   string `json:"status"`
+	ReportID   int64  `json:"report_id"`
+	URL        string `json:"url"`
+	Status     string `json:"status"`
+	ReportURL  string `json:"report_url"`  // Report page in the web UI
+	ReportHTML string `json:"report_html"` // HTML download, served once generated
+	ReportPDF  string `json:"report_pdf"`  // PDF download, served once generated
 }
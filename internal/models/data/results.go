@@ -37,13 +37,46 @@ type AddResultRequest struct {
 	Elapsed    string `json:"elapsed,omitempty"`       // The time it took to execute, e.g., "30m" or "1h 30m"
 	Defects    string `json:"defects,omitempty"`       // A comma-separated list of defects to link
 	AssignedTo int64  `json:"assignedto_id,omitempty"` // The ID of the user the test should be assigned to
-	// Custom fields are added dynamically via json.RawMessage if needed
+
+	// CustomFields holds custom result fields (custom_*), sent as top-level keys.
+	CustomFields CustomFieldValues `json:"-"`
+}
+
+// UnmarshalJSON decodes the request and collects custom_* fields into CustomFields.
+func (r *AddResultRequest) UnmarshalJSON(data []byte) error {
+	type plain AddResultRequest
+	if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+		return err
+	}
+	custom, err := decodeCustomFields(data, (*plain)(r))
+	if err != nil {
+		return err
+	}
+	r.CustomFields = custom
+	return nil
+}
+
+// MarshalJSON encodes the request with CustomFields as top-level keys.
+func (r AddResultRequest) MarshalJSON() ([]byte, error) {
+	type plain AddResultRequest
+	p := plain(r)
+	return encodeCustomFields(&p, r.CustomFields)
 }
 
 // AddResultForCaseRequest is the request for add_result_for_case.
 // Uses the same fields as AddResultRequest.
 type AddResultForCaseRequest AddResultRequest
 
+// UnmarshalJSON decodes the request and collects custom_* fields into CustomFields.
+func (r *AddResultForCaseRequest) UnmarshalJSON(data []byte) error {
+	return (*AddResultRequest)(r).UnmarshalJSON(data)
+}
+
+// MarshalJSON encodes the request with CustomFields as top-level keys.
+func (r AddResultForCaseRequest) MarshalJSON() ([]byte, error) {
+	return AddResultRequest(r).MarshalJSON()
+}
+
 // AddResultsRequest is the request for bulk add_results.
 // https://support.testrail.com/hc/en-us/articles/7077874763156-Results#addresults
 type AddResultsRequest struct {
@@ -59,6 +92,35 @@ type ResultEntry struct {
 	Elapsed    string `json:"elapsed,omitempty"`       // The time it took to execute
 	Defects    string `json:"defects,omitempty"`       // A comma-separated list of defects
 	AssignedTo int64  `json:"assignedto_id,omitempty"` // The ID of the user to assign to
+
+	// CustomFields holds custom result fields (custom_*), sent as top-level keys.
+	CustomFields CustomFieldValues `json:"-"`
+	// Attachments lists local files to attach to the created result; they
+	// are read from bulk result files and never sent with the result.
+	Attachments []string `json:"attachments,omitempty"`
+}
+
+// UnmarshalJSON decodes the entry and collects custom_* fields into CustomFields.
+func (e *ResultEntry) UnmarshalJSON(data []byte) error {
+	type plain ResultEntry
+	if err := json.Unmarshal(data, (*plain)(e)); err != nil {
+		return err
+	}
+	custom, err := decodeCustomFields(data, (*plain)(e))
+	if err != nil {
+		return err
+	}
+	e.CustomFields = custom
+	return nil
+}
+
+// MarshalJSON encodes the entry with CustomFields as top-level keys and
+// without Attachments.
+func (e ResultEntry) MarshalJSON() ([]byte, error) {
+	type plain ResultEntry
+	p := plain(e)
+	p.Attachments = nil
+	return encodeCustomFields(&p, e.CustomFields)
 }
 
 // AddResultsForCasesRequest is the request for add_results_for_cases.
@@ -76,4 +138,32 @@ type ResultForCaseEntry struct {
 	Elapsed    string `json:"elapsed,omitempty"`       // The time it took to execute
 	Defects    string `json:"defects,omitempty"`       // A comma-separated list of defects
 	AssignedTo int64  `json:"assignedto_id,omitempty"` // The ID of the user to assign to
+	// CustomFields holds custom result fields (custom_*), sent as top-level keys.
+	CustomFields CustomFieldValues `json:"-"`
+	// Attachments lists local files to attach to the created result; they
+	// are read from bulk result files and never sent with the result.
+	Attachments []string `json:"attachments,omitempty"`
+}
+
+// UnmarshalJSON decodes the entry and collects custom_* fields into CustomFields.
+func (e *ResultForCaseEntry) UnmarshalJSON(data []byte) error {
+	type plain ResultForCaseEntry
+	if err := json.Unmarshal(data, (*plain)(e)); err != nil {
+		return err
+	}
+	custom, err := decodeCustomFields(data, (*plain)(e))
+	if err != nil {
+		return err
+	}
+	e.CustomFields = custom
+	return nil
+}
+
+// MarshalJSON encodes the entry with CustomFields as top-level keys and
+// without Attachments.
+func (e ResultForCaseEntry) MarshalJSON() ([]byte, error) {
+	type plain ResultForCaseEntry
+	p := plain(e)
+	p.Attachments = nil
+	return encodeCustomFields(&p, e.CustomFields)
 }
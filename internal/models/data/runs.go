@@ -51,7 +51,7 @@ type AddRunRequest struct {
 	SuiteID     int64   `json:"suite_id"`                // The ID of the test suite to create the run from (required)
 	MilestoneID int64   `json:"milestone_id,omitempty"`  // The ID of the milestone to link the run to
 	AssignedTo  int64   `json:"assignedto_id,omitempty"` // The ID of the user the test run should be assigned to
-	IncludeAll  bool    `json:"include_all,omitempty"`   // True to include all test cases, false otherwise
+	IncludeAll  *bool   `json:"include_all,omitempty"`   // True to include all test cases; add_run defaults to true and then ignores case_ids
 	CaseIDs     []int64 `json:"case_ids,omitempty"`      // Array of case IDs to include (if include_all is false)
 	ConfigIDs   []int64 `json:"config_ids,omitempty"`    // Array of configuration IDs for the test run
 	Refs        string  `json:"refs,omitempty"`          // A string of references/requirements
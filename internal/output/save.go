@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"time"
 
 	embed "github.com/Korrnals/gotr/embedded"
@@ -273,9 +274,9 @@ func saveToCSV(data interface{}, filePath string) (string, error) {
 		return filePath, nil
 	}
 
-	// Get headers from first element
+	// Get headers from first element, plus extra columns of all rows
 	firstElem := v.Index(0)
-	headers := getHeaders(firstElem)
+	headers := append(getHeaders(firstElem), extraHeaders(v)...)
 
 	// Write headers
 	if err := writer.Write(headers); err != nil {
@@ -285,6 +286,7 @@ func saveToCSV(data interface{}, filePath string) (string, error) {
 	// Write data rows
 	for i := 0; i < v.Len(); i++ {
 		row := getRowValues(v.Index(i), headers)
+		fillExtraColumns(v.Index(i), headers, row)
 		if err := writer.Write(row); err != nil {
 			return "", fmt.Errorf("error writing CSV row: %w", err)
 		}
@@ -293,6 +295,51 @@ func saveToCSV(data interface{}, filePath string) (string, error) {
 	return filePath, nil
 }
 
+// extraColumnsProvider is implemented by records with dynamic fields
+// (e.g. data.Case custom fields) that are exported as additional columns.
+type extraColumnsProvider interface {
+	ExtraColumns() map[string]string
+}
+
+func extraColumnsOf(v reflect.Value) map[string]string {
+	if !v.CanInterface() {
+		return nil
+	}
+	if p, ok := v.Interface().(extraColumnsProvider); ok {
+		return p.ExtraColumns()
+	}
+	return nil
+}
+
+// extraHeaders returns the sorted union of extra column names over all rows.
+func extraHeaders(rows reflect.Value) []string {
+	seen := make(map[string]bool)
+	var headers []string
+	for i := 0; i < rows.Len(); i++ {
+		for name := range extraColumnsOf(rows.Index(i)) {
+			if !seen[name] {
+				seen[name] = true
+				headers = append(headers, name)
+			}
+		}
+	}
+	sort.Strings(headers)
+	return headers
+}
+
+// fillExtraColumns writes the extra column values of a row into values.
+func fillExtraColumns(v reflect.Value, headers, values []string) {
+	extra := extraColumnsOf(v)
+	if len(extra) == 0 {
+		return
+	}
+	for i, h := range headers {
+		if val, ok := extra[h]; ok && values[i] == "" {
+			values[i] = val
+		}
+	}
+}
+
 // getHeaders extracts header names from a struct or map
 func getHeaders(v reflect.Value) []string {
 	if v.Kind() == reflect.Ptr {
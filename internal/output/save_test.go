@@ -165,6 +165,38 @@ func TestSaveToFile_CSV(t *testing.T) {
 	assert.Equal(t, []string{"2", "Test Case 2"}, records[2])
 }
 
+type extraColumnsRow struct {
+	ID    int64             `json:"id"`
+	Extra map[string]string `json:"-"`
+}
+
+func (r extraColumnsRow) ExtraColumns() map[string]string { return r.Extra }
+
+func TestSaveToFile_CSVExtraColumns(t *testing.T) {
+	tempHome := t.TempDir()
+	origHome := os.Getenv("HOME")
+	os.Setenv("HOME", tempHome)
+	defer os.Setenv("HOME", origHome)
+
+	data := []extraColumnsRow{
+		{ID: 1, Extra: map[string]string{"custom_b": "x"}},
+		{ID: 2, Extra: map[string]string{"custom_a": "y", "custom_b": "z"}},
+	}
+
+	path, err := SaveToFile(data, "test-resource", "csv")
+	require.NoError(t, err)
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "custom_a", "custom_b"}, records[0])
+	assert.Equal(t, []string{"1", "", "x"}, records[1])
+	assert.Equal(t, []string{"2", "y", "z"}, records[2])
+}
+
 func TestSaveToFile_CSVWithMapSlice(t *testing.T) {
 	tempHome := t.TempDir()
 	origHome := os.Getenv("HOME")
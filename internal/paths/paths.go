@@ -19,6 +19,8 @@ const (
 	CacheDir    = "cache"    // API cache
 	ExportsDir  = "exports"  // user data exports
 	TempDir     = "temp"     // temporary files
+	SyncDir     = "sync"     // incremental sync state
+	TrashDir    = "trash"    // deleted objects kept for restore
 )
 
 // BaseDir returns the path to ~/.gotr.
@@ -96,6 +98,24 @@ func TempDirPath() (string, error) {
 	return filepath.Join(base, TempDir), nil
 }
 
+// SyncDirPath returns the path to ~/.gotr/sync.
+func SyncDirPath() (string, error) {
+	base, err := BaseDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, SyncDir), nil
+}
+
+// TrashDirPath returns the path to ~/.gotr/trash.
+func TrashDirPath() (string, error) {
+	base, err := BaseDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, TrashDir), nil
+}
+
 // ConfigFile returns the path to the main config file ~/.gotr/config/default.yaml.
 func ConfigFile() (string, error) {
 	dir, err := ConfigDirPath()
@@ -114,6 +134,8 @@ func EnsureAllDirs() error {
 		CacheDirPath,
 		ExportsDirPath,
 		TempDirPath,
+		SyncDirPath,
+		TrashDirPath,
 	}
 
 	for _, dirFunc := range dirs {
@@ -23,6 +23,8 @@ func TestBaseDirAndDerivedPaths_HomeUnset(t *testing.T) {
 		{name: "CacheDirPath", fn: CacheDirPath},
 		{name: "ExportsDirPath", fn: ExportsDirPath},
 		{name: "TempDirPath", fn: TempDirPath},
+		{name: "SyncDirPath", fn: SyncDirPath},
+		{name: "TrashDirPath", fn: TrashDirPath},
 		{name: "ConfigFile", fn: ConfigFile},
 		{name: "EnsureLogsDirPath", fn: EnsureLogsDirPath},
 	}
@@ -97,6 +99,22 @@ func TestBaseAndDerivedPaths(t *testing.T) {
 		t.Fatalf("TempDirPath = %q", tmp)
 	}
 
+	syncDir, err := SyncDirPath()
+	if err != nil {
+		t.Fatalf("SyncDirPath error: %v", err)
+	}
+	if syncDir != filepath.Join(wantBase, SyncDir) {
+		t.Fatalf("SyncDirPath = %q", syncDir)
+	}
+
+	trashDir, err := TrashDirPath()
+	if err != nil {
+		t.Fatalf("TrashDirPath error: %v", err)
+	}
+	if trashDir != filepath.Join(wantBase, TrashDir) {
+		t.Fatalf("TrashDirPath = %q", trashDir)
+	}
+
 	cfgFile, err := ConfigFile()
 	if err != nil {
 		t.Fatalf("ConfigFile error: %v", err)
@@ -165,6 +183,8 @@ func TestEnsureAllDirsAndEnsureDir(t *testing.T) {
 		CacheDirPath,
 		ExportsDirPath,
 		TempDirPath,
+		SyncDirPath,
+		TrashDirPath,
 	}
 	for _, fn := range check {
 		p, err := fn()
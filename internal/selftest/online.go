@@ -0,0 +1,564 @@
+// internal/selftest/online.go
+// Online checks of the TestRail server for `gotr doctor` and `self-test --online`.
+package selftest
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// Defaults of the online checks.
+const (
+	DefaultOnlineTimeout  = 15 * time.Second
+	DefaultLatencySamples = 10
+	DefaultSlowLatency    = 2 * time.Second
+	DefaultCertWarnBefore = 30 * 24 * time.Hour
+)
+
+// probeEndpoint is the cheap endpoint every user can read.
+const probeEndpoint = "get_statuses"
+
+var lookupHost = net.DefaultResolver.LookupHost
+
+// UserLookup is the subset of the API client the account check uses.
+type UserLookup interface {
+	GetUserByEmail(ctx context.Context, email string) (*data.User, error)
+	GetRoles(ctx context.Context) (data.GetRolesResponse, error)
+}
+
+// Server is the TestRail server the online checks talk to. Requests are sent
+// directly, bypassing the API client, so that status codes and headers are
+// visible to the checks.
+type Server struct {
+	BaseURL  string
+	Username string
+	APIKey   string
+	Insecure bool
+	// RateLimit is the request budget of gotr in req/min (0 = off), shown
+	// next to the limits the server advertises.
+	RateLimit int
+	Timeout   time.Duration
+	// RootCAs verifies the certificate; nil uses the system pool.
+	RootCAs *x509.CertPool
+
+	once   sync.Once
+	client *http.Client
+	probe  *probe
+	err    error
+}
+
+// probe is a response of the server.
+type probe struct {
+	status  int
+	header  http.Header
+	body    []byte
+	elapsed time.Duration
+}
+
+// apiError returns the "error" field of a TestRail error response.
+func (p *probe) apiError() string {
+	var e struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(p.body, &e) == nil {
+		return e.Error
+	}
+	return ""
+}
+
+func (s *Server) timeout() time.Duration {
+	if s.Timeout > 0 {
+		return s.Timeout
+	}
+	return DefaultOnlineTimeout
+}
+
+func (s *Server) url() (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(s.BaseURL))
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid or empty base URL: %s", s.BaseURL)
+	}
+	return u, nil
+}
+
+// get sends an authenticated GET request to an API endpoint.
+func (s *Server) get(endpoint string) (*probe, error) {
+	u, err := s.url()
+	if err != nil {
+		return nil, err
+	}
+	if s.client == nil {
+		s.client = &http.Client{
+			Timeout: s.timeout(),
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: s.Insecure, RootCAs: s.RootCAs},
+			},
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u.Scheme+"://"+u.Host+"/index.php?/api/v2/"+endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(s.Username, s.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; gotr doctor; +https://github.com/Korrnals/gotr)")
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	return &probe{status: resp.StatusCode, header: resp.Header, body: body, elapsed: time.Since(start)}, nil
+}
+
+// first returns the response to the first probe request, sent once and
+// shared by the checks.
+func (s *Server) first() (*probe, error) {
+	s.once.Do(func() { s.probe, s.err = s.get(probeEndpoint) })
+	return s.probe, s.err
+}
+
+// skipUnlessOK returns a skipped result when the API did not answer the
+// first probe with 200 OK and JSON; the checks that need a working API use it.
+func (s *Server) skipUnlessOK() (CheckResult, bool) {
+	p, err := s.first()
+	switch {
+	case err != nil:
+		return CheckResult{Result: ResultSkip, Message: "Server unreachable"}, true
+	case p.status != http.StatusOK:
+		return CheckResult{Result: ResultSkip, Message: fmt.Sprintf("API unavailable (HTTP %d)", p.status)}, true
+	case !json.Valid(p.body):
+		return CheckResult{Result: ResultSkip, Message: "API unavailable (not JSON)"}, true
+	}
+	return CheckResult{}, false
+}
+
+// OnlineCheckers returns the online checks of s in report order.
+func OnlineCheckers(s *Server, users UserLookup) []Checker {
+	return []Checker{
+		DNSChecker{Server: s},
+		TLSChecker{Server: s},
+		APIEnabledChecker{Server: s},
+		AuthChecker{Server: s},
+		LatencyChecker{Server: s},
+		PaginationChecker{Server: s},
+		RateLimitChecker{Server: s},
+		AccountChecker{Server: s, Users: users},
+	}
+}
+
+// DNSChecker resolves the host of the server.
+type DNSChecker struct{ Server *Server }
+
+// Name returns the display name of the DNS check.
+func (c DNSChecker) Name() string { return "DNS Resolution" }
+
+// Category returns the check category shown in self-test output.
+func (c DNSChecker) Category() string { return "Network" }
+
+// Check resolves the server host to its addresses.
+func (c DNSChecker) Check() CheckResult {
+	u, err := c.Server.url()
+	if err != nil {
+		return CheckResult{Result: ResultFail, Message: "Invalid base URL", Error: err, CanFix: true, FixCommand: "gotr config init"}
+	}
+	host := u.Hostname()
+	if net.ParseIP(host) != nil {
+		return CheckResult{Result: ResultPass, Message: fmt.Sprintf("%s is an IP address", host)}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.Server.timeout())
+	defer cancel()
+	addrs, err := lookupHost(ctx, host)
+	if err != nil {
+		return CheckResult{Result: ResultFail, Message: fmt.Sprintf("Cannot resolve %s", host), Error: err}
+	}
+	return CheckResult{
+		Result:  ResultPass,
+		Message: fmt.Sprintf("%s resolves to %d address(es)", host, len(addrs)),
+		Details: strings.Join(addrs, ", "),
+	}
+}
+
+// TLSChecker connects to the server and verifies its certificate and expiry.
+type TLSChecker struct {
+	Server *Server
+	// WarnBefore warns about certificates expiring sooner (0 = 30 days).
+	WarnBefore time.Duration
+}
+
+// Name returns the display name of the TLS check.
+func (c TLSChecker) Name() string { return "TLS Certificate" }
+
+// Category returns the check category shown in self-test output.
+func (c TLSChecker) Category() string { return "Network" }
+
+// Check performs a TLS handshake and reports the certificate validity.
+func (c TLSChecker) Check() CheckResult {
+	u, err := c.Server.url()
+	if err != nil {
+		return CheckResult{Result: ResultSkip, Message: "Invalid base URL"}
+	}
+	if u.Scheme != "https" {
+		return CheckResult{Result: ResultWarn, Message: "Plain HTTP: the API key is sent unencrypted"}
+	}
+	host, port := u.Hostname(), u.Port()
+	if port == "" {
+		port = "443"
+	}
+
+	// Verification is done below, so that an untrusted certificate can be
+	// reported together with its expiry.
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: c.Server.timeout()},
+		Config:    &tls.Config{ServerName: host, InsecureSkipVerify: true},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.Server.timeout())
+	defer cancel()
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return CheckResult{Result: ResultFail, Message: fmt.Sprintf("Cannot connect to %s:%s", host, port), Error: err}
+	}
+	state := conn.(*tls.Conn).ConnectionState()
+	conn.Close()
+
+	certs := state.PeerCertificates
+	leaf := certs[0]
+	left := time.Until(leaf.NotAfter)
+	details := fmt.Sprintf("%s, issuer %s, expires %s", tls.VersionName(state.Version), leaf.Issuer.CommonName, leaf.NotAfter.Format("2006-01-02"))
+
+	if left <= 0 {
+		return CheckResult{Result: ResultFail, Message: fmt.Sprintf("Certificate expired on %s", leaf.NotAfter.Format("2006-01-02")), Details: details}
+	}
+
+	opts := x509.VerifyOptions{DNSName: host, Roots: c.Server.RootCAs, Intermediates: x509.NewCertPool()}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	if _, err := leaf.Verify(opts); err != nil {
+		if c.Server.Insecure {
+			return CheckResult{Result: ResultWarn, Message: "Certificate not trusted; accepted because insecure is set", Details: details, Error: err}
+		}
+		return CheckResult{Result: ResultFail, Message: "Certificate not trusted", Details: details, Error: err}
+	}
+
+	days := int(left.Hours() / 24)
+	warnBefore := c.WarnBefore
+	if warnBefore <= 0 {
+		warnBefore = DefaultCertWarnBefore
+	}
+	if left < warnBefore {
+		return CheckResult{Result: ResultWarn, Message: fmt.Sprintf("Certificate expires in %d day(s)", days), Details: details}
+	}
+	return CheckResult{Result: ResultPass, Message: fmt.Sprintf("Valid for %d more days", days), Details: details}
+}
+
+// APIEnabledChecker verifies that the server answers as a TestRail API with
+// the API enabled.
+type APIEnabledChecker struct{ Server *Server }
+
+// Name returns the display name of the API check.
+func (c APIEnabledChecker) Name() string { return "API Enabled" }
+
+// Category returns the check category shown in self-test output.
+func (c APIEnabledChecker) Category() string { return "API" }
+
+// Check sends the first probe request and inspects the response.
+func (c APIEnabledChecker) Check() CheckResult {
+	p, err := c.Server.first()
+	if err != nil {
+		return CheckResult{Result: ResultFail, Message: "Request failed", Error: err}
+	}
+	msg := p.apiError()
+	switch {
+	case p.status == http.StatusForbidden && strings.Contains(strings.ToLower(msg), "disabled"):
+		return CheckResult{
+			Result:  ResultFail,
+			Message: "The API is disabled",
+			Details: "Enable it in Administration > Site Settings > API",
+		}
+	case !json.Valid(p.body):
+		return CheckResult{
+			Result:  ResultFail,
+			Message: fmt.Sprintf("No TestRail API at %s (HTTP %d, not JSON)", c.Server.BaseURL, p.status),
+			Details: "Check base_url: it must point to the TestRail installation",
+		}
+	}
+	return CheckResult{Result: ResultPass, Message: fmt.Sprintf("API responds (HTTP %d)", p.status)}
+}
+
+// AuthChecker verifies the username and API key.
+type AuthChecker struct{ Server *Server }
+
+// Name returns the display name of the authentication check.
+func (c AuthChecker) Name() string { return "Authentication" }
+
+// Category returns the check category shown in self-test output.
+func (c AuthChecker) Category() string { return "API" }
+
+// Check reports whether the server accepted the credentials.
+func (c AuthChecker) Check() CheckResult {
+	p, err := c.Server.first()
+	if err != nil {
+		return CheckResult{Result: ResultSkip, Message: "Server unreachable"}
+	}
+	switch p.status {
+	case http.StatusOK:
+		return CheckResult{Result: ResultPass, Message: fmt.Sprintf("Authenticated as %s", c.Server.Username)}
+	case http.StatusUnauthorized:
+		return CheckResult{
+			Result:     ResultFail,
+			Message:    "Authentication failed",
+			Details:    p.apiError(),
+			CanFix:     true,
+			FixCommand: "gotr config init",
+		}
+	}
+	return CheckResult{Result: ResultWarn, Message: fmt.Sprintf("Unexpected HTTP %d", p.status), Details: p.apiError()}
+}
+
+// LatencyChecker measures round-trip times of repeated API requests.
+type LatencyChecker struct {
+	Server *Server
+	// Samples is the number of requests (0 = 10).
+	Samples int
+	// Slow warns when the 90th percentile exceeds it (0 = 2s).
+	Slow time.Duration
+}
+
+// Name returns the display name of the latency check.
+func (c LatencyChecker) Name() string { return "Round-Trip Latency" }
+
+// Category returns the check category shown in self-test output.
+func (c LatencyChecker) Category() string { return "API" }
+
+// Check sends sequential requests and reports latency percentiles.
+func (c LatencyChecker) Check() CheckResult {
+	if res, skip := c.Server.skipUnlessOK(); skip {
+		return res
+	}
+	n := c.Samples
+	if n <= 0 {
+		n = DefaultLatencySamples
+	}
+	slow := c.Slow
+	if slow <= 0 {
+		slow = DefaultSlowLatency
+	}
+
+	samples := make([]time.Duration, 0, n)
+	for range n {
+		p, err := c.Server.get(probeEndpoint)
+		if err != nil {
+			return CheckResult{Result: ResultFail, Message: fmt.Sprintf("Request %d of %d failed", len(samples)+1, n), Error: err}
+		}
+		samples = append(samples, p.elapsed)
+	}
+	slices.Sort(samples)
+
+	p50, p90, p99 := percentile(samples, 50), percentile(samples, 90), percentile(samples, 99)
+	res := CheckResult{
+		Result:  ResultPass,
+		Message: fmt.Sprintf("p50 %s, p90 %s, p99 %s", round(p50), round(p90), round(p99)),
+		Details: fmt.Sprintf("%d × %s, min %s, max %s", n, probeEndpoint, round(samples[0]), round(samples[n-1])),
+	}
+	if p90 > slow {
+		res.Result = ResultWarn
+		res.Details += fmt.Sprintf("; p90 above %s", slow)
+	}
+	return res
+}
+
+// percentile returns the nearest-rank percentile of sorted samples.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+func round(d time.Duration) time.Duration { return d.Round(time.Millisecond) }
+
+// AccountChecker reports the role of the configured user.
+type AccountChecker struct {
+	Server *Server
+	Users  UserLookup
+}
+
+// Name returns the display name of the account check.
+func (c AccountChecker) Name() string { return "User Role" }
+
+// Category returns the check category shown in self-test output.
+func (c AccountChecker) Category() string { return "Account" }
+
+// Check looks up the user by its login email and resolves its role.
+func (c AccountChecker) Check() CheckResult {
+	if res, skip := c.Server.skipUnlessOK(); skip {
+		return res
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.Server.timeout())
+	defer cancel()
+
+	user, err := c.Users.GetUserByEmail(ctx, c.Server.Username)
+	if err == nil && user == nil {
+		err = errors.New("empty response")
+	}
+	if err != nil {
+		return CheckResult{
+			Result:  ResultWarn,
+			Message: fmt.Sprintf("Cannot look up user %s", c.Server.Username),
+			Details: "The username must be the login email of the user",
+			Error:   err,
+		}
+	}
+
+	role := user.Role
+	if roles, err := c.Users.GetRoles(ctx); err == nil {
+		for _, r := range roles {
+			if r.ID == user.RoleID {
+				role = r.Name
+			}
+		}
+	}
+	if role == "" {
+		role = fmt.Sprintf("role %d", user.RoleID)
+	}
+
+	res := CheckResult{
+		Result:  ResultPass,
+		Message: fmt.Sprintf("%s, %s", user.Name, role),
+		Details: "Administrator: user management and site settings are available",
+	}
+	if !user.IsAdmin {
+		res.Details = "Not an administrator: user management (get_users, add_user) and site settings are not available"
+	}
+	if !user.IsActive {
+		res.Result = ResultFail
+		res.Message += " (inactive)"
+	}
+	return res
+}
+
+// PaginationChecker detects whether list endpoints return flat arrays
+// (TestRail before 6.7) or paginated wrappers.
+type PaginationChecker struct{ Server *Server }
+
+// Name returns the display name of the pagination check.
+func (c PaginationChecker) Name() string { return "Pagination Style" }
+
+// Category returns the check category shown in self-test output.
+func (c PaginationChecker) Category() string { return "API" }
+
+// Check requests one project and inspects the shape of the response.
+func (c PaginationChecker) Check() CheckResult {
+	if res, skip := c.Server.skipUnlessOK(); skip {
+		return res
+	}
+	p, err := c.Server.get("get_projects&limit=1")
+	if err != nil {
+		return CheckResult{Result: ResultFail, Message: "Request failed", Error: err}
+	}
+	if p.status != http.StatusOK {
+		return CheckResult{Result: ResultWarn, Message: fmt.Sprintf("get_projects returned HTTP %d", p.status), Details: p.apiError()}
+	}
+
+	body := bytes.TrimSpace(p.body)
+	if bytes.HasPrefix(body, []byte("[")) {
+		return CheckResult{Result: ResultPass, Message: "Flat lists (TestRail before 6.7)", Details: "Lists are returned whole, without offset/limit"}
+	}
+	var wrapped struct {
+		Links json.RawMessage `json:"_links"`
+	}
+	if json.Unmarshal(body, &wrapped) == nil && wrapped.Links != nil {
+		return CheckResult{Result: ResultPass, Message: "Paginated lists (TestRail 6.7+)", Details: "Lists are read page by page with offset/limit"}
+	}
+	return CheckResult{Result: ResultFail, Message: "Unrecognized list format", Error: errors.New(truncate(string(body), 120))}
+}
+
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n]) + "…"
+	}
+	return s
+}
+
+// rateLimitHeaders are the headers servers and proxies use to advertise
+// request quotas, in display order.
+var rateLimitHeaders = []string{
+	"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset",
+	"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset",
+	"Retry-After",
+}
+
+// RateLimitChecker reports the rate-limit headers of the server next to the
+// request budget of gotr.
+type RateLimitChecker struct{ Server *Server }
+
+// Name returns the display name of the rate-limit check.
+func (c RateLimitChecker) Name() string { return "Rate Limits" }
+
+// Category returns the check category shown in self-test output.
+func (c RateLimitChecker) Category() string { return "API" }
+
+// Check inspects the headers of the first probe response.
+func (c RateLimitChecker) Check() CheckResult {
+	if res, skip := c.Server.skipUnlessOK(); skip {
+		return res
+	}
+	p, _ := c.Server.first()
+
+	budget := "gotr rate_limit: off"
+	if c.Server.RateLimit > 0 {
+		budget = fmt.Sprintf("gotr rate_limit: %d req/min", c.Server.RateLimit)
+	}
+
+	var found []string
+	for _, h := range rateLimitHeaders {
+		if v := p.header.Get(h); v != "" {
+			found = append(found, h+": "+v)
+		}
+	}
+	if len(found) == 0 {
+		return CheckResult{Result: ResultPass, Message: "No rate-limit headers", Details: budget}
+	}
+
+	res := CheckResult{Result: ResultPass, Message: strings.Join(found, ", "), Details: budget}
+	var limit, remaining int
+	_, lerr := fmt.Sscan(firstHeader(p.header, "X-RateLimit-Limit", "RateLimit-Limit"), &limit)
+	_, rerr := fmt.Sscan(firstHeader(p.header, "X-RateLimit-Remaining", "RateLimit-Remaining"), &remaining)
+	if lerr == nil && rerr == nil && limit > 0 && remaining*10 < limit {
+		res.Result = ResultWarn
+		res.Details += fmt.Sprintf("; %d of %d requests left", remaining, limit)
+	}
+	return res
+}
+
+// firstHeader returns the first non-empty header of names.
+func firstHeader(h http.Header, names ...string) string {
+	for _, n := range names {
+		if v := h.Get(n); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,192 @@
+package selftest
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubUsers struct {
+	user *data.User
+	err  error
+}
+
+func (s stubUsers) GetUserByEmail(ctx context.Context, email string) (*data.User, error) {
+	return s.user, s.err
+}
+
+func (s stubUsers) GetRoles(ctx context.Context) (data.GetRolesResponse, error) {
+	return data.GetRolesResponse{{ID: 1, Name: "Guest"}, {ID: 3, Name: "Tester"}}, nil
+}
+
+// testRailServer answers get_statuses with status and body and get_projects
+// with projects.
+func testRailServer(t *testing.T, status int, body, projects string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, key, _ := r.BasicAuth()
+		assert.Equal(t, "qa@example.com", user)
+		assert.Equal(t, "key", key)
+
+		w.Header().Set("X-RateLimit-Limit", "180")
+		w.Header().Set("X-RateLimit-Remaining", "170")
+		switch {
+		case strings.HasPrefix(r.URL.RawQuery, "/api/v2/get_statuses"):
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		case strings.HasPrefix(r.URL.RawQuery, "/api/v2/get_projects&limit=1"):
+			_, _ = w.Write([]byte(projects))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func trustedServer(srv *httptest.Server) *Server {
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	return &Server{BaseURL: srv.URL, Username: "qa@example.com", APIKey: "key", RootCAs: roots, RateLimit: 180}
+}
+
+// runOnline runs the online checks and returns the results by name.
+func runOnline(s *Server, users UserLookup) map[string]CheckResult {
+	runner := NewRunner()
+	for _, c := range OnlineCheckers(s, users) {
+		runner.Register(c)
+	}
+	results := make(map[string]CheckResult)
+	for _, c := range runner.Run().Checks {
+		results[c.Name] = c
+	}
+	return results
+}
+
+func TestOnlineCheckers_Healthy(t *testing.T) {
+	srv := testRailServer(t, http.StatusOK, `[{"id":1}]`, `{"offset":0,"limit":1,"size":1,"_links":{},"projects":[]}`)
+	users := stubUsers{user: &data.User{Name: "QA", RoleID: 3, IsActive: true}}
+
+	results := runOnline(trustedServer(srv), users)
+	require.Len(t, results, 8)
+	for name, r := range results {
+		assert.Equal(t, ResultPass, r.Result, "%s: %s %v", name, r.Message, r.Error)
+	}
+	assert.Equal(t, "127.0.0.1 is an IP address", results["DNS Resolution"].Message)
+	assert.Equal(t, "QA, Tester", results["User Role"].Message)
+	assert.Contains(t, results["User Role"].Details, "Not an administrator")
+	assert.Equal(t, "Paginated lists (TestRail 6.7+)", results["Pagination Style"].Message)
+	assert.Contains(t, results["Round-Trip Latency"].Message, "p50 ")
+	assert.Equal(t, "X-RateLimit-Limit: 180, X-RateLimit-Remaining: 170", results["Rate Limits"].Message)
+	assert.Equal(t, "gotr rate_limit: 180 req/min", results["Rate Limits"].Details)
+}
+
+func TestOnlineCheckers_AuthFailed(t *testing.T) {
+	srv := testRailServer(t, http.StatusUnauthorized, `{"error":"Authentication failed: invalid or missing user/password"}`, "[]")
+
+	results := runOnline(trustedServer(srv), stubUsers{})
+	assert.Equal(t, ResultPass, results["API Enabled"].Result)
+	assert.Equal(t, ResultFail, results["Authentication"].Result)
+	assert.Equal(t, "gotr config init", results["Authentication"].FixCommand)
+	for _, name := range []string{"Round-Trip Latency", "User Role", "Pagination Style", "Rate Limits"} {
+		assert.Equal(t, ResultSkip, results[name].Result, name)
+		assert.Equal(t, "API unavailable (HTTP 401)", results[name].Message, name)
+	}
+}
+
+func TestOnlineCheckers_APIDisabled(t *testing.T) {
+	srv := testRailServer(t, http.StatusForbidden, `{"error":"The API is disabled for your installation."}`, "[]")
+
+	results := runOnline(trustedServer(srv), stubUsers{})
+	assert.Equal(t, ResultFail, results["API Enabled"].Result)
+	assert.Equal(t, "The API is disabled", results["API Enabled"].Message)
+	assert.Equal(t, ResultWarn, results["Authentication"].Result)
+}
+
+func TestOnlineCheckers_NotTestRail(t *testing.T) {
+	srv := testRailServer(t, http.StatusOK, "<html>login</html>", "[]")
+
+	results := runOnline(trustedServer(srv), stubUsers{})
+	assert.Equal(t, ResultFail, results["API Enabled"].Result)
+	assert.Contains(t, results["API Enabled"].Message, "not JSON")
+}
+
+func TestAccountChecker(t *testing.T) {
+	srv := testRailServer(t, http.StatusOK, "[]", "[]")
+	s := trustedServer(srv)
+
+	res := AccountChecker{Server: s, Users: stubUsers{user: &data.User{Name: "Old", RoleID: 1, IsAdmin: true}}}.Check()
+	assert.Equal(t, ResultFail, res.Result)
+	assert.Equal(t, "Old, Guest (inactive)", res.Message)
+	assert.Contains(t, res.Details, "Administrator")
+
+	res = AccountChecker{Server: s, Users: stubUsers{err: errors.New("no such user")}}.Check()
+	assert.Equal(t, ResultWarn, res.Result)
+
+	res = PaginationChecker{Server: s}.Check()
+	assert.Equal(t, "Flat lists (TestRail before 6.7)", res.Message)
+}
+
+func TestTLSChecker(t *testing.T) {
+	srv := testRailServer(t, http.StatusOK, "[]", "[]")
+
+	untrusted := &Server{BaseURL: srv.URL}
+	res := TLSChecker{Server: untrusted}.Check()
+	assert.Equal(t, ResultFail, res.Result)
+	assert.Equal(t, "Certificate not trusted", res.Message)
+
+	untrusted.Insecure = true
+	res = TLSChecker{Server: untrusted}.Check()
+	assert.Equal(t, ResultWarn, res.Result)
+
+	// The test certificate expires in 2084.
+	res = TLSChecker{Server: trustedServer(srv), WarnBefore: 100 * 365 * 24 * time.Hour}.Check()
+	assert.Equal(t, ResultWarn, res.Result)
+	assert.Contains(t, res.Message, "Certificate expires in")
+
+	res = TLSChecker{Server: &Server{BaseURL: "http://testrail.local"}}.Check()
+	assert.Equal(t, ResultWarn, res.Result)
+	assert.Contains(t, res.Message, "Plain HTTP")
+}
+
+func TestDNSChecker(t *testing.T) {
+	original := lookupHost
+	defer func() { lookupHost = original }()
+
+	lookupHost = func(ctx context.Context, host string) ([]string, error) {
+		if host == "testrail.example.com" {
+			return []string{"10.0.0.1", "10.0.0.2"}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	res := DNSChecker{Server: &Server{BaseURL: "https://testrail.example.com"}}.Check()
+	assert.Equal(t, ResultPass, res.Result)
+	assert.Equal(t, "10.0.0.1, 10.0.0.2", res.Details)
+
+	res = DNSChecker{Server: &Server{BaseURL: "https://missing.example.com"}}.Check()
+	assert.Equal(t, ResultFail, res.Result)
+
+	res = DNSChecker{Server: &Server{BaseURL: ""}}.Check()
+	assert.Equal(t, ResultFail, res.Result)
+}
+
+func TestPercentile(t *testing.T) {
+	var samples []time.Duration
+	for i := 1; i <= 10; i++ {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+	assert.Equal(t, 5*time.Millisecond, percentile(samples, 50))
+	assert.Equal(t, 9*time.Millisecond, percentile(samples, 90))
+	assert.Equal(t, 10*time.Millisecond, percentile(samples, 99))
+	assert.Equal(t, time.Millisecond, percentile(samples[:1], 50))
+}
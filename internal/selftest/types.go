@@ -56,15 +56,16 @@ func (r Result) ResetColor() string {
 
 // CheckResult represents the result of a single check.
 type CheckResult struct {
-	Name       string        `json:"name"`        // check name
-	Category   string        `json:"category"`    // category (config, api, tests, etc.)
-	Result     Result        `json:"result"`      // status
-	Message    string        `json:"message"`     // human-readable message
-	Details    string        `json:"details"`     // details (optional)
-	Duration   time.Duration `json:"duration"`    // execution time
-	Error      error         `json:"-"`           // error (not serialized)
-	CanFix     bool          `json:"can_fix"`     // whether auto-fix is possible
-	FixCommand string        `json:"fix_command"` // command to fix the issue
+	Name       string        `json:"name"`            // check name
+	Category   string        `json:"category"`        // category (config, api, tests, etc.)
+	Result     Result        `json:"result"`          // status
+	Message    string        `json:"message"`         // human-readable message
+	Details    string        `json:"details"`         // details (optional)
+	Duration   time.Duration `json:"duration"`        // execution time
+	Error      error         `json:"-"`               // error (serialized as ErrorText)
+	ErrorText  string        `json:"error,omitempty"` // error message, set by Runner
+	CanFix     bool          `json:"can_fix"`         // whether auto-fix is possible
+	FixCommand string        `json:"fix_command"`     // command to fix the issue
 }
 
 // Report represents a complete self-test report.
@@ -165,6 +166,9 @@ func (r *Runner) Run() *Report {
 		result.Duration = time.Since(checkStart)
 		result.Name = checker.Name()
 		result.Category = checker.Category()
+		if result.Error != nil {
+			result.ErrorText = result.Error.Error()
+		}
 		report.Checks = append(report.Checks, result)
 	}
 
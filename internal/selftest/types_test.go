@@ -2,6 +2,7 @@ package selftest
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"io"
 	"os"
@@ -145,3 +146,25 @@ func TestReportPrintHumanAndFormatDetails(t *testing.T) {
 		t.Fatalf("formatDetails empty path=%q", got)
 	}
 }
+
+type errChecker struct{}
+
+func (errChecker) Name() string     { return "err" }
+func (errChecker) Category() string { return "cat" }
+func (errChecker) Check() CheckResult {
+	return CheckResult{Result: ResultFail, Error: errors.New("no such host")}
+}
+
+func TestRunner_SerializesError(t *testing.T) {
+	r := NewRunner()
+	r.Register(errChecker{})
+	report := r.Run()
+
+	b, err := json.Marshal(report.Checks[0])
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(b), `"error":"no such host"`) {
+		t.Fatalf("error not serialized: %s", b)
+	}
+}
@@ -0,0 +1,280 @@
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleInput() Input {
+	return Input{
+		Kind: KindRun,
+		ID:   7,
+		Name: "Regression",
+		Runs: []RunData{{
+			Run: data.Run{ID: 7, Name: "Regression"},
+			Tests: []data.Test{
+				{ID: 1, CaseID: 11, Title: "Login", StatusID: 1, AssignedTo: 100, PriorityID: 4, Estimate: "10m"},
+				{ID: 2, CaseID: 12, Title: "Logout | SSO", StatusID: 5, AssignedTo: 100, PriorityID: 2, Estimate: "5m"},
+				{ID: 3, CaseID: 13, Title: "Export", StatusID: 2, PriorityID: 2},
+				{ID: 4, CaseID: 14, Title: "Import", Estimate: "1h"},
+				{ID: 5, CaseID: 99, Title: "Audit", StatusID: 6, AssignedTo: 200},
+			},
+			Results: data.GetResultsResponse{
+				{ID: 1, TestID: 1, StatusID: 1, Elapsed: "12m", CreatedOn: 10},
+				{ID: 2, TestID: 2, StatusID: 5, Elapsed: "3m", Defects: "BUG-1", Comment: "first", CreatedOn: 10},
+				{ID: 3, TestID: 2, StatusID: 5, Elapsed: "1m 30s", Defects: "BUG-2, BUG-1", Comment: "second\nstack", CreatedOn: 20},
+				{ID: 4, TestID: 3, StatusID: 2, Defects: "BUG-3", CreatedOn: 10},
+			},
+		}},
+		Statuses: data.GetStatusesResponse{
+			{ID: 1, Name: "passed", Label: "Passed"},
+			{ID: 2, Name: "blocked", Label: "Blocked"},
+			{ID: 3, Name: "untested", Label: "Untested", IsUntested: true},
+			{ID: 5, Name: "failed", Label: "Failed"},
+			{ID: 6, Name: "custom_status1", Label: "Needs review"},
+		},
+		Priorities: data.GetPrioritiesResponse{{ID: 4, Name: "Critical"}, {ID: 2, Name: "Medium"}},
+		Users:      data.GetUsersResponse{{ID: 100, Name: "Alice"}},
+		CaseSections: map[int64]string{
+			11: "Auth", 12: "Auth > SSO", 13: "Data", 14: "Data",
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	r := Build(sampleInput())
+
+	assert.Equal(t, Counts{Total: 5, Passed: 1, Failed: 1, Blocked: 1, Untested: 1, Other: 1, PassRate: 25}, r.Summary)
+	assert.Equal(t, []StatusCount{
+		{ID: 1, Name: "Passed", Count: 1},
+		{ID: 2, Name: "Blocked", Count: 1},
+		{ID: 3, Name: "Untested", Count: 1},
+		{ID: 5, Name: "Failed", Count: 1},
+		{ID: 6, Name: "Needs review", Count: 1},
+	}, r.Statuses)
+
+	names := func(groups []Group) []string {
+		var res []string
+		for _, g := range groups {
+			res = append(res, g.Name)
+		}
+		return res
+	}
+	assert.Equal(t, []string{"Auth", "Auth > SSO", "Data", NoSection}, names(r.BySection))
+	assert.Equal(t, []string{"Alice", "User 200", Unassigned}, names(r.ByAssignee))
+	assert.Equal(t, []string{"Critical", "Medium", NoPriority}, names(r.ByPriority))
+	assert.Equal(t, 50.0, r.ByAssignee[0].PassRate)
+	assert.Equal(t, 0.0, r.BySection[2].PassRate, "blocked counts as tested")
+
+	require.Len(t, r.Failed, 1)
+	assert.Equal(t, Problem{
+		TestID: 2, CaseID: 12, RunID: 7, Title: "Logout | SSO", Section: "Auth > SSO",
+		Assignee: "Alice", Status: "Failed", Defects: []string{"BUG-1", "BUG-2"}, Comment: "second\nstack",
+	}, r.Failed[0])
+	require.Len(t, r.Blocked, 1)
+	assert.Equal(t, []string{"BUG-3"}, r.Blocked[0].Defects)
+
+	assert.Equal(t, TimeStats{
+		Estimate:         int64((75 * time.Minute).Seconds()),
+		Elapsed:          int64((16*time.Minute + 30*time.Second).Seconds()),
+		EstimatedTests:   3,
+		TimedTests:       2,
+		OverEstimate:     1,
+		UntestedEstimate: 3600,
+	}, r.Time)
+}
+
+func TestParseTimespan(t *testing.T) {
+	assert.Equal(t, 90*time.Minute, parseTimespan("1h 30m"))
+	assert.Equal(t, 2*time.Minute+10*time.Second, parseTimespan("2m10s"))
+	assert.Equal(t, 90*time.Minute, parseTimespan("1.5h"))
+	assert.Equal(t, 24*time.Hour, parseTimespan("1d"))
+	assert.Zero(t, parseTimespan(""))
+	assert.Zero(t, parseTimespan("soon"))
+	assert.Zero(t, parseTimespan("5"))
+
+	assert.Equal(t, "1h 1m 5s", FormatSeconds(3665))
+	assert.Equal(t, "0s", FormatSeconds(0))
+}
+
+func TestRender(t *testing.T) {
+	r := Build(sampleInput())
+	r.URL = "https://testrail.example.com/index.php?/runs/view/7"
+
+	var md bytes.Buffer
+	require.NoError(t, Markdown(&md, r))
+	assert.Contains(t, md.String(), "# Run 7: Regression")
+	assert.Contains(t, md.String(), "| 5 | 1 | 1 | 1 | 0 | 1 | 1 | 25.0% |")
+	assert.Contains(t, md.String(), "| T2 | Logout \\| SSO | Auth > SSO | Alice | Failed | BUG-1, BUG-2 | second |")
+	assert.Contains(t, md.String(), "| 16m 30s | 1h 15m | 2 | 3 | 1 | 1h |")
+
+	var html bytes.Buffer
+	require.NoError(t, HTML(&html, r))
+	assert.Contains(t, html.String(), "<title>Run 7: Regression</title>")
+	assert.Contains(t, html.String(), "<td>Auth &gt; SSO</td>")
+	assert.Contains(t, html.String(), `<a href="https://testrail.example.com/index.php?/runs/view/7">`)
+	assert.NotContains(t, html.String(), "<script")
+}
+
+func TestCollectMilestone(t *testing.T) {
+	mock := &client.MockClient{
+		GetMilestoneFunc: func(ctx context.Context, id int64) (*data.Milestone, error) {
+			return &data.Milestone{ID: id, Name: "1.0", ProjectID: 3}, nil
+		},
+		GetRunsFilteredFunc: func(ctx context.Context, projectID int64, filter data.RunFilter) (data.GetRunsResponse, error) {
+			assert.Equal(t, []int64{9}, filter.MilestoneIDs)
+			return data.GetRunsResponse{{ID: 1, SuiteID: 10}}, nil
+		},
+		GetPlansFilteredFunc: func(ctx context.Context, projectID int64, filter data.PlanFilter) (data.GetPlansResponse, error) {
+			return data.GetPlansResponse{{ID: 50}}, nil
+		},
+		GetPlanFunc: func(ctx context.Context, planID int64) (*data.Plan, error) {
+			return &data.Plan{ID: planID, Entries: []data.PlanEntry{{Runs: []data.Run{{ID: 2, SuiteID: 10}, {ID: 3, SuiteID: 20}}}}}, nil
+		},
+		GetTestsFunc: func(ctx context.Context, runID int64, filters map[string]string) ([]data.Test, error) {
+			return []data.Test{{ID: runID * 100, CaseID: runID, StatusID: 1}}, nil
+		},
+		GetSectionsFunc: func(ctx context.Context, projectID, suiteID int64) (data.GetSectionsResponse, error) {
+			return data.GetSectionsResponse{{ID: suiteID, Name: "S" + string(rune('0'+suiteID/10))}}, nil
+		},
+		GetCasesFunc: func(ctx context.Context, projectID, suiteID, sectionID int64) (data.GetCasesResponse, error) {
+			if suiteID == 10 {
+				return data.GetCasesResponse{{ID: 1, SectionID: 10}, {ID: 2, SectionID: 10}}, nil
+			}
+			return data.GetCasesResponse{{ID: 3, SectionID: 20}}, nil
+		},
+	}
+
+	in, err := CollectMilestone(context.Background(), mock, 9)
+	require.NoError(t, err)
+	assert.Equal(t, KindMilestone, in.Kind)
+	require.Len(t, in.Runs, 3)
+	assert.Equal(t, map[int64]string{1: "S1", 2: "S1", 3: "S2"}, in.CaseSections)
+
+	r := Build(in)
+	assert.Equal(t, 3, r.Summary.Passed)
+	assert.Len(t, r.Runs, 3)
+}
+
+// flakyMock serves runs 1-5, created in order, whose cases 10-13 have the
+// statuses of history[case][run-1]; 0 leaves the test without a result.
+func flakyMock(history map[int64][]int64) *client.MockClient {
+	return &client.MockClient{
+		GetRunsFilteredFunc: func(ctx context.Context, projectID int64, filter data.RunFilter) (data.GetRunsResponse, error) {
+			// Unordered on purpose.
+			return data.GetRunsResponse{
+				{ID: 3, CreatedOn: 300}, {ID: 1, CreatedOn: 100}, {ID: 5, CreatedOn: 500},
+				{ID: 2, CreatedOn: 200}, {ID: 4, CreatedOn: 400},
+			}, nil
+		},
+		GetStatusesFunc: func(ctx context.Context) (data.GetStatusesResponse, error) {
+			return data.GetStatusesResponse{
+				{ID: 1, Name: "passed"}, {ID: 2, Name: "blocked"}, {ID: 5, Name: "failed"},
+			}, nil
+		},
+		GetTestsFunc: func(ctx context.Context, runID int64, filters map[string]string) ([]data.Test, error) {
+			var tests []data.Test
+			for caseID := range history {
+				tests = append(tests, data.Test{ID: runID*100 + caseID, CaseID: caseID, Title: fmt.Sprintf("Case %d", caseID)})
+			}
+			return tests, nil
+		},
+		GetResultsForRunFunc: func(ctx context.Context, runID int64) (data.GetResultsResponse, error) {
+			var results data.GetResultsResponse
+			for caseID, statuses := range history {
+				if s := statuses[runID-1]; s != 0 {
+					results = append(results, data.Result{ID: runID*100 + caseID, TestID: runID*100 + caseID, StatusID: s, CreatedOn: runID * 100})
+				}
+			}
+			return results, nil
+		},
+	}
+}
+
+func TestCollectFlaky(t *testing.T) {
+	mock := flakyMock(map[int64][]int64{
+		// Run 1 falls outside --last 4.
+		10: {5, 1, 5, 1, 5},
+		11: {1, 1, 1, 1, 1},
+		12: {1, 5, 5, 1, 5},
+		13: {1, 0, 2, 5, 1},
+	})
+
+	r, err := CollectFlaky(context.Background(), mock, FlakyOptions{ProjectID: 3, Last: 4, MinResults: 3, Parallel: 2})
+	require.NoError(t, err)
+	require.Len(t, r.Runs, 4)
+	assert.Equal(t, int64(2), r.Runs[0].ID, "oldest first")
+	assert.Equal(t, 4, r.Analyzed)
+
+	require.Len(t, r.Cases, 2, "case 13 has 2 results, below --min-results")
+	c := r.Cases[0]
+	assert.Equal(t, int64(10), c.CaseID)
+	assert.Equal(t, 3, c.Flips)
+	assert.Equal(t, 1.0, c.FlipRate)
+	assert.Equal(t, 1, c.FailStreak)
+	assert.Equal(t, int64(400), c.LastPassedOn)
+	assert.Equal(t, int64(5), c.LastRunID)
+	assert.Equal(t, int64(510), c.LastTestID)
+
+	c = r.Cases[1]
+	assert.Equal(t, int64(12), c.CaseID)
+	assert.Equal(t, 2, c.Flips)
+	assert.Equal(t, 0.67, c.FlipRate)
+	assert.Equal(t, 2, c.LongestFailStreak)
+	assert.Equal(t, 1, c.Fixes)
+	assert.Equal(t, int64(200), c.MeanTimeToFix, "failing since 200, passed at 400")
+}
+
+func TestCollectFlaky_GrowsRunWindow(t *testing.T) {
+	day := int64(24 * 60 * 60)
+	now := time.Now().Unix()
+	all := data.GetRunsResponse{
+		{ID: 1, CreatedOn: now - 400*day}, {ID: 2, CreatedOn: now - 100*day},
+		{ID: 3, CreatedOn: now - 50*day}, {ID: 4, CreatedOn: now - day},
+	}
+	var windows []int64
+	mock := flakyMock(map[int64][]int64{10: {1, 5, 1, 5, 1}})
+	mock.GetRunsFilteredFunc = func(ctx context.Context, projectID int64, filter data.RunFilter) (data.GetRunsResponse, error) {
+		if filter.CreatedAfter.IsZero() {
+			windows = append(windows, 0)
+			return all, nil
+		}
+		windows = append(windows, (now-filter.CreatedAfter.Unix()+day/2)/day)
+		var runs data.GetRunsResponse
+		for _, r := range all {
+			if r.CreatedOn > filter.CreatedAfter.Unix() {
+				runs = append(runs, r)
+			}
+		}
+		return runs, nil
+	}
+
+	r, err := CollectFlaky(context.Background(), mock, FlakyOptions{ProjectID: 3, Last: 3})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{30, 60, 120}, windows, "stops once the window holds --last runs")
+	require.Len(t, r.Runs, 3)
+	assert.Equal(t, int64(2), r.Runs[0].ID)
+
+	windows = nil
+	r, err = CollectFlaky(context.Background(), mock, FlakyOptions{ProjectID: 3, Last: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{30, 60, 120, 240, 480, 960, 0}, windows, "falls back to all runs")
+	assert.Len(t, r.Runs, 4)
+}
+
+func TestCollectFlaky_RunError(t *testing.T) {
+	mock := flakyMock(map[int64][]int64{10: {1, 5, 1, 5, 1}})
+	mock.GetResultsForRunFunc = func(ctx context.Context, runID int64) (data.GetResultsResponse, error) {
+		return nil, fmt.Errorf("boom")
+	}
+	_, err := CollectFlaky(context.Background(), mock, FlakyOptions{ProjectID: 3})
+	assert.ErrorContains(t, err, "boom")
+}
@@ -0,0 +1,169 @@
+// internal/service/analytics/collect.go
+package analytics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Korrnals/gotr/internal/match"
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// sectionSep joins the section names of a section path.
+const sectionSep = " > "
+
+// apiClient is the subset of the API client the collectors use.
+type apiClient interface {
+	GetRun(ctx context.Context, runID int64) (*data.Run, error)
+	GetRunsFiltered(ctx context.Context, projectID int64, filter data.RunFilter) (data.GetRunsResponse, error)
+	GetPlan(ctx context.Context, planID int64) (*data.Plan, error)
+	GetPlansFiltered(ctx context.Context, projectID int64, filter data.PlanFilter) (data.GetPlansResponse, error)
+	GetMilestone(ctx context.Context, milestoneID int64) (*data.Milestone, error)
+	GetTests(ctx context.Context, runID int64, filters map[string]string) ([]data.Test, error)
+	GetResultsForRun(ctx context.Context, runID int64) (data.GetResultsResponse, error)
+	GetStatuses(ctx context.Context) (data.GetStatusesResponse, error)
+	GetPriorities(ctx context.Context) (data.GetPrioritiesResponse, error)
+	GetUsersByProject(ctx context.Context, projectID int64) (data.GetUsersResponse, error)
+	GetCases(ctx context.Context, projectID, suiteID, sectionID int64) (data.GetCasesResponse, error)
+	GetSections(ctx context.Context, projectID, suiteID int64) (data.GetSectionsResponse, error)
+}
+
+// Collect loads the data of a report of the given kind.
+func Collect(ctx context.Context, cli apiClient, kind string, id int64) (Input, error) {
+	switch kind {
+	case KindRun:
+		return CollectRun(ctx, cli, id)
+	case KindPlan:
+		return CollectPlan(ctx, cli, id)
+	case KindMilestone:
+		return CollectMilestone(ctx, cli, id)
+	}
+	return Input{}, fmt.Errorf("unknown report kind %q", kind)
+}
+
+// CollectRun loads the data of a run report.
+func CollectRun(ctx context.Context, cli apiClient, runID int64) (Input, error) {
+	run, err := cli.GetRun(ctx, runID)
+	if err != nil {
+		return Input{}, fmt.Errorf("failed to get run %d: %w", runID, err)
+	}
+	if run == nil {
+		return Input{}, fmt.Errorf("run %d not found", runID)
+	}
+	in := Input{Kind: KindRun, ID: run.ID, Name: run.Name, URL: run.URL}
+	err = collect(ctx, cli, &in, run.ProjectID, []data.Run{*run})
+	return in, err
+}
+
+// CollectPlan loads the data of a plan report: the runs of all its entries.
+func CollectPlan(ctx context.Context, cli apiClient, planID int64) (Input, error) {
+	plan, err := cli.GetPlan(ctx, planID)
+	if err != nil {
+		return Input{}, fmt.Errorf("failed to get plan %d: %w", planID, err)
+	}
+	if plan == nil {
+		return Input{}, fmt.Errorf("plan %d not found", planID)
+	}
+	in := Input{Kind: KindPlan, ID: plan.ID, Name: plan.Name, URL: plan.URL}
+	err = collect(ctx, cli, &in, plan.ProjectID, planRuns(plan))
+	return in, err
+}
+
+// CollectMilestone loads the data of a milestone report: its runs and the
+// runs of its plans. Runs of sub-milestones are not included.
+func CollectMilestone(ctx context.Context, cli apiClient, milestoneID int64) (Input, error) {
+	ms, err := cli.GetMilestone(ctx, milestoneID)
+	if err != nil {
+		return Input{}, fmt.Errorf("failed to get milestone %d: %w", milestoneID, err)
+	}
+	if ms == nil {
+		return Input{}, fmt.Errorf("milestone %d not found", milestoneID)
+	}
+	in := Input{Kind: KindMilestone, ID: ms.ID, Name: ms.Name, URL: ms.URL}
+
+	runs, err := cli.GetRunsFiltered(ctx, ms.ProjectID, data.RunFilter{MilestoneIDs: []int64{ms.ID}})
+	if err != nil {
+		return in, fmt.Errorf("failed to get runs of milestone %d: %w", ms.ID, err)
+	}
+	plans, err := cli.GetPlansFiltered(ctx, ms.ProjectID, data.PlanFilter{MilestoneIDs: []int64{ms.ID}})
+	if err != nil {
+		return in, fmt.Errorf("failed to get plans of milestone %d: %w", ms.ID, err)
+	}
+	for _, p := range plans {
+		// get_plans does not return the entries.
+		plan, err := cli.GetPlan(ctx, p.ID)
+		if err != nil {
+			return in, fmt.Errorf("failed to get plan %d: %w", p.ID, err)
+		}
+		if plan != nil {
+			runs = append(runs, planRuns(plan)...)
+		}
+	}
+	err = collect(ctx, cli, &in, ms.ProjectID, runs)
+	return in, err
+}
+
+func planRuns(plan *data.Plan) []data.Run {
+	var runs []data.Run
+	for _, e := range plan.Entries {
+		runs = append(runs, e.Runs...)
+	}
+	return runs
+}
+
+// collect loads the tests and results of runs and the reference data into in.
+func collect(ctx context.Context, cli apiClient, in *Input, projectID int64, runs []data.Run) error {
+	var err error
+	if in.Statuses, err = cli.GetStatuses(ctx); err != nil {
+		return fmt.Errorf("failed to get statuses: %w", err)
+	}
+	if in.Priorities, err = cli.GetPriorities(ctx); err != nil {
+		return fmt.Errorf("failed to get priorities: %w", err)
+	}
+	// Listing users may need more permissions than reading runs; assignees
+	// are then reported by ID.
+	in.Users, _ = cli.GetUsersByProject(ctx, projectID)
+
+	in.CaseSections = make(map[int64]string)
+	suites := make(map[int64]bool)
+	for _, run := range runs {
+		tests, err := cli.GetTests(ctx, run.ID, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get tests of run %d: %w", run.ID, err)
+		}
+		results, err := cli.GetResultsForRun(ctx, run.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get results of run %d: %w", run.ID, err)
+		}
+		in.Runs = append(in.Runs, RunData{Run: run, Tests: tests, Results: results})
+
+		if suites[run.SuiteID] {
+			continue
+		}
+		suites[run.SuiteID] = true
+		if err := loadSections(ctx, cli, in.CaseSections, projectID, run.SuiteID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadSections maps the cases of a suite to their section paths. Tests do
+// not carry their section, so it is looked up through the case.
+func loadSections(ctx context.Context, cli apiClient, caseSections map[int64]string, projectID, suiteID int64) error {
+	sections, err := cli.GetSections(ctx, projectID, suiteID)
+	if err != nil {
+		return fmt.Errorf("failed to get sections of suite %d: %w", suiteID, err)
+	}
+	cases, err := cli.GetCases(ctx, projectID, suiteID, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get cases of suite %d: %w", suiteID, err)
+	}
+	paths := match.SectionPaths(sections, sectionSep)
+	for _, c := range cases {
+		if p, ok := paths[c.SectionID]; ok {
+			caseSections[c.ID] = p
+		}
+	}
+	return nil
+}
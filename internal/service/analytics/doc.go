@@ -0,0 +1,13 @@
+// Package analytics computes statistics of a run, plan or milestone locally
+// from its tests and results, for `gotr analyze`.
+//
+// Collect* functions load the runs, tests, results and reference data
+// (statuses, priorities, users, section paths) into an [Input]; [Build]
+// turns it into a [Report] with pass rates by section, assignee and
+// priority, the failed and blocked tests with their defects, and elapsed
+// time against estimates. [Markdown] and [HTML] render self-contained
+// documents of a report.
+//
+// [CollectFlaky] follows the cases of the latest runs of a project and
+// ranks those whose results flip between passed and failed ([FlakyReport]).
+package analytics
@@ -0,0 +1,281 @@
+// internal/service/analytics/flaky.go
+package analytics
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/concurrency"
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// DefaultFlakyRuns is how many recent runs a flakiness report covers.
+const DefaultFlakyRuns = 20
+
+// CollectFlaky asks for the runs created in the last flakyWindow and doubles
+// the window until it holds enough runs. After flakyWindows tries all runs
+// are fetched.
+const (
+	flakyWindow  = 30 * 24 * time.Hour
+	flakyWindows = 6
+)
+
+// FlakyOptions selects the runs of a flakiness report and the cases it ranks.
+type FlakyOptions struct {
+	ProjectID   int64
+	SuiteID     int64
+	MilestoneID int64
+	// Last is the number of most recent runs to analyze.
+	Last int
+	// MinResults is the number of passed and failed results a case needs to
+	// be ranked.
+	MinResults int
+	// Parallel limits the runs fetched at the same time (0 = all).
+	Parallel int
+}
+
+// FlakyReport ranks the cases whose results flip between passed and failed
+// across the analyzed runs.
+type FlakyReport struct {
+	ProjectID   int64       `json:"project_id"`
+	SuiteID     int64       `json:"suite_id,omitempty"`
+	MilestoneID int64       `json:"milestone_id,omitempty"`
+	Runs        []FlakyRun  `json:"runs"`
+	Analyzed    int         `json:"analyzed_cases"`
+	Cases       []FlakyCase `json:"cases"`
+}
+
+// FlakyRun is an analyzed run.
+type FlakyRun struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	CreatedOn int64  `json:"created_on"`
+}
+
+// FlakyCase is the result history of a case across the analyzed runs.
+// Only passed and failed results count; other statuses are skipped.
+type FlakyCase struct {
+	CaseID  int64  `json:"case_id"`
+	Title   string `json:"title"`
+	Runs    int    `json:"runs"`
+	Results int    `json:"results"`
+	Passed  int    `json:"passed"`
+	Failed  int    `json:"failed"`
+	// Flips is the number of passed↔failed changes between consecutive
+	// results; FlipRate divides it by the number of changes possible.
+	Flips    int     `json:"flips"`
+	FlipRate float64 `json:"flip_rate"`
+	// FailStreak is the number of failed results since the last pass.
+	FailStreak        int   `json:"fail_streak"`
+	LongestFailStreak int   `json:"longest_fail_streak"`
+	LastPassedOn      int64 `json:"last_passed_on,omitempty"`
+	LastPassedRunID   int64 `json:"last_passed_run_id,omitempty"`
+	// MeanTimeToFix is the mean time from the first failure of a streak to
+	// the next pass, over Fixes streaks, in seconds.
+	MeanTimeToFix int64 `json:"mean_time_to_fix_seconds"`
+	Fixes         int   `json:"fixes"`
+	// LastRunID and LastTestID identify the case in the latest run.
+	LastRunID  int64 `json:"last_run_id"`
+	LastTestID int64 `json:"last_test_id"`
+}
+
+// flakyClient is the subset of the API client the flakiness report uses.
+type flakyClient interface {
+	GetRunsFiltered(ctx context.Context, projectID int64, filter data.RunFilter) (data.GetRunsResponse, error)
+	GetTests(ctx context.Context, runID int64, filters map[string]string) ([]data.Test, error)
+	GetResultsForRun(ctx context.Context, runID int64) (data.GetResultsResponse, error)
+	GetStatuses(ctx context.Context) (data.GetStatusesResponse, error)
+}
+
+// CollectFlaky loads the tests and results of the latest opts.Last runs of
+// a project, optionally limited to a suite or milestone, and ranks the
+// unstable cases. Runs are requested for a growing created_after window, so
+// the runs fetched depend on opts.Last rather than on the project history.
+// Runs inside test plans are not returned by get_runs and are not analyzed.
+func CollectFlaky(ctx context.Context, cli flakyClient, opts FlakyOptions, fetchOpts ...concurrency.FetchOption) (*FlakyReport, error) {
+	filter := data.RunFilter{}
+	if opts.SuiteID > 0 {
+		filter.SuiteIDs = []int64{opts.SuiteID}
+	}
+	if opts.MilestoneID > 0 {
+		filter.MilestoneIDs = []int64{opts.MilestoneID}
+	}
+	last := cmp.Or(opts.Last, DefaultFlakyRuns)
+	runs, err := recentRuns(ctx, cli, opts.ProjectID, filter, last)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get runs of project %d: %w", opts.ProjectID, err)
+	}
+	runs = latestRuns(runs, last)
+
+	statuses, err := cli.GetStatuses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statuses: %w", err)
+	}
+
+	byID := make(map[int64]data.Run, len(runs))
+	ids := make([]int64, 0, len(runs))
+	for _, r := range runs {
+		byID[r.ID] = r
+		ids = append(ids, r.ID)
+	}
+	if opts.Parallel > 0 {
+		fetchOpts = append(fetchOpts, concurrency.WithMaxConcurrency(opts.Parallel))
+	}
+	fetched, err := concurrency.FetchParallel(ctx, ids, func(runID int64) ([]RunData, error) {
+		tests, err := cli.GetTests(ctx, runID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tests: %w", err)
+		}
+		results, err := cli.GetResultsForRun(ctx, runID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get results: %w", err)
+		}
+		return []RunData{{Run: byID[runID], Tests: tests, Results: results}}, nil
+	}, fetchOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]RunData, 0, len(runs))
+	for _, id := range ids {
+		history = append(history, fetched[id]...)
+	}
+	r := BuildFlaky(history, statuses, opts.MinResults)
+	r.ProjectID, r.SuiteID, r.MilestoneID = opts.ProjectID, opts.SuiteID, opts.MilestoneID
+	return r, nil
+}
+
+// recentRuns returns the runs matching filter created in the smallest window
+// that holds at least n of them, or all of them.
+func recentRuns(ctx context.Context, cli flakyClient, projectID int64, filter data.RunFilter, n int) (data.GetRunsResponse, error) {
+	window := flakyWindow
+	for range flakyWindows {
+		f := filter
+		f.CreatedAfter = time.Now().Add(-window)
+		runs, err := cli.GetRunsFiltered(ctx, projectID, f)
+		if err != nil || len(runs) >= n {
+			return runs, err
+		}
+		window *= 2
+	}
+	return cli.GetRunsFiltered(ctx, projectID, filter)
+}
+
+// latestRuns returns the last n runs by creation, oldest first.
+func latestRuns(runs data.GetRunsResponse, n int) []data.Run {
+	sorted := slices.Clone(runs)
+	slices.SortFunc(sorted, func(a, b data.Run) int {
+		return cmp.Or(cmp.Compare(a.CreatedOn, b.CreatedOn), cmp.Compare(a.ID, b.ID))
+	})
+	if len(sorted) > n {
+		sorted = sorted[len(sorted)-n:]
+	}
+	return sorted
+}
+
+// flakyEvent is a passed or failed result of a case.
+type flakyEvent struct {
+	passed bool
+	at     int64
+	runID  int64
+}
+
+// BuildFlaky computes the flakiness report of runs given oldest first. Cases
+// with at least one flip and minResults results are ranked by flip rate,
+// then flips and failures.
+func BuildFlaky(runs []RunData, statuses data.GetStatusesResponse, minResults int) *FlakyReport {
+	byStatus := make(map[int64]data.Status, len(statuses))
+	for _, s := range statuses {
+		byStatus[s.ID] = s
+	}
+
+	r := &FlakyReport{Runs: []FlakyRun{}, Cases: []FlakyCase{}}
+	cases := make(map[int64]*FlakyCase)
+	events := make(map[int64][]flakyEvent)
+	for _, rd := range runs {
+		r.Runs = append(r.Runs, FlakyRun{ID: rd.Run.ID, Name: rd.Run.Name, CreatedOn: rd.Run.CreatedOn})
+
+		caseOf := make(map[int64]int64, len(rd.Tests))
+		for _, t := range rd.Tests {
+			caseOf[t.ID] = t.CaseID
+			c, ok := cases[t.CaseID]
+			if !ok {
+				c = &FlakyCase{CaseID: t.CaseID}
+				cases[t.CaseID] = c
+			}
+			c.Title, c.LastRunID, c.LastTestID = t.Title, rd.Run.ID, t.ID
+			c.Runs++
+		}
+		for testID, results := range resultsByTest(rd.Results) {
+			caseID, ok := caseOf[testID]
+			if !ok {
+				continue
+			}
+			for _, res := range results {
+				switch classify(byStatus, res.StatusID) {
+				case classPassed:
+					events[caseID] = append(events[caseID], flakyEvent{passed: true, at: res.CreatedOn, runID: rd.Run.ID})
+				case classFailed:
+					events[caseID] = append(events[caseID], flakyEvent{at: res.CreatedOn, runID: rd.Run.ID})
+				}
+			}
+		}
+	}
+
+	for caseID, evs := range events {
+		c := cases[caseID]
+		// Results of a run are ordered; runs may overlap in time.
+		slices.SortStableFunc(evs, func(a, b flakyEvent) int { return cmp.Compare(a.at, b.at) })
+		c.score(evs)
+		r.Analyzed++
+		if c.Flips > 0 && c.Results >= minResults {
+			r.Cases = append(r.Cases, *c)
+		}
+	}
+	slices.SortFunc(r.Cases, func(a, b FlakyCase) int {
+		return cmp.Or(
+			cmp.Compare(b.FlipRate, a.FlipRate),
+			b.Flips-a.Flips,
+			b.Failed-a.Failed,
+			cmp.Compare(a.CaseID, b.CaseID),
+		)
+	})
+	return r
+}
+
+// score computes the history metrics of a case from its events.
+func (c *FlakyCase) score(evs []flakyEvent) {
+	var fixTime, streakStart int64
+	for i, e := range evs {
+		c.Results++
+		if i > 0 && e.passed != evs[i-1].passed {
+			c.Flips++
+		}
+		if e.passed {
+			c.Passed++
+			c.LastPassedOn, c.LastPassedRunID = e.at, e.runID
+			if c.FailStreak > 0 {
+				c.Fixes++
+				fixTime += e.at - streakStart
+			}
+			c.FailStreak = 0
+			continue
+		}
+		c.Failed++
+		if c.FailStreak == 0 {
+			streakStart = e.at
+		}
+		c.FailStreak++
+		c.LongestFailStreak = max(c.LongestFailStreak, c.FailStreak)
+	}
+	if c.Results > 1 {
+		c.FlipRate = math.Round(float64(c.Flips)/float64(c.Results-1)*100) / 100
+	}
+	if c.Fixes > 0 {
+		c.MeanTimeToFix = fixTime / int64(c.Fixes)
+	}
+}
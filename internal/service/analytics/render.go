@@ -0,0 +1,204 @@
+// internal/service/analytics/render.go
+package analytics
+
+import (
+	htmltemplate "html/template"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// commentLimit is how many characters of a result comment the documents show.
+const commentLimit = 120
+
+var renderFuncs = map[string]any{
+	"title":    Title,
+	"pct":      func(v float64) string { return strconv.FormatFloat(v, 'f', 1, 64) + "%" },
+	"duration": FormatSeconds,
+	"join":     strings.Join,
+	"comment":  shortComment,
+	"cell":     mdCell,
+	"width":    func(v float64) string { return strconv.FormatFloat(v, 'f', 1, 64) },
+	"groupsOf": func(heading, column string, groups []Group) groupTable {
+		return groupTable{Heading: heading, Column: column, Groups: groups}
+	},
+	"problemsOf": func(heading string, problems []Problem) problemTable {
+		return problemTable{Heading: heading, Problems: problems}
+	},
+}
+
+// Title returns the heading of a report, e.g. "Run 12: Regression".
+func Title(r *Report) string {
+	kind := r.Kind
+	if kind != "" {
+		kind = strings.ToUpper(kind[:1]) + kind[1:]
+	}
+	return kind + " " + strconv.FormatInt(r.ID, 10) + ": " + r.Name
+}
+
+// shortComment returns the first line of a comment, cut to commentLimit.
+func shortComment(s string) string {
+	s, _, _ = strings.Cut(strings.TrimSpace(s), "\n")
+	if r := []rune(s); len(r) > commentLimit {
+		s = string(r[:commentLimit]) + "…"
+	}
+	return s
+}
+
+// mdCell makes s safe for a Markdown table cell.
+func mdCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+var markdownTmpl = template.Must(template.New("md").Funcs(renderFuncs).Parse(`# {{title .}}
+
+Generated {{.GeneratedAt.Format "2006-01-02 15:04 MST"}}{{if .URL}} · [Open in TestRail]({{.URL}}){{end}}
+
+## Summary
+
+| Total | Passed | Failed | Blocked | Retest | Untested | Other | Pass rate |
+| ---: | ---: | ---: | ---: | ---: | ---: | ---: | ---: |
+{{with .Summary}}| {{.Total}} | {{.Passed}} | {{.Failed}} | {{.Blocked}} | {{.Retest}} | {{.Untested}} | {{.Other}} | {{pct .PassRate}} |{{end}}
+
+Pass rate is the share of passed tests among the tested ones.
+{{- if .Statuses}}
+
+| Status | Tests |
+| --- | ---: |
+{{- range .Statuses}}
+| {{cell .Name}} | {{.Count}} |
+{{- end}}
+{{- end}}
+
+## Time
+
+| Elapsed | Estimate | Tests with elapsed | Tests with estimate | Over estimate | Untested estimate |
+| ---: | ---: | ---: | ---: | ---: | ---: |
+{{with .Time}}| {{duration .Elapsed}} | {{duration .Estimate}} | {{.TimedTests}} | {{.EstimatedTests}} | {{.OverEstimate}} | {{duration .UntestedEstimate}} |{{end}}
+{{- template "groups" (groupsOf "Runs" "Run" .Runs)}}
+{{- template "groups" (groupsOf "By section" "Section" .BySection)}}
+{{- template "groups" (groupsOf "By assignee" "Assignee" .ByAssignee)}}
+{{- template "groups" (groupsOf "By priority" "Priority" .ByPriority)}}
+{{- template "problems" (problemsOf "Failed tests" .Failed)}}
+{{- template "problems" (problemsOf "Blocked tests" .Blocked)}}
+{{- define "groups"}}
+
+## {{.Heading}}
+
+| {{.Column}} | Total | Passed | Failed | Blocked | Untested | Pass rate |
+| --- | ---: | ---: | ---: | ---: | ---: | ---: |
+{{- range .Groups}}
+| {{cell .Name}} | {{.Total}} | {{.Passed}} | {{.Failed}} | {{.Blocked}} | {{.Untested}} | {{pct .PassRate}} |
+{{- end}}
+{{- end}}
+{{- define "problems"}}
+
+## {{.Heading}} ({{len .Problems}})
+{{if .Problems}}
+| Test | Title | Section | Assignee | Status | Defects | Comment |
+| --- | --- | --- | --- | --- | --- | --- |
+{{- range .Problems}}
+| T{{.TestID}} | {{cell .Title}} | {{cell .Section}} | {{cell .Assignee}} | {{cell .Status}} | {{cell (join .Defects ", ")}} | {{cell (comment .Comment)}} |
+{{- end}}
+{{- else}}
+None.
+{{- end}}
+{{- end}}
+`))
+
+var htmlTmpl = htmltemplate.Must(htmltemplate.New("html").Funcs(renderFuncs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{title .}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Roboto, Helvetica, Arial, sans-serif; margin: 2em auto; max-width: 1100px; color: #222; padding: 0 1em; }
+h1 { margin-bottom: .2em; }
+.meta { color: #666; margin-bottom: 2em; }
+table { border-collapse: collapse; width: 100%; margin: .5em 0 2em; font-size: 14px; }
+th, td { border: 1px solid #ddd; padding: 6px 8px; text-align: left; vertical-align: top; }
+th { background: #f5f5f5; }
+td.n { text-align: right; white-space: nowrap; }
+.bar { background: #f2dede; height: 8px; border-radius: 4px; min-width: 80px; }
+.bar span { display: block; background: #5cb85c; height: 8px; border-radius: 4px; }
+.passed { color: #3c763d; } .failed { color: #a94442; } .blocked { color: #8a6d3b; }
+</style>
+</head>
+<body>
+<h1>{{title .}}</h1>
+<div class="meta">Generated {{.GeneratedAt.Format "2006-01-02 15:04 MST"}}{{if .URL}} · <a href="{{.URL}}">Open in TestRail</a>{{end}}</div>
+
+<h2>Summary</h2>
+<table>
+<tr><th>Total</th><th>Passed</th><th>Failed</th><th>Blocked</th><th>Retest</th><th>Untested</th><th>Other</th><th>Pass rate</th></tr>
+{{with .Summary}}<tr><td class="n">{{.Total}}</td><td class="n passed">{{.Passed}}</td><td class="n failed">{{.Failed}}</td><td class="n blocked">{{.Blocked}}</td><td class="n">{{.Retest}}</td><td class="n">{{.Untested}}</td><td class="n">{{.Other}}</td><td class="n">{{pct .PassRate}}</td></tr>{{end}}
+</table>
+<p>Pass rate is the share of passed tests among the tested ones.</p>
+{{- if .Statuses}}
+<table>
+<tr><th>Status</th><th>Tests</th></tr>
+{{- range .Statuses}}
+<tr><td>{{.Name}}</td><td class="n">{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+
+<h2>Time</h2>
+<table>
+<tr><th>Elapsed</th><th>Estimate</th><th>Tests with elapsed</th><th>Tests with estimate</th><th>Over estimate</th><th>Untested estimate</th></tr>
+{{with .Time}}<tr><td class="n">{{duration .Elapsed}}</td><td class="n">{{duration .Estimate}}</td><td class="n">{{.TimedTests}}</td><td class="n">{{.EstimatedTests}}</td><td class="n">{{.OverEstimate}}</td><td class="n">{{duration .UntestedEstimate}}</td></tr>{{end}}
+</table>
+{{template "groups" (groupsOf "Runs" "Run" .Runs)}}
+{{- template "groups" (groupsOf "By section" "Section" .BySection)}}
+{{- template "groups" (groupsOf "By assignee" "Assignee" .ByAssignee)}}
+{{- template "groups" (groupsOf "By priority" "Priority" .ByPriority)}}
+{{- template "problems" (problemsOf "Failed tests" .Failed)}}
+{{- template "problems" (problemsOf "Blocked tests" .Blocked)}}
+</body>
+</html>
+{{- define "groups"}}
+<h2>{{.Heading}}</h2>
+<table>
+<tr><th>{{.Column}}</th><th>Total</th><th>Passed</th><th>Failed</th><th>Blocked</th><th>Untested</th><th>Pass rate</th><th></th></tr>
+{{- range .Groups}}
+<tr><td>{{.Name}}</td><td class="n">{{.Total}}</td><td class="n passed">{{.Passed}}</td><td class="n failed">{{.Failed}}</td><td class="n blocked">{{.Blocked}}</td><td class="n">{{.Untested}}</td><td class="n">{{pct .PassRate}}</td><td><div class="bar"><span style="width: {{width .PassRate}}%"></span></div></td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- define "problems"}}
+<h2>{{.Heading}} ({{len .Problems}})</h2>
+{{- if .Problems}}
+<table>
+<tr><th>Test</th><th>Title</th><th>Section</th><th>Assignee</th><th>Status</th><th>Defects</th><th>Comment</th></tr>
+{{- range .Problems}}
+<tr><td>T{{.TestID}}</td><td>{{.Title}}</td><td>{{.Section}}</td><td>{{.Assignee}}</td><td>{{.Status}}</td><td>{{join .Defects ", "}}</td><td>{{comment .Comment}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>None.</p>
+{{- end}}
+{{- end}}
+`))
+
+// groupTable and problemTable are the arguments of the shared templates.
+type groupTable struct {
+	Heading, Column string
+	Groups          []Group
+}
+
+type problemTable struct {
+	Heading  string
+	Problems []Problem
+}
+
+// Markdown writes the report as a Markdown document.
+func Markdown(w io.Writer, r *Report) error {
+	return markdownTmpl.Execute(w, r)
+}
+
+// HTML writes the report as a self-contained HTML page.
+func HTML(w io.Writer, r *Report) error {
+	return htmlTmpl.Execute(w, r)
+}
@@ -0,0 +1,408 @@
+// internal/service/analytics/report.go
+package analytics
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// Report kinds.
+const (
+	KindRun       = "run"
+	KindPlan      = "plan"
+	KindMilestone = "milestone"
+)
+
+// Group names used when a test has no section, assignee or priority.
+const (
+	NoSection  = "(no section)"
+	Unassigned = "Unassigned"
+	NoPriority = "(no priority)"
+)
+
+// Report is the computed statistics of a run, plan or milestone.
+type Report struct {
+	Kind        string        `json:"kind"`
+	ID          int64         `json:"id"`
+	Name        string        `json:"name"`
+	URL         string        `json:"url,omitempty"`
+	GeneratedAt time.Time     `json:"generated_at"`
+	Summary     Counts        `json:"summary"`
+	Statuses    []StatusCount `json:"statuses"`
+	Runs        []Group       `json:"runs"`
+	BySection   []Group       `json:"by_section"`
+	ByAssignee  []Group       `json:"by_assignee"`
+	ByPriority  []Group       `json:"by_priority"`
+	Failed      []Problem     `json:"failed"`
+	Blocked     []Problem     `json:"blocked"`
+	Time        TimeStats     `json:"time"`
+}
+
+// Counts are test counts by status. Other counts custom statuses.
+// PassRate is the percentage of passed tests among the tested ones.
+type Counts struct {
+	Total    int     `json:"total"`
+	Passed   int     `json:"passed"`
+	Failed   int     `json:"failed"`
+	Blocked  int     `json:"blocked"`
+	Retest   int     `json:"retest"`
+	Untested int     `json:"untested"`
+	Other    int     `json:"other"`
+	PassRate float64 `json:"pass_rate"`
+}
+
+// Tested returns the number of tests that have a result.
+func (c Counts) Tested() int { return c.Total - c.Untested }
+
+// Group is the counts of the tests sharing a run, section, assignee or priority.
+type Group struct {
+	ID   int64  `json:"id,omitempty"`
+	Name string `json:"name"`
+	Counts
+}
+
+// StatusCount is the number of tests with a status.
+type StatusCount struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Problem is a failed or blocked test with the defects of its results and
+// the comment of the latest one.
+type Problem struct {
+	TestID   int64    `json:"test_id"`
+	CaseID   int64    `json:"case_id"`
+	RunID    int64    `json:"run_id"`
+	Title    string   `json:"title"`
+	Section  string   `json:"section"`
+	Assignee string   `json:"assignee"`
+	Status   string   `json:"status"`
+	Defects  []string `json:"defects,omitempty"`
+	Comment  string   `json:"comment,omitempty"`
+}
+
+// TimeStats compares the elapsed time of all results with the estimates of
+// the tests. Durations are in seconds.
+type TimeStats struct {
+	Estimate         int64 `json:"estimate_seconds"`
+	Elapsed          int64 `json:"elapsed_seconds"`
+	EstimatedTests   int   `json:"estimated_tests"`
+	TimedTests       int   `json:"timed_tests"`
+	OverEstimate     int   `json:"over_estimate_tests"`
+	UntestedEstimate int64 `json:"untested_estimate_seconds"`
+}
+
+// Input is the data a report is computed from.
+type Input struct {
+	Kind       string
+	ID         int64
+	Name       string
+	URL        string
+	Runs       []RunData
+	Statuses   data.GetStatusesResponse
+	Priorities data.GetPrioritiesResponse
+	Users      data.GetUsersResponse
+	// CaseSections maps a case ID to the full path of its section.
+	CaseSections map[int64]string
+}
+
+// RunData is a run with its tests and all their results.
+type RunData struct {
+	Run     data.Run
+	Tests   []data.Test
+	Results data.GetResultsResponse
+}
+
+// class is the kind of a status the counts are kept by.
+type class int
+
+const (
+	classUntested class = iota
+	classPassed
+	classFailed
+	classBlocked
+	classRetest
+	classOther
+)
+
+// statusUntested is the ID of the built-in untested status, which tests
+// without a status are counted under.
+const statusUntested = 3
+
+// systemStatuses are the names of TestRail's built-in statuses, used when
+// get_statuses did not return a status.
+var systemStatuses = map[int64]string{1: "passed", 2: "blocked", 3: "untested", 4: "retest", 5: "failed"}
+
+func (c *Counts) add(cl class) {
+	c.Total++
+	switch cl {
+	case classPassed:
+		c.Passed++
+	case classFailed:
+		c.Failed++
+	case classBlocked:
+		c.Blocked++
+	case classRetest:
+		c.Retest++
+	case classUntested:
+		c.Untested++
+	default:
+		c.Other++
+	}
+}
+
+func (c *Counts) finish() {
+	c.PassRate = 0
+	if tested := c.Tested(); tested > 0 {
+		c.PassRate = math.Round(float64(c.Passed)/float64(tested)*1000) / 10
+	}
+}
+
+// Build computes the report of in.
+func Build(in Input) *Report {
+	statuses := make(map[int64]data.Status, len(in.Statuses))
+	for _, s := range in.Statuses {
+		statuses[s.ID] = s
+	}
+	users := make(map[int64]string, len(in.Users))
+	for _, u := range in.Users {
+		users[u.ID] = u.Name
+	}
+	priorities := make(map[int64]string, len(in.Priorities))
+	for _, p := range in.Priorities {
+		priorities[p.ID] = p.Name
+	}
+
+	r := &Report{
+		Kind:        in.Kind,
+		ID:          in.ID,
+		Name:        in.Name,
+		URL:         in.URL,
+		GeneratedAt: time.Now().UTC(),
+		Runs:        []Group{},
+		Statuses:    []StatusCount{},
+		Failed:      []Problem{},
+		Blocked:     []Problem{},
+	}
+	sections := newGroups()
+	assignees := newGroups()
+	prios := newGroups()
+	statusCounts := make(map[int64]int)
+
+	for _, rd := range in.Runs {
+		run := Group{ID: rd.Run.ID, Name: rd.Run.Name}
+		if rd.Run.Config != "" {
+			run.Name += " (" + rd.Run.Config + ")"
+		}
+		results := resultsByTest(rd.Results)
+
+		for _, t := range rd.Tests {
+			cl := classify(statuses, t.StatusID)
+			section := cmp.Or(in.CaseSections[t.CaseID], NoSection)
+			assignee := userName(users, t.AssignedTo)
+			priority := NoPriority
+			if t.PriorityID != 0 {
+				priority = cmp.Or(priorities[t.PriorityID], "Priority "+strconv.FormatInt(t.PriorityID, 10))
+			}
+
+			r.Summary.add(cl)
+			run.add(cl)
+			sections.add(section, cl)
+			assignees.add(assignee, cl)
+			prios.add(priority, cl)
+			if t.StatusID != 0 {
+				statusCounts[t.StatusID]++
+			} else {
+				statusCounts[statusUntested]++
+			}
+
+			estimate := parseTimespan(t.Estimate)
+			var elapsed time.Duration
+			for _, res := range results[t.ID] {
+				elapsed += parseTimespan(res.Elapsed)
+			}
+			r.Time.add(estimate, elapsed, cl)
+
+			if cl == classFailed || cl == classBlocked {
+				p := Problem{
+					TestID:   t.ID,
+					CaseID:   t.CaseID,
+					RunID:    rd.Run.ID,
+					Title:    t.Title,
+					Section:  section,
+					Assignee: assignee,
+					Status:   statusName(statuses, t.StatusID),
+					Defects:  defects(results[t.ID]),
+				}
+				if res := results[t.ID]; len(res) > 0 {
+					p.Comment = strings.TrimSpace(res[len(res)-1].Comment)
+				}
+				if cl == classFailed {
+					r.Failed = append(r.Failed, p)
+				} else {
+					r.Blocked = append(r.Blocked, p)
+				}
+			}
+		}
+		run.finish()
+		r.Runs = append(r.Runs, run)
+	}
+	r.Summary.finish()
+
+	for id, n := range statusCounts {
+		r.Statuses = append(r.Statuses, StatusCount{ID: id, Name: statusName(statuses, id), Count: n})
+	}
+	slices.SortFunc(r.Statuses, func(a, b StatusCount) int {
+		return cmp.Or(b.Count-a.Count, cmp.Compare(a.ID, b.ID))
+	})
+
+	r.BySection = sections.sorted(func(a, b Group) int {
+		return cmp.Or(cmpBool(a.Name == NoSection, b.Name == NoSection), strings.Compare(a.Name, b.Name))
+	})
+	r.ByAssignee = assignees.sorted(func(a, b Group) int {
+		return cmp.Or(cmpBool(a.Name == Unassigned, b.Name == Unassigned), strings.Compare(a.Name, b.Name))
+	})
+	// Priorities keep the order get_priorities returns them in.
+	order := make(map[string]int, len(in.Priorities))
+	for i, p := range in.Priorities {
+		order[p.Name] = i
+	}
+	r.ByPriority = prios.sorted(func(a, b Group) int {
+		ia, okA := order[a.Name]
+		ib, okB := order[b.Name]
+		return cmp.Or(cmpBool(!okA, !okB), cmp.Compare(ia, ib), strings.Compare(a.Name, b.Name))
+	})
+	return r
+}
+
+func (ts *TimeStats) add(estimate, elapsed time.Duration, cl class) {
+	if estimate > 0 {
+		ts.Estimate += int64(estimate / time.Second)
+		ts.EstimatedTests++
+		if cl == classUntested {
+			ts.UntestedEstimate += int64(estimate / time.Second)
+		}
+	}
+	if elapsed > 0 {
+		ts.Elapsed += int64(elapsed / time.Second)
+		ts.TimedTests++
+		if estimate > 0 && elapsed > estimate {
+			ts.OverEstimate++
+		}
+	}
+}
+
+// groups accumulates counts by group name.
+type groups map[string]*Group
+
+func newGroups() groups { return make(groups) }
+
+func (g groups) add(name string, cl class) {
+	grp, ok := g[name]
+	if !ok {
+		grp = &Group{Name: name}
+		g[name] = grp
+	}
+	grp.add(cl)
+}
+
+func (g groups) sorted(less func(a, b Group) int) []Group {
+	res := make([]Group, 0, len(g))
+	for _, grp := range g {
+		grp.finish()
+		res = append(res, *grp)
+	}
+	slices.SortFunc(res, less)
+	return res
+}
+
+// classify returns the class of a status by its system name.
+func classify(statuses map[int64]data.Status, id int64) class {
+	if id == 0 {
+		return classUntested
+	}
+	name := systemStatuses[id]
+	if s, ok := statuses[id]; ok {
+		if s.IsUntested {
+			return classUntested
+		}
+		name = strings.ToLower(s.Name)
+	}
+	switch name {
+	case "passed":
+		return classPassed
+	case "failed":
+		return classFailed
+	case "blocked":
+		return classBlocked
+	case "retest":
+		return classRetest
+	case "untested":
+		return classUntested
+	}
+	return classOther
+}
+
+func statusName(statuses map[int64]data.Status, id int64) string {
+	if id == 0 {
+		return "Untested"
+	}
+	if s, ok := statuses[id]; ok {
+		return cmp.Or(s.Label, s.Name)
+	}
+	if name, ok := systemStatuses[id]; ok {
+		return strings.ToUpper(name[:1]) + name[1:]
+	}
+	return "Status " + strconv.FormatInt(id, 10)
+}
+
+func userName(users map[int64]string, id int64) string {
+	if id == 0 {
+		return Unassigned
+	}
+	return cmp.Or(users[id], "User "+strconv.FormatInt(id, 10))
+}
+
+// resultsByTest groups results by test, oldest first.
+func resultsByTest(results data.GetResultsResponse) map[int64][]data.Result {
+	res := make(map[int64][]data.Result)
+	for _, r := range results {
+		res[r.TestID] = append(res[r.TestID], r)
+	}
+	for _, list := range res {
+		slices.SortStableFunc(list, func(a, b data.Result) int {
+			return cmp.Or(cmp.Compare(a.CreatedOn, b.CreatedOn), cmp.Compare(a.ID, b.ID))
+		})
+	}
+	return res
+}
+
+// defects returns the distinct defects of the results in order of appearance.
+func defects(results []data.Result) []string {
+	var res []string
+	for _, r := range results {
+		for _, d := range strings.Split(r.Defects, ",") {
+			if d = strings.TrimSpace(d); d != "" && !slices.Contains(res, d) {
+				res = append(res, d)
+			}
+		}
+	}
+	return res
+}
+
+func cmpBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	}
+	return -1
+}
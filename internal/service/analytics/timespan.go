@@ -0,0 +1,53 @@
+// internal/service/analytics/timespan.go
+package analytics
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/service/importer"
+)
+
+// timespanUnits are the units of TestRail timespans. A day counts as 24
+// hours and a week as 7 days.
+var timespanUnits = map[byte]time.Duration{
+	's': time.Second,
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+// parseTimespan parses a TestRail timespan such as "1h 30m", "2m10s" or
+// "1.5h". A value that cannot be parsed counts as zero.
+func parseTimespan(s string) time.Duration {
+	s = strings.ToLower(strings.ReplaceAll(s, " ", ""))
+	var total time.Duration
+	for s != "" {
+		i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		if i <= 0 {
+			return 0
+		}
+		unit, ok := timespanUnits[s[i]]
+		if !ok {
+			return 0
+		}
+		n, err := strconv.ParseFloat(s[:i], 64)
+		if err != nil {
+			return 0
+		}
+		total += time.Duration(n * float64(unit))
+		s = s[i+1:]
+	}
+	return total
+}
+
+// FormatSeconds formats a number of seconds as a TestRail timespan
+// ("1h 2m 3s"); zero is "0s".
+func FormatSeconds(secs int64) string {
+	if secs <= 0 {
+		return "0s"
+	}
+	return importer.FormatElapsed(time.Duration(secs) * time.Second)
+}
@@ -0,0 +1,150 @@
+// internal/service/importer/cucumber.go
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Cucumber step statuses.
+const (
+	cucumberPassed    = "passed"
+	cucumberFailed    = "failed"
+	cucumberSkipped   = "skipped"
+	cucumberPending   = "pending"
+	cucumberUndefined = "undefined"
+	cucumberAmbiguous = "ambiguous"
+)
+
+type cucumberFeature struct {
+	URI      string            `json:"uri"`
+	Name     string            `json:"name"`
+	Tags     []cucumberTag     `json:"tags"`
+	Elements []cucumberElement `json:"elements"`
+}
+
+type cucumberElement struct {
+	Name    string         `json:"name"`
+	Keyword string         `json:"keyword"`
+	Type    string         `json:"type"` // "scenario" or "background"
+	Tags    []cucumberTag  `json:"tags"`
+	Before  []cucumberStep `json:"before"`
+	Steps   []cucumberStep `json:"steps"`
+	After   []cucumberStep `json:"after"`
+}
+
+type cucumberTag struct {
+	Name string `json:"name"`
+}
+
+type cucumberStep struct {
+	Keyword string         `json:"keyword"`
+	Name    string         `json:"name"`
+	Result  cucumberResult `json:"result"`
+}
+
+type cucumberResult struct {
+	Status       string `json:"status"`
+	Duration     int64  `json:"duration"` // nanoseconds
+	ErrorMessage string `json:"error_message"`
+}
+
+// ParseCucumberFile reads a Cucumber JSON report from a file.
+func ParseCucumberFile(path string) ([]TestResult, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	results, err := ParseCucumber(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return results, nil
+}
+
+// ParseCucumber reads a Cucumber JSON report: every scenario becomes one
+// test named after the scenario, with the feature as its suite. Background
+// steps count towards the scenario that follows them. Tags are listed
+// scenario tags first, then feature tags.
+func ParseCucumber(r io.Reader) ([]TestResult, error) {
+	var features []cucumberFeature
+	if err := json.NewDecoder(r).Decode(&features); err != nil {
+		return nil, fmt.Errorf("failed to parse Cucumber JSON: %w", err)
+	}
+
+	var results []TestResult
+	for _, f := range features {
+		var background []cucumberStep
+		for _, e := range f.Elements {
+			if e.Type == "background" {
+				background = append(background, e.Steps...)
+				continue
+			}
+			steps := append(append(append(append([]cucumberStep(nil), e.Before...), background...), e.Steps...), e.After...)
+			background = nil
+
+			res := cucumberScenario(steps)
+			res.Name = e.Name
+			res.ClassName = f.Name
+			res.Suite = f.URI
+			res.Tags = append(tagNames(e.Tags), tagNames(f.Tags)...)
+			results = append(results, res)
+		}
+	}
+	return results, nil
+}
+
+// cucumberScenario derives the outcome of a scenario from its steps: any
+// failed step fails it, ambiguous steps are errors, pending and undefined
+// steps skip it, and a scenario of only skipped steps is skipped.
+func cucumberScenario(steps []cucumberStep) TestResult {
+	res := TestResult{Outcome: OutcomePassed}
+	var problem *cucumberStep
+	skipped := len(steps) > 0
+
+	for i, s := range steps {
+		res.Duration += time.Duration(s.Result.Duration)
+		status := strings.ToLower(s.Result.Status)
+		if status != cucumberSkipped {
+			skipped = false
+		}
+
+		switch status {
+		case cucumberFailed:
+			if res.Outcome != OutcomeFailed {
+				res.Outcome, problem = OutcomeFailed, &steps[i]
+			}
+		case cucumberAmbiguous:
+			if res.Outcome == OutcomePassed || res.Outcome == OutcomeSkipped {
+				res.Outcome, problem = OutcomeError, &steps[i]
+			}
+		case cucumberPending, cucumberUndefined:
+			if res.Outcome == OutcomePassed {
+				res.Outcome, problem = OutcomeSkipped, &steps[i]
+			}
+		}
+	}
+	if skipped && res.Outcome == OutcomePassed {
+		res.Outcome = OutcomeSkipped
+	}
+
+	if problem != nil {
+		res.Message = strings.TrimSpace(fmt.Sprintf("%s%s: %s", problem.Keyword, problem.Name, problem.Result.Status))
+		res.Details = strings.TrimSpace(problem.Result.ErrorMessage)
+	}
+	return res
+}
+
+func tagNames(tags []cucumberTag) []string {
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		names = append(names, t.Name)
+	}
+	return names
+}
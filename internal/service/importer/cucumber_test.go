@@ -0,0 +1,76 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cucumberReport = `[
+  {
+    "uri": "features/login.feature",
+    "name": "Login",
+    "tags": [{"name": "@C100"}, {"name": "@smoke"}],
+    "elements": [
+      {"type": "background", "name": "", "steps": [
+        {"keyword": "Given ", "name": "the app is open", "result": {"status": "passed", "duration": 500000000}}
+      ]},
+      {"type": "scenario", "name": "Valid password", "tags": [{"name": "@C101"}], "steps": [
+        {"keyword": "When ", "name": "I log in", "result": {"status": "passed", "duration": 1000000000}}
+      ]},
+      {"type": "scenario", "name": "Wrong password", "tags": [{"name": "@C102"}], "steps": [
+        {"keyword": "When ", "name": "I log in with a wrong password", "result": {"status": "failed", "duration": 200000000, "error_message": "expected error banner"}},
+        {"keyword": "Then ", "name": "I see an error", "result": {"status": "skipped"}}
+      ]},
+      {"type": "scenario", "name": "Remember me", "steps": [
+        {"keyword": "When ", "name": "I tick remember me", "result": {"status": "undefined"}}
+      ]},
+      {"type": "scenario", "name": "Ambiguous", "steps": [
+        {"keyword": "When ", "name": "I log in", "result": {"status": "ambiguous"}}
+      ]}
+    ]
+  }
+]`
+
+func TestParseCucumber(t *testing.T) {
+	results, err := ParseCucumber(strings.NewReader(cucumberReport))
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	assert.Equal(t, "Valid password", results[0].Name)
+	assert.Equal(t, "Login", results[0].ClassName)
+	assert.Equal(t, "features/login.feature", results[0].Suite)
+	assert.Equal(t, OutcomePassed, results[0].Outcome)
+	assert.Equal(t, 1500*time.Millisecond, results[0].Duration, "background steps count")
+	assert.Equal(t, []string{"@C101", "@C100", "@smoke"}, results[0].Tags)
+
+	assert.Equal(t, OutcomeFailed, results[1].Outcome)
+	assert.Equal(t, "When I log in with a wrong password: failed", results[1].Message)
+	assert.Equal(t, "expected error banner", results[1].Details)
+	assert.Equal(t, 200*time.Millisecond, results[1].Duration, "background applies to the next scenario only")
+
+	assert.Equal(t, OutcomeSkipped, results[2].Outcome)
+	assert.Equal(t, OutcomeError, results[3].Outcome)
+}
+
+func TestParseCucumber_MatchByTag(t *testing.T) {
+	results, err := ParseCucumber(strings.NewReader(cucumberReport))
+	require.NoError(t, err)
+	mt, err := NewMatcher("", "")
+	require.NoError(t, err)
+
+	id, ok := mt.CaseID(results[0])
+	assert.True(t, ok)
+	assert.Equal(t, int64(101), id, "scenario tag wins over the feature tag")
+
+	id, _ = mt.CaseID(results[2])
+	assert.Equal(t, int64(100), id, "falls back to the feature tag")
+}
+
+func TestParseCucumber_Invalid(t *testing.T) {
+	_, err := ParseCucumber(strings.NewReader(`{"not": "an array"}`))
+	assert.ErrorContains(t, err, "failed to parse Cucumber JSON")
+}
@@ -0,0 +1,3 @@
+// Package importer converts external test reports (JUnit XML, Cucumber JSON)
+// into TestRail results and uploads them to a run.
+package importer
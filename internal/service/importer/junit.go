@@ -0,0 +1,176 @@
+// internal/service/importer/junit.go
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Outcome is the result of one test in an external report.
+type Outcome string
+
+// Test outcomes recognized in reports.
+const (
+	OutcomePassed  Outcome = "passed"
+	OutcomeFailed  Outcome = "failed"
+	OutcomeSkipped Outcome = "skipped"
+	OutcomeError   Outcome = "error"
+)
+
+// TestResult is one executed test read from a report.
+type TestResult struct {
+	Name       string
+	ClassName  string
+	Suite      string
+	Outcome    Outcome
+	Message    string // failure/error/skip message
+	Details    string // stack trace or failure body
+	Duration   time.Duration
+	Properties map[string]string
+	Tags       []string // BDD tags such as "@C1234"
+}
+
+// junitRoot covers both a <testsuites> and a bare <testsuite> root.
+type junitRoot struct {
+	XMLName xml.Name
+	junitSuite
+}
+
+type junitSuite struct {
+	Name       string          `xml:"name,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	Cases      []junitCase     `xml:"testcase"`
+	Suites     []junitSuite    `xml:"testsuite"` // nested suites
+}
+
+type junitCase struct {
+	Name       string          `xml:"name,attr"`
+	ClassName  string          `xml:"classname,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	Failure    *junitProblem   `xml:"failure"`
+	Error      *junitProblem   `xml:"error"`
+	Skipped    *junitProblem   `xml:"skipped"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// ParseJUnitFile reads a JUnit/xUnit XML report from a file.
+func ParseJUnitFile(path string) ([]TestResult, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	results, err := ParseJUnit(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return results, nil
+}
+
+// ParseJUnit reads a JUnit/xUnit XML report. Both a <testsuites> root and a
+// single <testsuite> root are accepted; nested suites are flattened.
+func ParseJUnit(r io.Reader) ([]TestResult, error) {
+	var root junitRoot
+	if err := xml.NewDecoder(r).Decode(&root); err != nil {
+		return nil, fmt.Errorf("failed to parse JUnit XML: %w", err)
+	}
+
+	var results []TestResult
+	switch root.XMLName.Local {
+	case "testsuites":
+		for _, s := range root.Suites {
+			results = appendSuite(results, s, nil)
+		}
+	case "testsuite":
+		results = appendSuite(results, root.junitSuite, nil)
+	default:
+		return nil, fmt.Errorf("unexpected root element <%s>, want <testsuites> or <testsuite>", root.XMLName.Local)
+	}
+	return results, nil
+}
+
+// appendSuite flattens a suite; suite properties are inherited by its tests.
+func appendSuite(results []TestResult, s junitSuite, inherited map[string]string) []TestResult {
+	props := mergeProperties(inherited, s.Properties)
+	for _, c := range s.Cases {
+		results = append(results, junitResult(s.Name, c, props))
+	}
+	for _, nested := range s.Suites {
+		results = appendSuite(results, nested, props)
+	}
+	return results
+}
+
+func junitResult(suite string, c junitCase, inherited map[string]string) TestResult {
+	res := TestResult{
+		Name:       c.Name,
+		ClassName:  c.ClassName,
+		Suite:      suite,
+		Outcome:    OutcomePassed,
+		Duration:   parseSeconds(c.Time),
+		Properties: mergeProperties(inherited, c.Properties),
+	}
+
+	var problem *junitProblem
+	switch {
+	case c.Failure != nil:
+		res.Outcome, problem = OutcomeFailed, c.Failure
+	case c.Error != nil:
+		res.Outcome, problem = OutcomeError, c.Error
+	case c.Skipped != nil:
+		res.Outcome, problem = OutcomeSkipped, c.Skipped
+	}
+	if problem != nil {
+		res.Message = strings.TrimSpace(problem.Message)
+		if res.Message == "" {
+			res.Message = strings.TrimSpace(problem.Type)
+		}
+		res.Details = strings.TrimSpace(problem.Body)
+	}
+	return res
+}
+
+func mergeProperties(base map[string]string, props []junitProperty) map[string]string {
+	if len(base) == 0 && len(props) == 0 {
+		return nil
+	}
+	res := make(map[string]string, len(base)+len(props))
+	for k, v := range base {
+		res[k] = v
+	}
+	for _, p := range props {
+		res[p.Name] = p.Value
+	}
+	return res
+}
+
+// parseSeconds parses the JUnit time attribute (seconds, possibly with a
+// thousands separator).
+func parseSeconds(s string) time.Duration {
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+	if s == "" {
+		return 0
+	}
+	secs, err := strconv.ParseFloat(s, 64)
+	if err != nil || secs < 0 {
+		return 0
+	}
+	return time.Duration(secs * float64(time.Second))
+}
@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const junitReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="auth" tests="4">
+    <properties><property name="env" value="ci"/></properties>
+    <testcase name="C101 login works" classname="auth.LoginTest" time="1.5"/>
+    <testcase name="logout" classname="auth.LogoutTest" time="0.2">
+      <properties><property name="test_id" value="C102"/></properties>
+      <failure message="expected 200" type="AssertionError">at logout_test.go:12</failure>
+    </testcase>
+    <testcase name="C103 flaky" classname="auth" time="1,200">
+      <error type="Timeout"/>
+    </testcase>
+    <testcase name="todo" classname="auth">
+      <skipped message="not implemented"/>
+    </testcase>
+    <testsuite name="nested">
+      <testcase name="C104 nested" time="0"/>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+
+func TestParseJUnit(t *testing.T) {
+	results, err := ParseJUnit(strings.NewReader(junitReport))
+	require.NoError(t, err)
+	require.Len(t, results, 5)
+
+	assert.Equal(t, "C101 login works", results[0].Name)
+	assert.Equal(t, OutcomePassed, results[0].Outcome)
+	assert.Equal(t, 1500*time.Millisecond, results[0].Duration)
+	assert.Equal(t, "ci", results[0].Properties["env"])
+
+	assert.Equal(t, OutcomeFailed, results[1].Outcome)
+	assert.Equal(t, "expected 200", results[1].Message)
+	assert.Equal(t, "at logout_test.go:12", results[1].Details)
+	assert.Equal(t, "C102", results[1].Properties["test_id"])
+
+	assert.Equal(t, OutcomeError, results[2].Outcome)
+	assert.Equal(t, "Timeout", results[2].Message)
+	assert.Equal(t, 1200*time.Second, results[2].Duration)
+
+	assert.Equal(t, OutcomeSkipped, results[3].Outcome)
+	assert.Equal(t, "nested", results[4].Suite)
+	assert.Equal(t, "ci", results[4].Properties["env"])
+}
+
+func TestParseJUnit_SingleSuiteRoot(t *testing.T) {
+	results, err := ParseJUnit(strings.NewReader(`<testsuite name="s"><testcase name="a"/><testcase name="b"/></testsuite>`))
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "s", results[1].Suite)
+}
+
+func TestParseJUnit_Invalid(t *testing.T) {
+	_, err := ParseJUnit(strings.NewReader(`<html></html>`))
+	assert.ErrorContains(t, err, "unexpected root element")
+
+	_, err = ParseJUnit(strings.NewReader(`<testsuite`))
+	assert.Error(t, err)
+
+	_, err = ParseJUnitFile("missing.xml")
+	assert.Error(t, err)
+}
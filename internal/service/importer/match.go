@@ -0,0 +1,239 @@
+// internal/service/importer/match.go
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// DefaultCasePattern matches a "C1234" token in a test name. The C must not
+// follow a letter or digit (ABC12), but underscores and other punctuation may
+// join the token to the name (test_C1234_login).
+const DefaultCasePattern = `(?:^|[^A-Za-z0-9])C(\d+)(?:[^0-9]|$)`
+
+// DefaultCaseProperty is the test property that carries the case ID.
+const DefaultCaseProperty = "test_id"
+
+// DefaultStatuses maps outcomes to the standard TestRail statuses
+// (1 Passed, 4 Retest, 5 Failed).
+var DefaultStatuses = map[Outcome]int64{
+	OutcomePassed:  1,
+	OutcomeFailed:  5,
+	OutcomeSkipped: 4,
+	OutcomeError:   5,
+}
+
+// Matcher finds the TestRail case ID of a test: first in the case property,
+// then by the pattern in the tags, the test name and the class name.
+type Matcher struct {
+	Pattern  *regexp.Regexp // first capture group is the case ID
+	Property string
+}
+
+// NewMatcher compiles a case ID pattern; empty values fall back to
+// DefaultCasePattern and DefaultCaseProperty.
+func NewMatcher(pattern, property string) (*Matcher, error) {
+	if pattern == "" {
+		pattern = DefaultCasePattern
+	}
+	if property == "" {
+		property = DefaultCaseProperty
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid case pattern %q: %w", pattern, err)
+	}
+	if re.NumSubexp() < 1 {
+		return nil, fmt.Errorf("case pattern %q must have a capture group for the case ID", pattern)
+	}
+	return &Matcher{Pattern: re, Property: property}, nil
+}
+
+// CaseID returns the case ID of a test.
+func (mt *Matcher) CaseID(t TestResult) (int64, bool) {
+	if v, ok := t.Properties[mt.Property]; ok {
+		if id, ok := parseCaseID(v); ok {
+			return id, true
+		}
+	}
+	for _, s := range append(append([]string(nil), t.Tags...), t.Name, t.ClassName) {
+		if m := mt.Pattern.FindStringSubmatch(s); m != nil {
+			if id, ok := parseCaseID(m[1]); ok {
+				return id, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// parseCaseID accepts "1234" and "C1234".
+func parseCaseID(s string) (int64, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "C")
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+// Match is a report test with the case it was matched to (CaseID 0 if none).
+type Match struct {
+	Test     TestResult
+	CaseID   int64
+	StatusID int64
+}
+
+// BuildResults matches tests to cases and converts the matched ones into
+// add_results_for_cases entries, one per case. Several tests of one case,
+// such as the scenarios of a feature tagged with the case, are combined:
+// the worst outcome wins, the comments are joined under the test names and
+// the durations are added up. All tests are returned in matches.
+func BuildResults(tests []TestResult, mt *Matcher, statuses map[Outcome]int64) (entries []data.ResultForCaseEntry, matches []Match) {
+	var groups []*caseGroup
+	byCase := make(map[int64]*caseGroup)
+	for _, t := range tests {
+		m := Match{Test: t, StatusID: statusFor(t.Outcome, statuses)}
+		if id, ok := mt.CaseID(t); ok {
+			m.CaseID = id
+			g := byCase[id]
+			if g == nil {
+				g = &caseGroup{caseID: id}
+				byCase[id] = g
+				groups = append(groups, g)
+			}
+			g.tests = append(g.tests, t)
+		}
+		matches = append(matches, m)
+	}
+
+	for _, g := range groups {
+		entries = append(entries, g.entry(statuses))
+	}
+	return entries, matches
+}
+
+// Unmatched returns the number of tests without a case ID. It differs from
+// len(matches)-len(entries) whenever several tests share a case.
+func Unmatched(matches []Match) int {
+	n := 0
+	for _, m := range matches {
+		if m.CaseID == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// caseGroup collects the tests matched to one case.
+type caseGroup struct {
+	caseID int64
+	tests  []TestResult
+}
+
+// entry combines the tests of g into one result.
+func (g *caseGroup) entry(statuses map[Outcome]int64) data.ResultForCaseEntry {
+	worst := g.tests[0]
+	var duration time.Duration
+	for _, t := range g.tests {
+		if outcomeRank[t.Outcome] > outcomeRank[worst.Outcome] {
+			worst = t
+		}
+		duration += t.Duration
+	}
+
+	comment := resultComment(worst)
+	if len(g.tests) > 1 {
+		parts := make([]string, 0, len(g.tests))
+		for _, t := range g.tests {
+			part := fmt.Sprintf("%s: %s", t.Name, t.Outcome)
+			if c := resultComment(t); c != "" {
+				part += "\n" + c
+			}
+			parts = append(parts, part)
+		}
+		comment = strings.Join(parts, "\n\n")
+	}
+	return data.ResultForCaseEntry{
+		CaseID:   g.caseID,
+		StatusID: statusFor(worst.Outcome, statuses),
+		Comment:  comment,
+		Elapsed:  FormatElapsed(duration),
+	}
+}
+
+// outcomeRank orders outcomes from best to worst.
+var outcomeRank = map[Outcome]int{
+	OutcomePassed:  0,
+	OutcomeSkipped: 1,
+	OutcomeFailed:  2,
+	OutcomeError:   3,
+}
+
+func statusFor(o Outcome, statuses map[Outcome]int64) int64 {
+	if id, ok := statuses[o]; ok {
+		return id
+	}
+	return DefaultStatuses[o]
+}
+
+// resultComment joins the failure message and the stack trace.
+func resultComment(t TestResult) string {
+	switch {
+	case t.Message == "":
+		return t.Details
+	case t.Details == "" || strings.Contains(t.Details, t.Message):
+		if t.Details != "" {
+			return t.Details
+		}
+		return t.Message
+	}
+	return t.Message + "\n\n" + t.Details
+}
+
+// FormatElapsed converts a duration to TestRail's timespan format ("1h 2m 3s").
+// TestRail does not accept zero seconds, so non-zero durations under a second
+// round up to "1s" and zero yields an empty string.
+func FormatElapsed(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	secs := int64((d + time.Second - 1) / time.Second)
+	h, m, s := secs/3600, secs/60%60, secs%60
+
+	var parts []string
+	if h > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", h))
+	}
+	if m > 0 {
+		parts = append(parts, fmt.Sprintf("%dm", m))
+	}
+	if s > 0 {
+		parts = append(parts, fmt.Sprintf("%ds", s))
+	}
+	return strings.Join(parts, " ")
+}
+
+// ParseStatuses parses "outcome=status_id" overrides (e.g. skipped=2) on top
+// of DefaultStatuses.
+func ParseStatuses(overrides map[string]int64) (map[Outcome]int64, error) {
+	res := make(map[Outcome]int64, len(DefaultStatuses))
+	for k, v := range DefaultStatuses {
+		res[k] = v
+	}
+	for k, v := range overrides {
+		o := Outcome(strings.ToLower(strings.TrimSpace(k)))
+		if _, ok := DefaultStatuses[o]; !ok {
+			return nil, fmt.Errorf("unknown outcome %q (want passed, failed, skipped or error)", k)
+		}
+		if v <= 0 {
+			return nil, fmt.Errorf("status ID for %s must be positive, got %d", k, v)
+		}
+		res[o] = v
+	}
+	return res, nil
+}
@@ -0,0 +1,120 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatcher_CaseID(t *testing.T) {
+	mt, err := NewMatcher("", "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		test TestResult
+		want int64
+	}{
+		{"name token", TestResult{Name: "C12 does things"}, 12},
+		{"class name token", TestResult{Name: "does things", ClassName: "suite.C13"}, 13},
+		{"property wins", TestResult{Name: "C12", Properties: map[string]string{"test_id": "C99"}}, 99},
+		{"numeric property", TestResult{Properties: map[string]string{"test_id": "7"}}, 7},
+		{"underscore separated", TestResult{Name: "test_C1234_login"}, 1234},
+		{"trailing token", TestResult{Name: "login_C77"}, 77},
+		{"tag", TestResult{Name: "login", Tags: []string{"@C5"}}, 5},
+		{"no token inside word", TestResult{Name: "ABC12"}, 0},
+		{"letter after the number", TestResult{Name: "C12x"}, 12},
+		{"nothing", TestResult{Name: "plain"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := mt.CaseID(tt.test)
+			assert.Equal(t, tt.want != 0, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewMatcher_CustomPattern(t *testing.T) {
+	mt, err := NewMatcher(`\[TR-(\d+)\]`, "case")
+	require.NoError(t, err)
+	id, ok := mt.CaseID(TestResult{Name: "[TR-55] checkout"})
+	assert.True(t, ok)
+	assert.Equal(t, int64(55), id)
+
+	_, err = NewMatcher(`TR-\d+`, "")
+	assert.ErrorContains(t, err, "capture group")
+	_, err = NewMatcher(`(`, "")
+	assert.Error(t, err)
+}
+
+func TestBuildResults(t *testing.T) {
+	results, err := ParseJUnit(strings.NewReader(junitReport))
+	require.NoError(t, err)
+	mt, _ := NewMatcher("", "")
+	statuses, err := ParseStatuses(map[string]int64{"skipped": 2})
+	require.NoError(t, err)
+
+	entries, matches := BuildResults(results, mt, statuses)
+	require.Len(t, matches, 5)
+	require.Len(t, entries, 4)
+
+	assert.Equal(t, int64(101), entries[0].CaseID)
+	assert.Equal(t, int64(1), entries[0].StatusID)
+	assert.Equal(t, "2s", entries[0].Elapsed)
+
+	assert.Equal(t, int64(102), entries[1].CaseID)
+	assert.Equal(t, int64(5), entries[1].StatusID)
+	assert.Equal(t, "expected 200\n\nat logout_test.go:12", entries[1].Comment)
+
+	assert.Equal(t, int64(103), entries[2].CaseID)
+	assert.Equal(t, "20m", entries[2].Elapsed)
+
+	assert.Equal(t, int64(0), matches[3].CaseID) // skipped "todo" has no case
+	assert.Equal(t, int64(2), matches[3].StatusID)
+
+	assert.Equal(t, int64(104), entries[3].CaseID)
+	assert.Empty(t, entries[3].Elapsed)
+}
+
+func TestBuildResults_CombinesTestsOfOneCase(t *testing.T) {
+	mt, _ := NewMatcher("", "")
+	tests := []TestResult{
+		{Name: "Scenario: declined card", Outcome: OutcomeFailed, Message: "card accepted", Duration: time.Second, Tags: []string{"@C7"}},
+		{Name: "Scenario: valid card", Outcome: OutcomePassed, Duration: 2 * time.Second, Tags: []string{"@C7"}},
+		{Name: "Scenario: other", Outcome: OutcomePassed, Tags: []string{"@C8"}},
+	}
+
+	entries, matches := BuildResults(tests, mt, DefaultStatuses)
+	require.Len(t, matches, 3)
+	require.Len(t, entries, 2)
+	assert.Zero(t, Unmatched(matches), "tests sharing a case are all matched")
+
+	assert.Equal(t, int64(7), entries[0].CaseID)
+	assert.Equal(t, int64(5), entries[0].StatusID, "a later pass does not hide a failure")
+	assert.Equal(t, "Scenario: declined card: failed\ncard accepted\n\nScenario: valid card: passed", entries[0].Comment)
+	assert.Equal(t, "3s", entries[0].Elapsed)
+	assert.Equal(t, int64(8), entries[1].CaseID)
+}
+
+func TestFormatElapsed(t *testing.T) {
+	assert.Equal(t, "", FormatElapsed(0))
+	assert.Equal(t, "1s", FormatElapsed(100*time.Millisecond))
+	assert.Equal(t, "1m 30s", FormatElapsed(90*time.Second))
+	assert.Equal(t, "1h 1s", FormatElapsed(time.Hour+time.Second))
+}
+
+func TestParseStatuses(t *testing.T) {
+	statuses, err := ParseStatuses(map[string]int64{"Error": 4})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), statuses[OutcomeError])
+	assert.Equal(t, int64(5), statuses[OutcomeFailed])
+
+	_, err = ParseStatuses(map[string]int64{"broken": 4})
+	assert.Error(t, err)
+	_, err = ParseStatuses(map[string]int64{"passed": 0})
+	assert.Error(t, err)
+}
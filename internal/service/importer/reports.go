@@ -0,0 +1,48 @@
+// internal/service/importer/reports.go
+package importer
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// Report formats accepted by ParseReports.
+const (
+	FormatJUnit    = "junit"
+	FormatCucumber = "cucumber"
+)
+
+// ParseReports reads report files of one format and concatenates their tests.
+func ParseReports(format string, files []string) ([]TestResult, error) {
+	var parse func(string) ([]TestResult, error)
+	switch format {
+	case FormatJUnit, "":
+		parse = ParseJUnitFile
+	case FormatCucumber:
+		parse = ParseCucumberFile
+	default:
+		return nil, fmt.Errorf("unknown report format %q (want %s or %s)", format, FormatJUnit, FormatCucumber)
+	}
+
+	var tests []TestResult
+	for _, file := range files {
+		parsed, err := parse(file)
+		if err != nil {
+			return nil, err
+		}
+		tests = append(tests, parsed...)
+	}
+	return tests, nil
+}
+
+// CaseIDs returns the distinct case IDs of the entries in ascending order.
+func CaseIDs(entries []data.ResultForCaseEntry) []int64 {
+	ids := make([]int64, 0, len(entries))
+	for _, e := range entries {
+		ids = append(ids, e.CaseID)
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
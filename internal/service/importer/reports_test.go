@@ -0,0 +1,32 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReports(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.xml")
+	b := filepath.Join(dir, "b.xml")
+	require.NoError(t, os.WriteFile(a, []byte(`<testsuite><testcase name="C1"/></testsuite>`), 0o644))
+	require.NoError(t, os.WriteFile(b, []byte(`<testsuite><testcase name="C2"/><testcase name="C3"/></testsuite>`), 0o644))
+
+	tests, err := ParseReports(FormatJUnit, []string{a, b})
+	require.NoError(t, err)
+	assert.Len(t, tests, 3)
+
+	_, err = ParseReports("nunit", []string{a})
+	assert.ErrorContains(t, err, "unknown report format")
+}
+
+func TestCaseIDs(t *testing.T) {
+	ids := CaseIDs([]data.ResultForCaseEntry{{CaseID: 3}, {CaseID: 1}, {CaseID: 3}})
+	assert.Equal(t, []int64{1, 3}, ids)
+}
@@ -0,0 +1,66 @@
+// internal/service/importer/upload.go
+package importer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// DefaultChunkSize is the number of results sent per add_results_for_cases call.
+const DefaultChunkSize = 100
+
+// resultsClient is the client method used to upload results.
+type resultsClient interface {
+	AddResultsForCases(ctx context.Context, runID int64, req *data.AddResultsForCasesRequest) (data.GetResultsResponse, error)
+}
+
+// Unposted drops the entries whose case already has a result with the same
+// status and comment as its latest result in the run, so that publishing the
+// same report again posts nothing twice. tests and results are those of the
+// run; skipped is the number of dropped entries.
+func Unposted(entries []data.ResultForCaseEntry, tests []data.Test, results data.GetResultsResponse) (pending []data.ResultForCaseEntry, skipped int) {
+	caseOf := make(map[int64]int64, len(tests))
+	for _, t := range tests {
+		caseOf[t.ID] = t.CaseID
+	}
+	latest := make(map[int64]data.Result, len(tests))
+	for _, r := range results {
+		caseID, ok := caseOf[r.TestID]
+		if !ok {
+			continue
+		}
+		if prev, seen := latest[caseID]; !seen || r.ID > prev.ID {
+			latest[caseID] = r
+		}
+	}
+
+	for _, e := range entries {
+		if r, ok := latest[e.CaseID]; ok && r.StatusID == e.StatusID && r.Comment == e.Comment {
+			skipped++
+			continue
+		}
+		pending = append(pending, e)
+	}
+	return pending, skipped
+}
+
+// Upload sends results to a run in chunks. On failure it returns the results
+// created by the chunks that succeeded together with the error.
+func Upload(ctx context.Context, cli resultsClient, runID int64, entries []data.ResultForCaseEntry, chunkSize int) (data.GetResultsResponse, error) {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	var created data.GetResultsResponse
+	for start := 0; start < len(entries); start += chunkSize {
+		end := min(start+chunkSize, len(entries))
+		res, err := cli.AddResultsForCases(ctx, runID, &data.AddResultsForCasesRequest{Results: entries[start:end]})
+		if err != nil {
+			return created, fmt.Errorf("results %d-%d of %d: %w", start+1, end, len(entries), err)
+		}
+		created = append(created, res...)
+	}
+	return created, nil
+}
@@ -0,0 +1,55 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpload_Chunks(t *testing.T) {
+	var sizes []int
+	mock := &client.MockClient{
+		AddResultsForCasesFunc: func(ctx context.Context, runID int64, req *data.AddResultsForCasesRequest) (data.GetResultsResponse, error) {
+			assert.Equal(t, int64(7), runID)
+			sizes = append(sizes, len(req.Results))
+			if len(sizes) == 3 {
+				return nil, errors.New("boom")
+			}
+			res := make(data.GetResultsResponse, len(req.Results))
+			return res, nil
+		},
+	}
+
+	entries := make([]data.ResultForCaseEntry, 5)
+	created, err := Upload(context.Background(), mock, 7, entries, 2)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "results 5-5 of 5")
+	assert.Len(t, created, 4)
+	assert.Equal(t, []int{2, 2, 1}, sizes)
+}
+
+func TestUnposted(t *testing.T) {
+	entries := []data.ResultForCaseEntry{
+		{CaseID: 1, StatusID: 1, Comment: "ok"},
+		{CaseID: 2, StatusID: 5, Comment: "boom"},
+		{CaseID: 3, StatusID: 1},
+		{CaseID: 4, StatusID: 1},
+	}
+	tests := []data.Test{{ID: 10, CaseID: 1}, {ID: 20, CaseID: 2}, {ID: 30, CaseID: 3}, {ID: 40, CaseID: 4}}
+	results := data.GetResultsResponse{
+		{ID: 100, TestID: 10, StatusID: 1, Comment: "ok"},
+		{ID: 101, TestID: 20, StatusID: 5, Comment: "boom"},
+		{ID: 102, TestID: 20, StatusID: 1, Comment: "fixed by hand"},
+		{ID: 103, TestID: 30, StatusID: 5},
+	}
+
+	pending, skipped := Unposted(entries, tests, results)
+	assert.Equal(t, 1, skipped)
+	assert.Equal(t, []int64{2, 3, 4}, CaseIDs(pending), "only the case whose latest result matches is skipped")
+}
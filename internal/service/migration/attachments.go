@@ -0,0 +1,179 @@
+// internal/service/migration/attachments.go
+package migration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// maxAttachmentConcurrency limits parallel downloads/uploads: attachments are
+// much heavier than JSON calls.
+const maxAttachmentConcurrency = 4
+
+// AttachmentFailure is an attachment that could not be copied.
+type AttachmentFailure struct {
+	SourceCaseID int64
+	TargetCaseID int64
+	AttachmentID int64 // 0 if the attachment list itself could not be loaded
+	Name         string
+	Err          error
+}
+
+func (f AttachmentFailure) String() string {
+	if f.AttachmentID == 0 {
+		return fmt.Sprintf("case %d: list attachments: %v", f.SourceCaseID, f.Err)
+	}
+	return fmt.Sprintf("case %d → %d: %s (attachment %d): %v", f.SourceCaseID, f.TargetCaseID, f.Name, f.AttachmentID, f.Err)
+}
+
+// AttachmentReport summarizes CopyCaseAttachments.
+type AttachmentReport struct {
+	Copied   int
+	Bytes    int64
+	Failures []AttachmentFailure
+}
+
+// attachmentTask is one file to copy from a source case to its new destination case.
+type attachmentTask struct {
+	pair       MappingPair
+	attachment data.Attachment
+}
+
+// CopyCaseAttachments downloads the attachments of every case created in
+// this run from the source and uploads them to the new destination case.
+// Work runs in a bounded pool; failures are reported per file and do not
+// stop the copy.
+func (m *Migration) CopyCaseAttachments(ctx context.Context) (*AttachmentReport, error) {
+	report := &AttachmentReport{}
+	if len(m.created) == 0 {
+		return report, nil
+	}
+
+	tmpDir, err := os.MkdirTemp("", "gotr-attachments-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory for attachments: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	m.logger.Infow("Starting attachments copy", "cases", len(m.created))
+
+	var mu sync.Mutex
+	tasks := m.listCaseAttachments(ctx, report, &mu)
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxAttachmentConcurrency)
+	for _, task := range tasks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(task attachmentTask) {
+			defer func() { <-sem }()
+			defer wg.Done()
+
+			n, err := m.copyAttachment(ctx, tmpDir, task)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				report.Failures = append(report.Failures, AttachmentFailure{
+					SourceCaseID: task.pair.SourceID,
+					TargetCaseID: task.pair.TargetID,
+					AttachmentID: task.attachment.ID,
+					Name:         attachmentName(task.attachment),
+					Err:          err,
+				})
+				m.logger.Errorw("Error copying attachment", "case_id", task.pair.SourceID, "attachment_id", task.attachment.ID, "error", err)
+				return
+			}
+			report.Copied++
+			report.Bytes += n
+		}(task)
+	}
+	wg.Wait()
+
+	sort.Slice(report.Failures, func(i, j int) bool {
+		a, b := report.Failures[i], report.Failures[j]
+		if a.SourceCaseID != b.SourceCaseID {
+			return a.SourceCaseID < b.SourceCaseID
+		}
+		return a.AttachmentID < b.AttachmentID
+	})
+
+	m.logger.Infow("Attachments copy completed", "copied", report.Copied, "bytes", report.Bytes, "failed", len(report.Failures))
+	return report, nil
+}
+
+// listCaseAttachments loads the attachment lists of the created cases.
+func (m *Migration) listCaseAttachments(ctx context.Context, report *AttachmentReport, mu *sync.Mutex) []attachmentTask {
+	var tasks []attachmentTask
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxAttachmentConcurrency)
+
+	for _, pair := range m.created {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(pair MappingPair) {
+			defer func() { <-sem }()
+			defer wg.Done()
+
+			list, err := m.src().GetAttachmentsForCase(ctx, pair.SourceID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				report.Failures = append(report.Failures, AttachmentFailure{SourceCaseID: pair.SourceID, TargetCaseID: pair.TargetID, Err: err})
+				m.logger.Errorw("Error listing case attachments", "case_id", pair.SourceID, "error", err)
+				return
+			}
+			for _, a := range list {
+				tasks = append(tasks, attachmentTask{pair: pair, attachment: a})
+			}
+		}(pair)
+	}
+	wg.Wait()
+	return tasks
+}
+
+// copyAttachment downloads one attachment into its own temp subdirectory
+// (the upload takes the file name from the path) and uploads it.
+func (m *Migration) copyAttachment(ctx context.Context, tmpDir string, task attachmentTask) (int64, error) {
+	dir := filepath.Join(tmpDir, strconv.FormatInt(task.attachment.ID, 10))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, attachmentName(task.attachment))
+	file, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	n, err := m.src().DownloadAttachment(ctx, task.attachment.ID, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("download: %w", err)
+	}
+
+	if _, err := m.Client.AddAttachmentToCase(ctx, task.pair.TargetID, path); err != nil {
+		return 0, fmt.Errorf("upload: %w", err)
+	}
+	return n, nil
+}
+
+// attachmentName returns a safe local file name for an attachment.
+func attachmentName(a data.Attachment) string {
+	for _, name := range []string{a.Name, a.Filename} {
+		if base := filepath.Base(name); base != "" && base != "." && base != "/" && base != ".." {
+			return base
+		}
+	}
+	return fmt.Sprintf("attachment_%d", a.ID)
+}
@@ -0,0 +1,100 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyCaseAttachments(t *testing.T) {
+	var mu sync.Mutex
+	uploaded := make(map[int64][]string)
+
+	src := &MockClient{
+		GetAttachmentsForCaseFunc: func(ctx context.Context, caseID int64) (data.GetAttachmentsResponse, error) {
+			switch caseID {
+			case 1:
+				return data.GetAttachmentsResponse{{ID: 11, Name: "screen.png"}, {ID: 12, Name: "broken.log"}}, nil
+			case 2:
+				return nil, errors.New("forbidden")
+			}
+			return nil, nil
+		},
+		DownloadAttachmentFunc: func(ctx context.Context, attachmentID int64, w io.Writer) (int64, error) {
+			if attachmentID == 12 {
+				return 0, errors.New("not found")
+			}
+			n, err := w.Write([]byte("content"))
+			return int64(n), err
+		},
+	}
+	dst := &MockClient{
+		AddAttachmentToCaseFunc: func(ctx context.Context, caseID int64, filePath string) (*data.AttachmentResponse, error) {
+			content, err := os.ReadFile(filePath)
+			if err != nil {
+				return nil, err
+			}
+			mu.Lock()
+			uploaded[caseID] = append(uploaded[caseID], filepath.Base(filePath)+":"+string(content))
+			mu.Unlock()
+			return &data.AttachmentResponse{AttachmentID: 99}, nil
+		},
+	}
+
+	m := setupTestMigration(t, dst)
+	m.SrcClient = src
+	m.created = []MappingPair{{SourceID: 1, TargetID: 101}, {SourceID: 2, TargetID: 102}, {SourceID: 3, TargetID: 103}}
+
+	report, err := m.CopyCaseAttachments(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, report.Copied)
+	assert.Equal(t, int64(len("content")), report.Bytes)
+	assert.Equal(t, map[int64][]string{101: {"screen.png:content"}}, uploaded)
+
+	require.Len(t, report.Failures, 2)
+	assert.Equal(t, int64(1), report.Failures[0].SourceCaseID)
+	assert.Equal(t, int64(12), report.Failures[0].AttachmentID)
+	assert.Contains(t, report.Failures[0].String(), "broken.log")
+	assert.Equal(t, int64(2), report.Failures[1].SourceCaseID)
+	assert.Contains(t, report.Failures[1].String(), "list attachments")
+}
+
+func TestCopyCaseAttachments_OnlyCreatedCases(t *testing.T) {
+	var listed []int64
+	mock := &MockClient{
+		AddCaseFunc: func(ctx context.Context, sectionID int64, req *data.AddCaseRequest) (*data.Case, error) {
+			return &data.Case{ID: 500}, nil
+		},
+		GetAttachmentsForCaseFunc: func(ctx context.Context, caseID int64) (data.GetAttachmentsResponse, error) {
+			listed = append(listed, caseID)
+			return nil, nil
+		},
+	}
+
+	m := setupTestMigration(t, mock)
+	m.cases.AddPair(2, 200, "existing")
+
+	cases := data.GetCasesResponse{{ID: 1, Title: "new"}, {ID: 2, Title: "mapped"}}
+	require.NoError(t, m.ImportCases(context.Background(), cases, false))
+
+	_, err := m.CopyCaseAttachments(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, listed)
+}
+
+func TestAttachmentName(t *testing.T) {
+	assert.Equal(t, "a.txt", attachmentName(data.Attachment{ID: 1, Name: "a.txt"}))
+	assert.Equal(t, "b.txt", attachmentName(data.Attachment{ID: 1, Filename: "../b.txt"}))
+	assert.Equal(t, "attachment_3", attachmentName(data.Attachment{ID: 3}))
+	assert.Equal(t, "attachment_4", attachmentName(data.Attachment{ID: 4, Name: ".."}))
+}
@@ -147,6 +147,33 @@ func (m *Migration) ExportSections(sections data.GetSectionsResponse, filtered b
 }
 
 // ExportMapping saves the shared step mapping to a JSON file via SharedStepMapping.Save.
+// The suite, section and case mappings and, for cross-instance sync, the
+// entity ID mappings are saved next to it.
 func (m *Migration) ExportMapping(dir string) error {
-	return m.mapping.Save(dir)
+	if err := m.mapping.Save(dir); err != nil {
+		return err
+	}
+	if err := m.suites.Save(dir); err != nil {
+		return fmt.Errorf("failed to save suite mapping: %w", err)
+	}
+	if err := m.sections.Save(dir); err != nil {
+		return fmt.Errorf("failed to save section mapping: %w", err)
+	}
+	if err := m.ExportCaseMapping(dir); err != nil {
+		return err
+	}
+	if m.entities != nil {
+		return m.entities.Save(dir)
+	}
+	return nil
+}
+
+// ExportCaseMapping saves the case mapping as mapping_cases_<timestamp>.json.
+// Loading it in a later run (LoadCaseMappingFromFile) updates the mapped
+// cases in place instead of creating duplicates.
+func (m *Migration) ExportCaseMapping(dir string) error {
+	if err := m.cases.Save(dir); err != nil {
+		return fmt.Errorf("failed to save case mapping: %w", err)
+	}
+	return nil
 }
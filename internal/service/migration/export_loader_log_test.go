@@ -745,7 +745,7 @@ func TestMigrationExportSharedStepsAndCases_Hotspots(t *testing.T) {
 		err = m.ExportCases(data.GetCasesResponse{{
 			ID:           4,
 			Title:        "Broken",
-			CustomFields: []byte("{invalid"),
+			CustomFields: data.CustomFieldValues{"custom_broken": []byte("{invalid")},
 		}}, true, t.TempDir())
 		if err == nil {
 			t.Fatalf("expected ExportCases() marshal error")
@@ -3,6 +3,7 @@ package migration
 
 import (
 	"context"
+	"time"
 
 	"github.com/Korrnals/gotr/internal/models/data"
 )
@@ -11,7 +12,7 @@ import (
 func (m *Migration) FetchSharedStepsData(ctx context.Context) (source, target data.GetSharedStepsResponse, err error) {
 	m.logger.Info("Starting to fetch shared steps from source project")
 
-	source, err = m.Client.GetSharedSteps(ctx, m.srcProject)
+	source, err = m.src().GetSharedSteps(ctx, m.srcProject)
 	if err != nil {
 		m.logger.Errorw("Error fetching source shared steps", "error", err)
 		return nil, nil, err
@@ -33,7 +34,7 @@ func (m *Migration) FetchSharedStepsData(ctx context.Context) (source, target da
 func (m *Migration) FetchCasesData(ctx context.Context) (source, target data.GetCasesResponse, err error) {
 	m.logger.Info("Starting to fetch cases from source suite")
 
-	source, err = m.Client.GetCases(ctx, m.srcProject, m.srcSuite, 0)
+	source, err = m.sourceCases(ctx)
 	if err != nil {
 		m.logger.Errorw("Error fetching source cases", "error", err)
 		return nil, nil, err
@@ -51,10 +52,72 @@ func (m *Migration) FetchCasesData(ctx context.Context) (source, target data.Get
 	return source, target, nil
 }
 
+// sourceCases returns all cases of the source suite. They are fetched once
+// per migration: the field check, the shared step filter and the case import
+// of a full sync work on the same list.
+func (m *Migration) sourceCases(ctx context.Context) (data.GetCasesResponse, error) {
+	if m.srcCases != nil {
+		return m.srcCases, nil
+	}
+	cases, err := m.src().GetCases(ctx, m.srcProject, m.srcSuite, 0)
+	if err != nil {
+		return nil, err
+	}
+	if cases == nil {
+		cases = data.GetCasesResponse{}
+	}
+	m.srcCases = cases
+	return cases, nil
+}
+
+// FetchCasesUpdatedSince is FetchCasesData for incremental sync: only source
+// cases updated at or after since are requested, while the target suite is
+// loaded in full for matching. A zero since loads all cases.
+// updated_after is exclusive and since is the updated_on of the newest case
+// seen by the previous sync, so the request starts one second earlier to
+// catch other cases updated in that second; the source is deduplicated by ID.
+func (m *Migration) FetchCasesUpdatedSince(ctx context.Context, since time.Time) (source, target data.GetCasesResponse, err error) {
+	if since.IsZero() {
+		return m.FetchCasesData(ctx)
+	}
+	m.logger.Infow("Starting to fetch updated cases from source suite", "updated_after", since.Unix())
+
+	source, err = m.src().GetCasesFiltered(ctx, m.srcProject, data.CaseFilter{SuiteID: m.srcSuite, UpdatedAfter: since.Add(-time.Second)})
+	if err != nil {
+		m.logger.Errorw("Error fetching source cases", "error", err)
+		return nil, nil, err
+	}
+	source = uniqueCases(source)
+	m.logger.Infow("Fetched updated cases from source", "count", len(source))
+
+	m.logger.Info("Starting to fetch cases from target suite")
+	target, err = m.Client.GetCases(ctx, m.dstProject, m.dstSuite, 0)
+	if err != nil {
+		m.logger.Errorw("Error fetching target cases", "error", err)
+		return nil, nil, err
+	}
+	m.logger.Infow("Fetched cases from target", "count", len(target))
+
+	return source, target, nil
+}
+
+// uniqueCases drops repeated case IDs, keeping the first occurrence.
+func uniqueCases(cases data.GetCasesResponse) data.GetCasesResponse {
+	seen := make(map[int64]bool, len(cases))
+	res := cases[:0:0]
+	for _, c := range cases {
+		if !seen[c.ID] {
+			seen[c.ID] = true
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
 // FetchSuitesData retrieves suites from both source and target projects.
 func (m *Migration) FetchSuitesData(ctx context.Context) (source, target data.GetSuitesResponse, err error) {
 	m.logger.Info("Starting to fetch suites from source project")
-	source, err = m.Client.GetSuites(ctx, m.srcProject)
+	source, err = m.src().GetSuites(ctx, m.srcProject)
 	if err != nil {
 		m.logger.Errorw("Error fetching source suites", "error", err)
 		return nil, nil, err
@@ -76,7 +139,7 @@ func (m *Migration) FetchSuitesData(ctx context.Context) (source, target data.Ge
 func (m *Migration) FetchSectionsData(ctx context.Context) (source, target data.GetSectionsResponse, err error) {
 	m.logger.Info("Starting to fetch sections from source suite")
 
-	source, err = m.Client.GetSections(ctx, m.srcProject, m.srcSuite)
+	source, err = m.src().GetSections(ctx, m.srcProject, m.srcSuite)
 	if err != nil {
 		m.logger.Errorw("Error fetching source sections", "suite_id", m.srcSuite, "error", err)
 		return nil, nil, err
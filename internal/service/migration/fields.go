@@ -0,0 +1,403 @@
+// internal/service/migration/fields.go
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+	"gopkg.in/yaml.v3"
+)
+
+// FieldOverrides is a manual custom field mapping loaded from YAML:
+//
+//	fields:              # source system name → destination system name
+//	  custom_browser: custom_target_browser
+//	options:             # source field → source option label → destination label
+//	  custom_browser:
+//	    Chrome: Google Chrome
+type FieldOverrides struct {
+	Fields  map[string]string            `yaml:"fields"`
+	Options map[string]map[string]string `yaml:"options"`
+}
+
+// LoadFieldOverrides reads a FieldOverrides YAML file.
+func LoadFieldOverrides(file string) (*FieldOverrides, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read field mapping %s: %w", file, err)
+	}
+	var o FieldOverrides
+	if err := yaml.Unmarshal(content, &o); err != nil {
+		return nil, fmt.Errorf("failed to parse field mapping %s: %w", file, err)
+	}
+	return &o, nil
+}
+
+// FieldMap describes how one source custom field is written on the destination.
+type FieldMap struct {
+	Source string // source system name
+	Target string // destination system name
+	TypeID int64
+
+	// Options maps source option IDs to destination option IDs (dropdown
+	// and multi-select fields); options are matched by label.
+	Options map[int64]int64
+	// UnmatchedOptions lists source option labels without a destination option.
+	UnmatchedOptions []string
+}
+
+// FieldProblem is a source custom field that cannot be written to the
+// destination. A blocking problem is a field the destination requires that
+// the migrated cases cannot fill, so add_case would fail for every case.
+type FieldProblem struct {
+	Field    string
+	Reason   string
+	Blocking bool
+}
+
+// requestFields are custom fields that buildCaseRequest copies directly
+// instead of through the field mapping.
+var requestFields = map[string]bool{
+	"custom_preconds":        true,
+	"custom_steps":           true,
+	"custom_expected":        true,
+	"custom_steps_separated": true,
+}
+
+func (p FieldProblem) String() string {
+	return p.Field + ": " + p.Reason
+}
+
+// FieldMapping holds the custom field schema mapping between source and
+// destination projects. Fields with problems have no entry in Fields.
+type FieldMapping struct {
+	Fields   map[string]*FieldMap // keyed by source system name
+	Problems []FieldProblem
+}
+
+// SetFieldOverrides sets a manual field/option mapping; it applies to the
+// next BuildFieldMapping.
+func (m *Migration) SetFieldOverrides(o *FieldOverrides) {
+	m.fieldOverrides = o
+	m.fields = nil
+}
+
+// FieldMapping returns the custom field mapping, or nil if it has not been built.
+func (m *Migration) FieldMapping() *FieldMapping {
+	return m.fields
+}
+
+// BuildFieldMapping loads case fields on both sides and matches them by
+// system name (or the override) and their options by label. Fields that are
+// missing on the destination or not assigned to the destination project are
+// reported as problems. The result is cached.
+func (m *Migration) BuildFieldMapping(ctx context.Context) (*FieldMapping, error) {
+	if m.fields != nil {
+		return m.fields, nil
+	}
+
+	srcFields, err := m.src().GetCaseFields(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source case fields: %w", err)
+	}
+	dstFields, err := m.Client.GetCaseFields(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get destination case fields: %w", err)
+	}
+
+	fm := matchCaseFields(srcFields, dstFields, m.srcProject, m.dstProject, m.fieldOverrides)
+	for _, p := range fm.Problems {
+		m.logger.Warnw("Custom field cannot be migrated", "field", p.Field, "reason", p.Reason)
+	}
+	for _, f := range fm.Fields {
+		if len(f.UnmatchedOptions) > 0 {
+			m.logger.Warnw("Custom field options without a destination counterpart", "field", f.Source, "options", f.UnmatchedOptions)
+		}
+	}
+	m.logger.Infow("Custom field mapping built", "fields", len(fm.Fields), "problems", len(fm.Problems))
+
+	m.fields = fm
+	return fm, nil
+}
+
+// CheckCaseFields builds the field mapping and returns the problems that
+// affect the given cases, so that they can be reported before any writes.
+// Blocking problems are always returned.
+func (m *Migration) CheckCaseFields(ctx context.Context, cases data.GetCasesResponse) ([]FieldProblem, error) {
+	used := usedCustomFields(cases)
+	fm, err := m.BuildFieldMapping(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []FieldProblem
+	for _, p := range fm.Problems {
+		if p.Blocking || used[p.Field] {
+			res = append(res, p)
+		}
+	}
+	for _, name := range sortedKeys(used) {
+		if f, ok := fm.Fields[name]; ok && len(f.UnmatchedOptions) > 0 {
+			res = append(res, FieldProblem{Field: name, Reason: "options without a destination counterpart: " + strings.Join(f.UnmatchedOptions, ", ")})
+		}
+	}
+	return res, nil
+}
+
+// CheckSuiteCaseFields runs CheckCaseFields on all cases of the source suite.
+// The cases are kept for the later migration steps.
+func (m *Migration) CheckSuiteCaseFields(ctx context.Context) ([]FieldProblem, error) {
+	cases, err := m.sourceCases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source cases: %w", err)
+	}
+	return m.CheckCaseFields(ctx, cases)
+}
+
+// BlockingFieldProblems returns the blocking problems among problems.
+func BlockingFieldProblems(problems []FieldProblem) []FieldProblem {
+	var res []FieldProblem
+	for _, p := range problems {
+		if p.Blocking {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+func usedCustomFields(cases data.GetCasesResponse) map[string]bool {
+	used := make(map[string]bool)
+	for _, c := range cases {
+		for k := range caseCustomFields(c) {
+			used[k] = true
+		}
+	}
+	return used
+}
+
+// caseCustomFields returns the custom field values of a case, including the
+// custom fields modeled on data.Case, so that all of them are translated
+// through the field mapping alike.
+func caseCustomFields(c data.Case) data.CustomFieldValues {
+	values := c.CustomFields.Clone()
+	modeled := map[string]any{
+		"custom_automation_type": c.CustomAutomationType,
+		"custom_mission":         c.CustomMission,
+		"custom_goals":           c.CustomGoals,
+	}
+	for name, v := range modeled {
+		if v == int64(0) || v == "" {
+			continue
+		}
+		if values == nil {
+			values = make(data.CustomFieldValues)
+		}
+		raw, _ := json.Marshal(v)
+		values[name] = raw
+	}
+	return values
+}
+
+func matchCaseFields(src, dst data.GetCaseFieldsResponse, srcProject, dstProject int64, o *FieldOverrides) *FieldMapping {
+	fm := &FieldMapping{Fields: make(map[string]*FieldMap)}
+
+	dstByName := make(map[string]data.CaseField, len(dst))
+	for _, f := range dst {
+		dstByName[normalizeKey(f.SystemName)] = f
+	}
+
+	for _, sf := range src {
+		targetName := sf.SystemName
+		if o != nil && o.Fields[sf.SystemName] != "" {
+			targetName = o.Fields[sf.SystemName]
+		}
+
+		df, ok := dstByName[normalizeKey(targetName)]
+		if !ok {
+			fm.Problems = append(fm.Problems, FieldProblem{Field: sf.SystemName, Reason: "missing on destination"})
+			continue
+		}
+		dstConfig, ok := fieldConfigFor(df, dstProject)
+		if !ok {
+			fm.Problems = append(fm.Problems, FieldProblem{Field: sf.SystemName, Reason: fmt.Sprintf("not assigned to destination project %d", dstProject)})
+			continue
+		}
+		if df.TypeID != sf.TypeID {
+			fm.Problems = append(fm.Problems, FieldProblem{Field: sf.SystemName, Reason: fmt.Sprintf("type %d differs from destination type %d", sf.TypeID, df.TypeID)})
+			continue
+		}
+
+		f := &FieldMap{Source: sf.SystemName, Target: df.SystemName, TypeID: sf.TypeID}
+		if sf.TypeID == data.CaseFieldTypeDropdown || sf.TypeID == data.CaseFieldTypeMultiSelect {
+			srcConfig, _ := fieldConfigFor(sf, srcProject)
+			var labels map[string]string
+			if o != nil {
+				labels = o.Options[sf.SystemName]
+			}
+			f.Options, f.UnmatchedOptions = matchFieldOptions(parseFieldItems(srcConfig.Options.Items), parseFieldItems(dstConfig.Options.Items), labels)
+		}
+		fm.Fields[sf.SystemName] = f
+	}
+
+	targeted := make(map[string]bool, len(fm.Fields))
+	for _, f := range fm.Fields {
+		targeted[normalizeKey(f.Target)] = true
+	}
+	for _, df := range dst {
+		cfg, ok := fieldConfigFor(df, dstProject)
+		if !ok || !cfg.Options.IsRequired || cfg.Options.DefaultValue != "" {
+			continue
+		}
+		if targeted[normalizeKey(df.SystemName)] || requestFields[df.SystemName] {
+			continue
+		}
+		fm.Problems = append(fm.Problems, FieldProblem{
+			Field:    df.SystemName,
+			Reason:   fmt.Sprintf("required in destination project %d but has no source counterpart", dstProject),
+			Blocking: true,
+		})
+	}
+	return fm
+}
+
+// fieldConfigFor returns the field context that applies to a project:
+// a project-specific context wins over the global one.
+func fieldConfigFor(f data.CaseField, projectID int64) (data.CaseFieldConfig, bool) {
+	var global *data.CaseFieldConfig
+	for i, c := range f.Configs {
+		for _, id := range c.Context.ProjectIDs {
+			if id == projectID {
+				return c, true
+			}
+		}
+		if c.Context.IsGlobal && global == nil {
+			global = &f.Configs[i]
+		}
+	}
+	if global != nil {
+		return *global, true
+	}
+	return data.CaseFieldConfig{}, false
+}
+
+// parseFieldItems parses dropdown items ("1, First\n2, Second") into ID → label.
+func parseFieldItems(items string) map[int64]string {
+	res := make(map[int64]string)
+	for _, line := range strings.Split(items, "\n") {
+		idStr, label, ok := strings.Cut(line, ",")
+		if !ok {
+			continue
+		}
+		id, err := strconv.ParseInt(strings.TrimSpace(idStr), 10, 64)
+		if err != nil {
+			continue
+		}
+		res[id] = strings.TrimSpace(label)
+	}
+	return res
+}
+
+// matchFieldOptions pairs options by label; overrides map source labels to
+// destination labels.
+func matchFieldOptions(src, dst map[int64]string, overrides map[string]string) (map[int64]int64, []string) {
+	dstByLabel := make(map[string]int64, len(dst))
+	for id, label := range dst {
+		dstByLabel[normalizeKey(label)] = id
+	}
+
+	pairs := make(map[int64]int64, len(src))
+	var unmatched []string
+	for id, label := range src {
+		target := label
+		if o, ok := overrides[label]; ok {
+			target = o
+		}
+		if dstID, ok := dstByLabel[normalizeKey(target)]; ok {
+			pairs[id] = dstID
+		} else {
+			unmatched = append(unmatched, label)
+		}
+	}
+	sort.Strings(unmatched)
+	return pairs, unmatched
+}
+
+// translateCustomFields rewrites custom field values for the destination:
+// fields are renamed, option and user IDs translated, and fields that
+// cannot be written are dropped.
+func (m *Migration) translateCustomFields(values data.CustomFieldValues) data.CustomFieldValues {
+	if m.fields == nil || len(values) == 0 {
+		return values
+	}
+
+	res := make(data.CustomFieldValues, len(values))
+	for key, raw := range values {
+		f, ok := m.fields.Fields[key]
+		if !ok {
+			continue
+		}
+		value, ok := m.translateFieldValue(f, raw)
+		if !ok {
+			continue
+		}
+		res[f.Target] = value
+	}
+	return res
+}
+
+func (m *Migration) translateFieldValue(f *FieldMap, raw json.RawMessage) (json.RawMessage, bool) {
+	switch f.TypeID {
+	case data.CaseFieldTypeDropdown:
+		var id int64
+		if err := json.Unmarshal(raw, &id); err != nil {
+			return raw, true
+		}
+		target, ok := f.Options[id]
+		if !ok {
+			return nil, false
+		}
+		return json.RawMessage(strconv.FormatInt(target, 10)), true
+	case data.CaseFieldTypeMultiSelect:
+		var ids []int64
+		if err := json.Unmarshal(raw, &ids); err != nil {
+			return raw, true
+		}
+		targets := make([]int64, 0, len(ids))
+		for _, id := range ids {
+			if target, ok := f.Options[id]; ok {
+				targets = append(targets, target)
+			}
+		}
+		out, _ := json.Marshal(targets)
+		return out, true
+	case data.CaseFieldTypeUser:
+		if m.entities == nil || m.entities.Users == nil {
+			return raw, true
+		}
+		var id int64
+		if err := json.Unmarshal(raw, &id); err != nil {
+			return raw, true
+		}
+		target, ok := m.entities.Users.GetTargetBySource(id)
+		if !ok {
+			return nil, false
+		}
+		return json.RawMessage(strconv.FormatInt(target, 10)), true
+	}
+	return raw, true
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,185 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func caseField(systemName string, typeID int64, items string, global bool, projects ...int64) data.CaseField {
+	var cfg data.CaseFieldConfig
+	cfg.Context.IsGlobal = global
+	cfg.Context.ProjectIDs = projects
+	cfg.Options.Items = items
+	return data.CaseField{SystemName: systemName, TypeID: typeID, Configs: []data.CaseFieldConfig{cfg}}
+}
+
+func TestMatchCaseFields(t *testing.T) {
+	src := data.GetCaseFieldsResponse{
+		caseField("custom_browser", data.CaseFieldTypeDropdown, "1, Chrome\n2, Firefox\n3, Edge", true),
+		caseField("custom_env", data.CaseFieldTypeString, "", true),
+		caseField("custom_only_src", data.CaseFieldTypeString, "", true),
+		caseField("custom_other_project", data.CaseFieldTypeString, "", false, 1),
+		caseField("custom_kind", data.CaseFieldTypeString, "", true),
+	}
+	dst := data.GetCaseFieldsResponse{
+		caseField("custom_browser", data.CaseFieldTypeDropdown, "10, firefox\n20, Google Chrome", false, 2),
+		caseField("custom_environment", data.CaseFieldTypeString, "", true),
+		caseField("custom_other_project", data.CaseFieldTypeString, "", false, 99),
+		caseField("custom_kind", data.CaseFieldTypeInteger, "", true),
+	}
+	overrides := &FieldOverrides{
+		Fields:  map[string]string{"custom_env": "custom_environment"},
+		Options: map[string]map[string]string{"custom_browser": {"Chrome": "Google Chrome"}},
+	}
+
+	fm := matchCaseFields(src, dst, 1, 2, overrides)
+
+	browser := fm.Fields["custom_browser"]
+	require.NotNil(t, browser)
+	assert.Equal(t, map[int64]int64{1: 20, 2: 10}, browser.Options)
+	assert.Equal(t, []string{"Edge"}, browser.UnmatchedOptions)
+	assert.Equal(t, "custom_environment", fm.Fields["custom_env"].Target)
+
+	reasons := map[string]string{}
+	for _, p := range fm.Problems {
+		reasons[p.Field] = p.Reason
+	}
+	assert.Equal(t, "missing on destination", reasons["custom_only_src"])
+	assert.Contains(t, reasons["custom_other_project"], "not assigned to destination project 2")
+	assert.Contains(t, reasons["custom_kind"], "type")
+	assert.Len(t, fm.Problems, 3)
+}
+
+func TestParseFieldItems(t *testing.T) {
+	assert.Equal(t, map[int64]string{1: "First", 2: "Second, with comma"}, parseFieldItems("1, First\r\n2, Second, with comma\nbroken\nx, bad id"))
+}
+
+func TestImportCases_TranslatesCustomFields(t *testing.T) {
+	var got *data.AddCaseRequest
+	mock := &MockClient{
+		GetCaseFieldsFunc: func(ctx context.Context) (data.GetCaseFieldsResponse, error) {
+			return data.GetCaseFieldsResponse{
+				caseField("custom_browser", data.CaseFieldTypeDropdown, "1, Chrome\n2, Firefox", true),
+				caseField("custom_platforms", data.CaseFieldTypeMultiSelect, "1, Linux\n2, Mac", true),
+			}, nil
+		},
+		AddCaseFunc: func(ctx context.Context, sectionID int64, req *data.AddCaseRequest) (*data.Case, error) {
+			got = req
+			return &data.Case{ID: 1}, nil
+		},
+	}
+	src := &MockClient{
+		GetCaseFieldsFunc: func(ctx context.Context) (data.GetCaseFieldsResponse, error) {
+			return data.GetCaseFieldsResponse{
+				caseField("custom_browser", data.CaseFieldTypeDropdown, "5, Firefox\n6, Chrome", true),
+				caseField("custom_platforms", data.CaseFieldTypeMultiSelect, "7, Mac\n8, Windows", true),
+				caseField("custom_gone", data.CaseFieldTypeString, "", true),
+			}, nil
+		},
+	}
+
+	m := setupTestMigration(t, mock)
+	m.SrcClient = src
+
+	cases := data.GetCasesResponse{{
+		ID:    3,
+		Title: "fields",
+		CustomFields: data.CustomFieldValues{
+			"custom_browser":   json.RawMessage(`6`),
+			"custom_platforms": json.RawMessage(`[7, 8]`),
+			"custom_gone":      json.RawMessage(`"x"`),
+		},
+	}}
+
+	problems, err := m.CheckCaseFields(context.Background(), cases)
+	require.NoError(t, err)
+	require.Len(t, problems, 2)
+	assert.Equal(t, "custom_gone: missing on destination", problems[0].String())
+	assert.Contains(t, problems[1].Reason, "Windows")
+
+	require.NoError(t, m.ImportCases(context.Background(), cases, false))
+	require.NotNil(t, got)
+	assert.Equal(t, []string{"custom_browser", "custom_platforms"}, got.CustomFields.Keys())
+	assert.JSONEq(t, `1`, string(got.CustomFields["custom_browser"]))
+	assert.JSONEq(t, `[2]`, string(got.CustomFields["custom_platforms"]))
+}
+
+func TestImportCases_TranslatesModeledDropdown(t *testing.T) {
+	var got *data.AddCaseRequest
+	mock := &MockClient{
+		GetCaseFieldsFunc: func(ctx context.Context) (data.GetCaseFieldsResponse, error) {
+			return data.GetCaseFieldsResponse{
+				caseField("custom_automation_type", data.CaseFieldTypeDropdown, "1, Manual\n2, Automated", true),
+			}, nil
+		},
+		AddCaseFunc: func(ctx context.Context, sectionID int64, req *data.AddCaseRequest) (*data.Case, error) {
+			got = req
+			return &data.Case{ID: 1}, nil
+		},
+	}
+	src := &MockClient{
+		GetCaseFieldsFunc: func(ctx context.Context) (data.GetCaseFieldsResponse, error) {
+			return data.GetCaseFieldsResponse{
+				caseField("custom_automation_type", data.CaseFieldTypeDropdown, "7, Automated\n8, Manual", true),
+			}, nil
+		},
+	}
+
+	m := setupTestMigration(t, mock)
+	m.SrcClient = src
+
+	cases := data.GetCasesResponse{{ID: 3, Title: "automated", CustomAutomationType: 7}}
+	problems, err := m.CheckCaseFields(context.Background(), cases)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	require.NoError(t, m.ImportCases(context.Background(), cases, false))
+	require.NotNil(t, got)
+	assert.JSONEq(t, `2`, string(got.CustomFields["custom_automation_type"]))
+}
+
+func TestCheckCaseFields_RequiredDestinationFieldBlocks(t *testing.T) {
+	required := caseField("custom_team", data.CaseFieldTypeString, "", true)
+	required.Configs[0].Options.IsRequired = true
+	withDefault := caseField("custom_area", data.CaseFieldTypeString, "", true)
+	withDefault.Configs[0].Options.IsRequired = true
+	withDefault.Configs[0].Options.DefaultValue = "core"
+	steps := caseField("custom_steps_separated", data.CaseFieldTypeStepResults, "", true)
+	steps.Configs[0].Options.IsRequired = true
+
+	mock := &MockClient{
+		GetCaseFieldsFunc: func(ctx context.Context) (data.GetCaseFieldsResponse, error) {
+			return data.GetCaseFieldsResponse{required, withDefault, steps}, nil
+		},
+	}
+	m := setupTestMigration(t, mock)
+	m.SrcClient = &MockClient{}
+
+	problems, err := m.CheckCaseFields(context.Background(), data.GetCasesResponse{{ID: 1, Title: "plain"}})
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Equal(t, "custom_team", problems[0].Field)
+	assert.True(t, problems[0].Blocking)
+	assert.Equal(t, problems, BlockingFieldProblems(problems))
+}
+
+func TestLoadFieldOverrides(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "fields.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("fields:\n  custom_a: custom_b\noptions:\n  custom_a:\n    Low: Minor\n"), 0o644))
+
+	o, err := LoadFieldOverrides(file)
+	require.NoError(t, err)
+	assert.Equal(t, "custom_b", o.Fields["custom_a"])
+	assert.Equal(t, "Minor", o.Options["custom_a"]["Low"])
+
+	_, err = LoadFieldOverrides(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/Korrnals/gotr/internal/match"
 	"github.com/Korrnals/gotr/internal/models/data"
 )
 
@@ -33,29 +34,34 @@ func (m *Migration) FilterSharedSteps(source, target data.GetSharedStepsResponse
 
 	m.logger.Info("Checking for duplicates in target project")
 	targetMap := make(map[string]int64)
+	targetItems := make([]match.Item, 0, len(target))
 	for _, t := range target {
-		val := fieldValue(t, m.compareField)
+		val := m.matchKey(t, nil)
 		if val != "" {
 			targetMap[val] = t.ID
+			targetItems = append(targetItems, match.Item{ID: t.ID, Name: t.Title, Key: val})
 		}
 	}
 
+	var newItems []match.Item
 	for _, step := range candidates {
-		val := fieldValue(step, m.compareField)
-		if existingID, ok := targetMap[val]; ok {
+		val := m.matchKey(step, nil)
+		if existingID, ok := targetMap[val]; ok && val != "" {
 			m.mapping.AddPair(step.ID, existingID, "existing")
 			m.logger.Infow("Duplicate found — added to mapping", "title", step.Title, "old_id", step.ID, "existing_id", existingID)
 		} else {
 			filtered = append(filtered, step)
+			newItems = append(newItems, match.Item{ID: step.ID, Name: step.Title, Key: val})
 		}
 	}
+	m.logNearMatches("shared step", newItems, targetItems)
 
 	m.logger.Infow("Ready to import new shared steps", "count", len(filtered))
 	return filtered, nil
 }
 
 // FilterSuites filters suites by duplicate detection (by name).
-// Duplicates are added to the mapping with status "existing".
+// Duplicates are added to the suite mapping with status "existing".
 // New (non-duplicate) suites are returned for import.
 func (m *Migration) FilterSuites(source, target data.GetSuitesResponse) (filtered data.GetSuitesResponse, err error) {
 	m.logger.Info("Starting suites filtering by duplicates (by name)")
@@ -69,7 +75,7 @@ func (m *Migration) FilterSuites(source, target data.GetSuitesResponse) (filtere
 
 	for _, s := range source {
 		if existingID, ok := targetMap[s.Name]; ok {
-			m.mapping.AddPair(s.ID, existingID, "existing")
+			m.suites.AddPair(s.ID, existingID, "existing")
 			m.logger.Infow("Duplicate suite found — added to mapping", "name", s.Name, "old_id", s.ID, "existing_id", existingID)
 		} else {
 			filtered = append(filtered, s)
@@ -81,26 +87,46 @@ func (m *Migration) FilterSuites(source, target data.GetSuitesResponse) (filtere
 }
 
 // FilterCases filters cases by duplicate detection (using compareField).
+// Cases created by an earlier run are kept so that they are updated in
+// place; other duplicates are added to the case mapping with status "existing"
+// and never overwritten.
 func (m *Migration) FilterCases(source, target data.GetCasesResponse) (filtered data.GetCasesResponse, err error) {
 	m.logger.Info("Starting cases filtering by duplicates")
 
 	targetMap := make(map[string]int64)
+	targetItems := make([]match.Item, 0, len(target))
 	for _, t := range target {
-		val := fieldValue(t, m.compareField)
+		val := m.matchKey(t, m.dstPaths)
 		if val != "" {
 			targetMap[val] = t.ID
+			targetItems = append(targetItems, match.Item{ID: t.ID, Name: t.Title, Key: val})
 		}
 	}
 
+	var newItems []match.Item
 	for _, c := range source {
-		val := fieldValue(c, m.compareField)
-		if _, exists := targetMap[val]; !exists {
+		if targetID, mapped := m.caseUpdateTarget(c.ID); mapped {
+			filtered = append(filtered, c)
+			m.logger.Infow("Mapped case found — will be updated in place", "title", c.Title, "target_id", targetID)
+			continue
+		}
+		if m.cases.Status(c.ID) == "existing" {
+			m.logger.Infow("Duplicate case found — skipped", "title", c.Title)
+			continue
+		}
+
+		val := m.matchKey(c, m.srcPaths)
+		if existingID, exists := targetMap[val]; !exists || val == "" {
 			filtered = append(filtered, c)
+			newItems = append(newItems, match.Item{ID: c.ID, Name: c.Title, Key: val})
 		} else {
+			m.cases.AddPair(c.ID, existingID, "existing")
 			m.logger.Infow("Duplicate case found — skipped", "title", c.Title)
 		}
 	}
 
+	m.logNearMatches("case", newItems, targetItems)
+
 	m.logger.Infow("Ready to import new cases", "count", len(filtered))
 	return filtered, nil
 }
@@ -120,9 +146,11 @@ func fieldValue(obj interface{}, field string) string {
 		return fmt.Sprintf("%v", f.Interface())
 	}
 
+	// "section_id" matches SectionID: underscores of snake_case names are ignored.
+	name := strings.ReplaceAll(field, "_", "")
 	t := v.Type()
 	for i := 0; i < t.NumField(); i++ {
-		if strings.EqualFold(t.Field(i).Name, field) {
+		if strings.EqualFold(t.Field(i).Name, name) {
 			f = v.Field(i)
 			if f.IsValid() {
 				return fmt.Sprintf("%v", f.Interface())
@@ -133,20 +161,24 @@ func fieldValue(obj interface{}, field string) string {
 	return ""
 }
 
-// FilterSections filters sections by duplicate detection in the target suite (by name).
+// FilterSections filters sections by duplicate detection in the target suite.
+// Sections are matched by their full path (names from the root down), so a
+// section with a common name under a different parent is not a duplicate.
 func (m *Migration) FilterSections(source, target data.GetSectionsResponse) (filtered data.GetSectionsResponse, err error) {
-	m.logger.Info("Starting sections filtering by duplicates (by name in suite)")
+	m.logger.Info("Starting sections filtering by duplicates (by path in suite)")
 
 	targetMap := make(map[string]int64)
+	targetPaths := sectionPaths(target)
 	for _, t := range target {
-		if t.Name != "" {
-			targetMap[t.Name] = t.ID
+		if path := targetPaths[t.ID]; path != "" {
+			targetMap[path] = t.ID
 		}
 	}
 
+	sourcePaths := sectionPaths(source)
 	for _, s := range source {
-		if existingID, ok := targetMap[s.Name]; ok {
-			m.mapping.AddPair(s.ID, existingID, "existing")
+		if existingID, ok := targetMap[sourcePaths[s.ID]]; ok {
+			m.sections.AddPair(s.ID, existingID, "existing")
 			m.logger.Infow("Duplicate section found — mapping added", "name", s.Name, "old_id", s.ID, "existing_id", existingID)
 		} else {
 			filtered = append(filtered, s)
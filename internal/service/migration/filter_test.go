@@ -119,10 +119,11 @@ func TestFilterSuites_TableDriven(t *testing.T) {
 				filteredNames = append(filteredNames, s.Name)
 			}
 			assert.Equal(t, tc.wantFilteredNames, filteredNames)
-			assert.Equal(t, len(tc.wantMappingSourceIDs), m.mapping.Count)
+			assert.Equal(t, len(tc.wantMappingSourceIDs), m.suites.Count)
+			assert.Zero(t, m.mapping.Count, "suites stay out of the shared step mapping")
 
 			for i := range tc.wantMappingSourceIDs {
-				got, ok := m.mapping.GetTargetBySource(tc.wantMappingSourceIDs[i])
+				got, ok := m.suites.GetTargetBySource(tc.wantMappingSourceIDs[i])
 				assert.True(t, ok)
 				assert.Equal(t, tc.wantMappingTargetIDs[i], got)
 			}
@@ -197,10 +198,11 @@ func TestFilterSections_TableDriven(t *testing.T) {
 				filteredNames = append(filteredNames, s.Name)
 			}
 			assert.Equal(t, tc.wantFilteredNames, filteredNames)
-			assert.Equal(t, len(tc.wantMappingSourceIDs), m.mapping.Count)
+			assert.Equal(t, len(tc.wantMappingSourceIDs), m.sections.Count)
+			assert.Zero(t, m.mapping.Count, "sections stay out of the shared step mapping")
 
 			for i := range tc.wantMappingSourceIDs {
-				got, ok := m.mapping.GetTargetBySource(tc.wantMappingSourceIDs[i])
+				got, ok := m.sections.GetTargetBySource(tc.wantMappingSourceIDs[i])
 				assert.True(t, ok)
 				assert.Equal(t, tc.wantMappingTargetIDs[i], got)
 			}
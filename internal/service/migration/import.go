@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/Korrnals/gotr/internal/models/data"
 )
@@ -73,7 +74,7 @@ func (m *Migration) ImportSharedSteps(ctx context.Context, filtered data.GetShar
 }
 
 // ImportSuites imports filtered suites in parallel.
-// Updates the mapping (AddPair with status "created" for new IDs).
+// Updates the suite mapping (AddPair with status "created" for new IDs).
 func (m *Migration) ImportSuites(ctx context.Context, filtered data.GetSuitesResponse, dryRun bool) error {
 	if dryRun || len(filtered) == 0 {
 		m.logger.Infow("Dry-run or no data — suites import skipped", "count", len(filtered))
@@ -109,7 +110,7 @@ func (m *Migration) ImportSuites(ctx context.Context, filtered data.GetSuitesRes
 			}
 
 			mu.Lock()
-			m.mapping.AddPair(s.ID, created.ID, "created")
+			m.suites.AddPair(s.ID, created.ID, "created")
 			m.importedCases++
 			m.logger.Infow("Successfully created suite", "old_id", s.ID, "new_id", created.ID, "name", s.Name)
 			mu.Unlock()
@@ -121,7 +122,10 @@ func (m *Migration) ImportSuites(ctx context.Context, filtered data.GetSuitesRes
 	return nil
 }
 
-// ImportSections imports filtered sections in parallel.
+// ImportSections imports filtered sections level by level, parents before
+// children, so that the section tree is preserved. ParentID and SuiteID are
+// remapped for the destination; siblings are created in display order, and
+// sibling groups of one level are imported in parallel.
 // Updates the mapping (AddPair with status "created" for new IDs).
 func (m *Migration) ImportSections(ctx context.Context, filtered data.GetSectionsResponse, dryRun bool) error {
 	if dryRun || len(filtered) == 0 {
@@ -131,49 +135,86 @@ func (m *Migration) ImportSections(ctx context.Context, filtered data.GetSection
 
 	m.logger.Infow("Starting sections import", "count", len(filtered))
 
-	var mu sync.Mutex
-	var wg sync.WaitGroup
-	sem := make(chan struct{}, maxImportConcurrency)
-
-	for _, section := range filtered {
-		wg.Add(1)
-		sem <- struct{}{}
-		go func(s data.Section) {
-			defer func() { <-sem }()
-			defer wg.Done()
+	inList := make(map[int64]bool, len(filtered))
+	for _, s := range filtered {
+		inList[s.ID] = true
+	}
 
-			// Prepare request
-			req := &data.AddSectionRequest{
-				Name:        s.Name,
-				Description: s.Description,
-				SuiteID:     s.SuiteID,
-				ParentID:    s.ParentID,
-			}
+	var mu sync.Mutex
+	for depth, level := range sectionLevels(filtered) {
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, maxImportConcurrency)
+
+		for _, group := range groupByParent(level) {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(siblings data.GetSectionsResponse) {
+				defer func() { <-sem }()
+				defer wg.Done()
+
+				for _, s := range siblings {
+					mu.Lock()
+					req, ok := m.buildSectionRequest(s, inList)
+					mu.Unlock()
+					if !ok {
+						mu.Lock()
+						m.logger.Errorw("Parent section was not imported — section skipped", "name", s.Name, "parent_id", s.ParentID)
+						mu.Unlock()
+						continue
+					}
 
-			// Create in target project
-			created, err := m.Client.AddSection(ctx, m.dstProject, req)
-			if err != nil {
-				mu.Lock()
-				m.logger.Errorw("Error importing section", "name", s.Name, "error", err)
-				mu.Unlock()
-				return
-			}
+					// Create in target project
+					created, err := m.Client.AddSection(ctx, m.dstProject, req)
+					if err != nil {
+						mu.Lock()
+						m.logger.Errorw("Error importing section", "name", s.Name, "error", err)
+						mu.Unlock()
+						continue
+					}
 
-			mu.Lock()
-			m.mapping.AddPair(s.ID, created.ID, "created")
-			m.importedCases++
-			m.logger.Infow("Successfully created section", "old_id", s.ID, "new_id", created.ID, "name", s.Name)
-			mu.Unlock()
-		}(section)
+					mu.Lock()
+					m.sections.AddPair(s.ID, created.ID, "created")
+					m.importedCases++
+					m.logger.Infow("Successfully created section", "old_id", s.ID, "new_id", created.ID, "name", s.Name, "depth", depth)
+					mu.Unlock()
+				}
+			}(group)
+		}
+		wg.Wait()
 	}
-	wg.Wait()
 
 	m.logger.Infow("Sections import completed", "imported", m.importedCases)
 	return nil
 }
 
+// buildSectionRequest prepares an add_section request with destination
+// suite and parent IDs. It returns false if the parent is part of the import
+// but has no destination counterpart (its creation failed).
+// The caller must hold the mapping lock.
+func (m *Migration) buildSectionRequest(s data.Section, inList map[int64]bool) (*data.AddSectionRequest, bool) {
+	req := &data.AddSectionRequest{
+		Name:        s.Name,
+		Description: s.Description,
+		SuiteID:     m.dstSuite,
+	}
+	if req.SuiteID == 0 {
+		req.SuiteID = translate(m.suites, s.SuiteID, s.SuiteID)
+	}
+
+	if s.ParentID != 0 {
+		parentID, ok := m.sections.GetTargetBySource(s.ParentID)
+		if !ok && inList[s.ParentID] {
+			return nil, false
+		}
+		req.ParentID = parentID
+	}
+	return req, true
+}
+
 // ImportCases imports filtered cases in parallel.
-// Replaces SharedStepID references using the mapping.
+// Each case is created in the destination section mapped from its source
+// section (missing sections are created first); cases already in the case
+// mapping are updated in place. Replaces SharedStepID references using the mapping.
 func (m *Migration) ImportCases(ctx context.Context, filtered data.GetCasesResponse, dryRun bool) error {
 	if dryRun || len(filtered) == 0 {
 		m.logger.Infow("Dry-run or no data — cases import skipped", "count", len(filtered))
@@ -182,6 +223,10 @@ func (m *Migration) ImportCases(ctx context.Context, filtered data.GetCasesRespo
 
 	m.logger.Infow("Starting cases import", "count", len(filtered))
 
+	if err := m.prepareCaseImport(ctx, filtered); err != nil {
+		return err
+	}
+
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 	sem := make(chan struct{}, maxImportConcurrency)
@@ -193,58 +238,21 @@ func (m *Migration) ImportCases(ctx context.Context, filtered data.GetCasesRespo
 			defer func() { <-sem }()
 			defer wg.Done()
 
-			// Prepare request
-			req := &data.AddCaseRequest{
-				Title:                caseData.Title,
-				TypeID:               caseData.TypeID,
-				PriorityID:           caseData.PriorityID,
-				TemplateID:           caseData.TemplateID,
-				MilestoneID:          caseData.MilestoneID,
-				Refs:                 caseData.Refs,
-				CustomPreconds:       caseData.CustomPreconds,
-				CustomStepsSeparated: make([]data.Step, len(caseData.CustomStepsSeparated)),
-			}
-
-			for i, orig := range caseData.CustomStepsSeparated {
-				newStep := data.Step{
-					Content:        orig.Content,
-					AdditionalInfo: orig.AdditionalInfo,
-					Expected:       orig.Expected,
-					Refs:           orig.Refs,
-					SharedStepID:   orig.SharedStepID,
-				}
-
-				if orig.SharedStepID != 0 {
-					if newID, exists := m.mapping.GetTargetBySource(orig.SharedStepID); exists {
-						newStep.SharedStepID = newID
-					}
-				}
-
-				req.CustomStepsSeparated[i] = newStep
-			}
-
-			// Create in target suite
-			created, err := m.Client.AddCase(ctx, m.dstSuite, req)
-			if err != nil {
+			if _, err := m.importCase(ctx, caseData, &mu); err != nil {
 				mu.Lock()
 				m.logger.Errorw("Error importing case", "title", caseData.Title, "error", err)
 				mu.Unlock()
-				return
 			}
-
-			mu.Lock()
-			m.importedCases++
-			m.logger.Infow("Successfully created case", "old_id", caseData.ID, "new_id", created.ID, "title", caseData.Title)
-			mu.Unlock()
 		}(c)
 	}
 	wg.Wait()
 
-	m.logger.Infow("Cases import completed", "imported", m.importedCases)
+	m.logger.Infow("Cases import completed", "imported", m.importedCases, "updated", m.updatedCases)
 	return nil
 }
 
 // ImportCasesReport is like ImportCases but returns lists of created IDs and errors for CLI reporting.
+// Cases updated in place are not listed in createdIDs (see UpdatedCases).
 func (m *Migration) ImportCasesReport(ctx context.Context, filtered data.GetCasesResponse, dryRun bool) (createdIDs []int64, errs []string, err error) {
 	if dryRun || len(filtered) == 0 {
 		m.logger.Infow("Dry-run or no data — cases import skipped", "count", len(filtered))
@@ -253,6 +261,10 @@ func (m *Migration) ImportCasesReport(ctx context.Context, filtered data.GetCase
 
 	m.logger.Infow("Starting cases import (report)", "count", len(filtered))
 
+	if err := m.prepareCaseImport(ctx, filtered); err != nil {
+		return nil, nil, err
+	}
+
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 	sem := make(chan struct{}, maxImportConcurrency)
@@ -264,38 +276,7 @@ func (m *Migration) ImportCasesReport(ctx context.Context, filtered data.GetCase
 			defer func() { <-sem }()
 			defer wg.Done()
 
-			// Prepare request
-			req := &data.AddCaseRequest{
-				Title:                caseData.Title,
-				TypeID:               caseData.TypeID,
-				PriorityID:           caseData.PriorityID,
-				TemplateID:           caseData.TemplateID,
-				MilestoneID:          caseData.MilestoneID,
-				Refs:                 caseData.Refs,
-				CustomPreconds:       caseData.CustomPreconds,
-				CustomStepsSeparated: make([]data.Step, len(caseData.CustomStepsSeparated)),
-			}
-
-			for i, orig := range caseData.CustomStepsSeparated {
-				newStep := data.Step{
-					Content:        orig.Content,
-					AdditionalInfo: orig.AdditionalInfo,
-					Expected:       orig.Expected,
-					Refs:           orig.Refs,
-					SharedStepID:   orig.SharedStepID,
-				}
-
-				if orig.SharedStepID != 0 {
-					if newID, exists := m.mapping.GetTargetBySource(orig.SharedStepID); exists {
-						newStep.SharedStepID = newID
-					}
-				}
-
-				req.CustomStepsSeparated[i] = newStep
-			}
-
-			// Create in target suite
-			created, err := m.Client.AddCase(ctx, m.dstSuite, req)
+			created, err := m.importCase(ctx, caseData, &mu)
 			if err != nil {
 				mu.Lock()
 				errs = append(errs, fmt.Sprintf("case %q: %v", caseData.Title, err))
@@ -304,15 +285,151 @@ func (m *Migration) ImportCasesReport(ctx context.Context, filtered data.GetCase
 				return
 			}
 
-			mu.Lock()
-			createdIDs = append(createdIDs, created.ID)
-			m.importedCases++
-			m.logger.Infow("Successfully created case (report)", "old_id", caseData.ID, "new_id", created.ID, "title", caseData.Title)
-			mu.Unlock()
+			if created != 0 {
+				mu.Lock()
+				createdIDs = append(createdIDs, created)
+				mu.Unlock()
+			}
 		}(c)
 	}
 	wg.Wait()
 
-	m.logger.Infow("Cases import (report) completed", "imported", m.importedCases)
+	m.logger.Infow("Cases import (report) completed", "imported", m.importedCases, "updated", m.updatedCases)
 	return createdIDs, errs, nil
 }
+
+// prepareCaseImport builds cross-instance ID mappings and the custom field
+// mapping (if cases carry custom fields) and makes sure the destination
+// sections of all cases exist.
+func (m *Migration) prepareCaseImport(ctx context.Context, cases data.GetCasesResponse) error {
+	if _, err := m.BuildEntityMappings(ctx); err != nil {
+		return err
+	}
+	if len(usedCustomFields(cases)) > 0 {
+		if _, err := m.BuildFieldMapping(ctx); err != nil {
+			return err
+		}
+	}
+	return m.ensureCaseSections(ctx, cases)
+}
+
+// caseUpdateTarget returns the destination case that a source case is
+// updated in place into. Only cases created by gotr qualify: a pair recorded
+// as "existing" points at a duplicate that was already in the destination,
+// and that case is left alone.
+func (m *Migration) caseUpdateTarget(sourceID int64) (int64, bool) {
+	targetID, ok := m.cases.GetTargetBySource(sourceID)
+	if !ok || m.cases.Status(sourceID) == "existing" {
+		return 0, false
+	}
+	return targetID, true
+}
+
+// importCase creates a case in its mapped destination section, or updates
+// the mapped destination case in place. It returns the ID of a newly created
+// case and 0 for an update. mu guards the mappings and counters.
+func (m *Migration) importCase(ctx context.Context, caseData data.Case, mu *sync.Mutex) (int64, error) {
+	mu.Lock()
+	req := m.buildCaseRequest(caseData)
+	sectionID, sectionOK := m.caseSectionID(caseData.SectionID)
+	targetID, mapped := m.caseUpdateTarget(caseData.ID)
+	existing := m.cases.Status(caseData.ID) == "existing"
+	mu.Unlock()
+
+	if existing {
+		m.logger.Infow("Case already in destination — skipped", "old_id", caseData.ID, "title", caseData.Title)
+		return 0, nil
+	}
+	if mapped {
+		update := updateCaseRequest(req)
+		if sectionOK && caseData.SectionID != 0 {
+			update.SectionID = &sectionID
+		}
+		if _, err := m.Client.UpdateCase(ctx, targetID, update); err != nil {
+			return 0, err
+		}
+
+		mu.Lock()
+		m.updatedCases++
+		m.logger.Infow("Successfully updated case", "old_id", caseData.ID, "target_id", targetID, "title", caseData.Title)
+		mu.Unlock()
+		return 0, nil
+	}
+
+	if !sectionOK {
+		return 0, fmt.Errorf("section %d has no destination counterpart", caseData.SectionID)
+	}
+	if caseData.SectionID != 0 {
+		req.SectionID = sectionID
+	}
+
+	// Create in the mapped destination section
+	created, err := m.Client.AddCase(ctx, sectionID, req)
+	if err != nil {
+		return 0, err
+	}
+
+	mu.Lock()
+	m.cases.AddPair(caseData.ID, created.ID, "created")
+	m.created = append(m.created, MappingPair{SourceID: caseData.ID, TargetID: created.ID, CreatedAt: time.Now(), Status: "created"})
+	m.importedCases++
+	m.logger.Infow("Successfully created case", "old_id", caseData.ID, "new_id", created.ID, "title", caseData.Title, "section_id", sectionID)
+	mu.Unlock()
+	return created.ID, nil
+}
+
+// updateCaseRequest converts an add_case request into an update_case request
+// that overwrites the same fields.
+func updateCaseRequest(req *data.AddCaseRequest) *data.UpdateCaseRequest {
+	return &data.UpdateCaseRequest{
+		Title:                &req.Title,
+		TypeID:               &req.TypeID,
+		PriorityID:           &req.PriorityID,
+		CustomPreconds:       &req.CustomPreconds,
+		CustomStepsSeparated: req.CustomStepsSeparated,
+		Refs:                 &req.Refs,
+		TemplateID:           &req.TemplateID,
+		CustomFields:         req.CustomFields,
+	}
+}
+
+// buildCaseRequest prepares an add_case request from a source case:
+// shared step references are replaced via the mapping, custom fields are
+// translated via the field mapping, and for cross-instance sync
+// instance-specific IDs are translated.
+func (m *Migration) buildCaseRequest(caseData data.Case) *data.AddCaseRequest {
+	req := &data.AddCaseRequest{
+		Title:                caseData.Title,
+		TypeID:               caseData.TypeID,
+		PriorityID:           caseData.PriorityID,
+		TemplateID:           caseData.TemplateID,
+		MilestoneID:          caseData.MilestoneID,
+		Refs:                 caseData.Refs,
+		CustomPreconds:       caseData.CustomPreconds,
+		CustomStepsSeparated: make([]data.Step, len(caseData.CustomStepsSeparated)),
+		CustomFields:         m.translateCustomFields(caseCustomFields(caseData)),
+	}
+
+	for i, orig := range caseData.CustomStepsSeparated {
+		newStep := data.Step{
+			Content:        orig.Content,
+			AdditionalInfo: orig.AdditionalInfo,
+			Expected:       orig.Expected,
+			Refs:           orig.Refs,
+			SharedStepID:   orig.SharedStepID,
+		}
+
+		if orig.SharedStepID != 0 {
+			if newID, exists := m.mapping.GetTargetBySource(orig.SharedStepID); exists {
+				newStep.SharedStepID = newID
+			}
+		}
+
+		req.CustomStepsSeparated[i] = newStep
+	}
+
+	if m.entities != nil {
+		m.entities.translateCaseRequest(req)
+	}
+	return req
+}
@@ -3,6 +3,7 @@ package migration // white-box tests — same package
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"sync"
 	"testing"
@@ -112,8 +113,8 @@ func TestMigration_ImportSuites(t *testing.T) {
 				t.Errorf("unexpected error: %v", err)
 			}
 
-			if m.mapping.Count != tt.wantCount {
-				t.Errorf("mapping.Count = %d, expected %d", m.mapping.Count, tt.wantCount)
+			if m.suites.Count != tt.wantCount {
+				t.Errorf("suites.Count = %d, expected %d", m.suites.Count, tt.wantCount)
 			}
 		})
 	}
@@ -215,13 +216,40 @@ func TestMigration_ImportSections(t *testing.T) {
 				t.Errorf("unexpected error: %v", err)
 			}
 
-			if m.mapping.Count != tt.wantCount {
-				t.Errorf("mapping.Count = %d, expected %d", m.mapping.Count, tt.wantCount)
+			if m.sections.Count != tt.wantCount {
+				t.Errorf("sections.Count = %d, expected %d", m.sections.Count, tt.wantCount)
 			}
 		})
 	}
 }
 
+func TestMigration_ImportSections_TranslatesSuiteThroughSuiteMapping(t *testing.T) {
+	var suiteIDs []int64
+	mock := &MockClient{
+		AddSectionFunc: func(ctx context.Context, projectID int64, req *data.AddSectionRequest) (*data.Section, error) {
+			suiteIDs = append(suiteIDs, req.SuiteID)
+			return &data.Section{ID: 100}, nil
+		},
+	}
+
+	// No destination suite: sections follow the migrated suites.
+	m, err := NewMigration(mock, 1, 0, 2, 0, "title", logDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	m.mapping.AddPair(5, 900, "existing") // shared step 5, unrelated to suite 5
+	m.suites.AddPair(5, 50, "created")
+
+	err = m.ImportSections(context.Background(), data.GetSectionsResponse{{ID: 1, Name: "A", SuiteID: 5}}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(suiteIDs) != 1 || suiteIDs[0] != 50 {
+		t.Errorf("section suite IDs = %v, expected [50]", suiteIDs)
+	}
+}
+
 func TestMigration_ImportCasesReport_DryRunAndMixedResults(t *testing.T) {
 	mock := &MockClient{}
 
@@ -375,3 +403,138 @@ func TestMigration_ImportSharedSteps_CopiesCustomSteps(t *testing.T) {
 	assert.Equal(t, 1, m.mapping.Count)
 	assert.Equal(t, 1, m.importedCases)
 }
+
+func TestMigration_ImportCases_PlacesCasesIntoMappedSections(t *testing.T) {
+	var mu sync.Mutex
+	sectionByTitle := map[string]int64{}
+	var createdSections []string
+
+	mock := &MockClient{
+		GetSectionsFunc: func(ctx context.Context, projectID, suiteID int64) (data.GetSectionsResponse, error) {
+			if projectID == 1 {
+				return data.GetSectionsResponse{
+					{ID: 1, Name: "API"},
+					{ID: 2, Name: "Auth", ParentID: 1},
+					{ID: 3, Name: "UI"},
+				}, nil
+			}
+			return data.GetSectionsResponse{{ID: 900, Name: "API"}}, nil
+		},
+		AddSectionFunc: func(ctx context.Context, projectID int64, req *data.AddSectionRequest) (*data.Section, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			createdSections = append(createdSections, req.Name)
+			assert.Equal(t, int64(900), req.ParentID, "Auth is created under the existing API section")
+			return &data.Section{ID: 901, Name: req.Name}, nil
+		},
+		AddCaseFunc: func(ctx context.Context, sectionID int64, req *data.AddCaseRequest) (*data.Case, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			sectionByTitle[req.Title] = sectionID
+			return &data.Case{ID: 100 + int64(len(sectionByTitle))}, nil
+		},
+	}
+
+	m, err := NewMigration(mock, 1, 10, 2, 20, "title", logDir())
+	assert.NoError(t, err)
+	defer m.Close()
+
+	err = m.ImportCases(context.Background(), data.GetCasesResponse{
+		{ID: 11, Title: "in API", SectionID: 1},
+		{ID: 12, Title: "in Auth", SectionID: 2},
+		{ID: 13, Title: "no section"},
+	}, false)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"Auth"}, createdSections, "only sections referenced by cases are created")
+	assert.Equal(t, int64(900), sectionByTitle["in API"])
+	assert.Equal(t, int64(901), sectionByTitle["in Auth"])
+	assert.Equal(t, int64(20), sectionByTitle["no section"])
+	assert.Equal(t, 3, m.CaseMapping().Count)
+}
+
+func TestMigration_ImportCasesReport_UpdatesMappedCasesInPlace(t *testing.T) {
+	var added, updated []int64
+	var mu sync.Mutex
+	mock := &MockClient{
+		AddCaseFunc: func(ctx context.Context, sectionID int64, req *data.AddCaseRequest) (*data.Case, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			added = append(added, sectionID)
+			return &data.Case{ID: 300}, nil
+		},
+		UpdateCaseFunc: func(ctx context.Context, caseID int64, req *data.UpdateCaseRequest) (*data.Case, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			updated = append(updated, caseID)
+			assert.Equal(t, "renamed", *req.Title)
+			return &data.Case{ID: caseID}, nil
+		},
+	}
+
+	m, err := NewMigration(mock, 1, 10, 2, 20, "title", logDir())
+	assert.NoError(t, err)
+	defer m.Close()
+	m.cases.AddPair(5, 205, "created")
+
+	filtered, err := m.FilterCases(
+		data.GetCasesResponse{{ID: 5, Title: "renamed"}, {ID: 6, Title: "new"}},
+		data.GetCasesResponse{{ID: 205, Title: "old title"}},
+	)
+	assert.NoError(t, err)
+	assert.Len(t, filtered, 2)
+
+	created, errs, err := m.ImportCasesReport(context.Background(), filtered, false)
+	assert.NoError(t, err)
+	assert.Empty(t, errs)
+	assert.Equal(t, []int64{300}, created)
+	assert.Equal(t, []int64{205}, updated)
+	assert.Equal(t, 1, m.UpdatedCases())
+}
+
+func TestMigration_ImportCasesReport_LeavesExistingDuplicatesAlone(t *testing.T) {
+	mock := &MockClient{
+		AddCaseFunc: func(ctx context.Context, sectionID int64, req *data.AddCaseRequest) (*data.Case, error) {
+			t.Errorf("case %q created again", req.Title)
+			return &data.Case{ID: 300}, nil
+		},
+		UpdateCaseFunc: func(ctx context.Context, caseID int64, req *data.UpdateCaseRequest) (*data.Case, error) {
+			t.Errorf("existing case %d overwritten", caseID)
+			return &data.Case{ID: caseID}, nil
+		},
+	}
+
+	m, err := NewMigration(mock, 1, 10, 2, 20, "title", logDir())
+	assert.NoError(t, err)
+	defer m.Close()
+	// A title duplicate found by an earlier run, loaded via --case-mapping.
+	m.cases.AddPair(5, 205, "existing")
+
+	filtered, err := m.FilterCases(
+		data.GetCasesResponse{{ID: 5, Title: "renamed"}},
+		data.GetCasesResponse{{ID: 205, Title: "old title"}},
+	)
+	assert.NoError(t, err)
+	assert.Empty(t, filtered)
+
+	created, errs, err := m.ImportCasesReport(context.Background(), data.GetCasesResponse{{ID: 5, Title: "renamed"}}, false)
+	assert.NoError(t, err)
+	assert.Empty(t, errs)
+	assert.Empty(t, created)
+	assert.Zero(t, m.UpdatedCases())
+}
+
+func TestMigration_BuildCaseRequest_CarriesCustomFields(t *testing.T) {
+	m := setupTestMigration(t, &MockClient{})
+
+	req := m.buildCaseRequest(data.Case{
+		Title: "with fields",
+		CustomFields: data.CustomFieldValues{
+			"custom_browser": json.RawMessage(`"Chrome"`),
+			"custom_unset":   json.RawMessage(`null`),
+		},
+	})
+
+	assert.Equal(t, []string{"custom_browser"}, req.CustomFields.Keys())
+	assert.Equal(t, "Chrome", req.CustomFields.String("custom_browser"))
+}
@@ -19,14 +19,18 @@ type MappingPair struct {
 }
 
 // SharedStepMapping holds the full mapping structure with project context.
+// Entity is empty for shared steps; other ID tables (see IDMapping) set it
+// to the kind of object they translate.
 type SharedStepMapping struct {
+	Entity       string        `json:"entity,omitempty"`
 	SrcProjectID int64         `json:"src_project_id"`
 	DstProjectID int64         `json:"dst_project_id"`
 	CreatedAt    time.Time     `json:"created_at"`
 	Count        int           `json:"count"`
 	Pairs        []MappingPair `json:"pairs"`
 
-	index map[int64]int64 // fast lookup (not exported)
+	index  map[int64]int64  // fast lookup (not exported)
+	status map[int64]string // pair status by source ID (not exported)
 }
 
 // NewSharedStepMapping creates a new SharedStepMapping for the given project pair.
@@ -38,6 +42,7 @@ func NewSharedStepMapping(srcProjectID, dstProjectID int64) *SharedStepMapping {
 		Count:        0,
 		Pairs:        make([]MappingPair, 0),
 		index:        make(map[int64]int64),
+		status:       make(map[int64]string),
 	}
 }
 
@@ -48,6 +53,7 @@ func (sm *SharedStepMapping) AddPair(sourceID, targetID int64, status string) {
 	}
 
 	sm.index[sourceID] = targetID
+	sm.status[sourceID] = status
 	sm.Pairs = append(sm.Pairs, MappingPair{
 		SourceID:  sourceID,
 		TargetID:  targetID,
@@ -63,6 +69,12 @@ func (sm *SharedStepMapping) GetTargetBySource(sourceID int64) (int64, bool) {
 	return targetID, ok
 }
 
+// Status returns the status of the pair for sourceID ("created" or
+// "existing"), or "" if the source ID is not mapped.
+func (sm *SharedStepMapping) Status(sourceID int64) string {
+	return sm.status[sourceID]
+}
+
 // SortPairs sorts pairs by source ID for consistent export output.
 func (sm *SharedStepMapping) SortPairs() {
 	sort.Slice(sm.Pairs, func(i, j int) bool {
@@ -86,7 +98,11 @@ func (sm *SharedStepMapping) Save(dir string) error {
 
 	sm.SortPairs()
 
-	file := filepath.Join(dir, fmt.Sprintf("mapping_%s.json", time.Now().Format("2006-01-02_15-04-05")))
+	name := "mapping"
+	if sm.Entity != "" {
+		name = "mapping_" + sm.Entity
+	}
+	file := filepath.Join(dir, fmt.Sprintf("%s_%s.json", name, time.Now().Format("2006-01-02_15-04-05")))
 	data := sm // marshal the entire struct
 
 	jsonData, err := json.MarshalIndent(data, "", "  ")
@@ -110,8 +126,10 @@ func LoadSharedStepMapping(file string) (*SharedStepMapping, error) {
 	}
 
 	sm.index = make(map[int64]int64)
+	sm.status = make(map[int64]string)
 	for _, p := range sm.Pairs {
 		sm.index[p.SourceID] = p.TargetID
+		sm.status[p.SourceID] = p.Status
 	}
 
 	return &sm, nil
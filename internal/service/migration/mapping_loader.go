@@ -29,6 +29,21 @@ func (m *Migration) LoadMappingFromFile(file string) error {
 	return fmt.Errorf("failed to load mapping from file %s", file)
 }
 
+// LoadCaseMappingFromFile loads a case mapping saved by an earlier run.
+// Cases found in it are updated in place instead of being created again.
+func (m *Migration) LoadCaseMappingFromFile(file string) error {
+	cm, err := LoadSharedStepMapping(file)
+	if err != nil {
+		return fmt.Errorf("failed to load case mapping from file %s: %w", file, err)
+	}
+	if cm.Entity != "" && cm.Entity != EntityCases {
+		return fmt.Errorf("file %s holds a %s mapping, not a case mapping", file, cm.Entity)
+	}
+	cm.Entity = EntityCases
+	m.cases = cm
+	return nil
+}
+
 func loadSimpleMapping(file string) (map[int64]int64, error) {
 	data, err := os.ReadFile(file)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSharedStepMapping_Save_MarshalError(t *testing.T) {
@@ -219,3 +220,31 @@ func TestSharedStepMapping_Save_EmptyDataCases(t *testing.T) {
 		})
 	}
 }
+
+func TestMigration_CaseMapping_SaveAndLoad(t *testing.T) {
+	m := setupTestMigration(t, &MockClient{})
+	m.cases.AddPair(1, 101, "created")
+
+	dir := t.TempDir()
+	require.NoError(t, m.ExportCaseMapping(dir))
+	files, _ := filepath.Glob(filepath.Join(dir, "mapping_cases_*.json"))
+	require.Len(t, files, 1)
+
+	next := setupTestMigration(t, &MockClient{})
+	require.NoError(t, next.LoadCaseMappingFromFile(files[0]))
+	got, ok := next.CaseMapping().GetTargetBySource(1)
+	assert.True(t, ok)
+	assert.Equal(t, int64(101), got)
+
+	m.mapping.AddPair(7, 70, "created")
+	require.NoError(t, m.mapping.Save(dir))
+	steps, _ := filepath.Glob(filepath.Join(dir, "mapping_2*.json"))
+	require.Len(t, steps, 1)
+	assert.NoError(t, next.LoadCaseMappingFromFile(steps[0]), "untagged mappings are accepted")
+
+	m.sections.AddPair(3, 30, "created")
+	require.NoError(t, m.sections.Save(dir))
+	sections, _ := filepath.Glob(filepath.Join(dir, "mapping_sections_*.json"))
+	require.Len(t, sections, 1)
+	assert.Error(t, next.LoadCaseMappingFromFile(sections[0]))
+}
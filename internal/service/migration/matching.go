@@ -0,0 +1,88 @@
+// internal/service/migration/matching.go
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Korrnals/gotr/internal/match"
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// keySectionPath is the compare key field holding a case's section path.
+const keySectionPath = "section_path"
+
+// SetMatching sets how duplicates are detected beyond an exact match of
+// compareField: normalization of the compared values, and a similarity
+// threshold above which near duplicates are logged for review.
+func (m *Migration) SetMatching(opts match.Options) {
+	m.matching = opts
+}
+
+// PrepareMatching loads what the compare key needs besides the items
+// themselves: the section paths of both suites for a key with section_path.
+func (m *Migration) PrepareMatching(ctx context.Context) error {
+	if !m.key().Has(keySectionPath) {
+		return nil
+	}
+	source, target, err := m.FetchSectionsData(ctx)
+	if err != nil {
+		return fmt.Errorf("load section paths for --compare-field: %w", err)
+	}
+	m.srcPaths = sectionPaths(source)
+	m.dstPaths = sectionPaths(target)
+	return nil
+}
+
+// key returns the parsed compareField; an invalid one matches nothing.
+func (m *Migration) key() match.Key {
+	k, err := match.ParseKey(m.compareField)
+	if err != nil {
+		return nil
+	}
+	return k
+}
+
+// matchKey returns the normalized duplicate-detection key of obj. paths are
+// the section paths of obj's side, used by the section_path key field.
+func (m *Migration) matchKey(obj interface{}, paths map[int64]string) string {
+	k := m.key()
+	if k == nil {
+		return ""
+	}
+	v := k.Value(func(name string) string {
+		if name == keySectionPath {
+			if c, ok := obj.(data.Case); ok {
+				return paths[c.SectionID]
+			}
+			return ""
+		}
+		return fieldValue(obj, name)
+	})
+	return m.matching.Normalize.Apply(v)
+}
+
+// logNearMatches logs the new items that look like an existing target item
+// without being equal to it. They are still imported.
+func (m *Migration) logNearMatches(kind string, items, targets []match.Item) {
+	if m.matching.Threshold <= 0 || len(items) == 0 || len(targets) == 0 {
+		return
+	}
+	opts := m.matching
+	opts.Normalize = match.Normalize{} // keys are normalized already
+	res, err := match.Match(items, targets, opts)
+	if err != nil {
+		m.logger.Warnw("Near-duplicate check skipped", "error", err)
+		return
+	}
+	if res.NearTruncated {
+		m.logger.Warnw("Near-duplicate check stopped early; some possible duplicates are not listed",
+			"kind", kind, "max_comparisons", match.DefaultMaxComparisons)
+	}
+	for _, p := range res.Near {
+		m.logger.Warnw("Possible duplicate "+kind+" — review before relying on the import",
+			"title", p.First.Name, "source_id", p.First.ID,
+			"similar_to", p.Second.Name, "target_id", p.Second.ID,
+			"score", fmt.Sprintf("%.2f", p.Score))
+	}
+}
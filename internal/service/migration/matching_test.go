@@ -0,0 +1,90 @@
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Korrnals/gotr/internal/match"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func filteredTitles(cases data.GetCasesResponse) []string {
+	titles := make([]string, 0, len(cases))
+	for _, c := range cases {
+		titles = append(titles, c.Title)
+	}
+	return titles
+}
+
+func TestFilterCases_Normalize(t *testing.T) {
+	source := data.GetCasesResponse{
+		{ID: 1, Title: "Login  with  SSO."},
+		{ID: 2, Title: "Logout"},
+	}
+	target := data.GetCasesResponse{{ID: 100, Title: "login with sso"}}
+
+	m := setupTestMigration(t, &MockClient{})
+	filtered, err := m.FilterCases(source, target)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Login  with  SSO.", "Logout"}, filteredTitles(filtered), "exact match by default")
+
+	m = setupTestMigration(t, &MockClient{})
+	m.SetMatching(match.Options{Normalize: match.Normalize{Whitespace: true, Case: true, Punctuation: true}})
+	filtered, err = m.FilterCases(source, target)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Logout"}, filteredTitles(filtered))
+	target100, ok := m.cases.GetTargetBySource(1)
+	assert.True(t, ok)
+	assert.Equal(t, int64(100), target100)
+}
+
+func TestFilterCases_CompositeKey(t *testing.T) {
+	mock := &MockClient{
+		GetSectionsFunc: func(ctx context.Context, projectID, suiteID int64) (data.GetSectionsResponse, error) {
+			if suiteID == 10 { // source suite
+				return data.GetSectionsResponse{{ID: 1, Name: "Auth"}, {ID: 2, Name: "Billing"}}, nil
+			}
+			return data.GetSectionsResponse{{ID: 7, Name: "Auth"}}, nil
+		},
+	}
+	source := data.GetCasesResponse{
+		{ID: 1, Title: "Smoke", SectionID: 1},
+		{ID: 2, Title: "Smoke", SectionID: 2},
+		{ID: 3, Title: "Refund", Refs: "TR-1", SectionID: 2},
+	}
+	target := data.GetCasesResponse{{ID: 100, Title: "Smoke", SectionID: 7}}
+
+	m := setupTestMigration(t, mock)
+	m.compareField = "section_path+title"
+	require.NoError(t, m.PrepareMatching(context.Background()))
+	filtered, err := m.FilterCases(source, target)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 3}, []int64{filtered[0].ID, filtered[1].ID},
+		"a case with the same title in another section is not a duplicate")
+
+	m = setupTestMigration(t, mock)
+	m.compareField = "refs+title"
+	require.NoError(t, m.PrepareMatching(context.Background()))
+	filtered, err = m.FilterCases(source, data.GetCasesResponse{{ID: 200, Title: "Refund", Refs: "TR-1"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Smoke", "Smoke"}, filteredTitles(filtered))
+}
+
+func TestFilterCases_NearDuplicatesAreImported(t *testing.T) {
+	m := setupTestMigration(t, &MockClient{})
+	m.SetMatching(match.Options{Threshold: 0.8})
+
+	filtered, err := m.FilterCases(
+		data.GetCasesResponse{{ID: 1, Title: "Checkout with coupon"}},
+		data.GetCasesResponse{{ID: 100, Title: "Checkout with coupons"}},
+	)
+	require.NoError(t, err)
+	assert.Len(t, filtered, 1, "near duplicates are only logged for review")
+}
+
+func TestFieldValue_SnakeCase(t *testing.T) {
+	assert.Equal(t, "5", fieldValue(data.Case{SectionID: 5}, "section_id"))
+	assert.Equal(t, "pre", fieldValue(data.Case{CustomPreconds: "pre"}, "custom_preconds"))
+}
@@ -12,7 +12,7 @@ func (m *Migration) MigrateSharedSteps(ctx context.Context, dryRun bool) error {
 		return err
 	}
 
-	sourceCases, err := m.Client.GetCases(ctx, m.srcProject, m.srcSuite, 0)
+	sourceCases, err := m.sourceCases(ctx)
 	if err != nil {
 		return err
 	}
@@ -49,6 +49,9 @@ func (m *Migration) MigrateCases(ctx context.Context, dryRun bool) error {
 	if err != nil {
 		return err
 	}
+	if err := m.PrepareMatching(ctx); err != nil {
+		return err
+	}
 
 	filtered, _ := m.FilterCases(source, target)
 
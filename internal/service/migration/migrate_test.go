@@ -31,7 +31,7 @@ func TestMigration_MigrateSuites(t *testing.T) {
 		err := m.MigrateSuites(context.Background(), false)
 		assert.NoError(t, err)
 
-		id, exists := m.mapping.GetTargetBySource(10)
+		id, exists := m.suites.GetTargetBySource(10)
 		assert.True(t, exists)
 		assert.Equal(t, int64(100), id)
 	})
@@ -87,6 +87,29 @@ func TestMigration_MigrateSharedSteps(t *testing.T) {
 		assert.Contains(t, err.Error(), "source_cases_for_shared_steps_error")
 		assert.Equal(t, 1, getCasesCalls)
 	})
+
+	t.Run("Source cases are fetched once for the field check, shared steps and cases", func(t *testing.T) {
+		sourceCalls := 0
+		mock := &MockClient{
+			GetCasesFunc: func(ctx context.Context, p, s, sec int64) (data.GetCasesResponse, error) {
+				if p == 1 {
+					sourceCalls++
+					return data.GetCasesResponse{{ID: 10, Title: "Case"}}, nil
+				}
+				return data.GetCasesResponse{}, nil
+			},
+		}
+
+		m := setupTestMigration(t, mock)
+		_, err := m.CheckSuiteCaseFields(context.Background())
+		assert.NoError(t, err)
+		assert.NoError(t, m.MigrateSharedSteps(context.Background(), true))
+		source, _, err := m.FetchCasesData(context.Background())
+		assert.NoError(t, err)
+
+		assert.Len(t, source, 1)
+		assert.Equal(t, 1, sourceCalls)
+	})
 }
 
 // TestMigration_MigrateSections verifies migration behavior for sections
@@ -0,0 +1,191 @@
+// internal/service/migration/sections.go
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Korrnals/gotr/internal/match"
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// sectionPathSep joins names in a section path; it cannot appear in a name.
+const sectionPathSep = "\x00"
+
+// caseSectionID returns the destination section for a source case section.
+// Cases without a section go to the destination suite container as before.
+func (m *Migration) caseSectionID(sourceSectionID int64) (int64, bool) {
+	if sourceSectionID == 0 {
+		return m.dstSuite, true
+	}
+	return m.sections.GetTargetBySource(sourceSectionID)
+}
+
+// ensureCaseSections makes sure every section referenced by cases has a
+// destination counterpart. Unmapped sections are matched by path in the
+// destination suite, and the rest are created together with their ancestors.
+func (m *Migration) ensureCaseSections(ctx context.Context, cases data.GetCasesResponse) error {
+	missing := make(map[int64]bool)
+	for _, c := range cases {
+		if c.SectionID == 0 {
+			continue
+		}
+		if _, ok := m.sections.GetTargetBySource(c.SectionID); !ok {
+			missing[c.SectionID] = true
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	m.logger.Infow("Resolving destination sections for cases", "unmapped", len(missing))
+	source, target, err := m.FetchSectionsData(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load sections for case placement: %w", err)
+	}
+	filtered, err := m.FilterSections(source, target)
+	if err != nil {
+		return err
+	}
+	return m.ImportSections(ctx, withAncestors(filtered, missing), false)
+}
+
+// withAncestors returns the sections with the given IDs plus all of their
+// ancestors from the same list, in list order.
+func withAncestors(sections data.GetSectionsResponse, ids map[int64]bool) data.GetSectionsResponse {
+	byID := make(map[int64]data.Section, len(sections))
+	for _, s := range sections {
+		byID[s.ID] = s
+	}
+
+	keep := make(map[int64]bool)
+	for id := range ids {
+		for s, ok := byID[id]; ok && !keep[s.ID]; s, ok = byID[s.ParentID] {
+			keep[s.ID] = true
+		}
+	}
+
+	var res data.GetSectionsResponse
+	for _, s := range sections {
+		if keep[s.ID] {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// sectionPaths returns the full name path (root → section) of every section.
+// A parent that is not in the list starts a new root.
+func sectionPaths(sections data.GetSectionsResponse) map[int64]string {
+	return match.SectionPaths(sections, sectionPathSep)
+}
+
+// sectionLevels splits sections into levels so that every parent from the
+// list comes before its children. Within a level, siblings keep display order.
+func sectionLevels(sections data.GetSectionsResponse) []data.GetSectionsResponse {
+	byID := make(map[int64]data.Section, len(sections))
+	for _, s := range sections {
+		byID[s.ID] = s
+	}
+
+	depths := make(map[int64]int, len(sections))
+	var depth func(id int64, seen map[int64]bool) int
+	depth = func(id int64, seen map[int64]bool) int {
+		if d, ok := depths[id]; ok {
+			return d
+		}
+		d := 0
+		s := byID[id]
+		if _, ok := byID[s.ParentID]; ok && s.ParentID != 0 && !seen[s.ParentID] {
+			seen[id] = true
+			d = depth(s.ParentID, seen) + 1
+		}
+		depths[id] = d
+		return d
+	}
+
+	var levels []data.GetSectionsResponse
+	for _, s := range sections {
+		d := depth(s.ID, map[int64]bool{})
+		for len(levels) <= d {
+			levels = append(levels, nil)
+		}
+		levels[d] = append(levels[d], s)
+	}
+	for _, level := range levels {
+		sortSiblings(level)
+	}
+	return levels
+}
+
+// groupByParent splits a level into sibling groups, in order of first appearance.
+func groupByParent(level data.GetSectionsResponse) []data.GetSectionsResponse {
+	index := make(map[int64]int)
+	var groups []data.GetSectionsResponse
+	for _, s := range level {
+		i, ok := index[s.ParentID]
+		if !ok {
+			i = len(groups)
+			index[s.ParentID] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], s)
+	}
+	return groups
+}
+
+func sortSiblings(sections data.GetSectionsResponse) {
+	sort.SliceStable(sections, func(i, j int) bool {
+		if sections[i].ParentID != sections[j].ParentID {
+			return sections[i].ParentID < sections[j].ParentID
+		}
+		return sections[i].DisplayOrder < sections[j].DisplayOrder
+	})
+}
+
+// FormatSectionTree renders the source section tree as indented text.
+// Sections from filtered (to be created) are marked "+", the rest "=" (already
+// present in the target and reused).
+func FormatSectionTree(source, filtered data.GetSectionsResponse) string {
+	toCreate := make(map[int64]bool, len(filtered))
+	for _, s := range filtered {
+		toCreate[s.ID] = true
+	}
+
+	known := make(map[int64]bool, len(source))
+	for _, s := range source {
+		known[s.ID] = true
+	}
+	children := make(map[int64]data.GetSectionsResponse)
+	for _, s := range source {
+		parent := s.ParentID
+		if !known[parent] {
+			parent = 0
+		}
+		children[parent] = append(children[parent], s)
+	}
+
+	var b strings.Builder
+	visited := make(map[int64]bool, len(source))
+	var walk func(parent int64, indent int)
+	walk = func(parent int64, indent int) {
+		siblings := children[parent]
+		sort.SliceStable(siblings, func(i, j int) bool { return siblings[i].DisplayOrder < siblings[j].DisplayOrder })
+		for _, s := range siblings {
+			if visited[s.ID] {
+				continue
+			}
+			visited[s.ID] = true
+			marker := "="
+			if toCreate[s.ID] {
+				marker = "+"
+			}
+			fmt.Fprintf(&b, "%s%s %s\n", strings.Repeat("  ", indent), marker, s.Name)
+			walk(s.ID, indent+1)
+		}
+	}
+	walk(0, 0)
+	return b.String()
+}
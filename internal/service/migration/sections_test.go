@@ -0,0 +1,206 @@
+package migration
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sectionTree is a small source tree:
+//
+//	Root
+//	  Child B (order 2)
+//	  Child A (order 1)
+//	    Leaf
+//	Other
+func sectionTree() data.GetSectionsResponse {
+	return data.GetSectionsResponse{
+		{ID: 4, Name: "Leaf", ParentID: 3, SuiteID: 10},
+		{ID: 2, Name: "Child B", ParentID: 1, SuiteID: 10, DisplayOrder: 2},
+		{ID: 3, Name: "Child A", ParentID: 1, SuiteID: 10, DisplayOrder: 1},
+		{ID: 1, Name: "Root", SuiteID: 10, DisplayOrder: 1},
+		{ID: 5, Name: "Other", SuiteID: 10, DisplayOrder: 2},
+	}
+}
+
+func TestSectionLevels_ParentsBeforeChildren(t *testing.T) {
+	levels := sectionLevels(sectionTree())
+	require.Len(t, levels, 3)
+
+	names := func(l data.GetSectionsResponse) []string {
+		res := make([]string, 0, len(l))
+		for _, s := range l {
+			res = append(res, s.Name)
+		}
+		return res
+	}
+	assert.Equal(t, []string{"Root", "Other"}, names(levels[0]))
+	assert.Equal(t, []string{"Child A", "Child B"}, names(levels[1]))
+	assert.Equal(t, []string{"Leaf"}, names(levels[2]))
+}
+
+func TestSectionLevels_CycleDoesNotHang(t *testing.T) {
+	levels := sectionLevels(data.GetSectionsResponse{
+		{ID: 1, Name: "A", ParentID: 2},
+		{ID: 2, Name: "B", ParentID: 1},
+	})
+	total := 0
+	for _, l := range levels {
+		total += len(l)
+	}
+	assert.Equal(t, 2, total)
+}
+
+func TestSectionPaths(t *testing.T) {
+	paths := sectionPaths(sectionTree())
+	assert.Equal(t, "Root", paths[1])
+	assert.Equal(t, "Root"+sectionPathSep+"Child A"+sectionPathSep+"Leaf", paths[4])
+	assert.Equal(t, "Other", paths[5])
+}
+
+func TestFilterSections_MatchesByPath(t *testing.T) {
+	m := setupTestMigration(t, &MockClient{})
+
+	source := data.GetSectionsResponse{
+		{ID: 1, Name: "API"},
+		{ID: 2, Name: "Login", ParentID: 1},
+		{ID: 3, Name: "UI"},
+		{ID: 4, Name: "Login", ParentID: 3},
+	}
+	target := data.GetSectionsResponse{
+		{ID: 100, Name: "API"},
+		{ID: 101, Name: "Login", ParentID: 100},
+	}
+
+	filtered, err := m.FilterSections(source, target)
+	require.NoError(t, err)
+
+	ids := make([]int64, 0, len(filtered))
+	for _, s := range filtered {
+		ids = append(ids, s.ID)
+	}
+	assert.Equal(t, []int64{3, 4}, ids)
+
+	got, ok := m.sections.GetTargetBySource(2)
+	assert.True(t, ok)
+	assert.Equal(t, int64(101), got)
+}
+
+func TestFilterSections_KeepsSectionsOutOfSharedStepMapping(t *testing.T) {
+	m := setupTestMigration(t, &MockClient{})
+
+	_, err := m.FilterSections(
+		data.GetSectionsResponse{{ID: 55, Name: "Login"}},
+		data.GetSectionsResponse{{ID: 155, Name: "Login"}},
+	)
+	require.NoError(t, err)
+
+	// Shared step 55 is unmapped; section 55 must not rewrite it.
+	req := m.buildCaseRequest(data.Case{Title: "c", CustomStepsSeparated: []data.Step{{SharedStepID: 55}}})
+	require.Len(t, req.CustomStepsSeparated, 1)
+	assert.Equal(t, int64(55), req.CustomStepsSeparated[0].SharedStepID)
+	assert.Zero(t, m.mapping.Count)
+}
+
+func TestMigration_ImportSections_PreservesHierarchy(t *testing.T) {
+	var mu sync.Mutex
+	var nextID int64 = 100
+	created := make(map[string]*data.AddSectionRequest)
+	createdIDs := make(map[string]int64)
+	var order []string
+
+	mock := &MockClient{
+		AddSectionFunc: func(ctx context.Context, projectID int64, req *data.AddSectionRequest) (*data.Section, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			nextID++
+			created[req.Name] = req
+			createdIDs[req.Name] = nextID
+			order = append(order, req.Name)
+			return &data.Section{ID: nextID, Name: req.Name}, nil
+		},
+	}
+
+	m, err := NewMigration(mock, 1, 10, 2, 20, "title", logDir())
+	require.NoError(t, err)
+	defer m.Close()
+
+	// "Other" already exists in the target; its new child hangs under it.
+	m.sections.AddPair(5, 500, "existing")
+	filtered := append(sectionTree()[:4:4], data.Section{ID: 6, Name: "Nested", ParentID: 5, SuiteID: 10})
+
+	require.NoError(t, m.ImportSections(context.Background(), filtered, false))
+	require.Len(t, created, 5)
+
+	assert.Equal(t, int64(0), created["Root"].ParentID)
+	assert.Equal(t, createdIDs["Root"], created["Child A"].ParentID)
+	assert.Equal(t, createdIDs["Root"], created["Child B"].ParentID)
+	assert.Equal(t, createdIDs["Child A"], created["Leaf"].ParentID)
+	assert.Equal(t, int64(500), created["Nested"].ParentID)
+	for name, req := range created {
+		assert.Equal(t, int64(20), req.SuiteID, name)
+	}
+
+	pos := make(map[string]int, len(order))
+	for i, name := range order {
+		pos[name] = i
+	}
+	assert.Less(t, pos["Root"], pos["Child A"])
+	assert.Less(t, pos["Child A"], pos["Child B"])
+	assert.Less(t, pos["Child A"], pos["Leaf"])
+}
+
+func TestMigration_ImportSections_SkipsChildrenOfFailedParent(t *testing.T) {
+	var mu sync.Mutex
+	var names []string
+	mock := &MockClient{
+		AddSectionFunc: func(ctx context.Context, projectID int64, req *data.AddSectionRequest) (*data.Section, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			names = append(names, req.Name)
+			if req.Name == "Root" {
+				return nil, assert.AnError
+			}
+			return &data.Section{ID: 200}, nil
+		},
+	}
+
+	m, err := NewMigration(mock, 1, 10, 2, 20, "title", logDir())
+	require.NoError(t, err)
+	defer m.Close()
+
+	require.NoError(t, m.ImportSections(context.Background(), sectionTree(), false))
+	assert.ElementsMatch(t, []string{"Root", "Other"}, names)
+	assert.Equal(t, 1, m.sections.Count)
+}
+
+func TestFormatSectionTree(t *testing.T) {
+	source := sectionTree()
+	filtered := data.GetSectionsResponse{source[0], source[1]} // Leaf, Child B
+
+	got := FormatSectionTree(source, filtered)
+	want := strings.Join([]string{
+		"= Root",
+		"  = Child A",
+		"    + Leaf",
+		"  + Child B",
+		"= Other",
+		"",
+	}, "\n")
+	assert.Equal(t, want, got)
+}
+
+func TestWithAncestors(t *testing.T) {
+	got := withAncestors(sectionTree(), map[int64]bool{4: true})
+	ids := make([]int64, 0, len(got))
+	for _, s := range got {
+		ids = append(ids, s.ID)
+	}
+	assert.Equal(t, []int64{4, 3, 1}, ids)
+}
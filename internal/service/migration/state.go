@@ -0,0 +1,144 @@
+// internal/service/migration/state.go
+package migration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// SyncState is the persisted state of incremental sync between one source
+// and one destination suite: the time of the last successful run and the
+// source→destination ID maps built so far.
+type SyncState struct {
+	SrcProject int64     `json:"src_project_id"`
+	SrcSuite   int64     `json:"src_suite_id"`
+	DstProject int64     `json:"dst_project_id"`
+	DstSuite   int64     `json:"dst_suite_id"`
+	LastSync   time.Time `json:"last_sync"`
+
+	Cases         map[int64]int64 `json:"cases"`                    // cases created by gotr, updated in place
+	ExistingCases map[int64]int64 `json:"existing_cases,omitempty"` // duplicates already in the destination
+	Sections      map[int64]int64 `json:"sections"`
+	Mapping       map[int64]int64 `json:"mapping"` // shared step mapping, as saved by ExportMapping
+}
+
+// SyncStateFile returns the state file path for a source/destination pair.
+// Profile names keep pairs on different TestRail servers apart.
+func SyncStateFile(dir, srcProfile string, srcProject, srcSuite int64, dstProfile string, dstProject, dstSuite int64) string {
+	side := func(profile string, project, suite int64) string {
+		if profile != "" {
+			return fmt.Sprintf("%s-%d-%d", profile, project, suite)
+		}
+		return fmt.Sprintf("%d-%d", project, suite)
+	}
+	return filepath.Join(dir, fmt.Sprintf("state_%s_to_%s.json", side(srcProfile, srcProject, srcSuite), side(dstProfile, dstProject, dstSuite)))
+}
+
+// LoadSyncState reads a state file. A missing file yields nil and no error
+// (first run).
+func LoadSyncState(file string) (*SyncState, error) {
+	content, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync state %s: %w", file, err)
+	}
+	var st SyncState
+	if err := json.Unmarshal(content, &st); err != nil {
+		return nil, fmt.Errorf("failed to parse sync state %s: %w", file, err)
+	}
+	return &st, nil
+}
+
+// Save writes the state to file, creating the directory if needed.
+func (st *SyncState) Save(file string) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, content, 0o644)
+}
+
+// ApplySyncState seeds the migration mappings from a previous run, so that
+// mapped cases are updated in place and their sections reused.
+func (m *Migration) ApplySyncState(st *SyncState) {
+	if st == nil {
+		return
+	}
+	for src, dst := range st.Mapping {
+		m.mapping.AddPair(src, dst, "existing")
+	}
+	for src, dst := range st.Sections {
+		m.sections.AddPair(src, dst, "existing")
+	}
+	for src, dst := range st.Cases {
+		m.cases.AddPair(src, dst, "created")
+	}
+	for src, dst := range st.ExistingCases {
+		m.cases.AddPair(src, dst, "existing")
+	}
+}
+
+// SyncState returns the state to persist with lastSync as the checkpoint.
+func (m *Migration) SyncState(lastSync time.Time) *SyncState {
+	return &SyncState{
+		SrcProject:    m.srcProject,
+		SrcSuite:      m.srcSuite,
+		DstProject:    m.dstProject,
+		DstSuite:      m.dstSuite,
+		LastSync:      lastSync,
+		Cases:         copyCasePairs(m.cases, false),
+		ExistingCases: copyCasePairs(m.cases, true),
+		Sections:      copyIndex(m.sections),
+		Mapping:       copyIndex(m.mapping),
+	}
+}
+
+// LatestCaseUpdate returns the newest updated_on of cases, or since if no
+// case is newer. The checkpoint comes from server timestamps, so the local
+// clock and the time spent syncing do not matter.
+func LatestCaseUpdate(cases data.GetCasesResponse, since time.Time) time.Time {
+	latest := since
+	for _, c := range cases {
+		if t := time.Unix(c.UpdatedOn, 0); t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+// copyCasePairs returns the pairs of mp recorded as "existing", or all
+// other pairs.
+func copyCasePairs(mp *IDMapping, existing bool) map[int64]int64 {
+	res := make(map[int64]int64)
+	if mp == nil {
+		return res
+	}
+	for _, p := range mp.Pairs {
+		if (p.Status == "existing") == existing {
+			res[p.SourceID] = p.TargetID
+		}
+	}
+	return res
+}
+
+func copyIndex(mp *IDMapping) map[int64]int64 {
+	res := make(map[int64]int64)
+	if mp == nil {
+		return res
+	}
+	for k, v := range mp.index {
+		res[k] = v
+	}
+	return res
+}
@@ -0,0 +1,108 @@
+package migration
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncStateFile(t *testing.T) {
+	assert.Equal(t, filepath.Join("dir", "state_1-2_to_3-4.json"), SyncStateFile("dir", "", 1, 2, "", 3, 4))
+	assert.Equal(t, filepath.Join("dir", "state_old-1-2_to_new-3-4.json"), SyncStateFile("dir", "old", 1, 2, "new", 3, 4))
+}
+
+func TestLoadSyncState_Missing(t *testing.T) {
+	st, err := LoadSyncState(filepath.Join(t.TempDir(), "state.json"))
+	require.NoError(t, err)
+	assert.Nil(t, st)
+}
+
+func TestLoadSyncState_Invalid(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, os.WriteFile(file, []byte("{broken"), 0o644))
+
+	_, err := LoadSyncState(file)
+	assert.Error(t, err)
+}
+
+func TestSyncState_RoundTrip(t *testing.T) {
+	m := setupTestMigration(t, &MockClient{})
+	m.mapping.AddPair(100, 200, "created")
+	m.sections.AddPair(10, 20, "created")
+	m.cases.AddPair(1, 2, "created")
+	m.cases.AddPair(3, 4, "existing")
+
+	startedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	file := filepath.Join(t.TempDir(), "sync", "state.json")
+	require.NoError(t, m.SyncState(startedAt).Save(file))
+
+	st, err := LoadSyncState(file)
+	require.NoError(t, err)
+	require.NotNil(t, st)
+	assert.True(t, startedAt.Equal(st.LastSync))
+	assert.Equal(t, map[int64]int64{1: 2}, st.Cases)
+	assert.Equal(t, map[int64]int64{3: 4}, st.ExistingCases)
+	assert.Equal(t, map[int64]int64{10: 20}, st.Sections)
+	assert.Equal(t, int64(200), st.Mapping[100])
+
+	next := setupTestMigration(t, &MockClient{})
+	next.ApplySyncState(st)
+	dst, ok := next.cases.GetTargetBySource(1)
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), dst)
+	_, ok = next.caseUpdateTarget(3)
+	assert.False(t, ok, "existing duplicates are not updated in place")
+	dst, ok = next.sections.GetTargetBySource(10)
+	assert.True(t, ok)
+	assert.Equal(t, int64(20), dst)
+	dst, ok = next.mapping.GetTargetBySource(100)
+	assert.True(t, ok)
+	assert.Equal(t, int64(200), dst)
+}
+
+func TestLatestCaseUpdate(t *testing.T) {
+	cases := data.GetCasesResponse{
+		{ID: 1, UpdatedOn: 999},
+		{ID: 2, UpdatedOn: 2000},
+		{ID: 3, UpdatedOn: 1500},
+	}
+
+	assert.Equal(t, int64(2000), LatestCaseUpdate(cases, time.Time{}).Unix())
+	assert.Equal(t, int64(3000), LatestCaseUpdate(cases, time.Unix(3000, 0)).Unix())
+	assert.Equal(t, int64(3000), LatestCaseUpdate(nil, time.Unix(3000, 0)).Unix())
+}
+
+func TestFetchCasesUpdatedSince_UsesServerFilter(t *testing.T) {
+	var filter data.CaseFilter
+	mock := &MockClient{
+		GetCasesFilteredFunc: func(ctx context.Context, projectID int64, f data.CaseFilter) (data.GetCasesResponse, error) {
+			assert.Equal(t, int64(1), projectID)
+			filter = f
+			// Case 6 was updated in the checkpoint second; pages may repeat a case.
+			return data.GetCasesResponse{{ID: 6, UpdatedOn: 1000}, {ID: 7, UpdatedOn: 2000}, {ID: 7, UpdatedOn: 2000}}, nil
+		},
+		GetCasesFunc: func(ctx context.Context, projectID, suiteID, sectionID int64) (data.GetCasesResponse, error) {
+			if projectID == 1 {
+				t.Fatal("source suite must not be loaded in full")
+			}
+			return data.GetCasesResponse{{ID: 70}}, nil
+		},
+	}
+	m := setupTestMigration(t, mock)
+
+	source, target, err := m.FetchCasesUpdatedSince(context.Background(), time.Unix(1000, 0))
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), filter.SuiteID)
+	assert.Equal(t, int64(999), filter.UpdatedAfter.Unix(), "updated_after is exclusive")
+	require.Len(t, source, 2)
+	assert.Equal(t, int64(6), source[0].ID)
+	assert.Equal(t, int64(7), source[1].ID)
+	require.Len(t, target, 1)
+}
@@ -0,0 +1,238 @@
+// internal/service/migration/translate.go
+package migration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// IDMapping is a source→target ID table for one kind of object.
+// It reuses the shared step mapping machinery (pairs with status, fast
+// lookup, JSON export); Entity names the kind of object.
+type IDMapping = SharedStepMapping
+
+// Entity kinds translated between TestRail instances.
+const (
+	EntityUsers      = "users"
+	EntityPriorities = "priorities"
+	EntityCaseTypes  = "case_types"
+	EntityTemplates  = "templates"
+	EntityStatuses   = "statuses"
+)
+
+// Entity kinds of project data created by the migration itself.
+const (
+	EntitySuites   = "suites"
+	EntitySections = "sections"
+	EntityCases    = "cases"
+)
+
+// EntityMappings holds ID translation tables for objects whose IDs differ
+// between two TestRail servers. Objects are matched by a stable natural key:
+// users by email, everything else by name. Case requests get their type,
+// priority and template translated (see translateCaseRequest) and user IDs
+// in custom fields are translated through Users; custom fields and their
+// options are mapped by system name in FieldMapping. Statuses are matched by
+// name too and saved with the other tables, for translating result statuses.
+type EntityMappings struct {
+	Users      *IDMapping
+	Priorities *IDMapping
+	CaseTypes  *IDMapping
+	Templates  *IDMapping
+	Statuses   *IDMapping
+
+	// Destination defaults used when a source ID has no counterpart.
+	DefaultPriorityID int64
+	DefaultCaseTypeID int64
+	DefaultTemplateID int64
+
+	// Unmatched lists source keys without a destination counterpart, per entity.
+	Unmatched map[string][]string
+}
+
+// All returns the non-nil tables in a fixed order.
+func (e *EntityMappings) All() []*IDMapping {
+	all := []*IDMapping{e.Users, e.Priorities, e.CaseTypes, e.Templates, e.Statuses}
+	res := make([]*IDMapping, 0, len(all))
+	for _, mp := range all {
+		if mp != nil {
+			res = append(res, mp)
+		}
+	}
+	return res
+}
+
+// Save writes every table to its own mapping_<entity>_<timestamp>.json file in dir.
+func (e *EntityMappings) Save(dir string) error {
+	for _, mp := range e.All() {
+		if err := mp.Save(dir); err != nil {
+			return fmt.Errorf("failed to save %s mapping: %w", mp.Entity, err)
+		}
+	}
+	return nil
+}
+
+// EntityMappings returns the cross-instance translation tables, or nil if
+// they have not been built (same-instance sync).
+func (m *Migration) EntityMappings() *EntityMappings {
+	return m.entities
+}
+
+// BuildEntityMappings loads users, priorities, case types, templates and
+// statuses from both instances and matches them. It is a no-op unless the migration is
+// cross-instance; the result is cached.
+func (m *Migration) BuildEntityMappings(ctx context.Context) (*EntityMappings, error) {
+	if !m.IsCrossInstance() {
+		return nil, nil
+	}
+	if m.entities != nil {
+		return m.entities, nil
+	}
+
+	m.logger.Info("Building cross-instance ID mappings")
+	src, dst := m.src(), m.Client
+	e := &EntityMappings{Unmatched: make(map[string][]string)}
+
+	srcUsers, err := src.GetUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source users: %w", err)
+	}
+	dstUsers, err := dst.GetUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get destination users: %w", err)
+	}
+	e.Users = matchEntities(e, EntityUsers, m.srcProject, m.dstProject, srcUsers, dstUsers,
+		func(u data.User) int64 { return u.ID },
+		func(u data.User) string { return u.Email })
+
+	srcPriorities, err := src.GetPriorities(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source priorities: %w", err)
+	}
+	dstPriorities, err := dst.GetPriorities(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get destination priorities: %w", err)
+	}
+	e.Priorities = matchEntities(e, EntityPriorities, m.srcProject, m.dstProject, srcPriorities, dstPriorities,
+		func(p data.Priority) int64 { return p.ID },
+		func(p data.Priority) string { return p.Name })
+	for _, p := range dstPriorities {
+		if p.IsDefault {
+			e.DefaultPriorityID = p.ID
+		}
+	}
+
+	srcTypes, err := src.GetCaseTypes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source case types: %w", err)
+	}
+	dstTypes, err := dst.GetCaseTypes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get destination case types: %w", err)
+	}
+	type caseType = struct {
+		ID        int64  `json:"id"`
+		IsDefault bool   `json:"is_default"`
+		Name      string `json:"name"`
+	}
+	e.CaseTypes = matchEntities(e, EntityCaseTypes, m.srcProject, m.dstProject, []caseType(srcTypes), []caseType(dstTypes),
+		func(t caseType) int64 { return t.ID },
+		func(t caseType) string { return t.Name })
+	for _, t := range dstTypes {
+		if t.IsDefault {
+			e.DefaultCaseTypeID = t.ID
+		}
+	}
+
+	srcTemplates, err := src.GetTemplates(ctx, m.srcProject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source templates: %w", err)
+	}
+	dstTemplates, err := dst.GetTemplates(ctx, m.dstProject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get destination templates: %w", err)
+	}
+	e.Templates = matchEntities(e, EntityTemplates, m.srcProject, m.dstProject, srcTemplates, dstTemplates,
+		func(t data.Template) int64 { return t.ID },
+		func(t data.Template) string { return t.Name })
+	for _, t := range dstTemplates {
+		if t.IsDefault {
+			e.DefaultTemplateID = t.ID
+		}
+	}
+
+	srcStatuses, err := src.GetStatuses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source statuses: %w", err)
+	}
+	dstStatuses, err := dst.GetStatuses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get destination statuses: %w", err)
+	}
+	e.Statuses = matchEntities(e, EntityStatuses, m.srcProject, m.dstProject, srcStatuses, dstStatuses,
+		func(s data.Status) int64 { return s.ID },
+		func(s data.Status) string { return s.Name })
+
+	for entity, keys := range e.Unmatched {
+		m.logger.Warnw("Source objects without a destination counterpart", "entity", entity, "keys", keys)
+	}
+	for _, mp := range e.All() {
+		m.logger.Infow("ID mapping built", "entity", mp.Entity, "pairs", mp.Count)
+	}
+
+	m.entities = e
+	return e, nil
+}
+
+// matchEntities pairs source and destination objects by a case-insensitive key.
+func matchEntities[T any](e *EntityMappings, entity string, srcProject, dstProject int64, src, dst []T, id func(T) int64, key func(T) string) *IDMapping {
+	mp := newIDMapping(entity, srcProject, dstProject)
+	dstIDs := make(map[string]int64, len(dst))
+	for _, d := range dst {
+		if k := normalizeKey(key(d)); k != "" {
+			dstIDs[k] = id(d)
+		}
+	}
+	for _, s := range src {
+		if targetID, ok := dstIDs[normalizeKey(key(s))]; ok {
+			mp.AddPair(id(s), targetID, "existing")
+		} else {
+			e.Unmatched[entity] = append(e.Unmatched[entity], key(s))
+		}
+	}
+	return mp
+}
+
+func newIDMapping(entity string, srcProject, dstProject int64) *IDMapping {
+	mp := NewSharedStepMapping(srcProject, dstProject)
+	mp.Entity = entity
+	return mp
+}
+
+func normalizeKey(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// translate returns the destination ID for sourceID, or fallback if unmapped.
+func translate(mp *IDMapping, sourceID, fallback int64) int64 {
+	if sourceID == 0 || mp == nil {
+		return fallback
+	}
+	if targetID, ok := mp.GetTargetBySource(sourceID); ok {
+		return targetID
+	}
+	return fallback
+}
+
+// translateCaseRequest rewrites instance-specific IDs of a case request for
+// the destination server. Milestones are project data that is not migrated,
+// so the reference is dropped.
+func (e *EntityMappings) translateCaseRequest(req *data.AddCaseRequest) {
+	req.TypeID = translate(e.CaseTypes, req.TypeID, e.DefaultCaseTypeID)
+	req.PriorityID = translate(e.Priorities, req.PriorityID, e.DefaultPriorityID)
+	req.TemplateID = translate(e.Templates, req.TemplateID, e.DefaultTemplateID)
+	req.MilestoneID = 0
+}
@@ -0,0 +1,129 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// instanceMock returns a mock of one TestRail server with the given ID offset,
+// so the same objects get different IDs on source and destination.
+func instanceMock(offset int64, extraPriority bool) *MockClient {
+	mock := &MockClient{
+		GetUsersFunc: func(ctx context.Context) (data.GetUsersResponse, error) {
+			return data.GetUsersResponse{{ID: offset + 1, Email: "QA@example.com"}}, nil
+		},
+		GetPrioritiesFunc: func(ctx context.Context) (data.GetPrioritiesResponse, error) {
+			res := data.GetPrioritiesResponse{
+				{ID: offset + 1, Name: "Medium", IsDefault: true},
+				{ID: offset + 2, Name: "High"},
+			}
+			if extraPriority {
+				res = append(res, data.Priority{ID: offset + 3, Name: "Blocker"})
+			}
+			return res, nil
+		},
+		GetCaseTypesFunc: func(ctx context.Context) (data.GetCaseTypesResponse, error) {
+			return data.GetCaseTypesResponse{
+				{ID: offset + 1, Name: "Other", IsDefault: true},
+				{ID: offset + 2, Name: "Functional"},
+			}, nil
+		},
+		GetTemplatesFunc: func(ctx context.Context, projectID int64) (data.GetTemplatesResponse, error) {
+			return data.GetTemplatesResponse{{ID: offset + 1, Name: "Test Case (Steps)", IsDefault: true}}, nil
+		},
+		GetStatusesFunc: func(ctx context.Context) (data.GetStatusesResponse, error) {
+			return data.GetStatusesResponse{{ID: offset + 1, Name: "passed"}, {ID: offset + 5, Name: "failed"}}, nil
+		},
+		GetCaseFieldsFunc: func(ctx context.Context) (data.GetCaseFieldsResponse, error) {
+			var res data.GetCaseFieldsResponse
+			raw := fmt.Sprintf(`[{"id": %d, "system_name": "custom_preconds"}]`, offset+1)
+			if err := json.Unmarshal([]byte(raw), &res); err != nil {
+				return nil, err
+			}
+			return res, nil
+		},
+	}
+	return mock
+}
+
+func TestBuildEntityMappings_SameInstance_NoOp(t *testing.T) {
+	m := setupTestMigration(t, &MockClient{})
+	e, err := m.BuildEntityMappings(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, e)
+	assert.False(t, m.IsCrossInstance())
+}
+
+func TestBuildEntityMappings_CrossInstance(t *testing.T) {
+	dst := instanceMock(100, false)
+	m := setupTestMigration(t, dst)
+	m.SetSourceClient(instanceMock(0, true))
+	require.True(t, m.IsCrossInstance())
+
+	e, err := m.BuildEntityMappings(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, e)
+
+	id, ok := e.Users.GetTargetBySource(1)
+	assert.True(t, ok)
+	assert.Equal(t, int64(101), id)
+
+	id, _ = e.Priorities.GetTargetBySource(2)
+	assert.Equal(t, int64(102), id)
+	assert.Equal(t, []string{"Blocker"}, e.Unmatched[EntityPriorities])
+	assert.Equal(t, int64(101), e.DefaultPriorityID)
+
+	id, _ = e.CaseTypes.GetTargetBySource(2)
+	assert.Equal(t, int64(102), id)
+	id, _ = e.Statuses.GetTargetBySource(5)
+	assert.Equal(t, int64(105), id)
+	assert.Len(t, e.All(), 5)
+
+	dir := t.TempDir()
+	require.NoError(t, e.Save(dir))
+	files, _ := filepath.Glob(filepath.Join(dir, "mapping_priorities_*.json"))
+	assert.Len(t, files, 1)
+}
+
+func TestImportCases_CrossInstance_TranslatesIDs(t *testing.T) {
+	var got *data.AddCaseRequest
+	dst := instanceMock(100, false)
+	dst.AddCaseFunc = func(ctx context.Context, sectionID int64, req *data.AddCaseRequest) (*data.Case, error) {
+		got = req
+		return &data.Case{ID: 500}, nil
+	}
+	m := setupTestMigration(t, dst)
+	m.SetSourceClient(instanceMock(0, true))
+
+	err := m.ImportCases(context.Background(), data.GetCasesResponse{
+		{ID: 7, Title: "Login", TypeID: 2, PriorityID: 3, TemplateID: 1, MilestoneID: 9},
+	}, false)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+
+	assert.Equal(t, int64(102), got.TypeID)
+	assert.Equal(t, int64(101), got.PriorityID, "unmatched priority falls back to destination default")
+	assert.Equal(t, int64(101), got.TemplateID)
+	assert.Zero(t, got.MilestoneID)
+}
+
+func TestExportMapping_WritesEntityMappings(t *testing.T) {
+	m := setupTestMigration(t, instanceMock(100, false))
+	m.SetSourceClient(instanceMock(0, false))
+	_, err := m.BuildEntityMappings(context.Background())
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, m.ExportMapping(dir))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 5)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/match"
 	"github.com/Korrnals/gotr/internal/models/data"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -15,19 +16,33 @@ import (
 
 // Migration holds the migration context: client, parameters, mapping, and logger.
 type Migration struct {
-	Client        client.ClientInterface // API client interface
+	Client        client.ClientInterface // API client interface (destination; also source unless SrcClient is set)
+	SrcClient     client.ClientInterface // source instance client for cross-instance sync; nil = same as Client
 	srcProject    int64
 	srcSuite      int64
 	dstProject    int64
 	dstSuite      int64
 	compareField  string
 	importedCases int // number of successfully imported cases
+	updatedCases  int // number of cases updated in place via the case mapping
 
 	mapping  *SharedStepMapping // shared step ID mapping (see mapping.go)
+	suites   *IDMapping         // suite ID mapping used to place imported sections
+	sections *IDMapping         // section ID mapping used to place cases (see sections.go)
+	cases    *IDMapping         // case ID mapping; persisted so later runs update in place
+	created  []MappingPair      // cases created in this run (see CopyCaseAttachments)
 	logger   *zap.SugaredLogger
 	logFile  *os.File // log file handle, closed in Close()
 
 	lastFilteredSteps data.GetSharedStepsResponse // filtered shared steps from last MigrateSharedSteps run
+	srcCases          data.GetCasesResponse       // source suite cases, fetched once (see sourceCases)
+	entities          *EntityMappings             // cross-instance ID translation (see translate.go)
+	fields            *FieldMapping               // custom field schema mapping (see fields.go)
+	fieldOverrides    *FieldOverrides             // manual field/option mapping for BuildFieldMapping
+
+	matching match.Options    // normalization and near-duplicate threshold (see matching.go)
+	srcPaths map[int64]string // source section paths, for a section_path compare key
+	dstPaths map[int64]string // target section paths, for a section_path compare key
 }
 
 // NewMigration creates a new Migration instance with a zap logger.
@@ -64,6 +79,9 @@ func NewMigration(cli client.ClientInterface, srcProject, srcSuite, dstProject,
 		compareField:  compareField,
 		importedCases: 0,
 		mapping:       NewSharedStepMapping(srcProject, dstProject), // from mapping.go
+		suites:        newIDMapping(EntitySuites, srcProject, dstProject),
+		sections:      newIDMapping(EntitySections, srcProject, dstProject),
+		cases:         newIDMapping(EntityCases, srcProject, dstProject),
 		logger:        logger,
 		logFile:       fileWriter,
 	}
@@ -87,11 +105,52 @@ func (m *Migration) Close() error {
 	return nil
 }
 
+// SetSourceClient makes the migration read source data from a different
+// TestRail instance. Writes and target reads keep using Client.
+func (m *Migration) SetSourceClient(src client.ClientInterface) {
+	m.SrcClient = src
+	m.entities = nil
+	m.fields = nil
+}
+
+// src returns the client used to read source data.
+func (m *Migration) src() client.ClientInterface {
+	if m.SrcClient != nil {
+		return m.SrcClient
+	}
+	return m.Client
+}
+
+// IsCrossInstance reports whether source and destination are different TestRail servers.
+func (m *Migration) IsCrossInstance() bool {
+	return m.SrcClient != nil && m.SrcClient != m.Client
+}
+
 // FilteredSharedSteps returns the filtered shared steps from the last MigrateSharedSteps run.
 func (m *Migration) FilteredSharedSteps() data.GetSharedStepsResponse {
 	return m.lastFilteredSteps
 }
 
+// CaseMapping returns the source→target case ID table.
+func (m *Migration) CaseMapping() *IDMapping {
+	return m.cases
+}
+
+// SuiteMapping returns the source→target suite ID table.
+func (m *Migration) SuiteMapping() *IDMapping {
+	return m.suites
+}
+
+// SectionMapping returns the source→target section ID table.
+func (m *Migration) SectionMapping() *IDMapping {
+	return m.sections
+}
+
+// UpdatedCases returns the number of cases updated in place by the last imports.
+func (m *Migration) UpdatedCases() int {
+	return m.updatedCases
+}
+
 // Mapping returns a simple map[sourceID]=targetID for external use
 func (m *Migration) Mapping() map[int64]int64 {
 	res := make(map[int64]int64)
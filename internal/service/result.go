@@ -3,10 +3,14 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"strconv"
+	"strings"
 
 	"github.com/Korrnals/gotr/internal/client"
 	"github.com/Korrnals/gotr/internal/log"
@@ -19,11 +23,14 @@ type resultClientInterface interface {
 	GetResults(ctx context.Context, testID int64) (data.GetResultsResponse, error)
 	GetResultsForCase(ctx context.Context, runID, caseID int64) (data.GetResultsResponse, error)
 	GetResultsForRun(ctx context.Context, runID int64) (data.GetResultsResponse, error)
+	GetResultsForRunFiltered(ctx context.Context, runID int64, filter data.ResultFilter) (data.GetResultsResponse, error)
 	GetRuns(ctx context.Context, projectID int64) (data.GetRunsResponse, error)
 	AddResult(ctx context.Context, testID int64, req *data.AddResultRequest) (*data.Result, error)
 	AddResultForCase(ctx context.Context, runID, caseID int64, req *data.AddResultRequest) (*data.Result, error)
 	AddResults(ctx context.Context, runID int64, req *data.AddResultsRequest) (data.GetResultsResponse, error)
 	AddResultsForCases(ctx context.Context, runID int64, req *data.AddResultsForCasesRequest) (data.GetResultsResponse, error)
+	GetResultFields(ctx context.Context) (data.GetResultFieldsResponse, error)
+	AddAttachmentToResult(ctx context.Context, resultID int64, filePath string) (*data.AttachmentResponse, error)
 }
 
 // ResultService provides methods for working with test results.
@@ -63,6 +70,14 @@ func (s *ResultService) GetForRun(ctx context.Context, runID int64) (data.GetRes
 	return s.client.GetResultsForRun(ctx, runID)
 }
 
+// GetForRunFiltered retrieves the run results matching a server-side filter.
+func (s *ResultService) GetForRunFiltered(ctx context.Context, runID int64, filter data.ResultFilter) (data.GetResultsResponse, error) {
+	if err := s.validateID(runID, "run_id"); err != nil {
+		return nil, err
+	}
+	return s.client.GetResultsForRunFiltered(ctx, runID, filter)
+}
+
 // GetRunsForProject retrieves the list of runs for a project (for interactive selection).
 func (s *ResultService) GetRunsForProject(ctx context.Context, projectID int64) (data.GetRunsResponse, error) {
 	if err := s.validateID(projectID, "project_id"); err != nil {
@@ -91,6 +106,9 @@ func (s *ResultService) AddForTest(ctx context.Context, testID int64, req *data.
 		log.L().Error("validation failed", zap.Error(err))
 		return nil, fmt.Errorf("request validation: %w", err)
 	}
+	if err := s.ValidateCustomFields(ctx, req.CustomFields); err != nil {
+		return nil, err
+	}
 
 	result, err := s.client.AddResult(ctx, testID, req)
 	if err != nil {
@@ -131,6 +149,9 @@ func (s *ResultService) AddForCase(ctx context.Context, runID, caseID int64, req
 		log.L().Error("validation failed", zap.Error(err))
 		return nil, fmt.Errorf("request validation: %w", err)
 	}
+	if err := s.ValidateCustomFields(ctx, req.CustomFields); err != nil {
+		return nil, err
+	}
 
 	result, err := s.client.AddResultForCase(ctx, runID, caseID, req)
 	if err != nil {
@@ -167,6 +188,13 @@ func (s *ResultService) AddResults(ctx context.Context, runID int64, req *data.A
 		log.L().Error("validation failed", zap.Error(err))
 		return nil, fmt.Errorf("request validation: %w", err)
 	}
+	custom := make([]data.CustomFieldValues, 0, len(req.Results))
+	for _, r := range req.Results {
+		custom = append(custom, r.CustomFields)
+	}
+	if err := s.ValidateCustomFields(ctx, custom...); err != nil {
+		return nil, err
+	}
 
 	results, err := s.client.AddResults(ctx, runID, req)
 	if err != nil {
@@ -189,9 +217,193 @@ func (s *ResultService) AddResultsForCases(ctx context.Context, runID int64, req
 	if err := s.validateAddResultsForCasesRequest(req); err != nil {
 		return nil, fmt.Errorf("request validation: %w", err)
 	}
+	custom := make([]data.CustomFieldValues, 0, len(req.Results))
+	for _, r := range req.Results {
+		custom = append(custom, r.CustomFields)
+	}
+	if err := s.ValidateCustomFields(ctx, custom...); err != nil {
+		return nil, err
+	}
 	return s.client.AddResultsForCases(ctx, runID, req)
 }
 
+// ValidateCustomFields checks custom result field values against
+// get_result_fields: every field must exist and be active, and its value
+// must match the field type. Result fields are fetched only when there is
+// something to check.
+func (s *ResultService) ValidateCustomFields(ctx context.Context, values ...data.CustomFieldValues) error {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, v := range values {
+		for _, k := range v.Keys() {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	fields, err := s.client.GetResultFields(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get result fields: %w", err)
+	}
+	bySystemName := make(map[string]data.ResultField, len(fields))
+	for _, f := range fields {
+		bySystemName[f.SystemName] = f
+	}
+
+	var problems []string
+	for _, k := range keys {
+		f, ok := bySystemName[k]
+		switch {
+		case !ok:
+			problems = append(problems, k+": unknown result field")
+			continue
+		case !f.IsActive:
+			problems = append(problems, k+": result field is inactive")
+			continue
+		}
+		for _, v := range values {
+			if raw, ok := v[k]; ok && !resultFieldValueValid(f.TypeID, raw) {
+				problems = append(problems, fmt.Sprintf("%s: invalid value %s for field type %d", k, raw, f.TypeID))
+				break
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid custom result fields:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// resultFieldValueValid checks the JSON shape of a value for a field type.
+// Null clears a field and is always accepted.
+func resultFieldValueValid(typeID int, raw json.RawMessage) bool {
+	raw = bytes.TrimSpace(raw)
+	if string(raw) == "null" {
+		return true
+	}
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return false
+	}
+
+	switch typeID {
+	case data.CaseFieldTypeString, data.CaseFieldTypeText, data.CaseFieldTypeURL, data.CaseFieldTypeDate:
+		_, ok := v.(string)
+		return ok
+	case data.CaseFieldTypeInteger, data.CaseFieldTypeDropdown, data.CaseFieldTypeUser, data.CaseFieldTypeMilestone:
+		n, ok := v.(float64)
+		return ok && n == float64(int64(n))
+	case data.CaseFieldTypeCheckbox:
+		_, ok := v.(bool)
+		return ok
+	case data.CaseFieldTypeMultiSelect:
+		items, ok := v.([]any)
+		if !ok {
+			return false
+		}
+		for _, item := range items {
+			if n, ok := item.(float64); !ok || n != float64(int64(n)) {
+				return false
+			}
+		}
+		return true
+	case data.CaseFieldTypeSteps, data.CaseFieldTypeStepResults:
+		_, ok := v.([]any)
+		return ok
+	}
+	return true
+}
+
+// ResultAttachmentFailure is a file that could not be attached to a result.
+// ResultID is 0 when no result could be matched to the file.
+type ResultAttachmentFailure struct {
+	ResultID int64
+	File     string
+	Err      error
+}
+
+// AttachmentsError lists the files that failed to upload after the results
+// themselves were created.
+type AttachmentsError struct {
+	Failures []ResultAttachmentFailure
+}
+
+func (e *AttachmentsError) Error() string {
+	lines := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		if f.ResultID == 0 {
+			lines = append(lines, fmt.Sprintf("%s: %v", f.File, f.Err))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("result %d: %s: %v", f.ResultID, f.File, f.Err))
+	}
+	return fmt.Sprintf("%d attachments failed to upload:\n  %s", len(e.Failures), strings.Join(lines, "\n  "))
+}
+
+// CheckAttachmentFiles checks that every file of files is a readable
+// regular file, so that results are not posted without their attachments.
+func CheckAttachmentFiles(files [][]string) error {
+	var problems []error
+	for i, entry := range files {
+		for _, file := range entry {
+			info, err := os.Stat(file)
+			switch {
+			case err != nil:
+				problems = append(problems, fmt.Errorf("result[%d]: attachment %w", i, err))
+			case !info.Mode().IsRegular():
+				problems = append(problems, fmt.Errorf("result[%d]: attachment %s is not a regular file", i, file))
+			}
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// AttachFiles uploads files[i] to results[i] through add_attachment_to_result;
+// bulk result endpoints return results in request order. It returns the
+// number of uploaded files and an *AttachmentsError for the failed ones,
+// which lists every file when the results cannot be matched to the entries.
+func (s *ResultService) AttachFiles(ctx context.Context, results data.GetResultsResponse, files [][]string) (int, error) {
+	total := 0
+	for _, f := range files {
+		total += len(f)
+	}
+	if total == 0 {
+		return 0, nil
+	}
+	if len(results) != len(files) {
+		mismatch := fmt.Errorf("not attached: got %d results for %d entries", len(results), len(files))
+		attErr := &AttachmentsError{}
+		for _, entry := range files {
+			for _, file := range entry {
+				attErr.Failures = append(attErr.Failures, ResultAttachmentFailure{File: file, Err: mismatch})
+			}
+		}
+		return 0, attErr
+	}
+
+	uploaded := 0
+	attErr := &AttachmentsError{}
+	for i, result := range results {
+		for _, file := range files[i] {
+			if _, err := s.client.AddAttachmentToResult(ctx, result.ID, file); err != nil {
+				log.L().Error("failed to attach file", zap.Int64("result_id", result.ID), zap.String("file", file), zap.Error(err))
+				attErr.Failures = append(attErr.Failures, ResultAttachmentFailure{ResultID: result.ID, File: file, Err: err})
+				continue
+			}
+			uploaded++
+		}
+	}
+	if len(attErr.Failures) > 0 {
+		return uploaded, attErr
+	}
+	return uploaded, nil
+}
+
 // ParseID parses an ID from command arguments.
 func (s *ResultService) ParseID(ctx context.Context, args []string, index int) (int64, error) {
 	if index >= len(args) {
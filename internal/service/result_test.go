@@ -480,3 +480,89 @@ func TestResultService_validateAddResultsForCasesRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestResultService_ValidateCustomFields(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	mock := &client.MockClient{
+		GetResultFieldsFunc: func(ctx context.Context) (data.GetResultFieldsResponse, error) {
+			calls++
+			return data.GetResultFieldsResponse{
+				{SystemName: "custom_env", TypeID: data.CaseFieldTypeString, IsActive: true},
+				{SystemName: "custom_build", TypeID: data.CaseFieldTypeInteger, IsActive: true},
+				{SystemName: "custom_browsers", TypeID: data.CaseFieldTypeMultiSelect, IsActive: true},
+				{SystemName: "custom_old", TypeID: data.CaseFieldTypeString},
+			}, nil
+		},
+	}
+	svc := NewResultService(mock)
+
+	assert.NoError(t, svc.ValidateCustomFields(ctx, nil, data.CustomFieldValues{}))
+	assert.Equal(t, 0, calls, "no custom fields, no request")
+
+	assert.NoError(t, svc.ValidateCustomFields(ctx, data.CustomFieldValues{
+		"custom_env":      []byte(`"staging"`),
+		"custom_build":    []byte(`42`),
+		"custom_browsers": []byte(`[1, 2]`),
+	}))
+
+	err := svc.ValidateCustomFields(ctx,
+		data.CustomFieldValues{"custom_build": []byte(`"x"`)},
+		data.CustomFieldValues{"custom_missing": []byte(`1`), "custom_old": []byte(`"a"`)},
+	)
+	assert.ErrorContains(t, err, "custom_build: invalid value")
+	assert.ErrorContains(t, err, "custom_missing: unknown result field")
+	assert.ErrorContains(t, err, "custom_old: result field is inactive")
+}
+
+func TestResultService_AddResultsForCases_RejectsUnknownField(t *testing.T) {
+	mock := &client.MockClient{
+		GetResultFieldsFunc: func(ctx context.Context) (data.GetResultFieldsResponse, error) {
+			return nil, nil
+		},
+		AddResultsForCasesFunc: func(ctx context.Context, runID int64, req *data.AddResultsForCasesRequest) (data.GetResultsResponse, error) {
+			t.Fatal("must not send invalid results")
+			return nil, nil
+		},
+	}
+	svc := NewResultService(mock)
+
+	_, err := svc.AddResultsForCases(context.Background(), 1, &data.AddResultsForCasesRequest{Results: []data.ResultForCaseEntry{
+		{CaseID: 1, StatusID: 1, CustomFields: data.CustomFieldValues{"custom_env": []byte(`"x"`)}},
+	}})
+	assert.ErrorContains(t, err, "unknown result field")
+}
+
+func TestResultService_AttachFiles(t *testing.T) {
+	var uploaded []string
+	mock := &client.MockClient{
+		AddAttachmentToResultFunc: func(ctx context.Context, resultID int64, filePath string) (*data.AttachmentResponse, error) {
+			if filePath == "missing.log" {
+				return nil, errors.New("no such file")
+			}
+			uploaded = append(uploaded, filePath)
+			return &data.AttachmentResponse{}, nil
+		},
+	}
+	svc := NewResultService(mock)
+	results := data.GetResultsResponse{{ID: 10}, {ID: 11}}
+
+	n, err := svc.AttachFiles(context.Background(), results, [][]string{{"a.png"}, {"b.png", "missing.log"}})
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"a.png", "b.png"}, uploaded)
+	var attErr *AttachmentsError
+	if assert.ErrorAs(t, err, &attErr) {
+		assert.Len(t, attErr.Failures, 1)
+		assert.Equal(t, int64(11), attErr.Failures[0].ResultID)
+	}
+
+	_, err = svc.AttachFiles(context.Background(), results[:1], [][]string{{"a.png"}, {"b.png"}})
+	if assert.ErrorAs(t, err, &attErr, "the results exist, so callers still print them") {
+		assert.Len(t, attErr.Failures, 2)
+	}
+	assert.ErrorContains(t, err, "got 1 results for 2 entries")
+
+	n, err = svc.AttachFiles(context.Background(), nil, [][]string{nil})
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 
 	"github.com/Korrnals/gotr/internal/client"
@@ -18,10 +19,12 @@ import (
 type runClientInterface interface {
 	GetRun(ctx context.Context, runID int64) (*data.Run, error)
 	GetRuns(ctx context.Context, projectID int64) (data.GetRunsResponse, error)
+	GetRunsFiltered(ctx context.Context, projectID int64, filter data.RunFilter) (data.GetRunsResponse, error)
 	AddRun(ctx context.Context, projectID int64, req *data.AddRunRequest) (*data.Run, error)
 	UpdateRun(ctx context.Context, runID int64, req *data.UpdateRunRequest) (*data.Run, error)
 	CloseRun(ctx context.Context, runID int64) (*data.Run, error)
 	DeleteRun(ctx context.Context, runID int64) error
+	GetTests(ctx context.Context, runID int64, filters map[string]string) ([]data.Test, error)
 }
 
 // RunService provides methods for working with test runs.
@@ -50,6 +53,14 @@ func (s *RunService) GetByProject(ctx context.Context, projectID int64) (data.Ge
 	return s.client.GetRuns(ctx, projectID)
 }
 
+// GetByProjectFiltered retrieves the project runs matching a server-side filter.
+func (s *RunService) GetByProjectFiltered(ctx context.Context, projectID int64, filter data.RunFilter) (data.GetRunsResponse, error) {
+	if err := s.validateID(projectID, "project_id"); err != nil {
+		return nil, err
+	}
+	return s.client.GetRunsFiltered(ctx, projectID, filter)
+}
+
 // Create creates a new test run with parameter validation.
 func (s *RunService) Create(ctx context.Context, projectID int64, req *data.AddRunRequest) (*data.Run, error) {
 	var name string
@@ -150,6 +161,99 @@ func (s *RunService) Delete(ctx context.Context, runID int64) error {
 	return nil
 }
 
+// FindOpen returns the open (not completed) run of a project with the given
+// name, suite and milestone, or nil if there is none. Only open runs of the
+// suite and milestone are fetched.
+func (s *RunService) FindOpen(ctx context.Context, projectID int64, name string, suiteID, milestoneID int64) (*data.Run, error) {
+	open := false
+	filter := data.RunFilter{IsCompleted: &open}
+	if suiteID > 0 {
+		filter.SuiteIDs = []int64{suiteID}
+	}
+	if milestoneID > 0 {
+		filter.MilestoneIDs = []int64{milestoneID}
+	}
+	runs, err := s.GetByProjectFiltered(ctx, projectID, filter)
+	if err != nil {
+		return nil, err
+	}
+	for i, r := range runs {
+		if !r.IsCompleted && r.Name == name && r.SuiteID == suiteID && r.MilestoneID == milestoneID {
+			return &runs[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// FindOrCreate reuses the open run with the name, suite and milestone of req,
+// or creates a new one. created reports whether the run was created.
+func (s *RunService) FindOrCreate(ctx context.Context, projectID int64, req *data.AddRunRequest) (run *data.Run, created bool, err error) {
+	if err := s.validateCreateRequest(req); err != nil {
+		return nil, false, fmt.Errorf("request validation: %w", err)
+	}
+
+	run, err = s.FindOpen(ctx, projectID, req.Name, req.SuiteID, req.MilestoneID)
+	if err != nil {
+		return nil, false, err
+	}
+	if run != nil {
+		log.L().Info("reusing test run", zap.Int64("run_id", run.ID), zap.String("name", run.Name))
+		return run, false, nil
+	}
+
+	run, err = s.Create(ctx, projectID, req)
+	if err != nil {
+		return nil, false, err
+	}
+	return run, true, nil
+}
+
+// AddCases adds the case IDs that are not yet part of a run. The run is
+// switched to include_all=false with the union of its current and the new
+// cases; runs that already include all cases are left unchanged. Returns the
+// added case IDs.
+func (s *RunService) AddCases(ctx context.Context, run *data.Run, caseIDs []int64) ([]int64, error) {
+	if run == nil {
+		return nil, errors.New("run cannot be nil")
+	}
+	if run.IncludeAll || len(caseIDs) == 0 {
+		return nil, nil
+	}
+
+	tests, err := s.client.GetTests(ctx, run.ID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tests of run %d: %w", run.ID, err)
+	}
+	current := make(map[int64]bool, len(tests))
+	all := make([]int64, 0, len(tests)+len(caseIDs))
+	for _, t := range tests {
+		if !current[t.CaseID] {
+			current[t.CaseID] = true
+			all = append(all, t.CaseID)
+		}
+	}
+
+	var added []int64
+	for _, id := range caseIDs {
+		if !current[id] {
+			current[id] = true
+			added = append(added, id)
+			all = append(all, id)
+		}
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+	slices.Sort(all)
+
+	includeAll := false
+	if _, err := s.Update(ctx, run.ID, &data.UpdateRunRequest{IncludeAll: &includeAll, CaseIDs: all}); err != nil {
+		return nil, err
+	}
+	log.L().Info("cases added to test run", zap.Int64("run_id", run.ID), zap.Int("added", len(added)))
+	return added, nil
+}
+
 // ParseID parses an ID from command arguments.
 func (s *RunService) ParseID(ctx context.Context, args []string, index int) (int64, error) {
 	if index >= len(args) {
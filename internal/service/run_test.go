@@ -307,3 +307,77 @@ func TestRunService_ConstructorsAndWrappers(t *testing.T) {
 	runSvc := NewRunService(httpClient)
 	assert.NotNil(t, runSvc)
 }
+
+func TestRunService_AddCases(t *testing.T) {
+	t.Run("include all is left unchanged", func(t *testing.T) {
+		svc := NewRunService(&client.MockClient{
+			GetTestsFunc: func(ctx context.Context, runID int64, filters map[string]string) ([]data.Test, error) {
+				t.Fatal("tests must not be loaded")
+				return nil, nil
+			},
+		})
+		added, err := svc.AddCases(context.Background(), &data.Run{ID: 1, IncludeAll: true}, []int64{1})
+		assert.NoError(t, err)
+		assert.Empty(t, added)
+	})
+
+	t.Run("nothing missing", func(t *testing.T) {
+		svc := NewRunService(&client.MockClient{
+			GetTestsFunc: func(ctx context.Context, runID int64, filters map[string]string) ([]data.Test, error) {
+				return []data.Test{{CaseID: 1}, {CaseID: 2}}, nil
+			},
+			UpdateRunFunc: func(ctx context.Context, runID int64, req *data.UpdateRunRequest) (*data.Run, error) {
+				t.Fatal("run must not be updated")
+				return nil, nil
+			},
+		})
+		added, err := svc.AddCases(context.Background(), &data.Run{ID: 1}, []int64{2, 1})
+		assert.NoError(t, err)
+		assert.Empty(t, added)
+	})
+
+	t.Run("tests error", func(t *testing.T) {
+		svc := NewRunService(&client.MockClient{
+			GetTestsFunc: func(ctx context.Context, runID int64, filters map[string]string) ([]data.Test, error) {
+				return nil, errors.New("boom")
+			},
+		})
+		_, err := svc.AddCases(context.Background(), &data.Run{ID: 1}, []int64{3})
+		assert.Error(t, err)
+	})
+}
+
+func TestRunService_FindOrCreate(t *testing.T) {
+	svc := NewRunService(&client.MockClient{
+		GetRunsFilteredFunc: func(ctx context.Context, projectID int64, filter data.RunFilter) (data.GetRunsResponse, error) {
+			assert.Equal(t, map[string]string{"is_completed": "0", "suite_id": "2"}, filter.Query())
+			return data.GetRunsResponse{{ID: 3, Name: "Nightly", SuiteID: 2}, {ID: 4, Name: "CI", SuiteID: 2}}, nil
+		},
+	})
+
+	run, created, err := svc.FindOrCreate(context.Background(), 1, &data.AddRunRequest{Name: "CI", SuiteID: 2})
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, int64(4), run.ID)
+
+	_, _, err = svc.FindOrCreate(context.Background(), 1, &data.AddRunRequest{SuiteID: 2})
+	assert.Error(t, err)
+}
+
+func TestRunService_GetByProjectFiltered(t *testing.T) {
+	completed := false
+	svc := NewRunService(&client.MockClient{
+		GetRunsFilteredFunc: func(ctx context.Context, projectID int64, filter data.RunFilter) (data.GetRunsResponse, error) {
+			assert.Equal(t, int64(1), projectID)
+			assert.Equal(t, map[string]string{"is_completed": "0"}, filter.Query())
+			return data.GetRunsResponse{{ID: 4}}, nil
+		},
+	})
+
+	runs, err := svc.GetByProjectFiltered(context.Background(), 1, data.RunFilter{IsCompleted: &completed})
+	assert.NoError(t, err)
+	assert.Len(t, runs, 1)
+
+	_, err = svc.GetByProjectFiltered(context.Background(), 0, data.RunFilter{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,534 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/concurrency"
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+var (
+	// ErrReadOnly is returned by every write against a snapshot.
+	ErrReadOnly = errors.New("snapshot is read-only")
+	// ErrNotInSnapshot is returned for reads of data a snapshot does not hold.
+	ErrNotInSnapshot = errors.New("not in snapshot")
+)
+
+// Client answers ClientInterface read calls from a loaded snapshot.
+type Client struct {
+	snap *Snapshot
+	path string
+}
+
+// Compile-time check: Client must implement ClientInterface.
+var _ client.ClientInterface = (*Client)(nil)
+
+// NewClient wraps a loaded snapshot; path is only used for display.
+func NewClient(snap *Snapshot, path string) *Client {
+	return &Client{snap: snap, path: path}
+}
+
+// Open loads the snapshot at path and wraps it in a Client.
+func Open(path string) (*Client, error) {
+	snap, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(snap, path), nil
+}
+
+// Snapshot returns the underlying snapshot.
+func (c *Client) Snapshot() *Snapshot { return c.snap }
+
+// Path returns the path the snapshot was opened from.
+func (c *Client) Path() string { return c.path }
+
+// readOnly builds the error returned by write methods.
+func (c *Client) readOnly(op string) error {
+	return fmt.Errorf("%s: %w (%s); drop --snapshot to write to the server", op, ErrReadOnly, c.path)
+}
+
+// missing builds the error returned for data the snapshot does not hold.
+func (c *Client) missing(format string, args ...any) error {
+	return fmt.Errorf("%s: %w (%s)", fmt.Sprintf(format, args...), ErrNotInSnapshot, c.path)
+}
+
+func (c *Client) project(projectID int64) (*ProjectData, error) {
+	p, ok := c.snap.Projects[projectID]
+	if !ok {
+		return nil, c.missing("project %d", projectID)
+	}
+	return p, nil
+}
+
+// captured returns v, or ErrNotInSnapshot when the resource was not captured.
+func captured[S ~[]T, T any](c *Client, v S, format string, args ...any) (S, error) {
+	if v == nil {
+		return nil, c.missing(format, args...)
+	}
+	return slices.Clone(v), nil
+}
+
+// find returns the first element of the projects' resource lists matching id.
+func find[T any](c *Client, what string, id int64, list func(*ProjectData) []T, match func(T) bool) (*T, error) {
+	for _, pid := range c.snap.projectIDs() {
+		for _, v := range list(c.snap.Projects[pid]) {
+			if match(v) {
+				return &v, nil
+			}
+		}
+	}
+	return nil, c.missing("%s %d", what, id)
+}
+
+// --- Projects ---
+
+func (c *Client) GetProjects(ctx context.Context) (data.GetProjectsResponse, error) {
+	projects := make(data.GetProjectsResponse, 0, len(c.snap.Projects))
+	for _, id := range c.snap.projectIDs() {
+		projects = append(projects, c.snap.Projects[id].Project)
+	}
+	return projects, nil
+}
+
+func (c *Client) GetProject(ctx context.Context, projectID int64) (*data.GetProjectResponse, error) {
+	p, err := c.project(projectID)
+	if err != nil {
+		return nil, err
+	}
+	project := data.GetProjectResponse(p.Project)
+	return &project, nil
+}
+
+// --- Cases ---
+
+func (c *Client) GetCases(ctx context.Context, projectID, suiteID, sectionID int64) (data.GetCasesResponse, error) {
+	return c.GetCasesFiltered(ctx, projectID, data.CaseFilter{SuiteID: suiteID, SectionID: sectionID})
+}
+
+// GetCasesFiltered applies the filter locally, with the same semantics as TestRail.
+func (c *Client) GetCasesFiltered(ctx context.Context, projectID int64, filter data.CaseFilter) (data.GetCasesResponse, error) {
+	p, err := c.project(projectID)
+	if err != nil {
+		return nil, err
+	}
+	cases := data.GetCasesResponse{}
+	for _, cs := range p.Cases {
+		if matchCase(cs, filter) {
+			cases = append(cases, cs)
+		}
+	}
+	return cases, nil
+}
+
+func matchCase(cs data.Case, f data.CaseFilter) bool {
+	return (f.SuiteID == 0 || cs.SuiteID == f.SuiteID) &&
+		(f.SectionID == 0 || cs.SectionID == f.SectionID) &&
+		inRange(cs.CreatedOn, f.CreatedAfter, f.CreatedBefore) &&
+		inRange(cs.UpdatedOn, f.UpdatedAfter, f.UpdatedBefore) &&
+		anyOf(f.CreatedBy, cs.CreatedBy) &&
+		anyOf(f.UpdatedBy, cs.UpdatedBy) &&
+		anyOf(f.MilestoneIDs, cs.MilestoneID) &&
+		anyOf(f.PriorityIDs, cs.PriorityID) &&
+		anyOf(f.TemplateIDs, cs.TemplateID) &&
+		anyOf(f.TypeIDs, cs.TypeID) &&
+		hasRef(cs.Refs, f.Refs) &&
+		(f.Title == "" || strings.Contains(strings.ToLower(cs.Title), strings.ToLower(f.Title)))
+}
+
+// inRange reports whether a Unix timestamp lies within [after, before];
+// zero bounds are open.
+func inRange(ts int64, after, before time.Time) bool {
+	if !after.IsZero() && ts < after.Unix() {
+		return false
+	}
+	if !before.IsZero() && ts > before.Unix() {
+		return false
+	}
+	return true
+}
+
+// anyOf reports whether id is one of ids; an empty list matches everything.
+func anyOf(ids []int64, id int64) bool {
+	return len(ids) == 0 || slices.Contains(ids, id)
+}
+
+// hasRef reports whether the comma-separated refs contain ref.
+func hasRef(refs, ref string) bool {
+	if ref == "" {
+		return true
+	}
+	for _, r := range strings.Split(refs, ",") {
+		if strings.EqualFold(strings.TrimSpace(r), ref) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) GetCasesPage(ctx context.Context, projectID, suiteID int64, offset, limit int) (data.GetCasesResponse, error) {
+	cases, err := c.GetCases(ctx, projectID, suiteID, 0)
+	if err != nil {
+		return nil, err
+	}
+	if offset >= len(cases) {
+		return data.GetCasesResponse{}, nil
+	}
+	end := len(cases)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return cases[offset:end], nil
+}
+
+func (c *Client) GetCase(ctx context.Context, caseID int64) (*data.Case, error) {
+	return find(c, "case", caseID,
+		func(p *ProjectData) []data.Case { return p.Cases },
+		func(cs data.Case) bool { return cs.ID == caseID })
+}
+
+func (c *Client) GetHistoryForCase(ctx context.Context, caseID int64) (*data.GetHistoryForCaseResponse, error) {
+	return nil, c.missing("history of case %d", caseID)
+}
+
+func (c *Client) GetCaseFields(ctx context.Context) (data.GetCaseFieldsResponse, error) {
+	return captured(c, c.snap.CaseFields, "case fields")
+}
+
+func (c *Client) GetCaseTypes(ctx context.Context) (data.GetCaseTypesResponse, error) {
+	return captured(c, c.snap.CaseTypes, "case types")
+}
+
+func (c *Client) DiffCasesData(ctx context.Context, pid1, pid2 int64, field string) (*data.DiffCasesResponse, error) {
+	cases1, err := c.GetCases(ctx, pid1, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	cases2, err := c.GetCases(ctx, pid2, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	return client.DiffCases(cases1, cases2, field), nil
+}
+
+func (c *Client) GetCasesParallel(ctx context.Context, projectID int64, suiteIDs []int64, workers int, monitor client.ProgressMonitor) (map[int64]data.GetCasesResponse, error) {
+	result := make(map[int64]data.GetCasesResponse, len(suiteIDs))
+	for _, suiteID := range suiteIDs {
+		cases, err := c.GetCases(ctx, projectID, suiteID, 0)
+		if err != nil {
+			return nil, err
+		}
+		result[suiteID] = cases
+		if monitor != nil {
+			monitor.Increment()
+		}
+	}
+	return result, nil
+}
+
+func (c *Client) GetSuitesParallel(ctx context.Context, projectIDs []int64, workers int, monitor client.ProgressMonitor) (map[int64]data.GetSuitesResponse, error) {
+	result := make(map[int64]data.GetSuitesResponse, len(projectIDs))
+	for _, projectID := range projectIDs {
+		suites, err := c.GetSuites(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+		result[projectID] = suites
+		if monitor != nil {
+			monitor.Increment()
+		}
+	}
+	return result, nil
+}
+
+func (c *Client) GetCasesForSuitesParallel(ctx context.Context, projectID int64, suiteIDs []int64, workers int, monitor client.ProgressMonitor) (data.GetCasesResponse, error) {
+	bySuite, err := c.GetCasesParallel(ctx, projectID, suiteIDs, workers, monitor)
+	if err != nil {
+		return nil, err
+	}
+	cases := data.GetCasesResponse{}
+	for _, suiteID := range suiteIDs {
+		cases = append(cases, bySuite[suiteID]...)
+	}
+	return cases, nil
+}
+
+// GetCasesParallelCtx returns the suites' cases; there is no execution
+// result since nothing is fetched.
+func (c *Client) GetCasesParallelCtx(ctx context.Context, projectID int64, suiteIDs []int64, config *concurrency.ControllerConfig) (data.GetCasesResponse, *concurrency.ExecutionResult, error) {
+	cases, err := c.GetCasesForSuitesParallel(ctx, projectID, suiteIDs, 0, nil)
+	return cases, nil, err
+}
+
+// --- Suites ---
+
+func (c *Client) GetSuites(ctx context.Context, projectID int64) (data.GetSuitesResponse, error) {
+	p, err := c.project(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return captured(c, p.Suites, "suites of project %d", projectID)
+}
+
+func (c *Client) GetSuite(ctx context.Context, suiteID int64) (*data.Suite, error) {
+	return find(c, "suite", suiteID,
+		func(p *ProjectData) []data.Suite { return p.Suites },
+		func(s data.Suite) bool { return s.ID == suiteID })
+}
+
+// --- Sections ---
+
+func (c *Client) GetSections(ctx context.Context, projectID, suiteID int64) (data.GetSectionsResponse, error) {
+	p, err := c.project(projectID)
+	if err != nil {
+		return nil, err
+	}
+	sections := data.GetSectionsResponse{}
+	for _, s := range p.Sections {
+		if suiteID == 0 || s.SuiteID == suiteID {
+			sections = append(sections, s)
+		}
+	}
+	return sections, nil
+}
+
+func (c *Client) GetSectionsParallelCtx(ctx context.Context, projectID int64, suiteIDs []int64, config *concurrency.ControllerConfig) (data.GetSectionsResponse, error) {
+	sections := data.GetSectionsResponse{}
+	for _, suiteID := range suiteIDs {
+		s, err := c.GetSections(ctx, projectID, suiteID)
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, s...)
+	}
+	return sections, nil
+}
+
+func (c *Client) GetSection(ctx context.Context, sectionID int64) (*data.Section, error) {
+	return find(c, "section", sectionID,
+		func(p *ProjectData) []data.Section { return p.Sections },
+		func(s data.Section) bool { return s.ID == sectionID })
+}
+
+// --- Shared steps ---
+
+func (c *Client) GetSharedSteps(ctx context.Context, projectID int64) (data.GetSharedStepsResponse, error) {
+	p, err := c.project(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return captured(c, p.SharedSteps, "shared steps of project %d", projectID)
+}
+
+func (c *Client) GetSharedStep(ctx context.Context, stepID int64) (*data.SharedStep, error) {
+	return find(c, "shared step", stepID,
+		func(p *ProjectData) []data.SharedStep { return p.SharedSteps },
+		func(s data.SharedStep) bool { return s.ID == stepID })
+}
+
+func (c *Client) GetSharedStepHistory(ctx context.Context, stepID int64) (*data.GetSharedStepHistoryResponse, error) {
+	return nil, c.missing("history of shared step %d", stepID)
+}
+
+// --- Runs ---
+
+func (c *Client) GetRuns(ctx context.Context, projectID int64) (data.GetRunsResponse, error) {
+	p, err := c.project(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return captured(c, p.Runs, "runs of project %d", projectID)
+}
+
+func (c *Client) GetRunsFiltered(ctx context.Context, projectID int64, filter data.RunFilter) (data.GetRunsResponse, error) {
+	if filter.Refs != "" {
+		return nil, c.missing("references of runs")
+	}
+	runs, err := c.GetRuns(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	filtered := data.GetRunsResponse{}
+	for _, r := range runs {
+		if inRange(r.CreatedOn, filter.CreatedAfter, filter.CreatedBefore) &&
+			anyOf(filter.CreatedBy, r.CreatedBy) &&
+			(filter.IsCompleted == nil || r.IsCompleted == *filter.IsCompleted) &&
+			anyOf(filter.MilestoneIDs, r.MilestoneID) &&
+			anyOf(filter.SuiteIDs, r.SuiteID) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered, nil
+}
+
+// GetRun looks the run up among standalone runs and runs of plan entries.
+func (c *Client) GetRun(ctx context.Context, runID int64) (*data.Run, error) {
+	return find(c, "run", runID, allRuns, func(r data.Run) bool { return r.ID == runID })
+}
+
+func allRuns(p *ProjectData) []data.Run {
+	runs := slices.Clone(p.Runs)
+	for _, plan := range p.Plans {
+		for _, entry := range plan.Entries {
+			runs = append(runs, entry.Runs...)
+		}
+	}
+	return runs
+}
+
+// --- Milestones ---
+
+func (c *Client) GetMilestone(ctx context.Context, milestoneID int64) (*data.Milestone, error) {
+	return find(c, "milestone", milestoneID,
+		func(p *ProjectData) []data.Milestone { return p.Milestones },
+		func(m data.Milestone) bool { return m.ID == milestoneID })
+}
+
+func (c *Client) GetMilestones(ctx context.Context, projectID int64) ([]data.Milestone, error) {
+	p, err := c.project(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return captured(c, p.Milestones, "milestones of project %d", projectID)
+}
+
+// --- Plans ---
+
+func (c *Client) GetPlan(ctx context.Context, planID int64) (*data.Plan, error) {
+	return find(c, "plan", planID,
+		func(p *ProjectData) []data.Plan { return p.Plans },
+		func(p data.Plan) bool { return p.ID == planID })
+}
+
+// GetPlans returns the project's plans without entries, as get_plans does.
+func (c *Client) GetPlans(ctx context.Context, projectID int64) (data.GetPlansResponse, error) {
+	p, err := c.project(projectID)
+	if err != nil {
+		return nil, err
+	}
+	plans, err := captured(c, p.Plans, "plans of project %d", projectID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range plans {
+		plans[i].Entries = nil
+	}
+	return plans, nil
+}
+
+func (c *Client) GetPlansFiltered(ctx context.Context, projectID int64, filter data.PlanFilter) (data.GetPlansResponse, error) {
+	plans, err := c.GetPlans(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	filtered := data.GetPlansResponse{}
+	for _, p := range plans {
+		if inRange(p.CreatedOn.Unix(), filter.CreatedAfter, filter.CreatedBefore) &&
+			anyOf(filter.CreatedBy, p.CreatedBy) &&
+			(filter.IsCompleted == nil || p.IsCompleted == *filter.IsCompleted) &&
+			anyOf(filter.MilestoneIDs, p.MilestoneID) {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered, nil
+}
+
+// --- Reference data ---
+
+func (c *Client) GetConfigs(ctx context.Context, projectID int64) (data.GetConfigsResponse, error) {
+	p, err := c.project(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return captured(c, p.Configs, "configurations of project %d", projectID)
+}
+
+func (c *Client) GetTemplates(ctx context.Context, projectID int64) (data.GetTemplatesResponse, error) {
+	p, err := c.project(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return captured(c, p.Templates, "templates of project %d", projectID)
+}
+
+func (c *Client) GetPriorities(ctx context.Context) (data.GetPrioritiesResponse, error) {
+	return captured(c, c.snap.Priorities, "priorities")
+}
+
+func (c *Client) GetStatuses(ctx context.Context) (data.GetStatusesResponse, error) {
+	return captured(c, c.snap.Statuses, "statuses")
+}
+
+func (c *Client) GetResultFields(ctx context.Context) (data.GetResultFieldsResponse, error) {
+	return captured(c, c.snap.ResultFields, "result fields")
+}
+
+func (c *Client) GetUsers(ctx context.Context) (data.GetUsersResponse, error) {
+	return captured(c, c.snap.Users, "users")
+}
+
+func (c *Client) GetUser(ctx context.Context, userID int64) (*data.User, error) {
+	for _, u := range c.snap.Users {
+		if u.ID == userID {
+			return &u, nil
+		}
+	}
+	return nil, c.missing("user %d", userID)
+}
+
+func (c *Client) GetUserByEmail(ctx context.Context, email string) (*data.User, error) {
+	for _, u := range c.snap.Users {
+		if strings.EqualFold(u.Email, email) {
+			return &u, nil
+		}
+	}
+	return nil, c.missing("user %s", email)
+}
+
+func (c *Client) GetLabels(ctx context.Context, projectID int64) (data.GetLabelsResponse, error) {
+	p, err := c.project(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return captured(c, p.Labels, "labels of project %d", projectID)
+}
+
+func (c *Client) GetLabel(ctx context.Context, labelID int64) (*data.Label, error) {
+	return find(c, "label", labelID,
+		func(p *ProjectData) []data.Label { return p.Labels },
+		func(l data.Label) bool { return l.ID == labelID })
+}
+
+func (c *Client) GetDatasets(ctx context.Context, projectID int64) (data.GetDatasetsResponse, error) {
+	p, err := c.project(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return captured(c, p.Datasets, "datasets of project %d", projectID)
+}
+
+func (c *Client) GetDataset(ctx context.Context, datasetID int64) (*data.Dataset, error) {
+	return find(c, "dataset", datasetID,
+		func(p *ProjectData) []data.Dataset { return p.Datasets },
+		func(d data.Dataset) bool { return d.ID == datasetID })
+}
+
+func (c *Client) GetGroups(ctx context.Context, projectID int64) (data.GetGroupsResponse, error) {
+	p, err := c.project(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return captured(c, p.Groups, "groups of project %d", projectID)
+}
+
+func (c *Client) GetGroup(ctx context.Context, groupID int64) (*data.Group, error) {
+	return find(c, "group", groupID,
+		func(p *ProjectData) []data.Group { return p.Groups },
+		func(g data.Group) bool { return g.ID == groupID })
+}
@@ -0,0 +1,98 @@
+package snapshot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Reads(t *testing.T) {
+	c := NewClient(createTestSnapshot(t), "snap")
+	ctx := context.Background()
+
+	projects, err := c.GetProjects(ctx)
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+
+	cases, err := c.GetCases(ctx, 1, 20, 0)
+	require.NoError(t, err)
+	assert.Len(t, cases, 2)
+
+	cases, err = c.GetCasesFiltered(ctx, 1, data.CaseFilter{
+		PriorityIDs:  []int64{4},
+		Refs:         "tr-2",
+		CreatedAfter: time.Unix(1690000000, 0),
+	})
+	require.NoError(t, err)
+	require.Len(t, cases, 2)
+	assert.Equal(t, "Login works", cases[0].Title)
+
+	page, err := c.GetCasesPage(ctx, 1, 10, 1, 250)
+	require.NoError(t, err)
+	assert.Len(t, page, 1)
+
+	cs, err := c.GetCase(ctx, 202)
+	require.NoError(t, err)
+	assert.Equal(t, "Logout", cs.Title)
+
+	completed := true
+	runs, err := c.GetRunsFiltered(ctx, 1, data.RunFilter{IsCompleted: &completed})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, int64(7), runs[0].ID)
+
+	run, err := c.GetRun(ctx, 9)
+	require.NoError(t, err, "runs of plan entries are found")
+	assert.Equal(t, int64(9), run.ID)
+
+	plans, err := c.GetPlans(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, plans[0].Entries)
+	plan, err := c.GetPlan(ctx, 3)
+	require.NoError(t, err)
+	assert.Len(t, plan.Entries, 1)
+
+	cases, all, err := c.GetCasesParallelCtx(ctx, 1, []int64{10, 20}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, all)
+	assert.Len(t, cases, 4)
+}
+
+func TestClient_MissingAndReadOnly(t *testing.T) {
+	c := NewClient(createTestSnapshot(t), "snap")
+	ctx := context.Background()
+
+	_, err := c.GetProject(ctx, 2)
+	assert.ErrorIs(t, err, ErrNotInSnapshot)
+	_, err = c.GetDatasets(ctx, 1)
+	assert.ErrorIs(t, err, ErrNotInSnapshot)
+	_, err = c.GetResultsForRun(ctx, 7)
+	assert.ErrorIs(t, err, ErrNotInSnapshot)
+
+	_, err = c.AddCase(ctx, 11, &data.AddCaseRequest{Title: "x"})
+	assert.ErrorIs(t, err, ErrReadOnly)
+	assert.ErrorContains(t, err, "add_case")
+	assert.ErrorIs(t, c.DeleteRun(ctx, 7), ErrReadOnly)
+}
+
+func TestClient_Endpoint(t *testing.T) {
+	c := NewClient(createTestSnapshot(t), "snap")
+	ctx := context.Background()
+
+	v, err := c.Endpoint(ctx, "get_cases/1", map[string]string{"suite_id": "10"})
+	require.NoError(t, err)
+	assert.Len(t, v, 2)
+
+	v, err = c.Endpoint(ctx, "get_statuses", nil)
+	require.NoError(t, err)
+	assert.Len(t, v, 1)
+
+	_, err = c.Endpoint(ctx, "get_tests/7", nil)
+	assert.ErrorIs(t, err, ErrNotInSnapshot)
+	_, err = c.Endpoint(ctx, "get_case/abc", nil)
+	assert.Error(t, err)
+}
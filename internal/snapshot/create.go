@@ -0,0 +1,141 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/debug"
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// Create captures the given projects and the server-wide reference data
+// through cli. Core project data (suites, sections, cases, shared steps,
+// milestones, runs and plans) must be readable; optional resources the
+// server refuses (e.g. datasets or groups on older editions) are left out
+// of the snapshot rather than failing the capture.
+func Create(ctx context.Context, cli client.ClientInterface, projectIDs []int64) (*Snapshot, error) {
+	if len(projectIDs) == 0 {
+		return nil, fmt.Errorf("at least one project ID is required")
+	}
+
+	s := &Snapshot{
+		Manifest: Manifest{
+			FormatVersion: FormatVersion,
+			CreatedAt:     time.Now().UTC(),
+			ProjectIDs:    projectIDs,
+		},
+		Projects: make(map[int64]*ProjectData, len(projectIDs)),
+	}
+
+	for _, id := range projectIDs {
+		p, err := createProject(ctx, cli, id)
+		if err != nil {
+			return nil, fmt.Errorf("project %d: %w", id, err)
+		}
+		s.Projects[id] = p
+	}
+
+	s.Statuses = optional(ctx, "statuses", cli.GetStatuses)
+	s.Priorities = optional(ctx, "priorities", cli.GetPriorities)
+	s.CaseFields = optional(ctx, "case fields", cli.GetCaseFields)
+	s.CaseTypes = optional(ctx, "case types", cli.GetCaseTypes)
+	s.ResultFields = optional(ctx, "result fields", cli.GetResultFields)
+	s.Users = optional(ctx, "users", cli.GetUsers)
+
+	return s, ctx.Err()
+}
+
+func createProject(ctx context.Context, cli client.ClientInterface, projectID int64) (*ProjectData, error) {
+	project, err := cli.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, fmt.Errorf("project not found")
+	}
+	p := &ProjectData{Project: data.Project(*project)}
+
+	if p.Suites, err = cli.GetSuites(ctx, projectID); err != nil {
+		return nil, fmt.Errorf("suites: %w", err)
+	}
+	p.Suites = nonNil(p.Suites)
+	p.Sections = data.GetSectionsResponse{}
+	p.Cases = data.GetCasesResponse{}
+	for _, suite := range p.Suites {
+		sections, err := cli.GetSections(ctx, projectID, suite.ID)
+		if err != nil {
+			return nil, fmt.Errorf("sections of suite %d: %w", suite.ID, err)
+		}
+		p.Sections = append(p.Sections, sections...)
+
+		cases, err := cli.GetCases(ctx, projectID, suite.ID, 0)
+		if err != nil {
+			return nil, fmt.Errorf("cases of suite %d: %w", suite.ID, err)
+		}
+		p.Cases = append(p.Cases, cases...)
+	}
+
+	if p.SharedSteps, err = cli.GetSharedSteps(ctx, projectID); err != nil {
+		return nil, fmt.Errorf("shared steps: %w", err)
+	}
+	if p.Milestones, err = cli.GetMilestones(ctx, projectID); err != nil {
+		return nil, fmt.Errorf("milestones: %w", err)
+	}
+	if p.Runs, err = cli.GetRuns(ctx, projectID); err != nil {
+		return nil, fmt.Errorf("runs: %w", err)
+	}
+	plans, err := cli.GetPlans(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("plans: %w", err)
+	}
+	// get_plans omits entries; keep the full plans so plan runs are available offline.
+	p.Plans = make(data.GetPlansResponse, 0, len(plans))
+	for _, plan := range plans {
+		full, err := cli.GetPlan(ctx, plan.ID)
+		if err != nil {
+			return nil, fmt.Errorf("plan %d: %w", plan.ID, err)
+		}
+		if full == nil {
+			full = &plan
+		}
+		p.Plans = append(p.Plans, *full)
+	}
+	p.SharedSteps = nonNil(p.SharedSteps)
+	p.Milestones = nonNil(p.Milestones)
+	p.Runs = nonNil(p.Runs)
+
+	p.Templates = optional(ctx, "templates", bind(cli.GetTemplates, projectID))
+	p.Configs = optional(ctx, "configs", bind(cli.GetConfigs, projectID))
+	p.Labels = optional(ctx, "labels", bind(cli.GetLabels, projectID))
+	p.Datasets = optional(ctx, "datasets", bind(cli.GetDatasets, projectID))
+	p.Groups = optional(ctx, "groups", bind(cli.GetGroups, projectID))
+
+	return p, nil
+}
+
+// bind fixes the project ID of a per-project getter.
+func bind[T any](fn func(context.Context, int64) (T, error), projectID int64) func(context.Context) (T, error) {
+	return func(ctx context.Context) (T, error) { return fn(ctx, projectID) }
+}
+
+// optional fetches a resource that may be unavailable; failures are logged
+// and leave the resource out of the snapshot (nil).
+func optional[T any, S ~[]T](ctx context.Context, name string, fn func(context.Context) (S, error)) S {
+	v, err := fn(ctx)
+	if err != nil {
+		debug.DebugPrint("{snapshot} - Skipping %s: %v", name, err)
+		return nil
+	}
+	return nonNil(v)
+}
+
+// nonNil turns a nil slice into an empty one, so "captured, none" is kept
+// apart from "not captured".
+func nonNil[T any, S ~[]T](v S) S {
+	if v == nil {
+		return S{}
+	}
+	return v
+}
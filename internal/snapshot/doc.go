@@ -0,0 +1,14 @@
+// Package snapshot captures a TestRail project as versioned JSON files and
+// serves it back offline.
+//
+// [Create] reads a project's suites, sections, cases, shared steps,
+// milestones, runs, plans and reference data through a live
+// [client.ClientInterface]; [Snapshot.Save] writes the result as a directory
+// (or a .tar.gz archive) with a manifest.json describing the format version,
+// the source server and the capture time; [Load] reads either form back.
+//
+// [Client] implements [client.ClientInterface] on top of a loaded snapshot,
+// which is what the global --snapshot flag plugs into the command tree: read
+// calls are answered from the dump, reads of data a snapshot does not hold
+// fail with [ErrNotInSnapshot], and every write fails with [ErrReadOnly].
+package snapshot
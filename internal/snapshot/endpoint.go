@@ -0,0 +1,87 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Endpoint answers a raw API v2 GET endpoint ("get_cases/30") from the
+// snapshot, as `gotr export` issues them. Only the suite_id and section_id
+// query parameters are honoured.
+func (c *Client) Endpoint(ctx context.Context, endpoint string, query map[string]string) (any, error) {
+	name, arg, _ := strings.Cut(strings.Trim(endpoint, "/"), "/")
+	id := int64(0)
+	if arg != "" {
+		var err error
+		if id, err = strconv.ParseInt(arg, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid ID in endpoint %s: %w", endpoint, err)
+		}
+	}
+	param := func(key string) int64 {
+		v, _ := strconv.ParseInt(query[key], 10, 64)
+		return v
+	}
+
+	switch name {
+	case "get_projects":
+		return c.GetProjects(ctx)
+	case "get_project":
+		return c.GetProject(ctx, id)
+	case "get_suites":
+		return c.GetSuites(ctx, id)
+	case "get_suite":
+		return c.GetSuite(ctx, id)
+	case "get_sections":
+		return c.GetSections(ctx, id, param("suite_id"))
+	case "get_section":
+		return c.GetSection(ctx, id)
+	case "get_cases":
+		return c.GetCases(ctx, id, param("suite_id"), param("section_id"))
+	case "get_case":
+		return c.GetCase(ctx, id)
+	case "get_shared_steps":
+		return c.GetSharedSteps(ctx, id)
+	case "get_shared_step":
+		return c.GetSharedStep(ctx, id)
+	case "get_milestones":
+		return c.GetMilestones(ctx, id)
+	case "get_milestone":
+		return c.GetMilestone(ctx, id)
+	case "get_runs":
+		return c.GetRuns(ctx, id)
+	case "get_run":
+		return c.GetRun(ctx, id)
+	case "get_plans":
+		return c.GetPlans(ctx, id)
+	case "get_plan":
+		return c.GetPlan(ctx, id)
+	case "get_templates":
+		return c.GetTemplates(ctx, id)
+	case "get_configs":
+		return c.GetConfigs(ctx, id)
+	case "get_labels":
+		return c.GetLabels(ctx, id)
+	case "get_datasets":
+		return c.GetDatasets(ctx, id)
+	case "get_groups":
+		return c.GetGroups(ctx, id)
+	case "get_statuses":
+		return c.GetStatuses(ctx)
+	case "get_priorities":
+		return c.GetPriorities(ctx)
+	case "get_case_fields":
+		return c.GetCaseFields(ctx)
+	case "get_case_types":
+		return c.GetCaseTypes(ctx)
+	case "get_result_fields":
+		return c.GetResultFields(ctx)
+	case "get_users":
+		return c.GetUsers(ctx)
+	case "get_user":
+		return c.GetUser(ctx, id)
+	default:
+		return nil, c.missing("%s", name)
+	}
+}
@@ -0,0 +1,373 @@
+package snapshot
+
+import (
+	"context"
+	"io"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// Writes: a snapshot never changes.
+
+func (c *Client) AddProject(ctx context.Context, req *data.AddProjectRequest) (*data.GetProjectResponse, error) {
+	return nil, c.readOnly("add_project")
+}
+
+func (c *Client) UpdateProject(ctx context.Context, projectID int64, req *data.UpdateProjectRequest) (*data.GetProjectResponse, error) {
+	return nil, c.readOnly("update_project")
+}
+
+func (c *Client) DeleteProject(ctx context.Context, projectID int64) error {
+	return c.readOnly("delete_project")
+}
+
+func (c *Client) AddCase(ctx context.Context, sectionID int64, req *data.AddCaseRequest) (*data.Case, error) {
+	return nil, c.readOnly("add_case")
+}
+
+func (c *Client) UpdateCase(ctx context.Context, caseID int64, req *data.UpdateCaseRequest) (*data.Case, error) {
+	return nil, c.readOnly("update_case")
+}
+
+func (c *Client) DeleteCase(ctx context.Context, caseID int64) error {
+	return c.readOnly("delete_case")
+}
+
+func (c *Client) UpdateCases(ctx context.Context, suiteID int64, req *data.UpdateCasesRequest) (*data.GetCasesResponse, error) {
+	return nil, c.readOnly("update_cases")
+}
+
+func (c *Client) DeleteCases(ctx context.Context, suiteID int64, req *data.DeleteCasesRequest) error {
+	return c.readOnly("delete_cases")
+}
+
+func (c *Client) CopyCasesToSection(ctx context.Context, sectionID int64, req *data.CopyCasesRequest) error {
+	return c.readOnly("copy_cases_to_section")
+}
+
+func (c *Client) MoveCasesToSection(ctx context.Context, sectionID int64, req *data.MoveCasesRequest) error {
+	return c.readOnly("move_cases_to_section")
+}
+
+func (c *Client) AddCaseField(ctx context.Context, req *data.AddCaseFieldRequest) (*data.AddCaseFieldResponse, error) {
+	return nil, c.readOnly("add_case_field")
+}
+
+func (c *Client) AddSuite(ctx context.Context, projectID int64, req *data.AddSuiteRequest) (*data.Suite, error) {
+	return nil, c.readOnly("add_suite")
+}
+
+func (c *Client) UpdateSuite(ctx context.Context, suiteID int64, req *data.UpdateSuiteRequest) (*data.Suite, error) {
+	return nil, c.readOnly("update_suite")
+}
+
+func (c *Client) DeleteSuite(ctx context.Context, suiteID int64) error {
+	return c.readOnly("delete_suite")
+}
+
+func (c *Client) AddSection(ctx context.Context, projectID int64, req *data.AddSectionRequest) (*data.Section, error) {
+	return nil, c.readOnly("add_section")
+}
+
+func (c *Client) UpdateSection(ctx context.Context, sectionID int64, req *data.UpdateSectionRequest) (*data.Section, error) {
+	return nil, c.readOnly("update_section")
+}
+
+func (c *Client) DeleteSection(ctx context.Context, sectionID int64) error {
+	return c.readOnly("delete_section")
+}
+
+func (c *Client) AddSharedStep(ctx context.Context, projectID int64, req *data.AddSharedStepRequest) (*data.SharedStep, error) {
+	return nil, c.readOnly("add_shared_step")
+}
+
+func (c *Client) UpdateSharedStep(ctx context.Context, stepID int64, req *data.UpdateSharedStepRequest) (*data.SharedStep, error) {
+	return nil, c.readOnly("update_shared_step")
+}
+
+func (c *Client) DeleteSharedStep(ctx context.Context, stepID int64, keepInCases int) error {
+	return c.readOnly("delete_shared_step")
+}
+
+func (c *Client) AddRun(ctx context.Context, projectID int64, req *data.AddRunRequest) (*data.Run, error) {
+	return nil, c.readOnly("add_run")
+}
+
+func (c *Client) UpdateRun(ctx context.Context, runID int64, req *data.UpdateRunRequest) (*data.Run, error) {
+	return nil, c.readOnly("update_run")
+}
+
+func (c *Client) CloseRun(ctx context.Context, runID int64) (*data.Run, error) {
+	return nil, c.readOnly("close_run")
+}
+
+func (c *Client) DeleteRun(ctx context.Context, runID int64) error {
+	return c.readOnly("delete_run")
+}
+
+func (c *Client) AddResult(ctx context.Context, testID int64, req *data.AddResultRequest) (*data.Result, error) {
+	return nil, c.readOnly("add_result")
+}
+
+func (c *Client) AddResultForCase(ctx context.Context, runID, caseID int64, req *data.AddResultRequest) (*data.Result, error) {
+	return nil, c.readOnly("add_result_for_case")
+}
+
+func (c *Client) AddResults(ctx context.Context, runID int64, req *data.AddResultsRequest) (data.GetResultsResponse, error) {
+	return nil, c.readOnly("add_results")
+}
+
+func (c *Client) AddResultsForCases(ctx context.Context, runID int64, req *data.AddResultsForCasesRequest) (data.GetResultsResponse, error) {
+	return nil, c.readOnly("add_results_for_cases")
+}
+
+func (c *Client) UpdateTest(ctx context.Context, testID int64, req *data.UpdateTestRequest) (*data.Test, error) {
+	return nil, c.readOnly("update_test")
+}
+
+func (c *Client) AddMilestone(ctx context.Context, projectID int64, req *data.AddMilestoneRequest) (*data.Milestone, error) {
+	return nil, c.readOnly("add_milestone")
+}
+
+func (c *Client) UpdateMilestone(ctx context.Context, milestoneID int64, req *data.UpdateMilestoneRequest) (*data.Milestone, error) {
+	return nil, c.readOnly("update_milestone")
+}
+
+func (c *Client) DeleteMilestone(ctx context.Context, milestoneID int64) error {
+	return c.readOnly("delete_milestone")
+}
+
+func (c *Client) AddPlan(ctx context.Context, projectID int64, req *data.AddPlanRequest) (*data.Plan, error) {
+	return nil, c.readOnly("add_plan")
+}
+
+func (c *Client) UpdatePlan(ctx context.Context, planID int64, req *data.UpdatePlanRequest) (*data.Plan, error) {
+	return nil, c.readOnly("update_plan")
+}
+
+func (c *Client) ClosePlan(ctx context.Context, planID int64) (*data.Plan, error) {
+	return nil, c.readOnly("close_plan")
+}
+
+func (c *Client) DeletePlan(ctx context.Context, planID int64) error {
+	return c.readOnly("delete_plan")
+}
+
+func (c *Client) AddPlanEntry(ctx context.Context, planID int64, req *data.AddPlanEntryRequest) (*data.Plan, error) {
+	return nil, c.readOnly("add_plan_entry")
+}
+
+func (c *Client) UpdatePlanEntry(ctx context.Context, planID int64, entryID string, req *data.UpdatePlanEntryRequest) (*data.Plan, error) {
+	return nil, c.readOnly("update_plan_entry")
+}
+
+func (c *Client) DeletePlanEntry(ctx context.Context, planID int64, entryID string) error {
+	return c.readOnly("delete_plan_entry")
+}
+
+func (c *Client) AddAttachmentToCase(ctx context.Context, caseID int64, filePath string) (*data.AttachmentResponse, error) {
+	return nil, c.readOnly("add_attachment_to_case")
+}
+
+func (c *Client) AddAttachmentToPlan(ctx context.Context, planID int64, filePath string) (*data.AttachmentResponse, error) {
+	return nil, c.readOnly("add_attachment_to_plan")
+}
+
+func (c *Client) AddAttachmentToPlanEntry(ctx context.Context, planID int64, entryID, filePath string) (*data.AttachmentResponse, error) {
+	return nil, c.readOnly("add_attachment_to_plan_entry")
+}
+
+func (c *Client) AddAttachmentToResult(ctx context.Context, resultID int64, filePath string) (*data.AttachmentResponse, error) {
+	return nil, c.readOnly("add_attachment_to_result")
+}
+
+func (c *Client) AddAttachmentToRun(ctx context.Context, runID int64, filePath string) (*data.AttachmentResponse, error) {
+	return nil, c.readOnly("add_attachment_to_run")
+}
+
+func (c *Client) DeleteAttachment(ctx context.Context, attachmentID int64) error {
+	return c.readOnly("delete_attachment")
+}
+
+func (c *Client) AddConfigGroup(ctx context.Context, projectID int64, req *data.AddConfigGroupRequest) (*data.ConfigGroup, error) {
+	return nil, c.readOnly("add_config_group")
+}
+
+func (c *Client) AddConfig(ctx context.Context, groupID int64, req *data.AddConfigRequest) (*data.Config, error) {
+	return nil, c.readOnly("add_config")
+}
+
+func (c *Client) UpdateConfigGroup(ctx context.Context, groupID int64, req *data.UpdateConfigGroupRequest) (*data.ConfigGroup, error) {
+	return nil, c.readOnly("update_config_group")
+}
+
+func (c *Client) UpdateConfig(ctx context.Context, configID int64, req *data.UpdateConfigRequest) (*data.Config, error) {
+	return nil, c.readOnly("update_config")
+}
+
+func (c *Client) DeleteConfigGroup(ctx context.Context, groupID int64) error {
+	return c.readOnly("delete_config_group")
+}
+
+func (c *Client) DeleteConfig(ctx context.Context, configID int64) error {
+	return c.readOnly("delete_config")
+}
+
+func (c *Client) AddUser(ctx context.Context, req data.AddUserRequest) (*data.User, error) {
+	return nil, c.readOnly("add_user")
+}
+
+func (c *Client) UpdateUser(ctx context.Context, userID int64, req data.UpdateUserRequest) (*data.User, error) {
+	return nil, c.readOnly("update_user")
+}
+
+func (c *Client) AddGroup(ctx context.Context, projectID int64, name string, userIDs []int64) (*data.Group, error) {
+	return nil, c.readOnly("add_group")
+}
+
+func (c *Client) UpdateGroup(ctx context.Context, groupID int64, name string, userIDs []int64) (*data.Group, error) {
+	return nil, c.readOnly("update_group")
+}
+
+func (c *Client) DeleteGroup(ctx context.Context, groupID int64) error {
+	return c.readOnly("delete_group")
+}
+
+func (c *Client) AddDataset(ctx context.Context, projectID int64, name string) (*data.Dataset, error) {
+	return nil, c.readOnly("add_dataset")
+}
+
+func (c *Client) UpdateDataset(ctx context.Context, datasetID int64, name string) (*data.Dataset, error) {
+	return nil, c.readOnly("update_dataset")
+}
+
+func (c *Client) DeleteDataset(ctx context.Context, datasetID int64) error {
+	return c.readOnly("delete_dataset")
+}
+
+func (c *Client) AddVariable(ctx context.Context, datasetID int64, name string) (*data.Variable, error) {
+	return nil, c.readOnly("add_variable")
+}
+
+func (c *Client) UpdateVariable(ctx context.Context, variableID int64, name string) (*data.Variable, error) {
+	return nil, c.readOnly("update_variable")
+}
+
+func (c *Client) DeleteVariable(ctx context.Context, variableID int64) error {
+	return c.readOnly("delete_variable")
+}
+
+func (c *Client) AddBDD(ctx context.Context, caseID int64, content string) (*data.BDD, error) {
+	return nil, c.readOnly("add_bdd")
+}
+
+func (c *Client) UpdateLabel(ctx context.Context, labelID int64, req data.UpdateLabelRequest) (*data.Label, error) {
+	return nil, c.readOnly("update_label")
+}
+
+func (c *Client) UpdateTestLabels(ctx context.Context, testID int64, labels []string) error {
+	return c.readOnly("update_test_labels")
+}
+
+func (c *Client) UpdateTestsLabels(ctx context.Context, runID int64, testIDs []int64, labels []string) error {
+	return c.readOnly("update_tests_labels")
+}
+
+// Reads of data a snapshot does not capture (tests, results, attachments,
+// reports, roles, ...).
+
+func (c *Client) GetResults(ctx context.Context, testID int64) (data.GetResultsResponse, error) {
+	return nil, c.missing("get_results")
+}
+
+func (c *Client) GetResultsForRun(ctx context.Context, runID int64) (data.GetResultsResponse, error) {
+	return nil, c.missing("get_results_for_run")
+}
+
+func (c *Client) GetResultsForRunFiltered(ctx context.Context, runID int64, filter data.ResultFilter) (data.GetResultsResponse, error) {
+	return nil, c.missing("get_results_for_run")
+}
+
+func (c *Client) GetResultsForCase(ctx context.Context, runID, caseID int64) (data.GetResultsResponse, error) {
+	return nil, c.missing("get_results_for_case")
+}
+
+func (c *Client) GetTest(ctx context.Context, testID int64) (*data.Test, error) {
+	return nil, c.missing("get_test")
+}
+
+func (c *Client) GetTests(ctx context.Context, runID int64, filters map[string]string) ([]data.Test, error) {
+	return nil, c.missing("get_tests")
+}
+
+func (c *Client) DownloadAttachment(ctx context.Context, attachmentID int64, w io.Writer) (int64, error) {
+	return 0, c.missing("download_attachment")
+}
+
+func (c *Client) GetAttachment(ctx context.Context, attachmentID int64) (*data.Attachment, error) {
+	return nil, c.missing("get_attachment")
+}
+
+func (c *Client) GetAttachmentsForCase(ctx context.Context, caseID int64) (data.GetAttachmentsResponse, error) {
+	return nil, c.missing("get_attachments_for_case")
+}
+
+func (c *Client) GetAttachmentsForPlan(ctx context.Context, planID int64) (data.GetAttachmentsResponse, error) {
+	return nil, c.missing("get_attachments_for_plan")
+}
+
+func (c *Client) GetAttachmentsForPlanEntry(ctx context.Context, planID int64, entryID string) (data.GetAttachmentsResponse, error) {
+	return nil, c.missing("get_attachments_for_plan_entry")
+}
+
+func (c *Client) GetAttachmentsForProject(ctx context.Context, projectID int64) (data.GetAttachmentsResponse, error) {
+	return nil, c.missing("get_attachments_for_project")
+}
+
+func (c *Client) GetAttachmentsForRun(ctx context.Context, runID int64) (data.GetAttachmentsResponse, error) {
+	return nil, c.missing("get_attachments_for_run")
+}
+
+func (c *Client) GetAttachmentsForTest(ctx context.Context, testID int64) (data.GetAttachmentsResponse, error) {
+	return nil, c.missing("get_attachments_for_test")
+}
+
+func (c *Client) GetUsersByProject(ctx context.Context, projectID int64) (data.GetUsersResponse, error) {
+	return nil, c.missing("get_users_by_project")
+}
+
+func (c *Client) GetReports(ctx context.Context, projectID int64) (data.GetReportsResponse, error) {
+	return nil, c.missing("get_reports")
+}
+
+func (c *Client) GetCrossProjectReports(ctx context.Context) (data.GetReportsResponse, error) {
+	return nil, c.missing("get_cross_project_reports")
+}
+
+func (c *Client) RunReport(ctx context.Context, templateID int64) (*data.RunReportResponse, error) {
+	return nil, c.missing("run_report")
+}
+
+func (c *Client) RunCrossProjectReport(ctx context.Context, templateID int64) (*data.RunReportResponse, error) {
+	return nil, c.missing("run_cross_project_report")
+}
+
+func (c *Client) DownloadReport(ctx context.Context, reportURL, mediaType string, w io.Writer) (int64, error) {
+	return 0, c.missing("report download")
+}
+
+func (c *Client) GetRoles(ctx context.Context) (data.GetRolesResponse, error) {
+	return nil, c.missing("get_roles")
+}
+
+func (c *Client) GetRole(ctx context.Context, roleID int64) (*data.Role, error) {
+	return nil, c.missing("get_role")
+}
+
+func (c *Client) GetVariables(ctx context.Context, datasetID int64) (data.GetVariablesResponse, error) {
+	return nil, c.missing("get_variables")
+}
+
+func (c *Client) GetBDD(ctx context.Context, caseID int64) (*data.BDD, error) {
+	return nil, c.missing("get_bdd")
+}
@@ -0,0 +1,337 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// FormatVersion is the on-disk layout version written to manifest.json.
+// Load refuses snapshots written by a newer version.
+const FormatVersion = 1
+
+const manifestFile = "manifest.json"
+
+// Manifest describes a snapshot.
+type Manifest struct {
+	FormatVersion int       `json:"format_version"`
+	CreatedAt     time.Time `json:"created_at"`
+	BaseURL       string    `json:"base_url,omitempty"`
+	ProjectIDs    []int64   `json:"project_ids"`
+	Generator     string    `json:"generator,omitempty"`
+}
+
+// ProjectData is everything captured for one project. A nil slice means the
+// resource was not captured (e.g. the server denied access to it); an empty
+// one means the project has none.
+type ProjectData struct {
+	Project     data.Project                `json:"project"`
+	Suites      data.GetSuitesResponse      `json:"suites"`
+	Sections    data.GetSectionsResponse    `json:"sections"`
+	Cases       data.GetCasesResponse       `json:"cases"`
+	SharedSteps data.GetSharedStepsResponse `json:"shared_steps"`
+	Milestones  []data.Milestone            `json:"milestones"`
+	Runs        data.GetRunsResponse        `json:"runs"`
+	Plans       data.GetPlansResponse       `json:"plans"` // with entries
+	Templates   data.GetTemplatesResponse   `json:"templates"`
+	Configs     data.GetConfigsResponse     `json:"configs"`
+	Labels      data.GetLabelsResponse      `json:"labels"`
+	Datasets    data.GetDatasetsResponse    `json:"datasets"`
+	Groups      data.GetGroupsResponse      `json:"groups"`
+}
+
+// Snapshot is a loaded or freshly captured dump.
+type Snapshot struct {
+	Manifest     Manifest
+	Projects     map[int64]*ProjectData
+	Statuses     data.GetStatusesResponse
+	Priorities   data.GetPrioritiesResponse
+	CaseFields   data.GetCaseFieldsResponse
+	CaseTypes    data.GetCaseTypesResponse
+	ResultFields data.GetResultFieldsResponse
+	Users        data.GetUsersResponse
+}
+
+// file is one JSON document of the on-disk layout.
+type file struct {
+	name string
+	v    any
+}
+
+// files lists the documents of the layout in a stable order; resources that
+// were not captured are left out.
+func (s *Snapshot) files() []file {
+	files := []file{{manifestFile, s.Manifest}}
+	add := func(name string, v any, captured bool) {
+		if captured {
+			files = append(files, file{name, v})
+		}
+	}
+	add("statuses.json", s.Statuses, s.Statuses != nil)
+	add("priorities.json", s.Priorities, s.Priorities != nil)
+	add("case_fields.json", s.CaseFields, s.CaseFields != nil)
+	add("case_types.json", s.CaseTypes, s.CaseTypes != nil)
+	add("result_fields.json", s.ResultFields, s.ResultFields != nil)
+	add("users.json", s.Users, s.Users != nil)
+
+	for _, id := range s.projectIDs() {
+		p := s.Projects[id]
+		dir := path.Join("projects", strconv.FormatInt(id, 10))
+		add(path.Join(dir, "project.json"), p.Project, true)
+		add(path.Join(dir, "suites.json"), p.Suites, p.Suites != nil)
+		add(path.Join(dir, "sections.json"), p.Sections, p.Sections != nil)
+		add(path.Join(dir, "cases.json"), p.Cases, p.Cases != nil)
+		add(path.Join(dir, "shared_steps.json"), p.SharedSteps, p.SharedSteps != nil)
+		add(path.Join(dir, "milestones.json"), p.Milestones, p.Milestones != nil)
+		add(path.Join(dir, "runs.json"), p.Runs, p.Runs != nil)
+		add(path.Join(dir, "plans.json"), p.Plans, p.Plans != nil)
+		add(path.Join(dir, "templates.json"), p.Templates, p.Templates != nil)
+		add(path.Join(dir, "configs.json"), p.Configs, p.Configs != nil)
+		add(path.Join(dir, "labels.json"), p.Labels, p.Labels != nil)
+		add(path.Join(dir, "datasets.json"), p.Datasets, p.Datasets != nil)
+		add(path.Join(dir, "groups.json"), p.Groups, p.Groups != nil)
+	}
+	return files
+}
+
+func (s *Snapshot) projectIDs() []int64 {
+	ids := make([]int64, 0, len(s.Projects))
+	for id := range s.Projects {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// IsArchive reports whether p names a .tar.gz archive rather than a directory.
+func IsArchive(p string) bool {
+	return strings.HasSuffix(p, ".tar.gz") || strings.HasSuffix(p, ".tgz")
+}
+
+// Save writes the snapshot to dst: a .tar.gz archive when dst ends in .tar.gz
+// or .tgz, a directory otherwise. An existing destination is not overwritten.
+func (s *Snapshot) Save(dst string) error {
+	if _, err := os.Stat(dst); err == nil {
+		return fmt.Errorf("snapshot destination %s already exists", dst)
+	}
+
+	encoded := make(map[string][]byte)
+	files := s.files()
+	for _, f := range files {
+		b, err := json.MarshalIndent(f.v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode %s: %w", f.name, err)
+		}
+		encoded[f.name] = b
+	}
+
+	if IsArchive(dst) {
+		return writeArchive(dst, files, encoded)
+	}
+	for _, f := range files {
+		name := filepath.Join(dst, filepath.FromSlash(f.name))
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(name, encoded[f.name], 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeArchive(dst string, files []file, encoded map[string][]byte) (err error) {
+	if dir := filepath.Dir(dst); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+	for _, f := range files {
+		b := encoded[f.name]
+		hdr := &tar.Header{Name: f.name, Mode: 0o644, Size: int64(len(b)), ModTime: now}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(b); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// Load reads a snapshot directory or .tar.gz archive written by Save.
+func Load(src string) (*Snapshot, error) {
+	var (
+		read func(name string) ([]byte, error)
+		err  error
+	)
+	if IsArchive(src) {
+		read, err = archiveReader(src)
+		if err != nil {
+			return nil, fmt.Errorf("read snapshot %s: %w", src, err)
+		}
+	} else {
+		read = func(name string) ([]byte, error) {
+			return os.ReadFile(filepath.Join(src, filepath.FromSlash(name)))
+		}
+	}
+
+	s, err := decode(read)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot %s: %w", src, err)
+	}
+	return s, nil
+}
+
+// archiveReader loads every regular file of a .tar.gz archive into memory.
+func archiveReader(src string) (func(string) ([]byte, error), error) {
+	f, err := os.Open(src)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(gz)
+	contents := make(map[string][]byte)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		var buf bytes.Buffer
+		if _, err := io.Copy(&buf, tr); err != nil {
+			return nil, err
+		}
+		contents[path.Clean(hdr.Name)] = buf.Bytes()
+	}
+	return func(name string) ([]byte, error) {
+		b, ok := contents[name]
+		if !ok {
+			return nil, fs.ErrNotExist
+		}
+		return b, nil
+	}, nil
+}
+
+func decode(read func(string) ([]byte, error)) (*Snapshot, error) {
+	s := &Snapshot{Projects: make(map[int64]*ProjectData)}
+
+	found, err := readJSON(read, manifestFile, &s.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%s not found: not a gotr snapshot", manifestFile)
+	}
+	if s.Manifest.FormatVersion < 1 || s.Manifest.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot format version %d (this gotr reads up to %d)",
+			s.Manifest.FormatVersion, FormatVersion)
+	}
+
+	globals := []file{
+		{"statuses.json", &s.Statuses},
+		{"priorities.json", &s.Priorities},
+		{"case_fields.json", &s.CaseFields},
+		{"case_types.json", &s.CaseTypes},
+		{"result_fields.json", &s.ResultFields},
+		{"users.json", &s.Users},
+	}
+	if err := readAll(read, globals); err != nil {
+		return nil, err
+	}
+
+	for _, id := range s.Manifest.ProjectIDs {
+		p := &ProjectData{}
+		dir := path.Join("projects", strconv.FormatInt(id, 10))
+		found, err := readJSON(read, path.Join(dir, "project.json"), &p.Project)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("project %d is listed in the manifest but missing", id)
+		}
+		err = readAll(read, []file{
+			{path.Join(dir, "suites.json"), &p.Suites},
+			{path.Join(dir, "sections.json"), &p.Sections},
+			{path.Join(dir, "cases.json"), &p.Cases},
+			{path.Join(dir, "shared_steps.json"), &p.SharedSteps},
+			{path.Join(dir, "milestones.json"), &p.Milestones},
+			{path.Join(dir, "runs.json"), &p.Runs},
+			{path.Join(dir, "plans.json"), &p.Plans},
+			{path.Join(dir, "templates.json"), &p.Templates},
+			{path.Join(dir, "configs.json"), &p.Configs},
+			{path.Join(dir, "labels.json"), &p.Labels},
+			{path.Join(dir, "datasets.json"), &p.Datasets},
+			{path.Join(dir, "groups.json"), &p.Groups},
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.Projects[id] = p
+	}
+	return s, nil
+}
+
+func readAll(read func(string) ([]byte, error), files []file) error {
+	for _, f := range files {
+		if _, err := readJSON(read, f.name, f.v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readJSON decodes one document; a missing document is not an error.
+func readJSON(read func(string) ([]byte, error), name string, v any) (bool, error) {
+	b, err := read(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return false, fmt.Errorf("decode %s: %w", name, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,110 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testServer is a mock client backing one project with two suites.
+func testServer() *client.MockClient {
+	return &client.MockClient{
+		GetProjectFunc: func(ctx context.Context, projectID int64) (*data.GetProjectResponse, error) {
+			return &data.GetProjectResponse{ID: projectID, Name: "Shop", SuiteMode: 3}, nil
+		},
+		GetSuitesFunc: func(ctx context.Context, projectID int64) (data.GetSuitesResponse, error) {
+			return data.GetSuitesResponse{{ID: 10, Name: "API"}, {ID: 20, Name: "UI"}}, nil
+		},
+		GetSectionsFunc: func(ctx context.Context, projectID, suiteID int64) (data.GetSectionsResponse, error) {
+			return data.GetSectionsResponse{{ID: suiteID + 1, Name: "Root", SuiteID: suiteID}}, nil
+		},
+		GetCasesFunc: func(ctx context.Context, projectID, suiteID, sectionID int64) (data.GetCasesResponse, error) {
+			return data.GetCasesResponse{
+				{ID: suiteID*10 + 1, Title: "Login works", SuiteID: suiteID, SectionID: suiteID + 1, PriorityID: 4, Refs: "TR-1, TR-2", CreatedOn: 1700000000},
+				{ID: suiteID*10 + 2, Title: "Logout", SuiteID: suiteID, SectionID: suiteID + 1, PriorityID: 2, CreatedOn: 1710000000},
+			}, nil
+		},
+		GetRunsFunc: func(ctx context.Context, projectID int64) (data.GetRunsResponse, error) {
+			return data.GetRunsResponse{{ID: 7, SuiteID: 10, IsCompleted: true}, {ID: 8, SuiteID: 20}}, nil
+		},
+		GetPlansFunc: func(ctx context.Context, projectID int64) (data.GetPlansResponse, error) {
+			return data.GetPlansResponse{{ID: 3, Name: "Release"}}, nil
+		},
+		GetPlanFunc: func(ctx context.Context, planID int64) (*data.Plan, error) {
+			return &data.Plan{ID: planID, Name: "Release", Entries: []data.PlanEntry{{ID: "e1", Runs: []data.Run{{ID: 9}}}}}, nil
+		},
+		GetStatusesFunc: func(ctx context.Context) (data.GetStatusesResponse, error) {
+			return data.GetStatusesResponse{{ID: 1, Name: "passed"}}, nil
+		},
+		GetDatasetsFunc: func(ctx context.Context, projectID int64) (data.GetDatasetsResponse, error) {
+			return nil, assert.AnError
+		},
+	}
+}
+
+func createTestSnapshot(t *testing.T) *Snapshot {
+	t.Helper()
+	s, err := Create(context.Background(), testServer(), []int64{1})
+	require.NoError(t, err)
+	return s
+}
+
+func TestCreate(t *testing.T) {
+	s := createTestSnapshot(t)
+
+	require.Contains(t, s.Projects, int64(1))
+	p := s.Projects[1]
+	assert.Equal(t, "Shop", p.Project.Name)
+	assert.Len(t, p.Suites, 2)
+	assert.Len(t, p.Sections, 2)
+	assert.Len(t, p.Cases, 4)
+	require.Len(t, p.Plans, 1)
+	assert.Len(t, p.Plans[0].Entries, 1, "plans are stored with entries")
+	assert.NotNil(t, p.Milestones, "captured but empty")
+	assert.Nil(t, p.Datasets, "a refused optional resource is left out")
+	assert.Equal(t, FormatVersion, s.Manifest.FormatVersion)
+
+	_, err := Create(context.Background(), testServer(), nil)
+	assert.Error(t, err)
+}
+
+func TestSaveLoad(t *testing.T) {
+	for _, name := range []string{"snap", "snap.tar.gz"} {
+		t.Run(name, func(t *testing.T) {
+			s := createTestSnapshot(t)
+			dst := filepath.Join(t.TempDir(), name)
+			require.NoError(t, s.Save(dst))
+			assert.Error(t, s.Save(dst), "existing destination is kept")
+
+			loaded, err := Load(dst)
+			require.NoError(t, err)
+			assert.Equal(t, s.Manifest.ProjectIDs, loaded.Manifest.ProjectIDs)
+			p := loaded.Projects[1]
+			require.NotNil(t, p)
+			assert.Len(t, p.Cases, 4)
+			assert.Equal(t, "TR-1, TR-2", p.Cases[0].Refs)
+			assert.NotNil(t, p.Milestones)
+			assert.Nil(t, p.Datasets)
+			assert.Len(t, loaded.Statuses, 1)
+		})
+	}
+}
+
+func TestLoad_RejectsUnknownFormat(t *testing.T) {
+	dir := t.TempDir()
+	_, err := Load(dir)
+	assert.ErrorContains(t, err, "not a gotr snapshot")
+
+	b, err := json.Marshal(Manifest{FormatVersion: FormatVersion + 1})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, manifestFile), b, 0o644))
+	_, err = Load(dir)
+	assert.ErrorContains(t, err, "unsupported snapshot format version")
+}
@@ -0,0 +1,380 @@
+package trash
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/debug"
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// The Capture functions return a nil entry when the server does not return
+// the object itself, i.e. there is nothing to keep.
+
+// CaptureCases captures test cases with their steps, the shared steps they
+// reference and their tests and results in the runs of their suites, which
+// TestRail deletes along with the cases.
+func CaptureCases(ctx context.Context, cli client.ClientInterface, caseIDs ...int64) (*Entry, error) {
+	cli = client.FreshReads(cli)
+	kind := KindCase
+	if len(caseIDs) > 1 {
+		kind = KindCases
+	}
+	e := newEntry(kind, caseIDs...)
+	for _, id := range caseIDs {
+		c, err := cli.GetCase(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("case %d: %w", id, err)
+		}
+		if c != nil {
+			e.Content.Cases = append(e.Content.Cases, *c)
+		}
+	}
+	if len(e.Content.Cases) == 0 {
+		return nil, nil
+	}
+
+	first := e.Content.Cases[0]
+	e.Manifest.SuiteID = first.SuiteID
+	e.Manifest.Title = first.Title
+	if len(e.Content.Cases) > 1 {
+		e.Manifest.Title = fmt.Sprintf("%d cases", len(e.Content.Cases))
+	}
+	suite, err := cli.GetSuite(ctx, first.SuiteID)
+	if err != nil {
+		return nil, fmt.Errorf("suite %d: %w", first.SuiteID, err)
+	}
+	if suite != nil {
+		e.Manifest.ProjectID = suite.ProjectID
+		if err := captureHistory(ctx, cli, &e.Content, suite.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+	e.Content.SharedSteps = sharedStepRefs(ctx, cli, e.Content.Cases)
+	e.count()
+	return e, nil
+}
+
+// CaptureSection captures a section with its subsections, their cases and
+// the tests and results of those cases in the runs of the suite.
+func CaptureSection(ctx context.Context, cli client.ClientInterface, sectionID int64) (*Entry, error) {
+	cli = client.FreshReads(cli)
+	section, err := cli.GetSection(ctx, sectionID)
+	if err != nil {
+		return nil, fmt.Errorf("section %d: %w", sectionID, err)
+	}
+	if section == nil {
+		return nil, nil
+	}
+	suite, err := cli.GetSuite(ctx, section.SuiteID)
+	if err != nil {
+		return nil, fmt.Errorf("suite %d: %w", section.SuiteID, err)
+	}
+	if suite == nil {
+		return nil, fmt.Errorf("suite %d not found", section.SuiteID)
+	}
+
+	e := newEntry(KindSection, sectionID)
+	e.Manifest.Title = section.Name
+	e.Manifest.ProjectID = suite.ProjectID
+	e.Manifest.SuiteID = suite.ID
+
+	sections, err := cli.GetSections(ctx, suite.ProjectID, suite.ID)
+	if err != nil {
+		return nil, fmt.Errorf("sections of suite %d: %w", suite.ID, err)
+	}
+	subtree := map[int64]bool{sectionID: true}
+	e.Content.Sections = data.GetSectionsResponse{*section}
+	for grew := true; grew; {
+		grew = false
+		for _, s := range sections {
+			if !subtree[s.ID] && subtree[s.ParentID] {
+				subtree[s.ID] = true
+				e.Content.Sections = append(e.Content.Sections, s)
+				grew = true
+			}
+		}
+	}
+
+	cases, err := cli.GetCases(ctx, suite.ProjectID, suite.ID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("cases of suite %d: %w", suite.ID, err)
+	}
+	for _, c := range cases {
+		if subtree[c.SectionID] {
+			e.Content.Cases = append(e.Content.Cases, c)
+		}
+	}
+	if err := captureHistory(ctx, cli, &e.Content, suite.ProjectID); err != nil {
+		return nil, err
+	}
+	e.Content.SharedSteps = sharedStepRefs(ctx, cli, e.Content.Cases)
+	e.count()
+	return e, nil
+}
+
+// CaptureSuite captures a suite with its sections, cases and the runs
+// created from it, including the runs of test plans.
+func CaptureSuite(ctx context.Context, cli client.ClientInterface, suiteID int64) (*Entry, error) {
+	cli = client.FreshReads(cli)
+	suite, err := cli.GetSuite(ctx, suiteID)
+	if err != nil {
+		return nil, fmt.Errorf("suite %d: %w", suiteID, err)
+	}
+	if suite == nil {
+		return nil, nil
+	}
+
+	e := newEntry(KindSuite, suiteID)
+	e.Manifest.Title = suite.Name
+	e.Manifest.ProjectID = suite.ProjectID
+	e.Manifest.SuiteID = suiteID
+	e.Content.Suites = data.GetSuitesResponse{*suite}
+	if err := captureSuiteTree(ctx, cli, &e.Content, suite.ProjectID, suiteID); err != nil {
+		return nil, err
+	}
+
+	runs, err := projectRuns(ctx, cli, suite.ProjectID, suiteID)
+	if err != nil {
+		return nil, err
+	}
+	for _, run := range runs {
+		if err := captureRun(ctx, cli, &e.Content, run); err != nil {
+			return nil, err
+		}
+	}
+	e.Content.SharedSteps = sharedStepRefs(ctx, cli, e.Content.Cases)
+	e.count()
+	return e, nil
+}
+
+// CaptureRun captures a test run with its tests and results.
+func CaptureRun(ctx context.Context, cli client.ClientInterface, runID int64) (*Entry, error) {
+	cli = client.FreshReads(cli)
+	run, err := cli.GetRun(ctx, runID)
+	if err != nil {
+		return nil, fmt.Errorf("run %d: %w", runID, err)
+	}
+	if run == nil {
+		return nil, nil
+	}
+
+	e := newEntry(KindRun, runID)
+	e.Manifest.Title = run.Name
+	e.Manifest.ProjectID = run.ProjectID
+	e.Manifest.SuiteID = run.SuiteID
+	if err := captureRun(ctx, cli, &e.Content, *run); err != nil {
+		return nil, err
+	}
+	e.count()
+	return e, nil
+}
+
+// CaptureProject captures a project with its milestones, shared steps,
+// suites, sections, cases and runs, including the runs of test plans.
+func CaptureProject(ctx context.Context, cli client.ClientInterface, projectID int64) (*Entry, error) {
+	cli = client.FreshReads(cli)
+	project, err := cli.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project %d: %w", projectID, err)
+	}
+	if project == nil {
+		return nil, nil
+	}
+
+	e := newEntry(KindProject, projectID)
+	e.Manifest.Title = project.Name
+	e.Manifest.ProjectID = projectID
+	p := data.Project(*project)
+	e.Content.Project = &p
+
+	if e.Content.Milestones, err = cli.GetMilestones(ctx, projectID); err != nil {
+		return nil, fmt.Errorf("milestones: %w", err)
+	}
+	if e.Content.SharedSteps, err = cli.GetSharedSteps(ctx, projectID); err != nil {
+		return nil, fmt.Errorf("shared steps: %w", err)
+	}
+	if e.Content.Suites, err = cli.GetSuites(ctx, projectID); err != nil {
+		return nil, fmt.Errorf("suites: %w", err)
+	}
+	for _, suite := range e.Content.Suites {
+		if err := captureSuiteTree(ctx, cli, &e.Content, projectID, suite.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	runs, err := projectRuns(ctx, cli, projectID, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, run := range runs {
+		if err := captureRun(ctx, cli, &e.Content, run); err != nil {
+			return nil, err
+		}
+	}
+	e.count()
+	return e, nil
+}
+
+// captureSuiteTree adds the sections and cases of a suite to c.
+func captureSuiteTree(ctx context.Context, cli client.ClientInterface, c *Content, projectID, suiteID int64) error {
+	sections, err := cli.GetSections(ctx, projectID, suiteID)
+	if err != nil {
+		return fmt.Errorf("sections of suite %d: %w", suiteID, err)
+	}
+	c.Sections = append(c.Sections, sections...)
+
+	cases, err := cli.GetCases(ctx, projectID, suiteID, 0)
+	if err != nil {
+		return fmt.Errorf("cases of suite %d: %w", suiteID, err)
+	}
+	c.Cases = append(c.Cases, cases...)
+	return nil
+}
+
+// captureRun adds a run with its tests and results to c.
+func captureRun(ctx context.Context, cli client.ClientInterface, c *Content, run data.Run) error {
+	tests, err := cli.GetTests(ctx, run.ID, nil)
+	if err != nil {
+		return fmt.Errorf("tests of run %d: %w", run.ID, err)
+	}
+	results, err := cli.GetResultsForRun(ctx, run.ID)
+	if err != nil {
+		return fmt.Errorf("results of run %d: %w", run.ID, err)
+	}
+	if c.Tests == nil {
+		c.Tests = make(map[int64][]data.Test)
+		c.Results = make(map[int64]data.GetResultsResponse)
+	}
+	c.Runs = append(c.Runs, run)
+	c.Tests[run.ID] = tests
+	c.Results[run.ID] = results
+	return nil
+}
+
+// projectRuns returns the runs of a project created from suiteID (any
+// suite when 0): the standalone runs followed by the runs of test plans,
+// which get_runs does not list.
+func projectRuns(ctx context.Context, cli client.ClientInterface, projectID, suiteID int64) (data.GetRunsResponse, error) {
+	var filter data.RunFilter
+	if suiteID != 0 {
+		filter.SuiteIDs = []int64{suiteID}
+	}
+	standalone, err := cli.GetRunsFiltered(ctx, projectID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("runs: %w", err)
+	}
+	all := append(data.GetRunsResponse(nil), standalone...)
+	plans, err := cli.GetPlans(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("plans: %w", err)
+	}
+	for _, p := range plans {
+		// get_plans leaves out the entries.
+		plan, err := cli.GetPlan(ctx, p.ID)
+		if err != nil {
+			return nil, fmt.Errorf("plan %d: %w", p.ID, err)
+		}
+		if plan == nil {
+			continue
+		}
+		for _, entry := range plan.Entries {
+			for _, run := range entry.Runs {
+				if run.PlanID == 0 {
+					run.PlanID = plan.ID
+				}
+				all = append(all, run)
+			}
+		}
+	}
+
+	seen := make(map[int64]bool, len(all))
+	var runs data.GetRunsResponse
+	for _, run := range all {
+		if seen[run.ID] || (suiteID != 0 && run.SuiteID != suiteID) {
+			continue
+		}
+		seen[run.ID] = true
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// captureHistory adds to c.History the tests and results of the cases of c
+// in the runs of their suites. The runs stay on the server, but TestRail
+// deletes these tests and results with the cases.
+func captureHistory(ctx context.Context, cli client.ClientInterface, c *Content, projectID int64) error {
+	caseIDs := make(map[int64]bool, len(c.Cases))
+	var suiteIDs []int64
+	for _, kase := range c.Cases {
+		caseIDs[kase.ID] = true
+		if !slices.Contains(suiteIDs, kase.SuiteID) {
+			suiteIDs = append(suiteIDs, kase.SuiteID)
+		}
+	}
+
+	for _, suiteID := range suiteIDs {
+		runs, err := projectRuns(ctx, cli, projectID, suiteID)
+		if err != nil {
+			return err
+		}
+		for _, run := range runs {
+			tests, err := cli.GetTests(ctx, run.ID, nil)
+			if err != nil {
+				return fmt.Errorf("tests of run %d: %w", run.ID, err)
+			}
+			h := RunHistory{Run: run}
+			testIDs := make(map[int64]bool)
+			for _, t := range tests {
+				if caseIDs[t.CaseID] {
+					h.Tests = append(h.Tests, t)
+					testIDs[t.ID] = true
+				}
+			}
+			if len(h.Tests) == 0 {
+				continue
+			}
+			results, err := cli.GetResultsForRun(ctx, run.ID)
+			if err != nil {
+				return fmt.Errorf("results of run %d: %w", run.ID, err)
+			}
+			for _, res := range results {
+				if testIDs[res.TestID] {
+					h.Results = append(h.Results, res)
+				}
+			}
+			c.History = append(c.History, h)
+		}
+	}
+	return nil
+}
+
+// sharedStepRefs fetches the shared steps referenced by the steps of cases.
+// Steps the server does not return are left out.
+func sharedStepRefs(ctx context.Context, cli client.ClientInterface, cases data.GetCasesResponse) data.GetSharedStepsResponse {
+	seen := make(map[int64]bool)
+	var ids []int64
+	for _, c := range cases {
+		for _, step := range c.CustomStepsSeparated {
+			if step.SharedStepID != 0 && !seen[step.SharedStepID] {
+				seen[step.SharedStepID] = true
+				ids = append(ids, step.SharedStepID)
+			}
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var steps data.GetSharedStepsResponse
+	for _, id := range ids {
+		step, err := cli.GetSharedStep(ctx, id)
+		if err != nil || step == nil {
+			debug.DebugPrint("{trash} - Skipping shared step %d: %v", id, err)
+			continue
+		}
+		steps = append(steps, *step)
+	}
+	return steps
+}
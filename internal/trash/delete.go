@@ -0,0 +1,69 @@
+package trash
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Korrnals/gotr/internal/paths"
+	"github.com/Korrnals/gotr/internal/ui"
+)
+
+// Dir returns the trash directory (tests replace it).
+var Dir = paths.TrashDirPath
+
+// SaveBeforeDelete runs capture for the object described by what, which is
+// about to be deleted, and saves the entry to the trash with baseURL. It
+// warns about what a restore cannot bring back and returns a nil entry when
+// the server does not return the object.
+func SaveBeforeDelete(ctx context.Context, what, baseURL string, quiet bool, capture func(context.Context) (*Entry, error)) (*Entry, error) {
+	entry, err := ui.RunWithStatus(ctx, ui.StatusConfig{
+		Title:  fmt.Sprintf("Saving %s to the trash", what),
+		Writer: os.Stderr,
+		Quiet:  quiet,
+	}, capture)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save %s to the trash (use --no-trash to delete anyway): %w", what, err)
+	}
+	if entry == nil {
+		ui.Warningf(os.Stderr, "%s not found, nothing saved to the trash", what)
+		return nil, nil
+	}
+	for _, run := range entry.Content.Unrestorable() {
+		reason := "closed"
+		if run.PlanID != 0 {
+			reason = fmt.Sprintf("part of test plan %d", run.PlanID)
+		}
+		ui.Warningf(os.Stderr, "Results of %s in run %d %q (%s) are deleted too; they are kept in the trash but restore cannot add them back",
+			what, run.ID, run.Name, reason)
+	}
+
+	root, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	entry.Manifest.BaseURL = baseURL
+	if err := entry.Save(root); err != nil {
+		return nil, fmt.Errorf("failed to save %s to the trash: %w", what, err)
+	}
+	return entry, nil
+}
+
+// FinishDelete reports where a deleted object was saved, or drops its entry
+// when the delete failed.
+func FinishDelete(entry *Entry, deleteErr error, quiet bool) {
+	if entry == nil {
+		return
+	}
+	root, err := Dir()
+	if err != nil {
+		return
+	}
+	if deleteErr != nil {
+		_ = Remove(root, entry.Manifest.ID)
+		return
+	}
+	if !quiet {
+		ui.Infof(os.Stderr, "Saved to the trash as %s (undo: gotr trash restore %s)", entry.Manifest.ID, entry.Manifest.ID)
+	}
+}
@@ -0,0 +1,17 @@
+// Package trash keeps a copy of TestRail objects before gotr deletes them,
+// so a delete can be undone.
+//
+// The Capture functions ([CaptureCases], [CaptureSection], [CaptureSuite],
+// [CaptureRun] and [CaptureProject]) read the full subtree of the object
+// through a live [client.ClientInterface]: sections, cases with their steps
+// and the shared steps they reference, runs with their tests and results.
+// [Entry.Save] writes it under the trash directory (~/.gotr/trash) as a
+// <timestamp> directory with a manifest.json and a content.json; [List],
+// [Load] and [Remove] manage the saved entries.
+//
+// [Restore] recreates the objects of an entry through the API. TestRail
+// assigns new IDs, so it returns the mapping from the new to the old IDs.
+//
+// Captures and [Restore] read through [client.FreshReads], so neither works
+// from stale entries of the response cache.
+package trash
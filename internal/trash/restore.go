@@ -0,0 +1,413 @@
+package trash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// untestedStatusID is the built-in "Untested" status, which cannot be
+// added as a result.
+const untestedStatusID = 3
+
+// Types of restored objects in the ID map.
+const (
+	TypeProject    = "project"
+	TypeMilestone  = "milestone"
+	TypeSharedStep = "shared_step"
+	TypeSuite      = "suite"
+	TypeSection    = "section"
+	TypeCase       = "case"
+	TypeRun        = "run"
+	TypeTest       = "test"
+)
+
+// Mapping pairs the new ID of a restored object with its old ID.
+type Mapping struct {
+	Type  string `json:"type"`
+	NewID int64  `json:"new_id"`
+	OldID int64  `json:"old_id"`
+}
+
+// restorer recreates the objects of an entry and tracks their new IDs.
+type restorer struct {
+	cli       client.ClientInterface
+	projectID int64
+	ids       map[string]map[int64]int64
+	idMap     []Mapping
+	skipped   []string
+}
+
+func (r *restorer) add(typ string, oldID, newID int64) {
+	if r.ids[typ] == nil {
+		r.ids[typ] = make(map[int64]int64)
+	}
+	r.ids[typ][oldID] = newID
+	r.idMap = append(r.idMap, Mapping{Type: typ, NewID: newID, OldID: oldID})
+}
+
+// id returns the new ID of a restored object, or oldID when the object was
+// not part of the entry (e.g. the parent section of a restored section).
+func (r *restorer) id(typ string, oldID int64) int64 {
+	if newID, ok := r.ids[typ][oldID]; ok {
+		return newID
+	}
+	return oldID
+}
+
+// Restore recreates the objects of e through cli and returns the ID map in
+// creation order. Objects keep their place: cases go back to their section,
+// sections to their parent and suites and runs to their project, unless
+// those were restored too. Runs of test plans come back as standalone runs.
+// Results are added in their original order under the current user, and the
+// results of restored cases go back to the runs that kept them; skipped
+// describes the runs that are closed, part of a test plan or gone, which
+// cannot take them. Result custom fields, case labels and test plans are
+// not restored. On error the map of the objects created so far is returned
+// with it.
+func Restore(ctx context.Context, cli client.ClientInterface, e *Entry) (idMap []Mapping, skipped []string, err error) {
+	r := &restorer{cli: client.FreshReads(cli), projectID: e.Manifest.ProjectID, ids: make(map[string]map[int64]int64)}
+	c := &e.Content
+
+	steps := []func(context.Context, *Content) error{r.sections, r.cases, r.runs, r.history}
+	switch e.Manifest.Kind {
+	case KindProject:
+		steps = append([]func(context.Context, *Content) error{r.project, r.milestones, r.sharedSteps, r.suites}, steps...)
+	case KindSuite:
+		steps = append([]func(context.Context, *Content) error{r.suites}, steps...)
+	case KindSection, KindCase, KindCases, KindRun:
+	default:
+		return nil, nil, fmt.Errorf("unknown trash entry kind %q", e.Manifest.Kind)
+	}
+
+	for _, step := range steps {
+		if err := step(ctx, c); err != nil {
+			return r.idMap, r.skipped, err
+		}
+	}
+	return r.idMap, r.skipped, nil
+}
+
+func (r *restorer) project(ctx context.Context, c *Content) error {
+	p := c.Project
+	if p == nil {
+		return fmt.Errorf("the entry holds no project")
+	}
+	created, err := r.cli.AddProject(ctx, &data.AddProjectRequest{
+		Name:             p.Name,
+		Announcement:     p.Announcement,
+		ShowAnnouncement: p.ShowAnnouncement,
+		SuiteMode:        p.SuiteMode,
+	})
+	if err != nil {
+		return fmt.Errorf("project %d: %w", p.ID, err)
+	}
+	r.projectID = created.ID
+	r.add(TypeProject, p.ID, created.ID)
+	return nil
+}
+
+func (r *restorer) milestones(ctx context.Context, c *Content) error {
+	for _, m := range c.Milestones {
+		req := &data.AddMilestoneRequest{Name: m.Name, Description: m.Description}
+		if m.DueOn.IsValid() {
+			req.DueOn = strconv.FormatInt(m.DueOn.Unix(), 10)
+		}
+		if m.StartOn.IsValid() {
+			req.StartOn = strconv.FormatInt(m.StartOn.Unix(), 10)
+		}
+		created, err := r.cli.AddMilestone(ctx, r.projectID, req)
+		if err != nil {
+			return fmt.Errorf("milestone %d: %w", m.ID, err)
+		}
+		r.add(TypeMilestone, m.ID, created.ID)
+	}
+	return nil
+}
+
+// sharedSteps recreates the shared steps of a project entry.
+func (r *restorer) sharedSteps(ctx context.Context, c *Content) error {
+	for _, s := range c.SharedSteps {
+		created, err := r.cli.AddSharedStep(ctx, r.projectID, &data.AddSharedStepRequest{
+			Title:                s.Title,
+			CustomStepsSeparated: s.CustomStepsSeparated,
+		})
+		if err != nil {
+			return fmt.Errorf("shared step %d: %w", s.ID, err)
+		}
+		r.add(TypeSharedStep, s.ID, created.ID)
+	}
+	return nil
+}
+
+func (r *restorer) suites(ctx context.Context, c *Content) error {
+	// A new single-suite project already has its suite.
+	if c.Project != nil && c.Project.SuiteMode == 1 && len(c.Suites) == 1 {
+		existing, err := r.cli.GetSuites(ctx, r.projectID)
+		if err != nil {
+			return fmt.Errorf("suites: %w", err)
+		}
+		if len(existing) == 1 {
+			r.add(TypeSuite, c.Suites[0].ID, existing[0].ID)
+			return nil
+		}
+	}
+
+	for _, s := range c.Suites {
+		created, err := r.cli.AddSuite(ctx, r.projectID, &data.AddSuiteRequest{Name: s.Name, Description: s.Description})
+		if err != nil {
+			return fmt.Errorf("suite %d: %w", s.ID, err)
+		}
+		r.add(TypeSuite, s.ID, created.ID)
+	}
+	return nil
+}
+
+// sections recreates the sections parents first.
+func (r *restorer) sections(ctx context.Context, c *Content) error {
+	inEntry := make(map[int64]bool, len(c.Sections))
+	for _, s := range c.Sections {
+		inEntry[s.ID] = true
+	}
+	pending := append(data.GetSectionsResponse(nil), c.Sections...)
+	sort.SliceStable(pending, func(i, j int) bool {
+		if pending[i].Depth != pending[j].Depth {
+			return pending[i].Depth < pending[j].Depth
+		}
+		return pending[i].DisplayOrder < pending[j].DisplayOrder
+	})
+
+	for len(pending) > 0 {
+		var next data.GetSectionsResponse
+		for _, s := range pending {
+			if inEntry[s.ParentID] {
+				if _, ok := r.ids[TypeSection][s.ParentID]; !ok {
+					next = append(next, s)
+					continue
+				}
+			}
+			created, err := r.cli.AddSection(ctx, r.projectID, &data.AddSectionRequest{
+				Name:        s.Name,
+				Description: s.Description,
+				SuiteID:     r.id(TypeSuite, s.SuiteID),
+				ParentID:    r.id(TypeSection, s.ParentID),
+			})
+			if err != nil {
+				return fmt.Errorf("section %d: %w", s.ID, err)
+			}
+			r.add(TypeSection, s.ID, created.ID)
+		}
+		if len(next) == len(pending) {
+			return fmt.Errorf("section %d: parent section %d cannot be restored", next[0].ID, next[0].ParentID)
+		}
+		pending = next
+	}
+	return nil
+}
+
+func (r *restorer) cases(ctx context.Context, c *Content) error {
+	for _, kase := range c.Cases {
+		sectionID := r.id(TypeSection, kase.SectionID)
+		req := r.caseRequest(kase)
+		req.SectionID = sectionID
+		created, err := r.cli.AddCase(ctx, sectionID, req)
+		if err != nil {
+			return fmt.Errorf("case %d: %w", kase.ID, err)
+		}
+		r.add(TypeCase, kase.ID, created.ID)
+	}
+	return nil
+}
+
+// caseRequest prepares an add_case request from a deleted case, pointing
+// its shared step references and milestone at the restored ones.
+func (r *restorer) caseRequest(c data.Case) *data.AddCaseRequest {
+	req := &data.AddCaseRequest{
+		Title:          c.Title,
+		TypeID:         c.TypeID,
+		PriorityID:     c.PriorityID,
+		TemplateID:     c.TemplateID,
+		MilestoneID:    r.id(TypeMilestone, c.MilestoneID),
+		Refs:           c.Refs,
+		Estimate:       c.Estimate,
+		CustomPreconds: c.CustomPreconds,
+		CustomSteps:    c.CustomSteps,
+		CustomExpected: c.CustomExpected,
+		CustomFields:   c.CustomFields.Clone(),
+	}
+	for _, step := range c.CustomStepsSeparated {
+		step.SharedStepID = r.id(TypeSharedStep, step.SharedStepID)
+		req.CustomStepsSeparated = append(req.CustomStepsSeparated, step)
+	}
+
+	// Modeled custom fields of Case that AddCaseRequest has no field for.
+	extra := map[string]any{
+		"custom_automation_type": c.CustomAutomationType,
+		"custom_mission":         c.CustomMission,
+		"custom_goals":           c.CustomGoals,
+	}
+	for name, v := range extra {
+		if v == int64(0) || v == "" {
+			continue
+		}
+		if req.CustomFields == nil {
+			req.CustomFields = make(data.CustomFieldValues)
+		}
+		b, _ := json.Marshal(v)
+		req.CustomFields[name] = b
+	}
+	return req
+}
+
+// runs recreates the runs with the cases of their tests, adds the results
+// oldest first and closes the runs that were closed. Runs of test plans
+// come back as standalone runs.
+func (r *restorer) runs(ctx context.Context, c *Content) error {
+	for _, run := range c.Runs {
+		tests := c.Tests[run.ID]
+		caseIDs := make([]int64, 0, len(tests))
+		for _, t := range tests {
+			caseIDs = append(caseIDs, r.id(TypeCase, t.CaseID))
+		}
+
+		includeAll := false
+		created, err := r.cli.AddRun(ctx, r.projectID, &data.AddRunRequest{
+			Name:        run.Name,
+			Description: run.Description,
+			SuiteID:     r.id(TypeSuite, run.SuiteID),
+			MilestoneID: r.id(TypeMilestone, run.MilestoneID),
+			AssignedTo:  run.AssignedTo,
+			IncludeAll:  &includeAll,
+			CaseIDs:     caseIDs,
+		})
+		if err != nil {
+			return fmt.Errorf("run %d: %w", run.ID, err)
+		}
+		r.add(TypeRun, run.ID, created.ID)
+
+		if err := r.results(ctx, created.ID, tests, c.Results[run.ID]); err != nil {
+			return fmt.Errorf("results of run %d: %w", run.ID, err)
+		}
+		if err := r.tests(ctx, created.ID, tests); err != nil {
+			return err
+		}
+		if run.IsCompleted {
+			if _, err := r.cli.CloseRun(ctx, created.ID); err != nil {
+				return fmt.Errorf("close run %d: %w", created.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// history adds the tests and results of restored cases back to the runs
+// that kept them. Closed runs and runs of test plans cannot take new tests;
+// they are skipped, as are runs that no longer exist.
+func (r *restorer) history(ctx context.Context, c *Content) error {
+	for _, h := range c.History {
+		run, err := r.cli.GetRun(ctx, h.Run.ID)
+		switch {
+		case err != nil || run == nil:
+			r.skip(h, "the run no longer exists")
+			continue
+		case run.IsCompleted:
+			r.skip(h, "the run is closed")
+			continue
+		case run.PlanID != 0:
+			r.skip(h, fmt.Sprintf("the run is part of test plan %d", run.PlanID))
+			continue
+		}
+
+		if !run.IncludeAll {
+			current, err := r.cli.GetTests(ctx, run.ID, nil)
+			if err != nil {
+				return fmt.Errorf("tests of run %d: %w", run.ID, err)
+			}
+			caseIDs := make([]int64, 0, len(current)+len(h.Tests))
+			for _, t := range current {
+				caseIDs = append(caseIDs, t.CaseID)
+			}
+			for _, t := range h.Tests {
+				caseIDs = append(caseIDs, r.id(TypeCase, t.CaseID))
+			}
+			if _, err := r.cli.UpdateRun(ctx, run.ID, &data.UpdateRunRequest{CaseIDs: caseIDs}); err != nil {
+				return fmt.Errorf("add cases to run %d: %w", run.ID, err)
+			}
+		}
+		if err := r.results(ctx, run.ID, h.Tests, h.Results); err != nil {
+			return fmt.Errorf("results of run %d: %w", run.ID, err)
+		}
+		if err := r.tests(ctx, run.ID, h.Tests); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *restorer) skip(h RunHistory, reason string) {
+	r.skipped = append(r.skipped, fmt.Sprintf("%d results in run %d %q not restored: %s",
+		len(h.Results), h.Run.ID, h.Run.Name, reason))
+}
+
+// results adds the results of the old tests to runID, oldest first, for
+// the cases of those tests.
+func (r *restorer) results(ctx context.Context, runID int64, tests []data.Test, old data.GetResultsResponse) error {
+	caseOf := make(map[int64]int64, len(tests))
+	for _, t := range tests {
+		caseOf[t.ID] = t.CaseID
+	}
+	results := append(data.GetResultsResponse(nil), old...)
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].CreatedOn != results[j].CreatedOn {
+			return results[i].CreatedOn < results[j].CreatedOn
+		}
+		return results[i].ID < results[j].ID
+	})
+	var entries []data.ResultForCaseEntry
+	for _, res := range results {
+		caseID, ok := caseOf[res.TestID]
+		if !ok || res.StatusID == 0 || res.StatusID == untestedStatusID {
+			continue
+		}
+		entries = append(entries, data.ResultForCaseEntry{
+			CaseID:     r.id(TypeCase, caseID),
+			StatusID:   res.StatusID,
+			Comment:    res.Comment,
+			Version:    res.Version,
+			Elapsed:    res.Elapsed,
+			Defects:    res.Defects,
+			AssignedTo: res.AssignedTo,
+		})
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	_, err := r.cli.AddResultsForCases(ctx, runID, &data.AddResultsForCasesRequest{Results: entries})
+	return err
+}
+
+// tests maps the tests of a restored run to the old tests by case.
+func (r *restorer) tests(ctx context.Context, runID int64, old []data.Test) error {
+	if len(old) == 0 {
+		return nil
+	}
+	created, err := r.cli.GetTests(ctx, runID, nil)
+	if err != nil {
+		return fmt.Errorf("tests of run %d: %w", runID, err)
+	}
+	byCase := make(map[int64]int64, len(created))
+	for _, t := range created {
+		byCase[t.CaseID] = t.ID
+	}
+	for _, t := range old {
+		if newID, ok := byCase[r.id(TypeCase, t.CaseID)]; ok {
+			r.add(TypeTest, t.ID, newID)
+		}
+	}
+	return nil
+}
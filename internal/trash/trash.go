@@ -0,0 +1,265 @@
+package trash
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/models/data"
+)
+
+// FormatVersion is the on-disk layout version written to manifest.json.
+// Load refuses entries written by a newer version.
+const FormatVersion = 1
+
+const (
+	manifestFile = "manifest.json"
+	contentFile  = "content.json"
+	idLayout     = "20060102-150405"
+)
+
+// Kinds of deleted objects.
+const (
+	KindProject = "project"
+	KindSuite   = "suite"
+	KindSection = "section"
+	KindCase    = "case"
+	KindCases   = "cases" // cases bulk delete
+	KindRun     = "run"
+)
+
+// ErrNotFound is returned by Load for an unknown entry ID.
+var ErrNotFound = errors.New("trash entry not found")
+
+// Manifest describes a trash entry.
+type Manifest struct {
+	FormatVersion int       `json:"format_version"`
+	ID            string    `json:"id"`
+	CreatedAt     time.Time `json:"created_at"`
+	BaseURL       string    `json:"base_url,omitempty"`
+	Kind          string    `json:"kind"`
+	ObjectIDs     []int64   `json:"object_ids"` // the deleted objects
+	Title         string    `json:"title,omitempty"`
+	ProjectID     int64     `json:"project_id,omitempty"`
+	SuiteID       int64     `json:"suite_id,omitempty"`
+	Counts        Counts    `json:"counts"`
+	Restored      *Restored `json:"restored,omitempty"`
+}
+
+// Counts is the number of objects of each type in an entry.
+type Counts struct {
+	Suites      int `json:"suites,omitempty"`
+	Sections    int `json:"sections,omitempty"`
+	Cases       int `json:"cases,omitempty"`
+	SharedSteps int `json:"shared_steps,omitempty"`
+	Milestones  int `json:"milestones,omitempty"`
+	Runs        int `json:"runs,omitempty"`
+	Tests       int `json:"tests,omitempty"`
+	Results     int `json:"results,omitempty"`
+	HistoryRuns int `json:"history_runs,omitempty"` // runs of Content.History
+}
+
+// Restored records a restore of the entry.
+type Restored struct {
+	At    time.Time `json:"at"`
+	IDMap []Mapping `json:"id_map"`
+}
+
+// Content is the captured subtree. Shared steps of a project entry are all
+// steps of the project; for other kinds they are the steps referenced by
+// the cases and are kept for reference only (they are not deleted with the
+// cases and are not recreated).
+type Content struct {
+	Project     *data.Project                     `json:"project,omitempty"`
+	Milestones  []data.Milestone                  `json:"milestones,omitempty"`
+	SharedSteps data.GetSharedStepsResponse       `json:"shared_steps,omitempty"`
+	Suites      data.GetSuitesResponse            `json:"suites,omitempty"`
+	Sections    data.GetSectionsResponse          `json:"sections,omitempty"`
+	Cases       data.GetCasesResponse             `json:"cases,omitempty"`
+	Runs        data.GetRunsResponse              `json:"runs,omitempty"`
+	Tests       map[int64][]data.Test             `json:"tests,omitempty"`   // by run ID
+	Results     map[int64]data.GetResultsResponse `json:"results,omitempty"` // by run ID
+	// History holds the tests and results of deleted cases in runs that are
+	// not deleted with them (case and section entries).
+	History []RunHistory `json:"history,omitempty"`
+}
+
+// RunHistory is the part of a run that TestRail deletes with some of its
+// cases: their tests and results. The run itself stays.
+type RunHistory struct {
+	Run     data.Run                `json:"run"`
+	Tests   []data.Test             `json:"tests"`
+	Results data.GetResultsResponse `json:"results,omitempty"`
+}
+
+// Unrestorable returns the runs of c.History that Restore cannot add the
+// results back to: closed runs and runs of test plans.
+func (c *Content) Unrestorable() data.GetRunsResponse {
+	var runs data.GetRunsResponse
+	for _, h := range c.History {
+		if h.Run.IsCompleted || h.Run.PlanID != 0 {
+			runs = append(runs, h.Run)
+		}
+	}
+	return runs
+}
+
+// Entry is a saved or freshly captured deleted object.
+type Entry struct {
+	Manifest Manifest
+	Content  Content
+}
+
+// newEntry starts an entry of kind for the given objects.
+func newEntry(kind string, ids ...int64) *Entry {
+	return &Entry{Manifest: Manifest{
+		FormatVersion: FormatVersion,
+		CreatedAt:     time.Now().UTC(),
+		Kind:          kind,
+		ObjectIDs:     ids,
+	}}
+}
+
+// count fills the manifest counts from the content.
+func (e *Entry) count() {
+	c := &e.Content
+	n := Counts{
+		Suites:      len(c.Suites),
+		Sections:    len(c.Sections),
+		Cases:       len(c.Cases),
+		SharedSteps: len(c.SharedSteps),
+		Milestones:  len(c.Milestones),
+		Runs:        len(c.Runs),
+	}
+	for _, tests := range c.Tests {
+		n.Tests += len(tests)
+	}
+	for _, results := range c.Results {
+		n.Results += len(results)
+	}
+	for _, h := range c.History {
+		n.HistoryRuns++
+		n.Tests += len(h.Tests)
+		n.Results += len(h.Results)
+	}
+	e.Manifest.Counts = n
+}
+
+// Save writes the entry as a new directory under root, named after its
+// creation time; the name becomes the entry ID.
+func (e *Entry) Save(root string) error {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return fmt.Errorf("cannot create trash directory: %w", err)
+	}
+	base := e.Manifest.CreatedAt.Local().Format(idLayout)
+	id := base
+	for n := 2; ; n++ {
+		err := os.Mkdir(filepath.Join(root, id), 0o755)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("cannot create trash entry: %w", err)
+		}
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+	e.Manifest.ID = id
+
+	if err := writeJSON(filepath.Join(root, id, contentFile), e.Content); err != nil {
+		return err
+	}
+	return e.SaveManifest(root)
+}
+
+// SaveManifest rewrites the manifest of a saved entry.
+func (e *Entry) SaveManifest(root string) error {
+	return writeJSON(filepath.Join(root, e.Manifest.ID, manifestFile), e.Manifest)
+}
+
+func writeJSON(name string, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", filepath.Base(name), err)
+	}
+	return os.WriteFile(name, b, 0o644)
+}
+
+func readJSON(name string, v any) error {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("decode %s: %w", filepath.Base(name), err)
+	}
+	return nil
+}
+
+func readManifest(root, id string) (*Manifest, error) {
+	if id == "" || id == "." || id == ".." || filepath.Base(id) != id {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	var m Manifest
+	if err := readJSON(filepath.Join(root, id, manifestFile), &m); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+		}
+		return nil, err
+	}
+	if m.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("trash entry %s has format version %d, this gotr supports up to %d",
+			id, m.FormatVersion, FormatVersion)
+	}
+	m.ID = id
+	return &m, nil
+}
+
+// Load reads the entry with the given ID from root.
+func Load(root, id string) (*Entry, error) {
+	m, err := readManifest(root, id)
+	if err != nil {
+		return nil, err
+	}
+	e := &Entry{Manifest: *m}
+	if err := readJSON(filepath.Join(root, id, contentFile), &e.Content); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// List returns the manifests of all entries under root, newest first. A
+// missing root means an empty trash.
+func List(root string) ([]Manifest, error) {
+	dirs, err := os.ReadDir(root)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var list []Manifest
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		m, err := readManifest(root, d.Name())
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *m)
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].CreatedAt.After(list[j].CreatedAt) })
+	return list, nil
+}
+
+// Remove deletes the entry with the given ID from root.
+func Remove(root, id string) error {
+	if _, err := readManifest(root, id); err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(root, id))
+}
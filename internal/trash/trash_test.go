@@ -0,0 +1,392 @@
+package trash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Korrnals/gotr/internal/client"
+	"github.com/Korrnals/gotr/internal/models/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// suiteServer serves suite 5 of project 30: section 10 with subsection 11,
+// cases 100 (in 11, using shared step 7) and 101 (in 10), the closed run 50
+// of the suite and run 51 of test plan 9.
+func suiteServer() *client.MockClient {
+	return &client.MockClient{
+		GetSuiteFunc: func(ctx context.Context, suiteID int64) (*data.Suite, error) {
+			return &data.Suite{ID: suiteID, Name: "Checkout", ProjectID: 30}, nil
+		},
+		GetSectionFunc: func(ctx context.Context, sectionID int64) (*data.Section, error) {
+			return &data.Section{ID: sectionID, Name: "Payment", SuiteID: 5}, nil
+		},
+		GetSectionsFunc: func(ctx context.Context, projectID, suiteID int64) (data.GetSectionsResponse, error) {
+			return data.GetSectionsResponse{
+				{ID: 11, Name: "Cards", SuiteID: 5, ParentID: 10, Depth: 1, DisplayOrder: 1},
+				{ID: 10, Name: "Payment", SuiteID: 5, DisplayOrder: 1},
+				{ID: 12, Name: "Delivery", SuiteID: 5, DisplayOrder: 2},
+			}, nil
+		},
+		GetCasesFunc: func(ctx context.Context, projectID, suiteID, sectionID int64) (data.GetCasesResponse, error) {
+			return data.GetCasesResponse{
+				{ID: 100, Title: "Pay by card", SectionID: 11, SuiteID: 5, CustomMission: "Pay",
+					CustomStepsSeparated: []data.Step{{Content: "Open cart"}, {SharedStepID: 7}}},
+				{ID: 101, Title: "Pay by invoice", SectionID: 10, SuiteID: 5},
+				{ID: 102, Title: "Courier", SectionID: 12, SuiteID: 5},
+			}, nil
+		},
+		GetSharedStepFunc: func(ctx context.Context, stepID int64) (*data.SharedStep, error) {
+			return &data.SharedStep{ID: stepID, Title: "Log in"}, nil
+		},
+		GetRunsFunc: func(ctx context.Context, projectID int64) (data.GetRunsResponse, error) {
+			return data.GetRunsResponse{
+				{ID: 50, Name: "Nightly", SuiteID: 5, ProjectID: 30, IsCompleted: true},
+				{ID: 52, Name: "Other suite", SuiteID: 6, ProjectID: 30},
+			}, nil
+		},
+		GetPlansFunc: func(ctx context.Context, projectID int64) (data.GetPlansResponse, error) {
+			return data.GetPlansResponse{{ID: 9, Name: "Release"}}, nil
+		},
+		GetPlanFunc: func(ctx context.Context, planID int64) (*data.Plan, error) {
+			return &data.Plan{ID: planID, Entries: []data.PlanEntry{{Runs: []data.Run{
+				{ID: 51, Name: "Release", SuiteID: 5, ProjectID: 30},
+				{ID: 53, Name: "Release other", SuiteID: 6, ProjectID: 30},
+			}}}}, nil
+		},
+		GetTestsFunc: func(ctx context.Context, runID int64, filters map[string]string) ([]data.Test, error) {
+			return []data.Test{{ID: 500, CaseID: 100, RunID: runID}, {ID: 501, CaseID: 101, RunID: runID}}, nil
+		},
+		GetResultsForRunFunc: func(ctx context.Context, runID int64) (data.GetResultsResponse, error) {
+			// Newest first, as returned by the API.
+			return data.GetResultsResponse{
+				{ID: 3, TestID: 500, StatusID: 1, CreatedOn: 300, Comment: "fixed"},
+				{ID: 2, TestID: 501, StatusID: untestedStatusID, CreatedOn: 200},
+				{ID: 1, TestID: 500, StatusID: 5, CreatedOn: 100, Defects: "BUG-1"},
+			}, nil
+		},
+	}
+}
+
+func TestCaptureSuite(t *testing.T) {
+	e, err := CaptureSuite(context.Background(), suiteServer(), 5)
+	require.NoError(t, err)
+
+	assert.Equal(t, KindSuite, e.Manifest.Kind)
+	assert.Equal(t, "Checkout", e.Manifest.Title)
+	assert.Equal(t, int64(30), e.Manifest.ProjectID)
+	assert.Equal(t, Counts{Suites: 1, Sections: 3, Cases: 3, SharedSteps: 1, Runs: 2, Tests: 4, Results: 6}, e.Manifest.Counts)
+	assert.Equal(t, int64(50), e.Content.Runs[0].ID)
+	assert.Equal(t, data.Run{ID: 51, Name: "Release", SuiteID: 5, ProjectID: 30, PlanID: 9}, e.Content.Runs[1], "runs of test plans are kept")
+	assert.Equal(t, "Log in", e.Content.SharedSteps[0].Title)
+}
+
+func TestCaptureSection(t *testing.T) {
+	e, err := CaptureSection(context.Background(), suiteServer(), 10)
+	require.NoError(t, err)
+
+	ids := func() (ids []int64) {
+		for _, s := range e.Content.Sections {
+			ids = append(ids, s.ID)
+		}
+		return ids
+	}()
+	assert.Equal(t, []int64{10, 11}, ids)
+	require.Len(t, e.Content.Cases, 2)
+	assert.Equal(t, int64(100), e.Content.Cases[0].ID)
+	assert.Equal(t, int64(101), e.Content.Cases[1].ID)
+	assert.Empty(t, e.Content.Runs)
+
+	// The runs stay, but the tests and results of the cases go with them.
+	require.Len(t, e.Content.History, 2)
+	assert.Equal(t, int64(50), e.Content.History[0].Run.ID)
+	assert.Len(t, e.Content.History[0].Tests, 2)
+	assert.Len(t, e.Content.History[0].Results, 3)
+	assert.Equal(t, 2, e.Manifest.Counts.HistoryRuns)
+	assert.Len(t, e.Content.Unrestorable(), 2, "a closed run and a plan run")
+}
+
+func TestCapture_NotFoundAndErrors(t *testing.T) {
+	ctx := context.Background()
+	e, err := CaptureCases(ctx, &client.MockClient{}, 1, 2)
+	assert.NoError(t, err)
+	assert.Nil(t, e)
+
+	e, err = CaptureRun(ctx, &client.MockClient{}, 1)
+	assert.NoError(t, err)
+	assert.Nil(t, e)
+
+	mock := &client.MockClient{
+		GetRunFunc: func(ctx context.Context, runID int64) (*data.Run, error) {
+			return &data.Run{ID: runID}, nil
+		},
+		GetTestsFunc: func(ctx context.Context, runID int64, filters map[string]string) ([]data.Test, error) {
+			return nil, errors.New("boom")
+		},
+	}
+	_, err = CaptureRun(ctx, mock, 1)
+	assert.ErrorContains(t, err, "tests of run 1: boom")
+}
+
+func TestSaveListLoadRemove(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "trash")
+	empty, err := List(root)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	created := time.Date(2026, 10, 17, 14, 15, 3, 0, time.Local)
+	first := newEntry(KindCase, 100)
+	first.Manifest.CreatedAt = created
+	first.Content.Cases = data.GetCasesResponse{{ID: 100, Title: "Pay by card", CustomFields: data.CustomFieldValues{"custom_browser": json.RawMessage(`"firefox"`)}}}
+	require.NoError(t, first.Save(root))
+	assert.Equal(t, "20261017-141503", first.Manifest.ID)
+
+	second := newEntry(KindRun, 50)
+	second.Manifest.CreatedAt = created.Add(time.Second / 2)
+	require.NoError(t, second.Save(root))
+	assert.Equal(t, "20261017-141503-2", second.Manifest.ID)
+
+	list, err := List(root)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, second.Manifest.ID, list[0].ID)
+
+	loaded, err := Load(root, first.Manifest.ID)
+	require.NoError(t, err)
+	assert.Equal(t, `"firefox"`, string(loaded.Content.Cases[0].CustomFields["custom_browser"]))
+
+	require.NoError(t, Remove(root, first.Manifest.ID))
+	_, err = Load(root, first.Manifest.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, Remove(root, "../trash"), ErrNotFound)
+
+	second.Manifest.FormatVersion = FormatVersion + 1
+	require.NoError(t, second.SaveManifest(root))
+	_, err = Load(root, second.Manifest.ID)
+	assert.ErrorContains(t, err, "format version")
+	_, statErr := os.Stat(filepath.Join(root, second.Manifest.ID, contentFile))
+	assert.NoError(t, statErr)
+}
+
+// recorder records the write requests of a restore and answers them with
+// new IDs counting up from 1000.
+type recorder struct {
+	next     int64
+	sections []data.AddSectionRequest
+	cases    []data.AddCaseRequest
+	runs     []data.AddRunRequest
+	results  []data.ResultForCaseEntry
+	closed   []int64
+}
+
+func (r *recorder) id() int64 {
+	r.next++
+	return 1000 + r.next
+}
+
+func (r *recorder) client(base *client.MockClient) *client.MockClient {
+	base.AddProjectFunc = func(ctx context.Context, req *data.AddProjectRequest) (*data.GetProjectResponse, error) {
+		return &data.GetProjectResponse{ID: r.id(), Name: req.Name, SuiteMode: req.SuiteMode}, nil
+	}
+	base.AddMilestoneFunc = func(ctx context.Context, projectID int64, req *data.AddMilestoneRequest) (*data.Milestone, error) {
+		return &data.Milestone{ID: r.id()}, nil
+	}
+	base.AddSharedStepFunc = func(ctx context.Context, projectID int64, req *data.AddSharedStepRequest) (*data.SharedStep, error) {
+		return &data.SharedStep{ID: r.id()}, nil
+	}
+	base.AddSuiteFunc = func(ctx context.Context, projectID int64, req *data.AddSuiteRequest) (*data.Suite, error) {
+		return &data.Suite{ID: r.id(), ProjectID: projectID}, nil
+	}
+	base.AddSectionFunc = func(ctx context.Context, projectID int64, req *data.AddSectionRequest) (*data.Section, error) {
+		r.sections = append(r.sections, *req)
+		return &data.Section{ID: r.id()}, nil
+	}
+	base.AddCaseFunc = func(ctx context.Context, sectionID int64, req *data.AddCaseRequest) (*data.Case, error) {
+		r.cases = append(r.cases, *req)
+		return &data.Case{ID: r.id(), SectionID: sectionID}, nil
+	}
+	base.AddRunFunc = func(ctx context.Context, projectID int64, req *data.AddRunRequest) (*data.Run, error) {
+		r.runs = append(r.runs, *req)
+		return &data.Run{ID: r.id(), ProjectID: projectID}, nil
+	}
+	base.AddResultsForCasesFunc = func(ctx context.Context, runID int64, req *data.AddResultsForCasesRequest) (data.GetResultsResponse, error) {
+		r.results = append(r.results, req.Results...)
+		return nil, nil
+	}
+	base.CloseRunFunc = func(ctx context.Context, runID int64) (*data.Run, error) {
+		r.closed = append(r.closed, runID)
+		return &data.Run{ID: runID, IsCompleted: true}, nil
+	}
+	return base
+}
+
+func TestRestoreSuite(t *testing.T) {
+	ctx := context.Background()
+	e, err := CaptureSuite(ctx, suiteServer(), 5)
+	require.NoError(t, err)
+
+	rec := &recorder{}
+	mock := rec.client(&client.MockClient{})
+	var newRunTests []data.Test
+	mock.GetTestsFunc = func(ctx context.Context, runID int64, filters map[string]string) ([]data.Test, error) {
+		return newRunTests, nil
+	}
+	// Sections 1002-1004 and cases 1005-1007 follow suite 1001; run 1008.
+	newRunTests = []data.Test{{ID: 9000, CaseID: 1005}, {ID: 9001, CaseID: 1006}}
+
+	idMap, skipped, err := Restore(ctx, mock, e)
+	require.NoError(t, err)
+	assert.Empty(t, skipped)
+
+	assert.Equal(t, []Mapping{
+		{TypeSuite, 1001, 5},
+		{TypeSection, 1002, 10},
+		{TypeSection, 1003, 12},
+		{TypeSection, 1004, 11},
+		{TypeCase, 1005, 100},
+		{TypeCase, 1006, 101},
+		{TypeCase, 1007, 102},
+		{TypeRun, 1008, 50},
+		{TypeTest, 9000, 500},
+		{TypeTest, 9001, 501},
+		{TypeRun, 1009, 51},
+		{TypeTest, 9000, 500},
+		{TypeTest, 9001, 501},
+	}, idMap)
+
+	// Subsection under the restored parent, in the restored suite.
+	assert.Equal(t, data.AddSectionRequest{Name: "Cards", SuiteID: 1001, ParentID: 1002}, rec.sections[2])
+	assert.Equal(t, int64(1004), rec.cases[0].SectionID)
+	assert.Equal(t, int64(7), rec.cases[0].CustomStepsSeparated[1].SharedStepID, "shared steps are not deleted with a suite")
+	assert.Equal(t, `"Pay"`, string(rec.cases[0].CustomFields["custom_mission"]))
+
+	require.Len(t, rec.runs, 2)
+	// add_run defaults include_all to true and then ignores case_ids
+	body, err := json.Marshal(rec.runs[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"Nightly","suite_id":1001,"include_all":false,"case_ids":[1005,1006]}`, string(body))
+	assert.Equal(t, []data.ResultForCaseEntry{
+		{CaseID: 1005, StatusID: 5, Defects: "BUG-1"},
+		{CaseID: 1005, StatusID: 1, Comment: "fixed"},
+	}, rec.results[:2])
+	assert.Equal(t, []int64{1008}, rec.closed)
+}
+
+func TestRestoreProject(t *testing.T) {
+	ctx := context.Background()
+	e := newEntry(KindProject, 30)
+	e.Manifest.ProjectID = 30
+	e.Content = Content{
+		Project:     &data.Project{ID: 30, Name: "Shop", SuiteMode: 1},
+		Milestones:  []data.Milestone{{ID: 3, Name: "1.0", DueOn: data.Timestamp{Time: time.Unix(1700000000, 0)}}},
+		SharedSteps: data.GetSharedStepsResponse{{ID: 7, Title: "Log in"}},
+		Suites:      data.GetSuitesResponse{{ID: 5, Name: "Master"}},
+		Sections:    data.GetSectionsResponse{{ID: 10, Name: "Payment", SuiteID: 5}},
+		Cases: data.GetCasesResponse{{ID: 100, Title: "Pay", SectionID: 10, MilestoneID: 3,
+			CustomStepsSeparated: []data.Step{{SharedStepID: 7}}}},
+	}
+
+	rec := &recorder{}
+	var milestone data.AddMilestoneRequest
+	mock := rec.client(&client.MockClient{
+		GetSuitesFunc: func(ctx context.Context, projectID int64) (data.GetSuitesResponse, error) {
+			assert.Equal(t, int64(1001), projectID)
+			return data.GetSuitesResponse{{ID: 77, Name: "Master"}}, nil
+		},
+	})
+	addMilestone := mock.AddMilestoneFunc
+	mock.AddMilestoneFunc = func(ctx context.Context, projectID int64, req *data.AddMilestoneRequest) (*data.Milestone, error) {
+		milestone = *req
+		return addMilestone(ctx, projectID, req)
+	}
+
+	idMap, _, err := Restore(ctx, mock, e)
+	require.NoError(t, err)
+	assert.Equal(t, []Mapping{
+		{TypeProject, 1001, 30},
+		{TypeMilestone, 1002, 3},
+		{TypeSharedStep, 1003, 7},
+		{TypeSuite, 77, 5},
+		{TypeSection, 1004, 10},
+		{TypeCase, 1005, 100},
+	}, idMap)
+	assert.Equal(t, "1700000000", milestone.DueOn)
+	assert.Equal(t, int64(77), rec.sections[0].SuiteID)
+	assert.Equal(t, int64(1002), rec.cases[0].MilestoneID)
+	assert.Equal(t, int64(1003), rec.cases[0].CustomStepsSeparated[0].SharedStepID)
+}
+
+func TestRestore_PartialOnError(t *testing.T) {
+	ctx := context.Background()
+	e := newEntry(KindCases, 100, 101)
+	e.Content.Cases = data.GetCasesResponse{{ID: 100, SectionID: 10}, {ID: 101, SectionID: 10}}
+
+	calls := 0
+	mock := &client.MockClient{
+		AddCaseFunc: func(ctx context.Context, sectionID int64, req *data.AddCaseRequest) (*data.Case, error) {
+			assert.Equal(t, int64(10), sectionID)
+			if calls++; calls == 2 {
+				return nil, errors.New("section deleted")
+			}
+			return &data.Case{ID: 200}, nil
+		},
+	}
+	idMap, _, err := Restore(ctx, mock, e)
+	assert.ErrorContains(t, err, "case 101: section deleted")
+	assert.Equal(t, []Mapping{{TypeCase, 200, 100}}, idMap)
+
+	e.Manifest.Kind = "plan"
+	_, _, err = Restore(ctx, mock, e)
+	assert.ErrorContains(t, err, "unknown trash entry kind")
+}
+
+func TestRestoreCases_History(t *testing.T) {
+	ctx := context.Background()
+	e := newEntry(KindCase, 100)
+	e.Content.Cases = data.GetCasesResponse{{ID: 100, SectionID: 10}}
+	e.Content.History = []RunHistory{
+		{
+			Run:     data.Run{ID: 60, Name: "Smoke"},
+			Tests:   []data.Test{{ID: 600, CaseID: 100}},
+			Results: data.GetResultsResponse{{ID: 1, TestID: 600, StatusID: 5, Comment: "broken"}},
+		},
+		{
+			Run:     data.Run{ID: 61, Name: "Old", IsCompleted: true},
+			Tests:   []data.Test{{ID: 610, CaseID: 100}},
+			Results: data.GetResultsResponse{{ID: 2, TestID: 610, StatusID: 1}},
+		},
+	}
+
+	rec := &recorder{}
+	var update data.UpdateRunRequest
+	mock := rec.client(&client.MockClient{
+		GetRunFunc: func(ctx context.Context, runID int64) (*data.Run, error) {
+			return &data.Run{ID: runID, IsCompleted: runID == 61}, nil
+		},
+		GetTestsFunc: func(ctx context.Context, runID int64, filters map[string]string) ([]data.Test, error) {
+			if update.CaseIDs != nil {
+				return []data.Test{{ID: 700, CaseID: 101}, {ID: 701, CaseID: 1001}}, nil
+			}
+			return []data.Test{{ID: 700, CaseID: 101}}, nil
+		},
+		UpdateRunFunc: func(ctx context.Context, runID int64, req *data.UpdateRunRequest) (*data.Run, error) {
+			assert.Equal(t, int64(60), runID)
+			update = *req
+			return &data.Run{ID: runID}, nil
+		},
+	})
+
+	idMap, skipped, err := Restore(ctx, mock, e)
+	require.NoError(t, err)
+	assert.Equal(t, []Mapping{{TypeCase, 1001, 100}, {TypeTest, 701, 600}}, idMap)
+	assert.Equal(t, []int64{101, 1001}, update.CaseIDs, "the restored case joins the run")
+	assert.Equal(t, []data.ResultForCaseEntry{{CaseID: 1001, StatusID: 5, Comment: "broken"}}, rec.results)
+	require.Len(t, skipped, 1)
+	assert.Contains(t, skipped[0], "run 61")
+	assert.Contains(t, skipped[0], "closed")
+}
@@ -82,6 +82,7 @@ var emojiMap = map[string]string{
 	// Warnings / errors
 	"⚠️": colored(ansiYellow, "!"),
 	"⚠":  colored(ansiYellow, "!"),
+	"❌":  colored(ansiRed, "x"),
 	// Retry / recovery
 	"🔄": colored(ansiMagenta, "*"),
 	"📥": colored(ansiCyan, "*"),